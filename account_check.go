@@ -0,0 +1,147 @@
+package sendlix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"google.golang.org/grpc"
+)
+
+// ErrAccountMismatch is returned by every call of a client whose API key
+// does not belong to ClientConfig.ExpectedAccountID or is not
+// ClientConfig.ExpectedKeyID, for example because a staging key was
+// deployed to production. Once a mismatch is detected, the client fails all
+// further calls without sending them.
+//
+// Example:
+//
+//	_, err := client.SendEmail(ctx, options, nil)
+//	if errors.Is(err, sendlix.ErrAccountMismatch) {
+//		log.Fatal("refusing to send with an API key of another account")
+//	}
+var ErrAccountMismatch = errors.New("API key does not belong to the expected account")
+
+// accountCheck compares the identity of the API key a client authenticates
+// with against ClientConfig.ExpectedAccountID and ExpectedKeyID.
+type accountCheck struct {
+	auth              IAuth
+	expectedAccountID string
+	expectedKeyID     int64
+
+	mu      sync.Mutex
+	checked string // Authentication header that passed the check
+	err     error  // Mismatch detected, returned by all further calls
+}
+
+// newAccountCheck returns the check configured by config, or nil if config
+// expects no identity.
+func newAccountCheck(auth IAuth, config *ClientConfig) *accountCheck {
+	if config.ExpectedAccountID == "" && config.ExpectedKeyID == 0 {
+		return nil
+	}
+	return &accountCheck{
+		auth:              auth,
+		expectedAccountID: config.ExpectedAccountID,
+		expectedKeyID:     config.ExpectedKeyID,
+	}
+}
+
+// check compares the identity behind the current authentication header
+// with the expected one. Headers that passed are not checked again, and a
+// mismatch is returned by every later check.
+//
+// Returns:
+//   - error: An error wrapping ErrAccountMismatch, or nil if the identity
+//     matches or no header can be obtained
+func (c *accountCheck) check(ctx context.Context) error {
+	c.mu.Lock()
+	err := c.err
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	// Failures to obtain a header are reported by the authentication
+	// interceptor
+	_, value, err := c.auth.GetAuthHeader(ctx)
+	if err != nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil || value == c.checked {
+		return c.err
+	}
+	if c.err = c.compare(value); c.err == nil {
+		c.checked = value
+	}
+	return c.err
+}
+
+// compare checks the key ID of the authentication and the account of the
+// token in the header value.
+func (c *accountCheck) compare(value string) error {
+	if c.expectedKeyID != 0 {
+		keyID, ok := authKeyID(c.auth)
+		if !ok {
+			return fmt.Errorf("%w: key ID of %T is unknown, expected %d", ErrAccountMismatch, c.auth, c.expectedKeyID)
+		}
+		if keyID != c.expectedKeyID {
+			return fmt.Errorf("%w: expected API key %d, got %d", ErrAccountMismatch, c.expectedKeyID, keyID)
+		}
+	}
+
+	if c.expectedAccountID != "" {
+		info, err := parseTokenInfo(strings.TrimPrefix(value, "Bearer "))
+		if err != nil {
+			return fmt.Errorf("%w: account of the token is unknown: %v", ErrAccountMismatch, err)
+		}
+		if info.AccountID == "" {
+			return fmt.Errorf("%w: token has no account claim, expected account %q", ErrAccountMismatch, c.expectedAccountID)
+		}
+		if info.AccountID != c.expectedAccountID {
+			return fmt.Errorf("%w: expected account %q, API key belongs to %q", ErrAccountMismatch, c.expectedAccountID, info.AccountID)
+		}
+	}
+
+	return nil
+}
+
+// result returns the outcome of the checks so far: whether any header
+// passed, and the mismatch detected, if any.
+func (c *accountCheck) result() (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.checked != "", c.err
+}
+
+// interceptor creates a gRPC unary interceptor that runs the check before
+// every call and fails calls with a mismatching identity. Token exchanges
+// pass through, as the check needs their result.
+func (c *accountCheck) interceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if method != pb.Auth_GetJwtToken_FullMethodName {
+			if err := c.check(ctx); err != nil {
+				return err
+			}
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// authKeyID returns the ID of the API key auth currently uses, or false if
+// auth does not use API keys.
+func authKeyID(auth IAuth) (int64, bool) {
+	if m, ok := auth.(*MultiAuth); ok {
+		return m.ActiveKeyID(), true
+	}
+	if a := inspectableAuth(auth); a != nil {
+		return a.currentKeyID(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,238 @@
+package sendlix
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/mail"
+	"os"
+	"path/filepath"
+)
+
+// AttachmentUploader uploads attachment content to storage controlled by the
+// caller, such as a CDN or object store, and returns a URL the Sendlix API can
+// fetch the attachment from.
+//
+// When ClientConfig.AttachmentUploader is set, attachments created with
+// NewAttachmentFromBytes, NewAttachmentFromReader, or NewAttachmentFromFile are
+// uploaded transparently before the email is sent and passed to the API as
+// ContentURL attachments.
+type AttachmentUploader interface {
+	// Upload stores the content read from r and returns its public URL.
+	Upload(ctx context.Context, filename, contentType string, r io.Reader) (string, error)
+}
+
+// AttachmentCleaner can optionally be implemented by an AttachmentUploader to
+// remove uploaded content again when the email could not be sent.
+type AttachmentCleaner interface {
+	// Delete removes content previously returned by Upload.
+	Delete(ctx context.Context, url string) error
+}
+
+// NewAttachmentFromBytes creates an attachment from in-memory content.
+// The content is uploaded through the configured AttachmentUploader when
+// the email is sent.
+//
+// Parameters:
+//   - filename: Name that will be shown for the attachment
+//   - contentType: MIME type of the attachment (e.g., "application/pdf")
+//   - data: Attachment content
+//
+// Returns:
+//   - Attachment: Content-based attachment
+func NewAttachmentFromBytes(filename, contentType string, data []byte) Attachment {
+	return Attachment{
+		Filename:    filename,
+		ContentType: contentType,
+		open: func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(data)), nil
+		},
+	}
+}
+
+// NewAttachmentFromReader creates an attachment whose content is read from r.
+// The reader is consumed once, when the email is sent, so the attachment
+// should not be reused for multiple sends.
+//
+// Parameters:
+//   - filename: Name that will be shown for the attachment
+//   - contentType: MIME type of the attachment (e.g., "text/csv")
+//   - r: Reader providing the attachment content
+//
+// Returns:
+//   - Attachment: Content-based attachment
+func NewAttachmentFromReader(filename, contentType string, r io.Reader) Attachment {
+	return Attachment{
+		Filename:    filename,
+		ContentType: contentType,
+		open: func() (io.ReadCloser, error) {
+			return io.NopCloser(r), nil
+		},
+	}
+}
+
+// NewAttachmentFromFile creates an attachment from a file on disk.
+// The file is opened when the email is sent; its base name is used as the
+// attachment filename.
+//
+// Parameters:
+//   - path: Path of the file to attach
+//   - contentType: MIME type of the attachment (e.g., "application/pdf")
+//
+// Returns:
+//   - Attachment: Content-based attachment
+//   - error: Error if the file does not exist or is a directory
+//
+// Example:
+//
+//	invoice, err := sendlix.NewAttachmentFromFile("/tmp/invoice-42.pdf", "application/pdf")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	_, err = client.SendEmail(ctx, options, &sendlix.AdditionalOptions{
+//		Attachments: []sendlix.Attachment{invoice},
+//	})
+func NewAttachmentFromFile(path, contentType string) (Attachment, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return Attachment{}, fmt.Errorf("failed to read attachment file: %w", err)
+	}
+	if info.IsDir() {
+		return Attachment{}, fmt.Errorf("attachment path %q is a directory", path)
+	}
+
+	return Attachment{
+		Filename:    filepath.Base(path),
+		ContentType: contentType,
+		open: func() (io.ReadCloser, error) {
+			return os.Open(path)
+		},
+	}, nil
+}
+
+// NewMessageAttachment creates an attachment containing a complete email
+// message, for example to forward a received email so that it opens as an
+// email rather than as text. The attachment uses the message/rfc822 content
+// type and is uploaded through the configured AttachmentUploader.
+//
+// The message is checked to be parseable with a From header. Its bytes are
+// uploaded unmodified: RFC 2046 forbids base64 and quoted-printable encoding
+// for message/rfc822 parts, so the message must not be re-encoded.
+//
+// Parameters:
+//   - eml: Complete message including headers, as for SendEMLEmail
+//   - filename: Name shown for the attachment; "message.eml" if empty
+//
+// Returns:
+//   - Attachment: Content-based message/rfc822 attachment
+//   - error: ErrInvalidMessageAttachment if the message cannot be parsed
+//
+// Example:
+//
+//	forwarded, err := sendlix.NewMessageAttachment(received, "original.eml")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func NewMessageAttachment(eml []byte, filename string) (Attachment, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(eml))
+	if err != nil {
+		return Attachment{}, newValidationError(CodeInvalidMessageAttachment, "eml", map[string]string{"reason": err.Error()})
+	}
+	if msg.Header.Get("From") == "" {
+		return Attachment{}, newValidationError(CodeInvalidMessageAttachment, "eml", map[string]string{"reason": "missing From header"})
+	}
+
+	if filename == "" {
+		filename = "message.eml"
+	}
+	return NewAttachmentFromBytes(filename, "message/rfc822", eml), nil
+}
+
+// uploadAttachments uploads all content-based attachments through the
+// configured uploader and returns a copy of the options referencing the
+// uploaded URLs, together with the URLs that were uploaded.
+//
+// If an upload fails, attachments uploaded so far are cleaned up and the
+// uploader's error is returned wrapped.
+func (c *EmailClient) uploadAttachments(ctx context.Context, additional *AdditionalOptions) (*AdditionalOptions, []string, error) {
+	if additional == nil {
+		return nil, nil, nil
+	}
+
+	needsUpload := false
+	for _, att := range additional.Attachments {
+		if att.open != nil {
+			needsUpload = true
+			break
+		}
+	}
+	if !needsUpload {
+		return additional, nil, nil
+	}
+
+	// Uploading is a side effect of sending, so it must not happen for
+	// sends that read-only mode will reject.
+	if c.config.ReadOnly {
+		return nil, nil, fmt.Errorf("%w: attachment uploads are not permitted", ErrReadOnlyMode)
+	}
+
+	uploader := c.config.AttachmentUploader
+	if uploader == nil {
+		return nil, nil, newValidationError(CodeMissingAttachmentUploader, "Attachments", nil)
+	}
+
+	resolved := *additional
+	resolved.Attachments = make([]Attachment, len(additional.Attachments))
+
+	var uploaded []string
+	for i, att := range additional.Attachments {
+		if att.open == nil {
+			resolved.Attachments[i] = att
+			continue
+		}
+
+		url, err := uploadAttachment(ctx, uploader, att)
+		if err != nil {
+			c.cleanupAttachments(ctx, uploaded)
+			return nil, nil, fmt.Errorf("failed to upload attachment %q: %w", att.Filename, err)
+		}
+
+		uploaded = append(uploaded, url)
+		resolved.Attachments[i] = Attachment{
+			ContentURL:  url,
+			Filename:    att.Filename,
+			ContentType: att.ContentType,
+		}
+	}
+
+	return &resolved, uploaded, nil
+}
+
+// uploadAttachment opens and uploads the content of a single attachment.
+func uploadAttachment(ctx context.Context, uploader AttachmentUploader, att Attachment) (string, error) {
+	r, err := att.open()
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	return uploader.Upload(ctx, att.Filename, att.ContentType, r)
+}
+
+// cleanupAttachments removes uploaded attachments if the uploader supports it.
+// Cleanup is best-effort; errors are ignored because the send has already failed.
+func (c *EmailClient) cleanupAttachments(ctx context.Context, urls []string) {
+	cleaner, ok := c.config.AttachmentUploader.(AttachmentCleaner)
+	if !ok {
+		return
+	}
+
+	// Use a context that survives the caller's cancellation, which may be
+	// the reason the send failed in the first place.
+	ctx = context.WithoutCancel(ctx)
+	for _, url := range urls {
+		_ = cleaner.Delete(ctx, url)
+	}
+}
@@ -2,15 +2,16 @@ package sendlix
 
 import (
 	"context"
-	"crypto/tls"
+	"errors"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	pb "github.com/sendlix/go-sdk/internal/proto"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
 )
 
 // IAuth defines the authentication interface that all authentication
@@ -37,11 +38,52 @@ type IAuth interface {
 // The Auth struct automatically handles token refresh when tokens expire,
 // providing seamless authentication for long-running applications.
 type Auth struct {
-	apiKey string        // The original API key in format "secret.keyID"
-	keyID  int64         // Parsed key ID from the API key
-	secret string        // Parsed secret from the API key
-	client pb.AuthClient // gRPC client for authentication service
-	token  *tokenCache   // Cached JWT token with expiration
+	observer AuthObserver // Optional observer for token lifecycle events
+	store    TokenStore   // Optional external token store
+	keyFile  *apiKeyFile  // File the key was read from, or nil
+	stats    authStats    // Counters since creation
+
+	mu          sync.Mutex       // Guards the connection, key, token, refresh, and bypassStore
+	client      pb.AuthClient    // gRPC client for authentication service
+	conn        *grpc.ClientConn // Connection backing client
+	ownsConn    bool             // Whether conn was dialed by this Auth
+	keyID       int64            // Parsed key ID from the API key
+	secret      string           // Parsed secret from the API key
+	token       *tokenCache      // Cached JWT token with expiration
+	refresh     *tokenRefresh    // Token exchange in progress, or nil
+	bypassStore bool             // Whether the next refresh skips the store
+
+	closeOnce sync.Once // Guards closing conn
+	closeErr  error     // Result of closing conn
+
+	refreshMargin    time.Duration    // How long before expiry a token is refreshed
+	clockSkew        time.Duration    // How far the local clock may lag the server's
+	fetchTimeout     time.Duration    // Timeout of a token exchange
+	keyFileReload    time.Duration    // How often the key file is checked for changes
+	keyProbeInterval time.Duration    // How often a MultiAuth tries preferred keys
+	verifyOnCreate   bool             // Whether the constructor calls Verify
+	now              func() time.Time // Clock used for token expiry
+
+	onRefresh func(expiresAt time.Time, err error) // Optional hook called after token exchanges
+}
+
+// DefaultRefreshMargin is how long before their expiry tokens are refreshed,
+// so that a request does not carry a token expiring while it is in flight.
+const DefaultRefreshMargin = 30 * time.Second
+
+// DefaultTokenFetchTimeout bounds token exchanges, so that a request with a
+// context without deadline does not hang if the authentication service does
+// not respond.
+const DefaultTokenFetchTimeout = 10 * time.Second
+
+// tokenRefresh is a token exchange shared by all callers that need a new
+// token while it is in progress. done is closed once token or err is set,
+// reported once the refresh hook has returned as well.
+type tokenRefresh struct {
+	done     chan struct{}
+	reported chan struct{}
+	token    *tokenCache
+	err      error
 }
 
 // tokenCache holds a JWT token along with its expiration time
@@ -49,18 +91,164 @@ type Auth struct {
 type tokenCache struct {
 	token     string    // The JWT token string
 	expiresAt time.Time // When the token expires
+	fetchedAt time.Time // When the token was obtained
+}
+
+// fresh reports whether the token can still be used at now. Tokens are
+// stale margin before they expire; the margin is capped at half the token
+// lifetime so short-lived tokens are not refreshed on every call.
+func (t *tokenCache) fresh(now time.Time, margin time.Duration) bool {
+	margin = min(margin, t.expiresAt.Sub(t.fetchedAt)/2)
+	return now.Before(t.expiresAt.Add(-margin))
+}
+
+// AuthOption configures optional behavior of an Auth instance.
+// Options are passed to NewAuth and applied before the instance is used.
+type AuthOption func(*Auth)
+
+// WithAuthConnection makes Auth use an existing gRPC connection for token
+// exchanges instead of dialing the Sendlix API itself. The connection is not
+// closed by Auth; its lifecycle remains the caller's responsibility.
+//
+// Parameters:
+//   - conn: Connection to a server implementing the Sendlix Auth service
+//
+// Returns:
+//   - AuthOption: Option to pass to NewAuth
+func WithAuthConnection(conn *grpc.ClientConn) AuthOption {
+	return func(a *Auth) {
+		a.conn = conn
+		a.ownsConn = false
+	}
+}
+
+// WithAuthObserver registers an observer that is notified about token
+// refreshes and cache hits. See AuthObserver.
+//
+// Parameters:
+//   - observer: Observer to notify
+//
+// Returns:
+//   - AuthOption: Option to pass to NewAuth
+func WithAuthObserver(observer AuthObserver) AuthOption {
+	return func(a *Auth) {
+		a.observer = observer
+	}
+}
+
+// WithTokenRefreshHook registers a function called after every exchange of
+// the API key for a JWT token, successful or not. Tokens served from the
+// cache or the TokenStore do not call it. The hook is called after callers
+// waiting for the exchange have received its result, so a slow hook does
+// not block concurrent requests; only the request that performed the
+// exchange waits for it.
+//
+// Parameters:
+//   - hook: Function receiving the expiry of the new token, or the zero
+//     time and the exchange error
+//
+// Returns:
+//   - AuthOption: Option to pass to NewAuth
+//
+// Example:
+//
+//	auth, err := sendlix.NewAuth(apiKey, sendlix.WithTokenRefreshHook(func(expiresAt time.Time, err error) {
+//		tokenRefreshes.Inc()
+//		if err != nil {
+//			log.Printf("sendlix token refresh failed: %v", err)
+//			return
+//		}
+//		log.Printf("sendlix token refreshed, expires at %s", expiresAt)
+//	}))
+func WithTokenRefreshHook(hook func(expiresAt time.Time, err error)) AuthOption {
+	return func(a *Auth) {
+		a.onRefresh = hook
+	}
+}
+
+// WithRefreshMargin sets how long before their expiry tokens are refreshed.
+// The margin is capped at half of a token's lifetime.
+//
+// Parameters:
+//   - margin: Refresh margin; 0 uses tokens until they expire.
+//     Default: DefaultRefreshMargin
+//
+// Returns:
+//   - AuthOption: Option to pass to NewAuth
+func WithRefreshMargin(margin time.Duration) AuthOption {
+	return func(a *Auth) {
+		a.refreshMargin = max(margin, 0)
+	}
+}
+
+// WithClockSkew sets how far the local clock may lag behind the clock of
+// the Sendlix API. Cached tokens are treated as expiring this much earlier,
+// so a machine with a drifted clock does not send tokens the server already
+// considers expired. Unlike the refresh margin, the skew is not capped by
+// the token lifetime.
+//
+// Parameters:
+//   - skew: Clock skew allowance; negative values are treated as 0.
+//     Default: 0
+//
+// Returns:
+//   - AuthOption: Option to pass to NewAuth
+//
+// Example:
+//
+//	auth, err := sendlix.NewAuth(apiKey, sendlix.WithClockSkew(30*time.Second))
+func WithClockSkew(skew time.Duration) AuthOption {
+	return func(a *Auth) {
+		a.clockSkew = max(skew, 0)
+	}
+}
+
+// WithTokenFetchTimeout sets the timeout of token exchanges. An exchange is
+// shared by all concurrent callers of GetAuthHeader, so it is not canceled
+// with the context of the caller that started it; the timeout bounds it
+// instead. Each caller still returns when its own context ends.
+//
+// Parameters:
+//   - timeout: Timeout of a token exchange; 0 disables it, leaving only the
+//     deadline of the context of the caller that started the exchange.
+//     Default: DefaultTokenFetchTimeout
+//
+// Returns:
+//   - AuthOption: Option to pass to NewAuth
+func WithTokenFetchTimeout(timeout time.Duration) AuthOption {
+	return func(a *Auth) {
+		a.fetchTimeout = max(timeout, 0)
+	}
+}
+
+// WithAuthClock replaces the clock used to decide whether a cached token has
+// expired. It is intended for tests that verify expiry behavior without
+// sleeping.
+//
+// Parameters:
+//   - now: Function returning the current time. Default: time.Now
+//
+// Returns:
+//   - AuthOption: Option to pass to NewAuth
+func WithAuthClock(now func() time.Time) AuthOption {
+	return func(a *Auth) {
+		a.now = now
+	}
 }
 
 // NewAuth creates a new Auth instance with the provided API key.
 // The API key must be in the format "secret.keyID" where secret is the
-// API secret and keyID is the numeric key identifier.
+// API secret and keyID is the numeric key identifier. The key is split at
+// its last dot, so the secret may contain dots.
 //
 // This constructor establishes a gRPC connection to the authentication service
-// and validates the API key format. The connection is used for JWT token
-// exchanges throughout the lifetime of the Auth instance.
+// at api.sendlix.com and validates the API key format. The connection is used
+// for JWT token exchanges throughout the lifetime of the Auth instance. Use
+// NewAuthWithConfig to connect to a different server.
 //
 // Parameters:
 //   - apiKey: API key in format "secret.keyID" (e.g., "abc123.456")
+//   - opts: Optional settings such as WithAuthObserver
 //
 // Returns:
 //   - *Auth: Configured authentication instance
@@ -78,45 +266,166 @@ type tokenCache struct {
 //   - Empty secret portion
 //   - Invalid key ID (non-numeric)
 //   - Connection failure to authentication service
-func NewAuth(apiKey string) (*Auth, error) {
-	parts := strings.Split(apiKey, ".")
+func NewAuth(apiKey string, opts ...AuthOption) (*Auth, error) {
+	return NewAuthWithConfig(apiKey, nil, opts...)
+}
+
+// APIKeyEnvVar is the environment variable read by NewAuthFromEnv.
+const APIKeyEnvVar = "SENDLIX_API_KEY"
+
+// NewAuthFromEnv creates a new Auth instance like NewAuth, reading the API
+// key from the SENDLIX_API_KEY environment variable.
+//
+// Parameters:
+//   - opts: Optional settings such as WithAuthObserver
+//
+// Returns:
+//   - *Auth: Configured authentication instance
+//   - error: ErrMissingAPIKeyEnv if the variable is unset or empty, a
+//     validation error naming the variable if the key is malformed, or a
+//     connection error
+//
+// Example:
+//
+//	auth, err := sendlix.NewAuthFromEnv()
+//	if err != nil {
+//		log.Fatal("Failed to create auth:", err)
+//	}
+func NewAuthFromEnv(opts ...AuthOption) (*Auth, error) {
+	return NewAuthFromEnvVar(APIKeyEnvVar, opts...)
+}
+
+// NewAuthFromEnvVar creates a new Auth instance like NewAuth, reading the API
+// key from the named environment variable. Surrounding whitespace, such as a
+// trailing newline from a secrets file, is removed. Error messages name the
+// variable but never include its value.
+//
+// Parameters:
+//   - name: Name of the environment variable, e.g. "MAILER_SENDLIX_KEY"
+//   - opts: Optional settings such as WithAuthObserver
+//
+// Returns:
+//   - *Auth: Configured authentication instance
+//   - error: ErrMissingAPIKeyEnv if the variable is unset or empty, a
+//     validation error naming the variable if the key is malformed, or a
+//     connection error
+func NewAuthFromEnvVar(name string, opts ...AuthOption) (*Auth, error) {
+	apiKey := strings.TrimSpace(os.Getenv(name))
+	if apiKey == "" {
+		return nil, newValidationError(CodeMissingAPIKeyEnv, name, map[string]string{"name": name})
+	}
+
+	auth, err := NewAuth(apiKey, opts...)
+	if err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			return nil, fmt.Errorf("environment variable %s: %w", name, err)
+		}
+		return nil, err
+	}
+	return auth, nil
+}
 
-	if len(parts) != 2 {
-		return nil, fmt.Errorf("invalid API key format. Expected format: 'secret.keyID'")
+// parseAPIKey splits an API key into its secret and key ID.
+func parseAPIKey(apiKey string) (string, int64, error) {
+	// The key ID is the numeric suffix; the secret itself may contain dots
+	sep := strings.LastIndex(apiKey, ".")
+
+	if sep < 0 {
+		return "", 0, newValidationError(CodeInvalidAPIKeyFormat, "apiKey", nil)
 	}
 
-	secret := parts[0]
+	secret := apiKey[:sep]
 
 	if secret == "" {
-		return nil, fmt.Errorf("invalid API key format. Secret cannot be empty")
+		return "", 0, newValidationError(CodeEmptyAPISecret, "apiKey", nil)
 	}
 
-	keyID, err := strconv.ParseInt(parts[1], 10, 64)
+	keyID, err := strconv.ParseInt(apiKey[sep+1:], 10, 64)
 
 	if err != nil {
-		return nil, fmt.Errorf("invalid key ID: %v", err)
+		// The reason omits the input, which may be part of a malformed secret
+		var numErr *strconv.NumError
+		if errors.As(err, &numErr) {
+			err = numErr.Err
+		}
+		return "", 0, newValidationError(CodeInvalidKeyID, "apiKey", map[string]string{"reason": err.Error()})
 	}
 
-	// Create gRPC connection for auth
-	config := &tls.Config{}
-	creds := credentials.NewTLS(config)
+	return secret, keyID, nil
+}
 
-	conn, err := grpc.NewClient("api.sendlix.com:443",
-		grpc.WithTransportCredentials(creds),
-		grpc.WithUserAgent("sendlix-go-sdk/1.0.0"),
-	)
+// NewAuthWithConfig creates a new Auth instance like NewAuth, but connects
+// to the authentication service using the ServerAddress, UserAgent, and TLS
+// settings of config, the same way clients do. This allows running the SDK
+// against a staging environment or a local server with a self-signed
+// certificate.
+//
+// Parameters:
+//   - apiKey: API key in format "secret.keyID" (e.g., "abc123.456")
+//   - config: Client configuration (optional, uses defaults if nil)
+//   - opts: Optional settings such as WithAuthObserver
+//
+// Returns:
+//   - *Auth: Configured authentication instance
+//   - error: Validation or connection error
+//
+// Example:
+//
+//	config := sendlix.DefaultClientConfig()
+//	config.ServerAddress = "localhost:50051"
+//	config.TLSConfig = &tls.Config{RootCAs: pool} // pool trusts the local CA
+//
+//	auth, err := sendlix.NewAuthWithConfig("your-secret.123456", config)
+//	if err != nil {
+//		log.Fatal("Failed to create auth:", err)
+//	}
+func NewAuthWithConfig(apiKey string, config *ClientConfig, opts ...AuthOption) (*Auth, error) {
+	if config == nil {
+		config = DefaultClientConfig()
+	}
+
+	secret, keyID, err := parseAPIKey(apiKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to auth service: %v", err)
+		return nil, err
 	}
 
-	client := pb.NewAuthClient(conn)
+	auth := &Auth{
+		keyID:            keyID,
+		secret:           secret,
+		refreshMargin:    DefaultRefreshMargin,
+		fetchTimeout:     DefaultTokenFetchTimeout,
+		keyProbeInterval: DefaultKeyProbeInterval,
+		now:              time.Now,
+	}
+	for _, opt := range opts {
+		opt(auth)
+	}
+
+	if auth.conn == nil {
+		// Create gRPC connection for auth
+		conn, err := grpc.NewClient(config.ServerAddress,
+			grpc.WithTransportCredentials(transportCredentials(config)),
+			grpc.WithUserAgent(config.UserAgent),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to auth service: %v", err)
+		}
+
+		auth.conn = conn
+		auth.ownsConn = true
+	}
+
+	auth.client = pb.NewAuthClient(auth.conn)
+
+	if auth.verifyOnCreate {
+		if err := auth.Verify(context.Background()); err != nil {
+			auth.Close()
+			return nil, err
+		}
+	}
 
-	return &Auth{
-		apiKey: apiKey,
-		keyID:  keyID,
-		secret: secret,
-		client: client,
-	}, nil
+	return auth, nil
 }
 
 // GetAuthHeader returns the authorization header for authenticated requests.
@@ -124,10 +433,23 @@ func NewAuth(apiKey string) (*Auth, error) {
 // and caching automatically.
 //
 // The method first checks if a valid cached token exists. If the cached token
-// is still valid (not expired), it returns the cached token immediately.
-// If no valid cached token exists, it requests a new JWT token from the
+// is still valid, it returns the cached token immediately. Tokens are
+// refreshed DefaultRefreshMargin before they expire (see WithRefreshMargin),
+// so requests never carry a token that is about to expire.
+// If no valid cached token exists, it uses the token of the TokenStore set
+// with WithTokenStore, if any, or requests a new JWT token from the
 // authentication service and caches it for future use.
 //
+// Token exchanges time out after DefaultTokenFetchTimeout (see
+// WithTokenFetchTimeout), even if ctx has no deadline.
+//
+// GetAuthHeader is safe for concurrent use, so one Auth can be shared between
+// clients. Concurrent callers needing a new token share a single token
+// exchange and receive its result. The exchange keeps running when the
+// context of the caller that started it is canceled, so the other callers
+// still receive a token; any caller whose context ends while waiting,
+// including the one that started the exchange, returns the context error.
+//
 // Parameters:
 //   - ctx: Context for the authentication request
 //
@@ -147,12 +469,174 @@ func NewAuth(apiKey string) (*Auth, error) {
 // The returned token is automatically cached and reused until it expires,
 // minimizing the number of authentication requests to the server.
 func (a *Auth) GetAuthHeader(ctx context.Context) (string, string, error) {
+	if a.keyFile != nil {
+		a.checkKeyFile()
+	}
+
+	a.mu.Lock()
 	// Check if we have a valid cached token
-	if a.token != nil && time.Now().Before(a.token.expiresAt) {
-		return "authorization", "Bearer " + a.token.token, nil
+	if token := a.token; token != nil && token.fresh(a.skewedNow(), a.refreshMargin) {
+		a.mu.Unlock()
+		a.stats.cacheHits.Add(1)
+		if a.observer != nil {
+			a.observer.OnTokenServedFromCache(a.now().Sub(token.fetchedAt))
+		}
+		return "authorization", "Bearer " + token.token, nil
+	}
+
+	// Join a token exchange started by another caller
+	if refresh := a.refresh; refresh != nil {
+		a.mu.Unlock()
+		select {
+		case <-refresh.done:
+		case <-ctx.Done():
+			return "", "", ctx.Err()
+		}
+		if refresh.err != nil {
+			return "", "", refresh.err
+		}
+		a.stats.cacheHits.Add(1)
+		if a.observer != nil {
+			a.observer.OnTokenServedFromCache(a.now().Sub(refresh.token.fetchedAt))
+		}
+		return "authorization", "Bearer " + refresh.token.token, nil
+	}
+
+	refresh := &tokenRefresh{done: make(chan struct{}), reported: make(chan struct{})}
+	a.refresh = refresh
+	bypassStore := a.bypassStore
+	a.bypassStore = false
+	a.mu.Unlock()
+
+	// The exchange is shared with the callers joining it, so it runs
+	// detached from ctx and this caller waits for it like they do, except
+	// that it also waits for the refresh hook
+	exchangeCtx, cancel := a.exchangeContext(ctx)
+	go func() {
+		defer cancel()
+		a.refreshToken(exchangeCtx, refresh, bypassStore)
+	}()
+
+	select {
+	case <-refresh.reported:
+	case <-ctx.Done():
+		return "", "", ctx.Err()
+	}
+	if refresh.err != nil {
+		return "", "", refresh.err
+	}
+	return "authorization", "Bearer " + refresh.token.token, nil
+}
+
+// exchangeContext returns the context of a token exchange started by a
+// caller with ctx. It keeps the values of ctx but not its cancellation, as
+// other callers may join the exchange. Without a fetch timeout, the
+// deadline of ctx still bounds the exchange.
+func (a *Auth) exchangeContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	detached := context.WithoutCancel(ctx)
+	if deadline, ok := ctx.Deadline(); ok && a.fetchTimeout <= 0 {
+		return context.WithDeadline(detached, deadline)
+	}
+	return detached, func() {}
+}
+
+// refreshToken performs the shared token exchange refresh, caches its
+// token, and reports it to the refresh hook.
+func (a *Auth) refreshToken(ctx context.Context, refresh *tokenRefresh, bypassStore bool) {
+	defer close(refresh.reported)
+
+	var exchanged bool
+	refresh.token, exchanged, refresh.err = a.obtainToken(ctx, bypassStore)
+
+	a.mu.Lock()
+	if refresh.err == nil {
+		a.token = refresh.token
+	}
+	a.refresh = nil
+	a.mu.Unlock()
+	close(refresh.done)
+
+	// Waiting callers have their result, so a slow hook delays only the
+	// caller that started the exchange
+	if exchanged && a.onRefresh != nil {
+		var expiresAt time.Time
+		if refresh.err == nil {
+			expiresAt = refresh.token.expiresAt
+		}
+		a.onRefresh(expiresAt, refresh.err)
+	}
+}
+
+// InvalidateToken discards the cached JWT token, so that the next
+// GetAuthHeader exchanges the API key for a new token. Use it after
+// rotating API keys on the server, when the cached token is no longer
+// accepted. The next exchange also skips the TokenStore set with
+// WithTokenStore and replaces the token stored there.
+//
+// Clients call InvalidateToken automatically and retry once when a call
+// fails with an UNAUTHENTICATED status.
+//
+// Example:
+//
+//	// After revoking the old key in the dashboard
+//	auth.InvalidateToken()
+func (a *Auth) InvalidateToken() {
+	a.mu.Lock()
+	a.token = nil
+	a.bypassStore = true
+	a.mu.Unlock()
+}
+
+// TokenExpiry returns the expiry of the cached JWT token without
+// requesting one.
+//
+// Returns:
+//   - time.Time: Expiry of the cached token, or the zero time
+//   - bool: Whether a token is cached; false before the first
+//     GetAuthHeader and after InvalidateToken
+func (a *Auth) TokenExpiry() (time.Time, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.token == nil {
+		return time.Time{}, false
+	}
+	return a.token.expiresAt, true
+}
+
+// HasValidToken reports whether GetAuthHeader would serve the cached token
+// without a token exchange. A token is no longer valid once it is within
+// the refresh margin of its expiry (see WithRefreshMargin and WithClockSkew).
+//
+// Returns:
+//   - bool: Whether a fresh token is cached
+func (a *Auth) HasValidToken() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.token != nil && a.token.fresh(a.skewedNow(), a.refreshMargin)
+}
+
+// skewedNow returns the current time plus the clock skew allowance, the
+// earliest time the server's clock may show.
+func (a *Auth) skewedNow() time.Time {
+	return a.now().Add(a.clockSkew)
+}
+
+// invalidateHeader discards the cached token if header is still its
+// authorization header value. Calls rejected with the same token thus
+// cause a single token exchange.
+func (a *Auth) invalidateHeader(header string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.token != nil && header == "Bearer "+a.token.token {
+		a.token = nil
+		a.bypassStore = true
 	}
+}
 
-	// Get new token
+// fetchToken exchanges the API key for a new JWT token and reports the
+// exchange to the stats and observer.
+func (a *Auth) fetchToken(ctx context.Context) (*tokenCache, error) {
+	a.mu.Lock()
 	req := &pb.AuthRequest{
 		Key: &pb.AuthRequest_ApiKey{
 			ApiKey: &pb.ApiKey{
@@ -161,18 +645,163 @@ func (a *Auth) GetAuthHeader(ctx context.Context) (string, string, error) {
 			},
 		},
 	}
+	client := a.client
+	a.mu.Unlock()
+
+	if a.fetchTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.fetchTimeout)
+		defer cancel()
+	}
+
+	if a.observer != nil {
+		a.observer.OnTokenRefreshStart()
+	}
+	start := time.Now()
 
-	resp, err := a.client.GetJwtToken(ctx, req)
+	resp, err := client.GetJwtToken(ctx, req)
+	duration := time.Since(start)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to get JWT token: %v", err)
+		err = fmt.Errorf("failed to get JWT token: %w", mapPermissionError(pb.Auth_GetJwtToken_FullMethodName, err))
+		a.stats.recordFailure(err)
+		if a.observer != nil {
+			a.observer.OnTokenRefreshFailure(duration, err)
+		}
+		return nil, err
 	}
 
-	// Cache the token
-	expiresAt := resp.Expires.AsTime()
-	a.token = &tokenCache{
+	token := &tokenCache{
 		token:     resp.Token,
-		expiresAt: expiresAt,
+		expiresAt: resp.Expires.AsTime(),
+		fetchedAt: a.now(),
+	}
+
+	a.stats.refreshes.Add(1)
+	a.stats.lastFailed.Store(false)
+	if a.observer != nil {
+		a.observer.OnTokenRefreshSuccess(duration, token.expiresAt)
+	}
+
+	return token, nil
+}
+
+// Close closes the connection used for token exchanges if NewAuth dialed it.
+// Connections passed with WithAuthConnection remain open, as the caller owns
+// them. Close is safe to call multiple times; later calls return the result
+// of the first. GetAuthHeader fails after Close unless a cached token is
+// still valid.
+//
+// Long-running services that create an Auth per tenant must close it when
+// the tenant is no longer served, or wrap it with OwnedAuth so the client
+// using it closes it.
+//
+// Returns:
+//   - error: Any error encountered while closing the connection
+//
+// Example:
+//
+//	auth, err := sendlix.NewAuth("secret.keyid")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer auth.Close()
+func (a *Auth) Close() error {
+	a.closeOnce.Do(func() {
+		a.mu.Lock()
+		conn, owned := a.conn, a.ownsConn
+		a.mu.Unlock()
+		if conn != nil && owned {
+			a.closeErr = conn.Close()
+		}
+	})
+	return a.closeErr
+}
+
+// GetConnection returns the gRPC connection used for token exchanges.
+func (a *Auth) GetConnection() *grpc.ClientConn {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.conn
+}
+
+// useConnection switches token exchanges to conn, which the caller owns,
+// and closes the connection NewAuth dialed, if any. Exchanges in progress
+// on that connection fail.
+func (a *Auth) useConnection(conn *grpc.ClientConn) {
+	a.mu.Lock()
+	old, owned := a.conn, a.ownsConn
+	a.conn, a.client, a.ownsConn = conn, pb.NewAuthClient(conn), false
+	a.mu.Unlock()
+
+	if old != nil && owned && old != conn {
+		old.Close()
 	}
+}
+
+// String returns a description of the Auth identifying its API key by key
+// ID. The secret is masked, so an Auth can be logged safely, including with
+// the %v and %+v verbs.
+//
+// Returns:
+//   - string: Description such as "Auth(keyID=123, secret=abc1…)"
+func (a *Auth) String() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return fmt.Sprintf("Auth(keyID=%d, secret=%s)", a.keyID, maskSecret(a.secret))
+}
+
+// GoString returns a Go-syntax representation of the Auth for the %#v verb,
+// masking the secret like String.
+//
+// Returns:
+//   - string: Representation such as `&sendlix.Auth{keyID:123, secret:"abc1…"}`
+func (a *Auth) GoString() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return fmt.Sprintf("&sendlix.Auth{keyID:%d, secret:%q}", a.keyID, maskSecret(a.secret))
+}
+
+// maskSecret returns the first characters of a secret followed by an
+// ellipsis. Secrets too short to reveal part of them are masked entirely.
+func maskSecret(secret string) string {
+	const shown = 4
+	runes := []rune(secret)
+	if len(runes) < 2*shown {
+		return "…"
+	}
+	return string(runes[:shown]) + "…"
+}
+
+// ownedAuth is an Auth whose lifecycle belongs to the client using it.
+type ownedAuth struct {
+	*Auth
+}
 
-	return "authorization", "Bearer " + resp.Token, nil
+// OwnedAuth hands the lifecycle of auth to the client it is passed to:
+// closing the client closes auth as well. Clients created with an API key
+// string own their internal Auth in the same way.
+//
+// Do not share an owned Auth between clients; closing one of them would
+// break authentication for the others.
+//
+// Parameters:
+//   - auth: Authentication to hand over
+//
+// Returns:
+//   - IAuth: Authentication to pass to a client constructor
+//
+// Example:
+//
+//	auth, err := sendlix.NewAuth(tenant.APIKey)
+//	if err != nil {
+//		return err
+//	}
+//	client, err := sendlix.NewEmailClient(sendlix.OwnedAuth(auth), nil)
+//	if err != nil {
+//		auth.Close()
+//		return err
+//	}
+//	defer client.Close() // closes auth too
+func OwnedAuth(auth *Auth) IAuth {
+	return ownedAuth{auth}
 }
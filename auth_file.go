@@ -0,0 +1,173 @@
+package sendlix
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// apiKeyFile tracks the file an Auth read its API key from.
+type apiKeyFile struct {
+	path string
+
+	mu        sync.Mutex // Guards modTime and checkedAt, and serializes reloads
+	modTime   time.Time  // Modification time of the file when it was read
+	checkedAt time.Time  // When the modification time was last checked
+}
+
+// WithKeyFileReload makes an Auth created with NewAuthFromFile check the
+// modification time of its key file at most once per interval when a token
+// is requested, and reload the key when the file has changed. A failed
+// reload, for example while the file is being replaced, keeps the current
+// key and is retried at the next check.
+//
+// Parameters:
+//   - interval: Minimum time between checks. Default: 0 (the file is only
+//     read again by Reload)
+//
+// Returns:
+//   - AuthOption: Option to pass to NewAuthFromFile
+func WithKeyFileReload(interval time.Duration) AuthOption {
+	return func(a *Auth) {
+		a.keyFileReload = max(interval, 0)
+	}
+}
+
+// NewAuthFromFile creates a new Auth instance like NewAuth, reading the API
+// key from a file such as a mounted Kubernetes secret. Surrounding
+// whitespace is removed. With WithKeyFileReload, or by calling Reload, a
+// rotated key is picked up without restarting the process.
+//
+// Parameters:
+//   - path: Path of the file containing the API key
+//   - opts: Optional settings such as WithKeyFileReload
+//
+// Returns:
+//   - *Auth: Configured authentication instance
+//   - error: ErrMissingAPIKeyFile if the file does not exist; a validation
+//     error naming the file, such as ErrInvalidAPIKeyFormat, if it contains a
+//     malformed key; or a read or connection error
+//
+// Example:
+//
+//	auth, err := sendlix.NewAuthFromFile("/var/run/secrets/sendlix/api-key",
+//		sendlix.WithKeyFileReload(30*time.Second))
+//	if errors.Is(err, sendlix.ErrMissingAPIKeyFile) {
+//		log.Fatal("Sendlix secret is not mounted")
+//	}
+func NewAuthFromFile(path string, opts ...AuthOption) (*Auth, error) {
+	apiKey, modTime, err := readAPIKeyFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := NewAuth(apiKey, opts...)
+	if err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			return nil, fmt.Errorf("API key file %s: %w", path, err)
+		}
+		return nil, err
+	}
+	auth.keyFile = &apiKeyFile{path: path, modTime: modTime, checkedAt: auth.now()}
+	return auth, nil
+}
+
+// Reload reads the API key file of an Auth created with NewAuthFromFile
+// again. If the key has changed, the cached token is discarded, so the next
+// request exchanges the new key for a token. On error the current key is
+// kept. Reload does nothing for an Auth not created from a file.
+//
+// Returns:
+//   - error: The errors of NewAuthFromFile for a missing file or a
+//     malformed key
+//
+// Example:
+//
+//	signal.Notify(reload, syscall.SIGHUP)
+//	for range reload {
+//		if err := auth.Reload(); err != nil {
+//			log.Printf("keeping current Sendlix API key: %v", err)
+//		}
+//	}
+func (a *Auth) Reload() error {
+	if a.keyFile == nil {
+		return nil
+	}
+
+	a.keyFile.mu.Lock()
+	defer a.keyFile.mu.Unlock()
+	a.keyFile.checkedAt = a.now()
+	return a.reloadKeyFile()
+}
+
+// checkKeyFile reloads the key file if its modification time changed since
+// it was read, checking at most once per keyFileReload.
+func (a *Auth) checkKeyFile() {
+	if a.keyFileReload <= 0 {
+		return
+	}
+
+	f := a.keyFile
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := a.now()
+	if now.Sub(f.checkedAt) < a.keyFileReload {
+		return
+	}
+	f.checkedAt = now
+
+	info, err := os.Stat(f.path)
+	if err != nil || info.ModTime().Equal(f.modTime) {
+		return
+	}
+	a.reloadKeyFile()
+}
+
+// reloadKeyFile reads the key file and replaces the key if it changed. The
+// caller must hold keyFile.mu.
+func (a *Auth) reloadKeyFile() error {
+	f := a.keyFile
+	apiKey, modTime, err := readAPIKeyFile(f.path)
+	if err != nil {
+		return err
+	}
+	secret, keyID, err := parseAPIKey(apiKey)
+	if err != nil {
+		return fmt.Errorf("API key file %s: %w", f.path, err)
+	}
+	f.modTime = modTime
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if secret != a.secret || keyID != a.keyID {
+		a.secret, a.keyID = secret, keyID
+		a.token = nil
+		a.bypassStore = true
+	}
+	return nil
+}
+
+// readAPIKeyFile returns the trimmed content and modification time of an
+// API key file.
+func readAPIKeyFile(path string) (string, time.Time, error) {
+	// Stat before reading, so a change during the read is seen by the next
+	// check
+	info, err := os.Stat(path)
+	if err == nil {
+		var data []byte
+		if data, err = os.ReadFile(path); err == nil {
+			return strings.TrimSpace(string(data)), info.ModTime(), nil
+		}
+	}
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", time.Time{}, newValidationError(CodeMissingAPIKeyFile, path, map[string]string{"path": path})
+	}
+	return "", time.Time{}, fmt.Errorf("failed to read API key file %s: %w", path, err)
+}
@@ -0,0 +1,84 @@
+package sendlix
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// AuthObserver receives notifications about the JWT token lifecycle of an Auth
+// instance. It can be used to export metrics such as token age, refresh
+// latency, and refresh failures.
+//
+// Callbacks are invoked synchronously from GetAuthHeader, so implementations
+// should return quickly and must be safe for concurrent use.
+type AuthObserver interface {
+	// OnTokenRefreshStart is called before a new token is requested.
+	OnTokenRefreshStart()
+
+	// OnTokenRefreshSuccess is called after a token was obtained.
+	// duration is the latency of the token exchange.
+	OnTokenRefreshSuccess(duration time.Duration, expiresAt time.Time)
+
+	// OnTokenRefreshFailure is called when requesting a token failed.
+	OnTokenRefreshFailure(duration time.Duration, err error)
+
+	// OnTokenServedFromCache is called when a cached token is reused.
+	// tokenAge is the time since the token was obtained.
+	OnTokenServedFromCache(tokenAge time.Duration)
+}
+
+// AuthStats contains counters describing the token cache and auth health
+// since the Auth instance was created.
+type AuthStats struct {
+	// Refreshes is the number of successful token exchanges
+	Refreshes uint64
+	// CacheHits is the number of requests served with a cached token
+	CacheHits uint64
+	// StoreHits is the number of tokens loaded from the TokenStore instead
+	// of a token exchange
+	StoreHits uint64
+	// Failures is the number of failed token exchanges
+	Failures uint64
+	// LastError is the most recent token exchange error, or nil
+	LastError error
+}
+
+// authStats holds the atomic counters backing AuthStats.
+type authStats struct {
+	refreshes  atomic.Uint64
+	cacheHits  atomic.Uint64
+	storeHits  atomic.Uint64
+	failures   atomic.Uint64
+	lastError  atomic.Pointer[error]
+	lastFailed atomic.Bool
+}
+
+// recordFailure counts a failed token exchange and remembers its error.
+func (s *authStats) recordFailure(err error) {
+	s.failures.Add(1)
+	s.lastError.Store(&err)
+	s.lastFailed.Store(true)
+}
+
+// Stats returns the token cache and refresh counters of this Auth instance.
+// Reading the counters is cheap and safe for concurrent use.
+//
+// Returns:
+//   - AuthStats: Counters since the Auth instance was created
+//
+// Example:
+//
+//	stats := auth.Stats()
+//	fmt.Printf("refreshes=%d hits=%d failures=%d\n", stats.Refreshes, stats.CacheHits, stats.Failures)
+func (a *Auth) Stats() AuthStats {
+	stats := AuthStats{
+		Refreshes: a.stats.refreshes.Load(),
+		CacheHits: a.stats.cacheHits.Load(),
+		StoreHits: a.stats.storeHits.Load(),
+		Failures:  a.stats.failures.Load(),
+	}
+	if err := a.stats.lastError.Load(); err != nil {
+		stats.LastError = *err
+	}
+	return stats
+}
@@ -0,0 +1,91 @@
+package sendlix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidCredentials is wrapped by Verify errors when the authentication
+// service rejected the API key. Retrying will not help until the key is
+// replaced.
+var ErrInvalidCredentials = errors.New("API key was rejected")
+
+// ErrAuthUnreachable is wrapped by Verify errors when the authentication
+// service could not be reached or failed, so the API key could not be
+// checked. Such failures are usually transient.
+var ErrAuthUnreachable = errors.New("authentication service could not be reached")
+
+// WithVerifyOnCreate makes NewAuth and the other Auth constructors call
+// Verify before returning, so that a service with an invalid API key fails
+// at startup instead of on its first send. The check is bounded by the token
+// fetch timeout (see WithTokenFetchTimeout).
+//
+// Returns:
+//   - AuthOption: Option to pass to NewAuth
+//
+// Example:
+//
+//	auth, err := sendlix.NewAuthFromEnv(sendlix.WithVerifyOnCreate())
+//	if errors.Is(err, sendlix.ErrInvalidCredentials) {
+//		log.Fatal("SENDLIX_API_KEY was rejected: ", err)
+//	}
+func WithVerifyOnCreate() AuthOption {
+	return func(a *Auth) {
+		a.verifyOnCreate = true
+	}
+}
+
+// Verify exchanges the API key for a new JWT token immediately, bypassing
+// the cached token and the TokenStore. The new token is cached and used by
+// subsequent requests.
+//
+// Parameters:
+//   - ctx: Context for the token exchange
+//
+// Returns:
+//   - error: nil if the key was accepted; an error wrapping
+//     ErrInvalidCredentials if the authentication service rejected it; or an
+//     error wrapping ErrAuthUnreachable if the service could not be reached
+//
+// Example:
+//
+//	if err := auth.Verify(ctx); err != nil {
+//		if errors.Is(err, sendlix.ErrInvalidCredentials) {
+//			log.Fatal("invalid Sendlix API key: ", err)
+//		}
+//		log.Printf("could not verify Sendlix API key, continuing: %v", err)
+//	}
+func (a *Auth) Verify(ctx context.Context) error {
+	a.InvalidateToken()
+	_, _, err := a.GetAuthHeader(ctx)
+	return verifyError(err)
+}
+
+// Verify obtains an authentication header like GetAuthHeader, failing over
+// between the keys, and classifies failures like Auth.Verify. Tokens cached
+// for the keys are reused.
+//
+// Parameters:
+//   - ctx: Context for the token exchanges
+//
+// Returns:
+//   - error: nil if a key was accepted; an error wrapping
+//     ErrInvalidCredentials if all keys were rejected; or an error wrapping
+//     ErrAuthUnreachable if the service could not be reached
+func (m *MultiAuth) Verify(ctx context.Context) error {
+	_, _, err := m.GetAuthHeader(ctx)
+	return verifyError(err)
+}
+
+// verifyError classifies the error of a token exchange made by Verify.
+func verifyError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case keyRejected(err):
+		return fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	default:
+		return fmt.Errorf("%w: %w", ErrAuthUnreachable, err)
+	}
+}
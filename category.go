@@ -0,0 +1,152 @@
+package sendlix
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxCategoryDistance is the maximum edit distance for a registered category
+// to be suggested as a near-match of an unknown category.
+const maxCategoryDistance = 2
+
+// CategoryRegistry holds the category names an application is allowed to use.
+// When set on ClientConfig.CategoryRegistry, SendEmail, SendEMLEmail, and
+// SendGroupEmail reject categories that are not registered, which catches
+// typos before they split analytics.
+//
+// A CategoryRegistry is immutable after creation and safe for concurrent use.
+type CategoryRegistry struct {
+	names map[string]struct{}
+}
+
+// NewCategoryRegistry creates a registry allowing the given category names.
+// Names are case-sensitive; empty names are ignored.
+//
+// Parameters:
+//   - names: Allowed category names
+//
+// Returns:
+//   - *CategoryRegistry: Registry containing the names
+//
+// Example:
+//
+//	config := sendlix.DefaultClientConfig()
+//	config.CategoryRegistry = sendlix.NewCategoryRegistry("otp", "receipt", "marketing")
+func NewCategoryRegistry(names ...string) *CategoryRegistry {
+	r := &CategoryRegistry{names: make(map[string]struct{}, len(names))}
+	for _, name := range names {
+		if name != "" {
+			r.names[name] = struct{}{}
+		}
+	}
+	return r
+}
+
+// Contains reports whether a category name is registered.
+func (r *CategoryRegistry) Contains(name string) bool {
+	_, ok := r.names[name]
+	return ok
+}
+
+// Names returns all registered category names in sorted order.
+func (r *CategoryRegistry) Names() []string {
+	names := make([]string, 0, len(r.names))
+	for name := range r.names {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Suggest returns registered categories that are close to name, ordered by
+// similarity. Comparison ignores case, so "Receipt" suggests "receipt".
+//
+// Parameters:
+//   - name: Category name to find near-matches for
+//
+// Returns:
+//   - []string: Near-matching registered names, best match first
+func (r *CategoryRegistry) Suggest(name string) []string {
+	type match struct {
+		name     string
+		distance int
+	}
+
+	var matches []match
+	lower := strings.ToLower(name)
+	for candidate := range r.names {
+		if d := editDistance(lower, strings.ToLower(candidate)); d <= maxCategoryDistance {
+			matches = append(matches, match{candidate, d})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].name < matches[j].name
+	})
+
+	suggestions := make([]string, len(matches))
+	for i, m := range matches {
+		suggestions[i] = m.name
+	}
+	return suggestions
+}
+
+// Categories returns the category names registered in
+// ClientConfig.CategoryRegistry, or nil if no registry is configured.
+//
+// Returns:
+//   - []string: Registered category names in sorted order
+func (c *BaseClient) Categories() []string {
+	if c.config.CategoryRegistry == nil {
+		return nil
+	}
+	return c.config.CategoryRegistry.Names()
+}
+
+// validateCategory checks a category against the configured registry.
+// Empty categories are always allowed.
+func (c *BaseClient) validateCategory(category, field string) error {
+	registry := c.config.CategoryRegistry
+	if category == "" || registry == nil || c.config.AllowUnregisteredCategories {
+		return nil
+	}
+	if registry.Contains(category) {
+		return nil
+	}
+
+	suggestions := "none"
+	if matches := registry.Suggest(category); len(matches) > 0 {
+		suggestions = strings.Join(matches, ", ")
+	}
+	return newValidationError(CodeUnknownCategory, field, map[string]string{
+		"category":    category,
+		"suggestions": suggestions,
+	})
+}
+
+// editDistance returns the Levenshtein distance between two strings.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
@@ -3,20 +3,42 @@ package sendlix
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
+	"log/slog"
+	"sync"
+	"time"
 
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/encoding"
+	_ "google.golang.org/grpc/encoding/gzip" // Registers the "gzip" compressor
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 // BaseClient provides common functionality for all API clients.
 // It manages the gRPC connection, authentication, and common client configuration.
 // All specific API clients (EmailClient, GroupClient, etc.) embed this type.
 type BaseClient struct {
-	conn   *grpc.ClientConn
-	auth   IAuth
-	config *ClientConfig
+	auth    IAuth
+	config  *ClientConfig
+	stats   *StatsCollector
+	health  *healthTracker
+	account *accountCheck
+
+	// connMu guards the connection, which Reconnect replaces
+	connMu      sync.RWMutex
+	conn        *grpc.ClientConn
+	calls       *sync.WaitGroup // Calls in progress on conn
+	closed      bool
+	reconnectMu sync.Mutex
+	dialOptions []grpc.DialOption
+	sharedAuth  *Auth // Auth using conn for token exchanges, if any
 }
 
 // ClientConfig holds configuration options for API clients.
@@ -30,9 +52,235 @@ type ClientConfig struct {
 	// Default: "sendlix-go-sdk/1.0.0"
 	UserAgent string
 
+	// RequestTimeout is the default deadline of every call, including the
+	// token exchange it may need. A caller's context deadline that ends
+	// earlier takes precedence. Default: 0 (calls are only bounded by the
+	// caller's context)
+	RequestTimeout time.Duration
+
+	// RetryPolicy retries calls failing with transient errors such as
+	// UNAVAILABLE. See RetryPolicy. Default: nil (failed calls are not
+	// retried)
+	RetryPolicy *RetryPolicy
+
+	// TLSConfig is used as is for the TLS connections of clients and of
+	// the authentication service, for example to trust a private CA with
+	// RootCAs or to present client certificates. When set, Insecure,
+	// SessionTicketsDisabled, and ClientSessionCacheSize are ignored.
+	// Default: nil (system roots, configured by the fields below)
+	TLSConfig *tls.Config
+
 	// Insecure determines whether to skip TLS certificate verification.
 	// Only use true for testing purposes. Default: false
+	//
+	// Deprecated: Set TLSConfig, with RootCAs to trust a private CA or
+	// InsecureSkipVerify for tests. Insecure is ignored when TLSConfig is
+	// set.
 	Insecure bool
+
+	// SessionTicketsDisabled disables TLS session resumption via session
+	// tickets. Default: false
+	SessionTicketsDisabled bool
+
+	// ClientSessionCacheSize enables TLS session resumption with a client
+	// session cache holding up to this many sessions. Resumed handshakes are
+	// faster when connections are re-established. Default: 0 (no cache)
+	ClientSessionCacheSize int
+
+	// KeepaliveTime enables keepalive pings on connections idle for this
+	// long, so that intermediaries do not drop idle connections silently.
+	// gRPC raises values below 10 seconds to 10 seconds, and servers may
+	// close connections pinging more often than they permit.
+	// Default: 0 (gRPC defaults, no keepalive pings)
+	KeepaliveTime time.Duration
+
+	// KeepaliveTimeout is how long to wait for a ping acknowledgement before
+	// closing the connection. Only used with KeepaliveTime.
+	// Default: 0 (gRPC default of 20 seconds)
+	KeepaliveTimeout time.Duration
+
+	// PermitWithoutStream sends keepalive pings even when no call is in
+	// progress, which long-lived connections between bursts of sends need.
+	// Only used with KeepaliveTime. Default: false
+	PermitWithoutStream bool
+
+	// Compression compresses the requests of all calls with the named gRPC
+	// compressor, such as "gzip", which pays off for EML messages with large
+	// attachments. Compressors other than "gzip" must be registered with
+	// google.golang.org/grpc/encoding. Default: "" (no compression)
+	Compression string
+
+	// MaxSendMsgSize is the largest request in bytes the client sends, for
+	// example to send EML messages of more than 4 MB. SendEmail and
+	// SendEMLEmail fail with ErrMessageTooLarge before any request is made
+	// if the email exceeds it; EmailClient.EstimateRequestSize computes the
+	// size SendEmail checks. Without it the client sends requests of any size, and a
+	// request the server rejects as too large still fails with
+	// ErrMessageTooLarge, but only after it was transferred. Default: 0
+	// (gRPC default, effectively unlimited)
+	MaxSendMsgSize int
+
+	// MaxRecvMsgSize is the largest response in bytes the client accepts.
+	// Default: 0 (gRPC default of 4 MB)
+	MaxRecvMsgSize int
+
+	// DialOptions are passed to grpc.NewClient after the options of the SDK,
+	// for example to add stats handlers, resolvers, or interceptors.
+	// Interceptors added with grpc.WithChainUnaryInterceptor run after the
+	// SDK's interceptors, so they see authenticated requests; one set with
+	// grpc.WithUnaryInterceptor runs before them. Options replacing a setting
+	// of the SDK, such as grpc.WithTransportCredentials or
+	// grpc.WithUserAgent, take precedence over ClientConfig.
+	// Default: nil
+	DialOptions []grpc.DialOption
+
+	// ReadOnly permits read operations such as membership checks but fails
+	// every mutating call (sends, inserts, removals) with ErrReadOnlyMode
+	// before any request is made. Intended for disaster-recovery drills
+	// against production configuration. Default: false
+	ReadOnly bool
+
+	// ExpectedAccountID is the Sendlix account the API key must belong to,
+	// compared with the account claim of the token before the first call.
+	// On a mismatch every call fails with ErrAccountMismatch. Default: ""
+	// (any account)
+	ExpectedAccountID string
+
+	// ExpectedKeyID is the ID of the API key the client must authenticate
+	// with, i.e. the part after the dot of "secret.keyID". On a mismatch
+	// every call fails with ErrAccountMismatch. Default: 0 (any key)
+	ExpectedKeyID int64
+
+	// DisableNameNormalization turns off the display name normalization applied
+	// to all addresses when building requests. See NormalizeDisplayName.
+	// Default: false
+	DisableNameNormalization bool
+
+	// LowercaseEmailLocalPart makes GroupClient lowercase the local part of
+	// email addresses in addition to the domain. See NormalizeEmail.
+	// Default: false
+	LowercaseEmailLocalPart bool
+
+	// AttachmentUploader uploads content-based attachments before sending and
+	// replaces them with URL attachments. Default: nil (content-based
+	// attachments are rejected)
+	AttachmentUploader AttachmentUploader
+
+	// SendRecorder is called after every successful send with the message IDs,
+	// recipients, and job ID of the send. Default: nil (no recording)
+	SendRecorder SendRecorder
+
+	// RecordFailurePolicy defines whether SendRecorder errors are returned to
+	// the caller. Default: RecordFailureWarn
+	RecordFailurePolicy RecordFailurePolicy
+
+	// OnRecordError is called with SendRecorder errors under RecordFailureWarn.
+	// Default: nil (errors are ignored)
+	OnRecordError func(record SendRecord, err error)
+
+	// InlineCSS enables CSS inlining for the HTML content of SendEmail and
+	// SendGroupEmail. The HTML is transformed when building the request; the
+	// caller's options are left unchanged. See InlineCSSWithOptions.
+	// Default: nil (HTML is sent as is)
+	InlineCSS *InlineCSSOptions
+
+	// PlaceholderCheck enables checking {{placeholder}} usage in the subject
+	// and content of SendEmail and SendGroupEmail against known substitution
+	// keys. See CheckPlaceholders. Default: nil (no checking)
+	PlaceholderCheck *PlaceholderCheckConfig
+
+	// SenderCheck enables advisory checks of the From and ReplyTo addresses
+	// of SendEmail and SendGroupEmail. See CheckSender.
+	// Default: nil (no checking)
+	SenderCheck *SenderCheckConfig
+
+	// CategoryRegistry restricts the categories accepted by send methods to
+	// the registered names. Unknown categories fail with ErrUnknownCategory.
+	// Default: nil (any category is accepted)
+	CategoryRegistry *CategoryRegistry
+
+	// AllowUnregisteredCategories accepts categories missing from
+	// CategoryRegistry while keeping the registry available through
+	// Categories. Default: false
+	AllowUnregisteredCategories bool
+
+	// LowQuotaThreshold is the EmailsLeft value at or below which OnLowQuota
+	// is called. Default: 0 (called when the quota is exhausted)
+	LowQuotaThreshold int64
+
+	// OnLowQuota is called by EmailClient when the EmailsLeft value reported
+	// by a send response drops to or below LowQuotaThreshold. It is called at
+	// most once until the quota rises above the threshold again.
+	// Default: nil (no callback)
+	OnLowQuota func(emailsLeft int64)
+
+	// StrictWarnings lists warning codes, such as WarningFreemailFrom, that
+	// reject a call with ErrStrictWarning instead of being reported through
+	// WithWarnings. Default: nil (warnings never fail a call)
+	StrictWarnings []ErrorCode
+
+	// FailOnEmptyGroup makes SendGroupEmail return ErrEmptyGroup when the
+	// API reports no sent messages. Default: false (an empty group is a
+	// successful send of zero emails)
+	FailOnEmptyGroup bool
+
+	// QuotaCoordinator coordinates the email quota with other senders before
+	// and after every send. See QuotaCoordinator. Default: nil (no
+	// coordination)
+	QuotaCoordinator QuotaCoordinator
+
+	// CollectStats enables an in-memory latency summary per API method,
+	// available through Stats. Default: false
+	CollectStats bool
+
+	// Logger receives a debug record for every call with the method,
+	// duration, status code, request ID, and message IDs, plus the subject
+	// and number of recipients of sends. API keys, tokens, addresses, and
+	// message bodies are never logged. Default: nil (no logging)
+	Logger *slog.Logger
+
+	// TracerProvider enables OpenTelemetry tracing: every call is recorded
+	// as a client span, a child of the span in the caller's context, with
+	// its method, status code, and for sends the recipient count, category,
+	// and remaining quota. Pass otel.GetTracerProvider() to use the global
+	// provider. Default: nil (no tracing)
+	TracerProvider trace.TracerProvider
+
+	// Metrics receives the status code and duration of every call, and the
+	// number of emails sent and left after every send. See
+	// MetricsCollector and the sendlixprom package. Default: nil (no
+	// metrics)
+	Metrics MetricsCollector
+
+	// MembershipCache enables client-side caching of CheckEmailInGroup results
+	// on GroupClient. Default: nil (caching disabled)
+	MembershipCache *MembershipCacheConfig
+
+	// RecipientResolver resolves the recipient references created with
+	// RecipientRef when EmailClient sends. Default: nil (references are
+	// rejected with ErrMissingRecipientResolver)
+	RecipientResolver RecipientResolver
+
+	// RecipientCacheTTL is how long resolved references are cached.
+	// Failed resolutions are not cached. A negative value disables caching.
+	// Default: DefaultRecipientCacheTTL
+	RecipientCacheTTL time.Duration
+
+	// QuietHours holds back EmailClient sends during configured periods,
+	// such as promotional email overnight. See QuietHours.
+	// Default: nil (sends are never held back)
+	QuietHours *QuietHours
+
+	// SpoolOnUnavailable retains EmailClient.SendEmail calls failing with
+	// UNAVAILABLE in memory and delivers them when the API is reachable
+	// again. See SpoolConfig. Default: nil (such sends fail)
+	SpoolOnUnavailable *SpoolConfig
+
+	// SubstitutionLimits rejects group entries whose Substitutions exceed
+	// the given limits or use keys that are not placeholder names, before
+	// any request is made. See SubstitutionLimits.
+	// Default: nil (substitutions are not checked)
+	SubstitutionLimits *SubstitutionLimits
 }
 
 // DefaultClientConfig returns the default client configuration with
@@ -93,40 +341,139 @@ func DefaultClientConfig() *ClientConfig {
 func NewBaseClient(auth IAuth, config *ClientConfig) (*BaseClient, error) {
 
 	if auth == nil {
-		return nil, fmt.Errorf("authentication is required")
+		return nil, newValidationError(CodeMissingAuth, "auth", nil)
 	}
 
 	if config == nil {
 		config = DefaultClientConfig()
 	}
+	if err := validateRetryPolicy(config.RetryPolicy); err != nil {
+		return nil, err
+	}
+	if config.Compression != "" && encoding.GetCompressor(config.Compression) == nil {
+		return nil, newValidationError(CodeUnknownCompressor, "Compression", map[string]string{"name": config.Compression})
+	}
 
-	var creds credentials.TransportCredentials
-	if config.Insecure {
-		creds = credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})
-	} else {
-		creds = credentials.NewTLS(&tls.Config{})
+	var interceptors []grpc.UnaryClientInterceptor
+	if config.RequestTimeout > 0 {
+		interceptors = append(interceptors, timeoutInterceptor(config.RequestTimeout))
+	}
+	var stats *StatsCollector
+	if config.CollectStats {
+		stats = NewStatsCollector(DefaultStatsWindow)
+		interceptors = append(interceptors, stats.interceptor())
+	}
+	if config.ReadOnly {
+		interceptors = append(interceptors, readOnlyInterceptor())
+	}
+	if config.RetryPolicy != nil {
+		interceptors = append(interceptors, retryInterceptor(config.RetryPolicy))
+	}
+	if config.TracerProvider != nil {
+		interceptors = append(interceptors, tracingInterceptor(config.TracerProvider))
+	}
+	if config.Logger != nil {
+		interceptors = append(interceptors, logInterceptor(config.Logger))
+	}
+	if config.Metrics != nil {
+		interceptors = append(interceptors, metricsInterceptor(config.Metrics))
+	}
+	account := newAccountCheck(auth, config)
+	if account != nil {
+		interceptors = append(interceptors, account.interceptor())
 	}
+	health := &healthTracker{}
+	interceptors = append(interceptors, health.interceptor(), authInterceptor(auth), permissionInterceptor(), requestIDInterceptor())
 
-	conn, err := grpc.NewClient(config.ServerAddress,
-		grpc.WithTransportCredentials(creds),
+	dialOptions := []grpc.DialOption{
+		grpc.WithTransportCredentials(transportCredentials(config)),
 		grpc.WithUserAgent(config.UserAgent),
-		grpc.WithUnaryInterceptor(authInterceptor(auth)),
-	)
+		grpc.WithChainUnaryInterceptor(interceptors...),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(deterministicCodec{})),
+	}
+	if config.KeepaliveTime > 0 {
+		dialOptions = append(dialOptions, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                config.KeepaliveTime,
+			Timeout:             config.KeepaliveTimeout,
+			PermitWithoutStream: config.PermitWithoutStream,
+		}))
+	}
+	if config.Compression != "" {
+		dialOptions = append(dialOptions, grpc.WithDefaultCallOptions(grpc.UseCompressor(config.Compression)))
+	}
+	if config.MaxSendMsgSize > 0 {
+		dialOptions = append(dialOptions, grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(config.MaxSendMsgSize)))
+	}
+	if config.MaxRecvMsgSize > 0 {
+		dialOptions = append(dialOptions, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(config.MaxRecvMsgSize)))
+	}
+
+	dialOptions = append(dialOptions, config.DialOptions...)
+
+	conn, err := grpc.NewClient(config.ServerAddress, dialOptions...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to server: %v", err)
 	}
 
 	return &BaseClient{
-		conn:   conn,
-		auth:   auth,
-		config: config,
+		auth:        auth,
+		config:      config,
+		stats:       stats,
+		health:      health,
+		account:     account,
+		conn:        conn,
+		calls:       &sync.WaitGroup{},
+		dialOptions: dialOptions,
 	}, nil
 }
 
+// transportCredentials builds the transport credentials for connections to
+// the Sendlix API from the client configuration. Client connections and the
+// connection of the authentication service both use it, so TLS settings
+// apply to the token exchange as well.
+//
+// Parameters:
+//   - config: Client configuration
+//
+// Returns:
+//   - credentials.TransportCredentials: TLS credentials for grpc.NewClient
+func transportCredentials(config *ClientConfig) credentials.TransportCredentials {
+	return credentials.NewTLS(newTLSConfig(config))
+}
+
+// newTLSConfig builds the TLS configuration for connections to the
+// Sendlix API from the client configuration. A configured TLSConfig is
+// copied, so the connection does not share it with the caller.
+//
+// Parameters:
+//   - config: Client configuration
+//
+// Returns:
+//   - *tls.Config: TLS configuration for transport credentials
+func newTLSConfig(config *ClientConfig) *tls.Config {
+	if config.TLSConfig != nil {
+		return config.TLSConfig.Clone()
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify:     config.Insecure,
+		SessionTicketsDisabled: config.SessionTicketsDisabled,
+	}
+
+	if config.ClientSessionCacheSize > 0 {
+		tlsConfig.ClientSessionCache = tls.NewLRUClientSessionCache(config.ClientSessionCacheSize)
+	}
+
+	return tlsConfig
+}
+
 // Close closes the gRPC connection and releases associated resources.
 // This method should be called when the client is no longer needed to prevent
 // resource leaks. It's safe to call Close multiple times.
 //
+// An Auth owned by the client, because it was created from an API key string
+// or passed through OwnedAuth, is closed as well.
+//
 // Returns:
 //   - error: Any error encountered while closing the connection
 //
@@ -138,44 +485,105 @@ func NewBaseClient(auth IAuth, config *ClientConfig) (*BaseClient, error) {
 //	}
 //	defer client.Close() // Ensure cleanup
 func (c *BaseClient) Close() error {
-	if c.conn != nil {
-		return c.conn.Close()
+	c.connMu.Lock()
+	conn := c.conn
+	c.closed = true
+	c.connMu.Unlock()
+
+	var errs []error
+	if conn != nil {
+		errs = append(errs, conn.Close())
+	}
+	if owned, ok := c.auth.(ownedAuth); ok {
+		errs = append(errs, owned.Close())
 	}
-	return nil
+	return errors.Join(errs...)
 }
 
 // GetConnection returns the underlying gRPC connection.
 // This method is primarily used internally by specific API clients
-// to create their respective gRPC service clients.
+// to create their respective gRPC service clients. After Reconnect, it
+// returns the new connection.
 //
 // Returns:
 //   - *grpc.ClientConn: The underlying gRPC connection
 func (c *BaseClient) GetConnection() *grpc.ClientConn {
+	c.connMu.RLock()
+	defer c.connMu.RUnlock()
 	return c.conn
 }
 
+// timeoutInterceptor creates a gRPC unary interceptor that bounds every call
+// by timeout, unless the caller's context ends earlier. It runs first, so
+// the timeout covers the token exchange and the retry of the authentication
+// interceptor as well.
+//
+// Parameters:
+//   - timeout: Default deadline of a call
+//
+// Returns:
+//   - grpc.UnaryClientInterceptor: Configured timeout interceptor
+func timeoutInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= timeout {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// headerInvalidator is implemented by authentication that caches tokens and
+// can discard a token rejected by the server.
+type headerInvalidator interface {
+	invalidateHeader(header string)
+}
+
 // authInterceptor creates a gRPC unary interceptor that automatically adds
 // authentication headers to all outgoing requests. This interceptor retrieves
 // the authentication header from the provided IAuth implementation and adds
 // it to the request metadata.
 //
+// When a call fails with an UNAUTHENTICATED status and auth caches tokens,
+// as Auth does, the rejected token is discarded and the call is retried
+// exactly once with a new token.
+//
+// Token exchanges pass through unchanged, as they are made by the Auth
+// itself when it shares the client's connection (see
+// NewClientsWithSharedConn) and must not request a token in turn.
+//
 // Parameters:
 //   - auth: Authentication implementation to use for header generation
 //
 // Returns:
 //   - grpc.UnaryClientInterceptor: Configured authentication interceptor
 func authInterceptor(auth IAuth) grpc.UnaryClientInterceptor {
-	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	invoke := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) (string, error) {
 		// Get auth header
 		key, value, err := auth.GetAuthHeader(ctx)
 		if err != nil {
-			return fmt.Errorf("failed to get auth header: %v", err)
+			return "", fmt.Errorf("%w: %w", ErrAuthFailed, err)
+		}
+
+		// Add auth header to context and call the method
+		return value, invoker(metadata.AppendToOutgoingContext(ctx, key, value), method, req, reply, cc, opts...)
+	}
+
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if method == pb.Auth_GetJwtToken_FullMethodName {
+			return invoker(ctx, method, req, reply, cc, opts...)
 		}
 
-		// Add auth header to context
-		ctx = metadata.AppendToOutgoingContext(ctx, key, value)
+		value, err := invoke(ctx, method, req, reply, cc, invoker, opts...)
+		invalidator, ok := auth.(headerInvalidator)
+		if !ok || value == "" || status.Code(err) != codes.Unauthenticated {
+			return err
+		}
 
-		// Call the method
-		return invoker(ctx, method, req, reply, cc, opts...)
+		invalidator.invalidateHeader(value)
+		_, err = invoke(ctx, method, req, reply, cc, invoker, opts...)
+		return err
 	}
 }
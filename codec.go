@@ -0,0 +1,55 @@
+package sendlix
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// deterministicCodec is the gRPC codec of client connections. It encodes
+// messages like the default protobuf codec, except that map entries, such
+// as the substitutions of group entries, are written in sorted key order.
+// Requests built from the same input are therefore byte-identical across
+// calls and processes, which request hashes used for idempotency and
+// recorded fixtures rely on.
+type deterministicCodec struct{}
+
+var _ encoding.Codec = deterministicCodec{}
+
+// Marshal encodes v with map entries sorted by key.
+func (deterministicCodec) Marshal(v any) ([]byte, error) {
+	msg := messageV2Of(v)
+	if msg == nil {
+		return nil, fmt.Errorf("failed to marshal, message is %T, want proto.Message", v)
+	}
+	return proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+}
+
+// Unmarshal decodes data into v.
+func (deterministicCodec) Unmarshal(data []byte, v any) error {
+	msg := messageV2Of(v)
+	if msg == nil {
+		return fmt.Errorf("failed to unmarshal, message is %T, want proto.Message", v)
+	}
+	return proto.Unmarshal(data, msg)
+}
+
+// Name returns the name of the protobuf codec, so the content type of
+// requests is unchanged.
+func (deterministicCodec) Name() string {
+	return "proto"
+}
+
+// messageV2Of returns v as a protobuf message, converting messages of the
+// generated legacy API, or nil if v is not a message.
+func messageV2Of(v any) proto.Message {
+	switch v := v.(type) {
+	case protoadapt.MessageV2:
+		return v
+	case protoadapt.MessageV1:
+		return protoadapt.MessageV2Of(v)
+	}
+	return nil
+}
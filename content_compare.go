@@ -0,0 +1,156 @@
+package sendlix
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// CompareOptions selects the differences ContentEqual and ContentHash
+// ignore. Without options, the HTML of two contents must tokenize to the
+// same markup, so only differences of the source such as entity spelling,
+// tag name case, or the slash of self-closing tags are ignored.
+type CompareOptions struct {
+	// IgnoreWhitespace collapses runs of whitespace in text to a single
+	// space, trims text, and drops text consisting of whitespace only, such
+	// as indentation between tags. Text inside <pre> and <textarea> is kept
+	// as is. Plain text content is compared with its whitespace collapsed.
+	IgnoreWhitespace bool
+
+	// IgnoreComments drops HTML comments, except Outlook conditional
+	// comments such as <!--[if mso]>, which affect rendering.
+	IgnoreComments bool
+
+	// NormalizeAttributes compares the attributes of an element regardless
+	// of their order, and with surrounding whitespace of values trimmed.
+	NormalizeAttributes bool
+}
+
+// ContentEqual reports whether two contents render the same, ignoring the
+// differences selected by opts. HTML is compared token by token with a
+// tolerant tokenizer, so malformed markup is compared as well.
+//
+// Parameters:
+//   - a, b: Contents to compare
+//   - opts: Differences to ignore
+//
+// Returns:
+//   - bool: True if the contents are equal
+//
+// Example:
+//
+//	opts := sendlix.CompareOptions{IgnoreWhitespace: true, IgnoreComments: true, NormalizeAttributes: true}
+//	if sendlix.ContentEqual(deployed, template, opts) {
+//		return // nothing changed, skip the proof send
+//	}
+func ContentEqual(a, b MailContent, opts CompareOptions) bool {
+	return canonicalContent(a, opts) == canonicalContent(b, opts)
+}
+
+// ContentHash returns a hex-encoded SHA-256 hash of content that is equal
+// for contents ContentEqual considers equal under the same opts, for
+// example to cache which templates were deployed.
+//
+// Parameters:
+//   - content: Content to hash
+//   - opts: Differences to ignore
+//
+// Returns:
+//   - string: Hash of the content
+func ContentHash(content MailContent, opts CompareOptions) string {
+	sum := sha256.Sum256([]byte(canonicalContent(content, opts)))
+	return hex.EncodeToString(sum[:])
+}
+
+// canonicalContent serializes content so that contents equal under opts
+// produce the same string.
+func canonicalContent(content MailContent, opts CompareOptions) string {
+	text := content.Text
+	if opts.IgnoreWhitespace {
+		text = strings.Join(strings.Fields(text), " ")
+	}
+
+	// Lengths prefix the parts, so no part can imitate a boundary
+	markup := canonicalHTML(content.HTML, opts)
+	return strconv.FormatBool(content.Tracking) + ";" +
+		strconv.Itoa(len(markup)) + ":" + markup +
+		strconv.Itoa(len(text)) + ":" + text
+}
+
+// canonicalHTML re-serializes an HTML document token by token, applying
+// opts.
+func canonicalHTML(document string, opts CompareOptions) string {
+	var b strings.Builder
+	var text strings.Builder // Text since the last tag, across ignored comments
+	preformatted := 0        // Depth of <pre> and <textarea> elements
+
+	flushText := func() {
+		t := text.String()
+		text.Reset()
+		if opts.IgnoreWhitespace && preformatted == 0 {
+			t = strings.Join(strings.Fields(t), " ")
+		}
+		b.WriteString(html.EscapeString(t))
+	}
+
+	z := html.NewTokenizer(strings.NewReader(document))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			// Reading a string only ends with io.EOF
+			flushText()
+			return b.String()
+		}
+		tok := z.Token()
+		if tt == html.TextToken {
+			text.WriteString(tok.Data)
+			continue
+		}
+		if tt == html.CommentToken && opts.IgnoreComments && !isConditionalComment(tok.Data) {
+			continue
+		}
+		flushText()
+
+		switch tt {
+		case html.CommentToken:
+			b.WriteString("<!--" + tok.Data + "-->")
+		case html.DoctypeToken:
+			b.WriteString("<!doctype " + strings.ToLower(tok.Data) + ">")
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if tok.Data == "pre" || tok.Data == "textarea" {
+				preformatted++
+			}
+			b.WriteString("<" + tok.Data)
+			attrs := tok.Attr
+			if opts.NormalizeAttributes {
+				attrs = slices.Clone(attrs)
+				for i := range attrs {
+					attrs[i].Val = strings.TrimSpace(attrs[i].Val)
+				}
+				slices.SortStableFunc(attrs, func(x, y html.Attribute) int {
+					return strings.Compare(x.Key, y.Key)
+				})
+			}
+			for _, attr := range attrs {
+				b.WriteString(" " + attr.Key + `="` + html.EscapeString(attr.Val) + `"`)
+			}
+			b.WriteString(">")
+		case html.EndTagToken:
+			if (tok.Data == "pre" || tok.Data == "textarea") && preformatted > 0 {
+				preformatted--
+			}
+			b.WriteString("</" + tok.Data + ">")
+		}
+	}
+}
+
+// isConditionalComment reports whether a comment is an Outlook conditional
+// comment, such as "[if mso]>...<![endif]".
+func isConditionalComment(data string) bool {
+	data = strings.TrimSpace(data)
+	return strings.HasPrefix(data, "[if") || strings.HasSuffix(data, "[endif]")
+}
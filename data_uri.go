@@ -0,0 +1,172 @@
+package sendlix
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Size limits of data URIs, measured as the length of the encoded URI.
+// Base64 grows images by a third, and Gmail clips messages larger than
+// 102 KB, so data URIs are only suitable for small images such as logos.
+const (
+	// DataURIWarningSize is the size above which EmbedImageDataURI reports
+	// a WarningLargeDataURI, as some email clients strip large data URIs
+	DataURIWarningSize = 8 * 1024
+	// MaxDataURISize is the maximum size of a single data URI
+	MaxDataURISize = 32 * 1024
+	// MaxDataURIMessageSize is the maximum total size of the data URIs in
+	// img src attributes of one HTML document
+	MaxDataURIMessageSize = 64 * 1024
+)
+
+// imageMimeTypes maps image types to their MIME type.
+var imageMimeTypes = map[MimeType]string{
+	MimeTypePNG:  "image/png",
+	MimeTypeJPEG: "image/jpeg",
+	MimeTypeGIF:  "image/gif",
+}
+
+// EmbedImageDataURI embeds an image as a data: URI into every img element
+// of the HTML whose src attribute is placeholder. Use it for tiny images
+// such as logos; larger images should be sent as Images of MailOptions.
+//
+// Only the matching img tags are rewritten, with their attributes escaped;
+// the rest of the HTML is returned unchanged. The size of the data URI is
+// checked against MaxDataURISize, and the total size of all data URIs in the
+// result against MaxDataURIMessageSize. A data URI larger than
+// DataURIWarningSize is reported as a WarningLargeDataURI to the collector
+// of ctx (see WithWarnings). Without a client there is no
+// ClientConfig.StrictWarnings to apply; use the EmbedImageDataURI method of
+// a client to honor it.
+//
+// Parameters:
+//   - ctx: Context receiving warnings
+//   - htmlContent: HTML document or fragment
+//   - placeholder: src attribute value to replace, e.g. "{{logo}}"
+//     (required)
+//   - data: Raw image bytes
+//   - imageType: Type of the image
+//
+// Returns:
+//   - string: HTML with the image embedded
+//   - error: ErrInvalidImageType, ErrImagePlaceholderNotFound,
+//     ErrDataURITooLarge, or ErrDataURIsTooLarge
+//
+// Example:
+//
+//	content, err := sendlix.EmbedImageDataURI(ctx, `<img src="{{logo}}" alt="Logo">`, "{{logo}}", logoPNG, sendlix.MimeTypePNG)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func EmbedImageDataURI(ctx context.Context, htmlContent, placeholder string, data []byte, imageType MimeType) (string, error) {
+	return embedImageDataURI(ctx, htmlContent, placeholder, data, imageType, func(ctx context.Context, w Warning) error {
+		collectWarning(ctx, w)
+		return nil
+	})
+}
+
+// EmbedImageDataURI behaves like the package-level EmbedImageDataURI, but
+// reports WarningLargeDataURI through the client, so that it fails with
+// ErrStrictWarning if the code is listed in ClientConfig.StrictWarnings.
+//
+// Parameters:
+//   - ctx: Context receiving warnings
+//   - htmlContent: HTML document or fragment
+//   - placeholder: src attribute value to replace, e.g. "{{logo}}"
+//     (required)
+//   - data: Raw image bytes
+//   - imageType: Type of the image
+//
+// Returns:
+//   - string: HTML with the image embedded
+//   - error: ErrInvalidImageType, ErrImagePlaceholderNotFound,
+//     ErrDataURITooLarge, ErrDataURIsTooLarge, or ErrStrictWarning
+func (c *BaseClient) EmbedImageDataURI(ctx context.Context, htmlContent, placeholder string, data []byte, imageType MimeType) (string, error) {
+	return embedImageDataURI(ctx, htmlContent, placeholder, data, imageType, c.warn)
+}
+
+// embedImageDataURI implements EmbedImageDataURI, reporting warnings with
+// warn.
+func embedImageDataURI(ctx context.Context, htmlContent, placeholder string, data []byte, imageType MimeType, warn func(context.Context, Warning) error) (string, error) {
+	mimeType, ok := imageMimeTypes[imageType]
+	if !ok {
+		return "", newValidationError(CodeInvalidImageType, "imageType", map[string]string{"type": strconv.Itoa(int(imageType))})
+	}
+
+	uri := "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data)
+	if len(uri) > MaxDataURISize {
+		return "", newValidationError(CodeDataURITooLarge, "data", map[string]string{
+			"placeholder": placeholder,
+			"size":        strconv.Itoa(len(uri)),
+			"max":         strconv.Itoa(MaxDataURISize),
+		})
+	}
+
+	var b strings.Builder
+	replaced, total := 0, 0
+	z := html.NewTokenizer(strings.NewReader(htmlContent))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		// Copy the raw text first, as reading the token lowercases names in
+		// place
+		raw := string(z.Raw())
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			b.WriteString(raw)
+			continue
+		}
+
+		token := z.Token()
+		if token.Data != "img" {
+			b.WriteString(raw)
+			continue
+		}
+		matched := false
+		for i, a := range token.Attr {
+			if a.Namespace != "" || a.Key != "src" {
+				continue
+			}
+			if placeholder != "" && a.Val == placeholder {
+				token.Attr[i].Val = uri
+				matched = true
+			}
+			if len(token.Attr[i].Val) >= 5 && strings.EqualFold(token.Attr[i].Val[:5], "data:") {
+				total += len(token.Attr[i].Val)
+			}
+		}
+		if !matched {
+			b.WriteString(raw)
+			continue
+		}
+		replaced++
+		b.WriteString(token.String())
+	}
+
+	if replaced == 0 {
+		return "", newValidationError(CodeImagePlaceholderNotFound, "placeholder", map[string]string{"placeholder": placeholder})
+	}
+	if total > MaxDataURIMessageSize {
+		return "", newValidationError(CodeDataURIsTooLarge, "htmlContent", map[string]string{
+			"size": strconv.Itoa(total),
+			"max":  strconv.Itoa(MaxDataURIMessageSize),
+		})
+	}
+	if len(uri) > DataURIWarningSize {
+		if err := warn(ctx, Warning{
+			Code:    WarningLargeDataURI,
+			Field:   "data",
+			Message: fmt.Sprintf("data URI for %q is %d bytes; some email clients strip data URIs larger than %d bytes", placeholder, len(uri), DataURIWarningSize),
+		}); err != nil {
+			return "", err
+		}
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,163 @@
+package sendlix
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Sender sends emails on behalf of the package-level Send and SendGroup.
+// EmailClient implements it; tests can install a fake with
+// SetDefaultClient.
+type Sender interface {
+	SendEmail(ctx context.Context, options MailOptions, additional *AdditionalOptions) ([]string, error)
+	SendGroupEmail(ctx context.Context, data GroupMailData) error
+}
+
+// defaultClientState is the default client and the result of its lazy
+// creation.
+type defaultClientState struct {
+	once   sync.Once
+	sender Sender
+	err    error
+	owned  *EmailClient // Client created from the environment, closed by CloseDefault
+}
+
+var (
+	defaultClientMu sync.Mutex
+	defaultClient   = &defaultClientState{}
+)
+
+// DefaultClient returns the client used by Send and SendGroup. Unless one
+// was installed with SetDefaultClient, it is an EmailClient created on first
+// use, with default configuration and the API key of the SENDLIX_API_KEY
+// environment variable.
+//
+// The default client keeps its connection open for the lifetime of the
+// process, until CloseDefault is called. If its creation fails, the error
+// is returned by every later call until CloseDefault or SetDefaultClient
+// resets the default client.
+//
+// Returns:
+//   - Sender: Default client
+//   - error: ErrMissingAPIKeyEnv if SENDLIX_API_KEY is unset or empty, or
+//     another error creating the client
+func DefaultClient() (Sender, error) {
+	defaultClientMu.Lock()
+	state := defaultClient
+	defaultClientMu.Unlock()
+
+	state.once.Do(func() {
+		auth, err := NewAuthFromEnv()
+		if err != nil {
+			state.err = fmt.Errorf("failed to create the default client: %w", err)
+			return
+		}
+		client, err := NewEmailClient(OwnedAuth(auth), nil)
+		if err != nil {
+			auth.Close()
+			state.err = fmt.Errorf("failed to create the default client: %w", err)
+			return
+		}
+		state.sender, state.owned = client, client
+	})
+	return state.sender, state.err
+}
+
+// SetDefaultClient installs sender as the client of Send and SendGroup,
+// typically a fake in tests or an EmailClient with custom configuration.
+// The caller keeps ownership of sender: CloseDefault does not close it. A
+// default client created from the environment before is closed. Passing
+// nil restores lazy creation from the environment.
+//
+// Parameters:
+//   - sender: Client to use, or nil
+//
+// Example:
+//
+//	sendlix.SetDefaultClient(fake)
+//	t.Cleanup(func() { sendlix.SetDefaultClient(nil) })
+func SetDefaultClient(sender Sender) {
+	state := &defaultClientState{}
+	if sender != nil {
+		state.once.Do(func() { state.sender = sender })
+	}
+	replaceDefaultClient(state)
+}
+
+// CloseDefault closes the connection of the default client if it was
+// created from the environment, and resets the default client, so the next
+// Send creates a new one. Long-running programs using Send should call it
+// on shutdown; short-lived tools can rely on process exit. It does nothing
+// for clients installed with SetDefaultClient, which their caller closes.
+//
+// Returns:
+//   - error: Error closing the connection
+func CloseDefault() error {
+	return replaceDefaultClient(&defaultClientState{})
+}
+
+// replaceDefaultClient installs state and closes the client created from
+// the environment by the previous state, if any.
+func replaceDefaultClient(state *defaultClientState) error {
+	defaultClientMu.Lock()
+	old := defaultClient
+	defaultClient = state
+	defaultClientMu.Unlock()
+
+	// Waits for a creation in progress
+	old.once.Do(func() {})
+	if old.owned != nil {
+		return old.owned.Close()
+	}
+	return nil
+}
+
+// Send sends an email with the default client, see DefaultClient. It suits
+// small tools sending a few emails; applications should create an
+// EmailClient, whose configuration and lifecycle they control.
+//
+// Parameters:
+//   - ctx: Context for the request (supports cancellation and timeouts)
+//   - options: Email configuration including recipients, subject, and content
+//   - additional: Optional advanced settings like attachments and scheduling
+//
+// Returns:
+//   - []string: List of message IDs for the sent emails
+//   - error: Error creating the default client, or validation or sending
+//     error
+//
+// Example:
+//
+//	// SENDLIX_API_KEY=secret.keyID
+//	_, err := sendlix.Send(ctx, sendlix.MailOptions{
+//		From:    sendlix.EmailAddress{Email: "cron@example.com"},
+//		To:      []sendlix.EmailAddress{{Email: "ops@example.com"}},
+//		Subject: "Backup finished",
+//		Text:    "The nightly backup completed.",
+//	}, nil)
+func Send(ctx context.Context, options MailOptions, additional *AdditionalOptions) ([]string, error) {
+	client, err := DefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.SendEmail(ctx, options, additional)
+}
+
+// SendGroup sends an email to a group with the default client, see
+// DefaultClient.
+//
+// Parameters:
+//   - ctx: Context for the request (supports cancellation and timeouts)
+//   - data: Group email configuration
+//
+// Returns:
+//   - error: Error creating the default client, or validation or sending
+//     error
+func SendGroup(ctx context.Context, data GroupMailData) error {
+	client, err := DefaultClient()
+	if err != nil {
+		return err
+	}
+	return client.SendGroupEmail(ctx, data)
+}
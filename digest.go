@@ -0,0 +1,328 @@
+package sendlix
+
+import (
+	"bytes"
+	"html/template"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	// DefaultDigestMaxItems is the default maximum number of items of a digest.
+	DefaultDigestMaxItems = 50
+
+	// DefaultDigestMaxSize is the default maximum size of a digest's HTML in
+	// bytes. Gmail clips messages whose HTML exceeds about 102 KB.
+	DefaultDigestMaxSize = 100 * 1024
+)
+
+// Digest builds the content of digest emails, such as weekly summaries,
+// from a header, repeated items, and a footer. The HTML parts are
+// concatenated in order and a plain text part is generated from them.
+//
+// A digest enforces a maximum item count and HTML size. Items beyond the
+// budget make Build fail, unless a "view more" link is configured with
+// ViewMore, in which case the digest is truncated and ends with the link.
+//
+// Methods return the digest for chaining; errors of template items are
+// reported by Build.
+//
+// Example:
+//
+//	item := template.Must(template.New("item").Parse(`<h2><a href="{{.URL}}">{{.Title}}</a></h2><p>{{.Summary}}</p>`))
+//	digest := sendlix.NewDigest("<h1>5 new items</h1>", "<p>You receive this weekly.</p>").
+//		ViewMore("https://example.com/feed", "View all items")
+//	for _, post := range posts {
+//		digest.AddTemplateItem(item, post)
+//	}
+//	content, err := digest.Build()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+type Digest struct {
+	header, footer string
+	items          []string
+	maxItems       int
+	maxSize        int
+	moreURL        string
+	moreLabel      string
+	err            error
+}
+
+// NewDigest creates a digest with the given header and footer HTML and the
+// default limits DefaultDigestMaxItems and DefaultDigestMaxSize.
+//
+// Parameters:
+//   - headerHTML: HTML placed before the items (optional)
+//   - footerHTML: HTML placed after the items and the "view more" link (optional)
+//
+// Returns:
+//   - *Digest: Empty digest
+func NewDigest(headerHTML, footerHTML string) *Digest {
+	return &Digest{
+		header:   headerHTML,
+		footer:   footerHTML,
+		maxItems: DefaultDigestMaxItems,
+		maxSize:  DefaultDigestMaxSize,
+	}
+}
+
+// AddItem appends an item given as HTML.
+//
+// Parameters:
+//   - itemHTML: HTML of the item
+//
+// Returns:
+//   - *Digest: The digest, for chaining
+func (d *Digest) AddItem(itemHTML string) *Digest {
+	d.items = append(d.items, itemHTML)
+	return d
+}
+
+// AddTemplateItem appends an item rendered from an HTML template. Execution
+// errors are returned by Build.
+//
+// Parameters:
+//   - tmpl: Template of the item
+//   - data: Data passed to the template
+//
+// Returns:
+//   - *Digest: The digest, for chaining
+func (d *Digest) AddTemplateItem(tmpl *template.Template, data any) *Digest {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		if d.err == nil {
+			d.err = err
+		}
+		return d
+	}
+	return d.AddItem(buf.String())
+}
+
+// MaxItems sets the maximum number of items.
+//
+// Parameters:
+//   - n: Maximum item count. Default: DefaultDigestMaxItems
+//
+// Returns:
+//   - *Digest: The digest, for chaining
+func (d *Digest) MaxItems(n int) *Digest {
+	d.maxItems = n
+	return d
+}
+
+// MaxSize sets the maximum size of the digest's HTML, including header,
+// footer, and "view more" link.
+//
+// Parameters:
+//   - bytes: Maximum HTML size in bytes. Default: DefaultDigestMaxSize
+//
+// Returns:
+//   - *Digest: The digest, for chaining
+func (d *Digest) MaxSize(bytes int) *Digest {
+	d.maxSize = bytes
+	return d
+}
+
+// ViewMore enables truncation: items exceeding the item or size budget are
+// dropped and replaced by a link to url.
+//
+// Parameters:
+//   - url: Target of the link, e.g. the full list of items
+//   - label: Link text; "View more" if empty
+//
+// Returns:
+//   - *Digest: The digest, for chaining
+func (d *Digest) ViewMore(url, label string) *Digest {
+	if label == "" {
+		label = "View more"
+	}
+	d.moreURL = url
+	d.moreLabel = label
+	return d
+}
+
+// Build assembles the digest content. The text part is generated from the
+// HTML: block elements start new lines, list items are prefixed with "- ",
+// and links are followed by their URL in parentheses.
+//
+// Returns:
+//   - MailContent: HTML and text content; use it as the content of a group
+//     email or copy HTML and Text into MailOptions
+//   - error: ErrEmptyDigest if no items were added, ErrDigestTooLarge if
+//     the items exceed the budget and no "view more" link is configured, or
+//     a template execution error
+func (d *Digest) Build() (MailContent, error) {
+	if d.err != nil {
+		return MailContent{}, d.err
+	}
+	if len(d.items) == 0 {
+		return MailContent{}, newValidationError(CodeEmptyDigest, "items", nil)
+	}
+
+	size := len(d.header) + len(d.footer)
+	for _, item := range d.items {
+		size += len(item)
+	}
+	n := len(d.items)
+	if n > d.maxItems || size > d.maxSize {
+		if d.moreURL == "" {
+			reason := "content exceeds the maximum size of " + strconv.Itoa(d.maxSize) + " bytes"
+			if n > d.maxItems {
+				reason = strconv.Itoa(n) + " items exceed the maximum of " + strconv.Itoa(d.maxItems)
+			}
+			return MailContent{}, newValidationError(CodeDigestTooLarge, "items", map[string]string{"reason": reason})
+		}
+		n = d.fit()
+		if n == 0 {
+			return MailContent{}, newValidationError(CodeDigestTooLarge, "items", map[string]string{
+				"reason": "no item fits within the maximum size of " + strconv.Itoa(d.maxSize) + " bytes",
+			})
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(d.header)
+	for _, item := range d.items[:n] {
+		b.WriteString(item)
+	}
+	if n < len(d.items) {
+		b.WriteString(d.viewMoreHTML())
+	}
+	b.WriteString(d.footer)
+
+	content := b.String()
+	return MailContent{HTML: content, Text: htmlToText(content)}, nil
+}
+
+// fit returns how many items fit into the budget when followed by the
+// "view more" link.
+func (d *Digest) fit() int {
+	size := len(d.header) + len(d.footer) + len(d.viewMoreHTML())
+	n := 0
+	for _, item := range d.items {
+		if n == d.maxItems || size+len(item) > d.maxSize {
+			break
+		}
+		size += len(item)
+		n++
+	}
+	return n
+}
+
+// viewMoreHTML returns the HTML of the "view more" link.
+func (d *Digest) viewMoreHTML() string {
+	return `<p><a href="` + template.HTMLEscapeString(d.moreURL) + `">` + template.HTMLEscapeString(d.moreLabel) + `</a></p>`
+}
+
+// htmlToText renders an HTML fragment as plain text.
+func htmlToText(fragment string) string {
+	nodes, err := html.ParseFragment(strings.NewReader(fragment), &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body})
+	if err != nil {
+		return ""
+	}
+
+	var r textRenderer
+	for _, n := range nodes {
+		r.render(n)
+	}
+	return strings.TrimSpace(strings.Join(r.lines, "\n")) + "\n"
+}
+
+// textRenderer collects the lines of htmlToText.
+type textRenderer struct {
+	lines []string
+	line  strings.Builder
+}
+
+// textBlocks are the elements rendered on lines of their own.
+var textBlocks = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.H1: true, atom.H2: true, atom.H3: true,
+	atom.H4: true, atom.H5: true, atom.H6: true, atom.Li: true, atom.Ul: true,
+	atom.Ol: true, atom.Tr: true, atom.Table: true, atom.Blockquote: true,
+	atom.Hr: true,
+}
+
+// textParagraphs are the block elements followed by a blank line.
+var textParagraphs = map[atom.Atom]bool{
+	atom.P: true, atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true,
+	atom.H5: true, atom.H6: true, atom.Ul: true, atom.Ol: true, atom.Table: true,
+	atom.Blockquote: true, atom.Hr: true,
+}
+
+func (r *textRenderer) render(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		r.write(n.Data)
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.Style, atom.Script, atom.Head, atom.Title:
+		return
+	case atom.Br:
+		r.flush()
+		return
+	case atom.Img:
+		r.write(attr(n, "alt"))
+		return
+	}
+
+	block := textBlocks[n.DataAtom]
+	if block {
+		r.flush()
+	}
+	if n.DataAtom == atom.Li {
+		r.line.WriteString("- ")
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		r.render(c)
+	}
+	if n.DataAtom == atom.A {
+		if href := attr(n, "href"); href != "" && !strings.HasPrefix(href, "#") && !strings.HasPrefix(href, "mailto:") {
+			r.write(" (" + href + ")")
+		}
+	}
+	if block {
+		r.flush()
+	}
+	if textParagraphs[n.DataAtom] && len(r.lines) > 0 && r.lines[len(r.lines)-1] != "" {
+		r.lines = append(r.lines, "")
+	}
+}
+
+// write appends text to the current line, collapsing whitespace.
+func (r *textRenderer) write(text string) {
+	if text == "" {
+		return
+	}
+	if isTextSpace(text[0]) && r.line.Len() > 0 && !strings.HasSuffix(r.line.String(), " ") {
+		r.line.WriteString(" ")
+	}
+	if words := strings.Fields(text); len(words) > 0 {
+		r.line.WriteString(strings.Join(words, " "))
+		if isTextSpace(text[len(text)-1]) {
+			r.line.WriteString(" ")
+		}
+	}
+}
+
+// flush ends the current line, if it has content.
+func (r *textRenderer) flush() {
+	line := strings.TrimSpace(r.line.String())
+	r.line.Reset()
+	if line == "" || strings.TrimSpace(strings.TrimPrefix(line, "-")) == "" {
+		return
+	}
+	r.lines = append(r.lines, line)
+}
+
+func isTextSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
@@ -0,0 +1,153 @@
+package sendlix
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	pb "github.com/sendlix/go-sdk/internal/proto"
+)
+
+// MaxDisplayNameLength is the maximum number of characters allowed in a
+// display name after normalization.
+const MaxDisplayNameLength = 256
+
+// NormalizeDisplayName cleans up a display name so it renders consistently
+// in email clients. Names copied from CRMs or spreadsheets often contain
+// characters that look fine in logs but render oddly or trip spam filters.
+//
+// Normalization performs the following steps:
+//   - removes zero-width characters (e.g. U+200B, U+200D, U+FEFF)
+//   - removes bidirectional control characters (e.g. the RTL override U+202E)
+//   - removes other control and formatting characters
+//   - collapses runs of whitespace, including tabs and newlines, into one space
+//   - trims leading and trailing whitespace
+//
+// The SDK applies this normalization to every display name when building
+// requests, unless ClientConfig.DisableNameNormalization is set.
+//
+// Parameters:
+//   - name: Display name to normalize
+//
+// Returns:
+//   - string: Normalized display name
+//
+// Example:
+//
+//	name := sendlix.NormalizeDisplayName("  Jane\t\tDoe\u200b ")
+//	fmt.Println(name) // Output: "Jane Doe"
+func NormalizeDisplayName(name string) string {
+	var b strings.Builder
+	b.Grow(len(name))
+
+	pendingSpace := false
+	for _, r := range name {
+		switch {
+		case unicode.IsSpace(r):
+			pendingSpace = b.Len() > 0
+		case unicode.IsControl(r), unicode.Is(unicode.Cf, r):
+			// Zero-width, bidi, and other invisible characters
+		default:
+			if pendingSpace {
+				b.WriteByte(' ')
+				pendingSpace = false
+			}
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// ContainsBidiControl reports whether s contains Unicode bidirectional control
+// characters such as the right-to-left override (U+202E). These characters
+// are removed by NormalizeDisplayName, but their presence in a sender or
+// recipient name is often a sign of spoofing attempts and worth flagging.
+// Send methods report such names as WarningBidiControl.
+//
+// Parameters:
+//   - s: String to inspect
+//
+// Returns:
+//   - bool: true if s contains bidirectional control characters
+func ContainsBidiControl(s string) bool {
+	return strings.ContainsFunc(s, func(r rune) bool {
+		return unicode.Is(unicode.Bidi_Control, r)
+	})
+}
+
+// prepareDisplayName normalizes a display name unless normalization is
+// disabled, enforces MaxDisplayNameLength, and reports names containing
+// bidirectional control characters as WarningBidiControl.
+func (c *BaseClient) prepareDisplayName(ctx context.Context, name, field string) (string, error) {
+	if ContainsBidiControl(name) {
+		if err := c.warn(ctx, Warning{
+			Code:    WarningBidiControl,
+			Field:   field,
+			Message: "display name contains bidirectional control characters, which are often used for spoofing",
+		}); err != nil {
+			return "", err
+		}
+	}
+
+	if !c.config.DisableNameNormalization {
+		name = NormalizeDisplayName(name)
+	}
+
+	if length := utf8.RuneCountInString(name); length > MaxDisplayNameLength {
+		return "", newValidationError(CodeDisplayNameTooLong, field, map[string]string{
+			"field":  field,
+			"length": strconv.Itoa(length),
+			"max":    strconv.Itoa(MaxDisplayNameLength),
+		})
+	}
+
+	return name, nil
+}
+
+// convertEmailAddress converts an EmailAddress to the protobuf EmailData format,
+// preparing the display name according to the client configuration.
+//
+// Parameters:
+//   - ctx: Context receiving warnings
+//   - addr: EmailAddress to convert
+//   - field: Name of the field the address belongs to, used in errors
+//
+// Returns:
+//   - *pb.EmailData: Protobuf representation of the email address
+//   - error: Validation error if the display name is too long
+func (c *BaseClient) convertEmailAddress(ctx context.Context, addr EmailAddress, field string) (*pb.EmailData, error) {
+	name, err := c.prepareDisplayName(ctx, addr.Name, field)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.EmailData{
+		Email: addr.Email,
+		Name:  name,
+	}, nil
+}
+
+// convertEmailAddressList converts a slice of EmailAddress to protobuf EmailData slice.
+//
+// Parameters:
+//   - ctx: Context receiving warnings
+//   - addrs: Slice of EmailAddress to convert
+//   - field: Name of the field the addresses belong to, used in errors
+//
+// Returns:
+//   - []*pb.EmailData: Slice of protobuf EmailData representations
+//   - error: Validation error if a display name is too long
+func (c *BaseClient) convertEmailAddressList(ctx context.Context, addrs []EmailAddress, field string) ([]*pb.EmailData, error) {
+	result := make([]*pb.EmailData, len(addrs))
+	for i, addr := range addrs {
+		data, err := c.convertEmailAddress(ctx, addr, field+"["+strconv.Itoa(i)+"]")
+		if err != nil {
+			return nil, err
+		}
+		result[i] = data
+	}
+	return result, nil
+}
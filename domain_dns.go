@@ -0,0 +1,309 @@
+package sendlix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// DNSRecords are the DNS records a sending domain is expected to publish.
+type DNSRecords struct {
+	// SPF is the expected SPF mechanism, e.g. "include:spf.sendlix.com", or a
+	// complete SPF record starting with "v=spf1" (optional)
+	SPF string
+
+	// DKIM lists the expected DKIM selector records (optional)
+	DKIM []DKIMRecord
+
+	// DMARC is the expected DMARC record, e.g. "v=DMARC1; p=quarantine".
+	// Every tag given must be present with the same value; other tags are
+	// ignored. Use "v=DMARC1" to only require a DMARC record (optional)
+	DMARC string
+}
+
+// DKIMRecord is an expected DKIM selector record at
+// "<selector>._domainkey.<domain>".
+type DKIMRecord struct {
+	// Selector is the DKIM selector, e.g. "sendlix1" (required)
+	Selector string
+
+	// Value is the expected TXT value, e.g. "v=DKIM1; k=rsa; p=MIGf...".
+	// Whitespace is ignored when comparing
+	Value string
+
+	// CNAME is the host the selector record should point to. The record
+	// matches if it resolves to the same TXT value as this host, so both
+	// CNAME records and flattened copies of the target's TXT record are
+	// accepted. Used when Value is empty
+	CNAME string
+}
+
+// DNSRecordStatus is the outcome of checking one DNS record.
+type DNSRecordStatus int
+
+const (
+	// DNSRecordFound means the record is published as expected
+	DNSRecordFound DNSRecordStatus = iota
+	// DNSRecordMissing means no record of the expected kind exists
+	DNSRecordMissing
+	// DNSRecordMismatch means a record exists but differs from the expected
+	// value; see DNSRecordCheck.Actual
+	DNSRecordMismatch
+	// DNSRecordLookupFailed means the lookup failed, e.g. with a timeout;
+	// see DNSRecordCheck.Err
+	DNSRecordLookupFailed
+)
+
+// String returns the name of the status.
+func (s DNSRecordStatus) String() string {
+	switch s {
+	case DNSRecordFound:
+		return "found"
+	case DNSRecordMissing:
+		return "missing"
+	case DNSRecordMismatch:
+		return "mismatched"
+	case DNSRecordLookupFailed:
+		return "lookup-failed"
+	default:
+		return fmt.Sprintf("DNSRecordStatus(%d)", int(s))
+	}
+}
+
+// DNSRecordCheck is the result of checking one expected DNS record.
+type DNSRecordCheck struct {
+	// Type is the record kind: "SPF", "DKIM", or "DMARC"
+	Type string
+	// Name is the queried host name, e.g. "_dmarc.example.com"
+	Name string
+	// Expected is the expected value
+	Expected string
+	// Actual contains the published values of the record kind, if any
+	Actual []string
+	// Status is the outcome of the check
+	Status DNSRecordStatus
+	// Detail explains a mismatch, e.g. "p is \"none\", expected \"quarantine\""
+	Detail string
+	// Err is the lookup error for DNSRecordLookupFailed
+	Err error
+}
+
+// CheckDomainDNS checks whether a sending domain publishes the expected SPF,
+// DKIM, and DMARC records. Use it to give actionable feedback while a domain
+// is being set up, before asking Sendlix to verify it.
+//
+// Every expected record is checked independently; lookup failures are
+// reported per record rather than as an error.
+//
+// Parameters:
+//   - ctx: Context for the DNS lookups (supports cancellation and timeouts)
+//   - domain: Sending domain, e.g. "example.com" (required)
+//   - expected: Records the domain should publish
+//   - resolver: Resolver to use, e.g. for split-horizon DNS
+//     (optional, uses net.DefaultResolver if nil)
+//
+// Returns:
+//   - []DNSRecordCheck: Results in the order SPF, DKIM, DMARC
+//   - error: ErrMissingDomain if domain is empty
+//
+// Example:
+//
+//	checks, err := sendlix.CheckDomainDNS(ctx, "example.com", sendlix.DNSRecords{
+//		SPF:   "include:spf.sendlix.com",
+//		DKIM:  []sendlix.DKIMRecord{{Selector: "sendlix1", CNAME: "sendlix1.dkim.sendlix.com"}},
+//		DMARC: "v=DMARC1",
+//	}, nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, check := range checks {
+//		if check.Status != sendlix.DNSRecordFound {
+//			fmt.Printf("%s record at %s is %s: %s\n", check.Type, check.Name, check.Status, check.Detail)
+//		}
+//	}
+func CheckDomainDNS(ctx context.Context, domain string, expected DNSRecords, resolver *net.Resolver) ([]DNSRecordCheck, error) {
+	domain = strings.TrimSuffix(strings.TrimSpace(domain), ".")
+	if domain == "" {
+		return nil, newValidationError(CodeMissingDomain, "domain", nil)
+	}
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	var checks []DNSRecordCheck
+	if expected.SPF != "" {
+		checks = append(checks, checkSPF(ctx, resolver, domain, expected.SPF))
+	}
+	for _, dkim := range expected.DKIM {
+		checks = append(checks, checkDKIM(ctx, resolver, domain, dkim))
+	}
+	if expected.DMARC != "" {
+		checks = append(checks, checkDMARC(ctx, resolver, domain, expected.DMARC))
+	}
+	return checks, nil
+}
+
+// lookupTXT returns the TXT records of name. A nonexistent name or one
+// without TXT records yields no records and no error.
+func lookupTXT(ctx context.Context, resolver *net.Resolver, name string) ([]string, error) {
+	records, err := resolver.LookupTXT(ctx, name)
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return nil, nil
+	}
+	return records, err
+}
+
+// txtWithPrefix returns the records starting with prefix, ignoring case.
+func txtWithPrefix(records []string, prefix string) []string {
+	var result []string
+	for _, record := range records {
+		if len(record) >= len(prefix) && strings.EqualFold(record[:len(prefix)], prefix) {
+			result = append(result, record)
+		}
+	}
+	return result
+}
+
+func checkSPF(ctx context.Context, resolver *net.Resolver, domain, expected string) DNSRecordCheck {
+	check := DNSRecordCheck{Type: "SPF", Name: domain, Expected: expected}
+	records, err := lookupTXT(ctx, resolver, domain)
+	if err != nil {
+		check.Status, check.Err = DNSRecordLookupFailed, err
+		return check
+	}
+
+	check.Actual = txtWithPrefix(records, "v=spf1")
+	switch {
+	case len(check.Actual) == 0:
+		check.Status = DNSRecordMissing
+	case len(check.Actual) > 1:
+		check.Status = DNSRecordMismatch
+		check.Detail = "multiple SPF records are published; merge them into one"
+	case len(txtWithPrefix([]string{expected}, "v=spf1")) == 1:
+		if strings.EqualFold(strings.Join(strings.Fields(check.Actual[0]), " "), strings.Join(strings.Fields(expected), " ")) {
+			check.Status = DNSRecordFound
+		} else {
+			check.Status = DNSRecordMismatch
+			check.Detail = "SPF record differs from the expected record"
+		}
+	default:
+		check.Status = DNSRecordMismatch
+		check.Detail = fmt.Sprintf("SPF record does not contain %q", expected)
+		for _, term := range strings.Fields(check.Actual[0]) {
+			if strings.EqualFold(term, expected) {
+				check.Status, check.Detail = DNSRecordFound, ""
+			}
+		}
+	}
+	return check
+}
+
+func checkDKIM(ctx context.Context, resolver *net.Resolver, domain string, expected DKIMRecord) DNSRecordCheck {
+	check := DNSRecordCheck{Type: "DKIM", Name: expected.Selector + "._domainkey." + domain, Expected: expected.Value}
+	if check.Expected == "" {
+		check.Expected = "CNAME " + expected.CNAME
+	}
+
+	records, err := lookupTXT(ctx, resolver, check.Name)
+	if err != nil {
+		check.Status, check.Err = DNSRecordLookupFailed, err
+		return check
+	}
+	check.Actual = records
+	if len(records) == 0 {
+		check.Status = DNSRecordMissing
+		return check
+	}
+
+	want := []string{expected.Value}
+	if expected.Value == "" {
+		// The target's TXT records are what a CNAME or its flattened copy
+		// resolves to
+		want, err = lookupTXT(ctx, resolver, expected.CNAME)
+		if err != nil {
+			check.Status, check.Err = DNSRecordLookupFailed, fmt.Errorf("lookup of CNAME target: %w", err)
+			return check
+		}
+		if len(want) == 0 {
+			check.Status = DNSRecordMismatch
+			check.Detail = fmt.Sprintf("CNAME target %q has no TXT record", expected.CNAME)
+			return check
+		}
+	}
+
+	for _, record := range records {
+		for _, w := range want {
+			if stripSpace(record) == stripSpace(w) {
+				check.Status = DNSRecordFound
+				return check
+			}
+		}
+	}
+	check.Status = DNSRecordMismatch
+	check.Detail = "DKIM key differs from the expected key"
+	return check
+}
+
+func checkDMARC(ctx context.Context, resolver *net.Resolver, domain, expected string) DNSRecordCheck {
+	check := DNSRecordCheck{Type: "DMARC", Name: "_dmarc." + domain, Expected: expected}
+	records, err := lookupTXT(ctx, resolver, check.Name)
+	if err != nil {
+		check.Status, check.Err = DNSRecordLookupFailed, err
+		return check
+	}
+
+	check.Actual = txtWithPrefix(records, "v=DMARC1")
+	if len(check.Actual) == 0 {
+		check.Status = DNSRecordMissing
+		return check
+	}
+	if len(check.Actual) > 1 {
+		check.Status = DNSRecordMismatch
+		check.Detail = "multiple DMARC records are published; receivers ignore all of them"
+		return check
+	}
+
+	actual := parseTags(check.Actual[0])
+	var mismatches []string
+	for _, tag := range strings.Split(expected, ";") {
+		name, value, _ := strings.Cut(tag, "=")
+		name, value = strings.ToLower(strings.TrimSpace(name)), strings.TrimSpace(value)
+		if name == "" {
+			continue
+		}
+		got, ok := actual[name]
+		switch {
+		case !ok:
+			mismatches = append(mismatches, fmt.Sprintf("%s is missing, expected %q", name, value))
+		case !strings.EqualFold(got, value):
+			mismatches = append(mismatches, fmt.Sprintf("%s is %q, expected %q", name, got, value))
+		}
+	}
+	if len(mismatches) > 0 {
+		check.Status = DNSRecordMismatch
+		check.Detail = strings.Join(mismatches, "; ")
+		return check
+	}
+	check.Status = DNSRecordFound
+	return check
+}
+
+// parseTags parses a "name=value; ..." tag list with lowercase names.
+func parseTags(record string) map[string]string {
+	tags := make(map[string]string)
+	for _, tag := range strings.Split(record, ";") {
+		name, value, ok := strings.Cut(tag, "=")
+		if ok {
+			tags[strings.ToLower(strings.TrimSpace(name))] = strings.TrimSpace(value)
+		}
+	}
+	return tags
+}
+
+// stripSpace removes all whitespace from s.
+func stripSpace(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
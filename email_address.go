@@ -0,0 +1,57 @@
+package sendlix
+
+import "strings"
+
+// NormalizeEmail returns the canonical form of an email address used for
+// group membership operations. GroupClient applies it to every address it
+// sends, so "User@Example.COM " and "User@example.com" refer to the same member.
+//
+// Normalization performs the following steps:
+//   - trims surrounding whitespace
+//   - strips surrounding angle brackets, as in "<user@example.com>"
+//   - lowercases the domain, which is case-insensitive by definition
+//   - lowercases the local part only if lowercaseLocalPart is set
+//
+// The local part (before the @) is case-sensitive according to RFC 5321, so
+// lowercasing it is lossy in theory. In practice virtually all mailbox
+// providers treat it case-insensitively; enable ClientConfig.LowercaseEmailLocalPart
+// when addresses reach your groups with inconsistent casing.
+//
+// Normalizing an already normalized address returns it unchanged.
+//
+// Parameters:
+//   - email: Email address to normalize
+//   - lowercaseLocalPart: Whether to lowercase the local part as well
+//
+// Returns:
+//   - string: Normalized email address
+//
+// Example:
+//
+//	sendlix.NormalizeEmail(" <John.Doe@Example.COM>", false) // "John.Doe@example.com"
+//	sendlix.NormalizeEmail(" <John.Doe@Example.COM>", true)  // "john.doe@example.com"
+func NormalizeEmail(email string, lowercaseLocalPart bool) string {
+	email = strings.TrimSpace(email)
+	if strings.HasPrefix(email, "<") && strings.HasSuffix(email, ">") {
+		email = strings.TrimSpace(email[1 : len(email)-1])
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		if lowercaseLocalPart {
+			return strings.ToLower(email)
+		}
+		return email
+	}
+
+	local, domain := email[:at], email[at+1:]
+	if lowercaseLocalPart {
+		local = strings.ToLower(local)
+	}
+	return local + "@" + strings.ToLower(domain)
+}
+
+// normalizeEmail applies NormalizeEmail according to the client configuration.
+func (c *BaseClient) normalizeEmail(email string) string {
+	return NormalizeEmail(email, c.config.LowercaseEmailLocalPart)
+}
@@ -2,10 +2,15 @@ package sendlix
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"strconv"
 	"time"
 
 	pb "github.com/sendlix/go-sdk/internal/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -17,7 +22,12 @@ import (
 // All email operations require proper authentication through the configured IAuth implementation.
 type EmailClient struct {
 	*BaseClient
-	client pb.EmailClient
+	client     pb.EmailClient
+	quota      *quotaTracker
+	recipients *recipientCache
+	spool      *sendSpool
+	defaults   Defaults
+	derived    bool
 }
 
 // NewEmailClient creates a new email client with the provided authentication and configuration.
@@ -52,20 +62,45 @@ type EmailClient struct {
 //	}
 //	defer client.Close()
 func NewEmailClient(auth interface{}, config *ClientConfig) (*EmailClient, error) {
-	resolvedAuth, err := resolveAuth(auth)
+	if err := validateEmailConfig(config); err != nil {
+		return nil, err
+	}
+
+	resolvedAuth, err := resolveAuth(auth, config)
 	if err != nil {
 		return nil, err
 	}
 
 	baseClient, err := NewBaseClient(resolvedAuth, config)
 	if err != nil {
+		closeResolvedAuth(auth, resolvedAuth)
 		return nil, err
 	}
 
+	return newEmailClient(baseClient), nil
+}
+
+// newEmailClient creates an email client using the connection of baseClient.
+func newEmailClient(baseClient *BaseClient) *EmailClient {
 	return &EmailClient{
 		BaseClient: baseClient,
-		client:     pb.NewEmailClient(baseClient.GetConnection()),
-	}, nil
+		client:     pb.NewEmailClient(baseClient.stubConn()),
+		quota:      &quotaTracker{},
+		recipients: &recipientCache{},
+		spool:      newSendSpool(baseClient.config.SpoolOnUnavailable),
+	}
+}
+
+// validateEmailConfig checks the settings of config used only by
+// EmailClient.
+func validateEmailConfig(config *ClientConfig) error {
+	if config == nil {
+		return nil
+	}
+	if err := validateQuietHours(config.QuietHours); err != nil {
+		return err
+	}
+	return validateSpool(config.SpoolOnUnavailable)
 }
 
 // EmailAddress represents an email address with an optional display name.
@@ -74,10 +109,13 @@ func NewEmailClient(auth interface{}, config *ClientConfig) (*EmailClient, error
 // The display name is optional and when provided, creates email addresses in the format
 // "Display Name <email@domain.com>". When omitted, only the email address is used.
 type EmailAddress struct {
-	// Email is the email address (required)
+	// Email is the email address (required unless Ref is set)
 	Email string
 	// Name is the optional display name for the email address
 	Name string
+	// Ref is a recipient reference resolved by ClientConfig.RecipientResolver
+	// when sending; Email and Name are ignored if it is set. See RecipientRef.
+	Ref string
 }
 
 // String returns a properly formatted string representation of the email address.
@@ -135,7 +173,7 @@ func NewEmailAddress(addr interface{}) (*EmailAddress, error) {
 	case *EmailAddress:
 		return v, nil
 	default:
-		return nil, fmt.Errorf("invalid email address type: %T", addr)
+		return nil, newValidationError(CodeInvalidEmailAddressType, "addr", map[string]string{"type": fmt.Sprintf("%T", addr)})
 	}
 }
 
@@ -186,6 +224,10 @@ type Image struct {
 
 // Attachment represents a file attachment for email messages.
 // Attachments are referenced by URL and include metadata for proper handling.
+//
+// Attachments created with NewAttachmentFromBytes, NewAttachmentFromReader, or
+// NewAttachmentFromFile carry their content instead of a URL and are uploaded
+// through ClientConfig.AttachmentUploader before sending.
 type Attachment struct {
 	// ContentURL is the URL where the attachment content can be retrieved
 	ContentURL string
@@ -195,6 +237,9 @@ type Attachment struct {
 
 	// ContentType is the MIME type of the attachment (e.g., "application/pdf")
 	ContentType string
+
+	// open returns the content of content-based attachments
+	open func() (io.ReadCloser, error)
 }
 
 // MailOptions contains all the required and optional parameters for sending an email.
@@ -241,13 +286,16 @@ type MailOptions struct {
 // These options allow for advanced features like scheduling and file attachments.
 type AdditionalOptions struct {
 	// Attachments is a list of files to attach to the email (optional)
+	// Every attachment needs a ContentURL or content
 	Attachments []Attachment
 
 	// Category is used for email categorization and analytics (optional)
+	// An empty category is not sent
 	Category string
 
 	// SendAt schedules the email to be sent at a specific time (optional)
-	// If nil, the email is sent immediately
+	// If nil, the email is sent immediately. A pointer to the zero time is
+	// rejected with ErrZeroSendAt rather than sent as a 1970 timestamp.
 	SendAt *time.Time
 }
 
@@ -288,7 +336,14 @@ type GroupMailData struct {
 //
 // Returns:
 //   - []string: List of message IDs for the sent emails
-//   - error: Validation or sending error
+//   - error: Validation or sending error, or an error wrapping ErrSpooled
+//     if ClientConfig.SpoolOnUnavailable retained the email for later
+//     delivery
+//
+// The API returns message IDs without any per-recipient correlation data, so
+// the order of the returned IDs is not guaranteed to match the order of To,
+// CC, or BCC. Do not assume that the i-th ID belongs to To[i]; correlate IDs
+// through delivery webhooks instead.
 //
 // Example:
 //
@@ -322,23 +377,154 @@ type GroupMailData struct {
 //   - Authentication failures
 //   - Network connectivity issues
 func (c *EmailClient) SendEmail(ctx context.Context, options MailOptions, additional *AdditionalOptions) ([]string, error) {
+	options, additional = c.applyDefaults(options, additional)
+
+	options, err := c.resolveRecipients(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	additional, err = c.applyQuietHours(ctx, additional)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.buildSendMailRequest(ctx, options, additional)
+	if err != nil {
+		return nil, err
+	}
+
+	record := SendRecord{
+		Type:       SendTypeEmail,
+		Recipients: recipientEmails(options),
+	}
+	if additional != nil {
+		record.Category = additional.Category
+	}
+	if err := c.beforeSend(ctx, record); err != nil {
+		return nil, err
+	}
+
+	// Attachments are uploaded only once the request is known to be valid,
+	// so a rejected send never leaves uploaded objects behind.
+	additional, uploaded, err := c.uploadAttachments(ctx, additional)
+	if err != nil {
+		return nil, err
+	}
+	if len(uploaded) > 0 {
+		req.AdditionalInfos = convertAdditionalOptions(additional)
+	}
+
+	if err := c.checkRequestSize(req, "options"); err != nil {
+		c.cleanupAttachments(ctx, uploaded)
+		return nil, err
+	}
+
+	reserved := int64(len(options.To) + len(options.CC) + len(options.BCC))
+
+	spool := func(cause error) error {
+		return c.spool.add(&spoolEntry{
+			email:    SpooledEmail{Options: options, Additional: additional},
+			client:   c,
+			ctx:      context.WithoutCancel(ctx),
+			req:      req,
+			reserved: reserved,
+			uploaded: uploaded,
+			record:   record,
+		}, cause)
+	}
+	// Spooled emails are delivered first, so later sends keep their order
+	if c.spool != nil && c.spool.holding() {
+		return nil, spool(errSpoolBacklog)
+	}
+
+	messageIDs, err := c.deliver(ctx, req, reserved, record)
+	if c.spool != nil && status.Code(err) == codes.Unavailable {
+		return nil, spool(err)
+	}
+	if err != nil && messageIDs == nil {
+		c.cleanupAttachments(ctx, uploaded)
+	}
+	return messageIDs, err
+}
+
+// deliver sends a request built by buildSendMailRequest and records the
+// send, coordinating the quota around it.
+//
+// Parameters:
+//   - ctx: Context for the request
+//   - req: Request to send
+//   - reserved: Number of recipients to reserve quota for
+//   - record: Record of the send without message and request IDs
+//
+// Returns:
+//   - []string: Message IDs, or nil if the email was not sent
+//   - error: Quota, send, or recording error
+func (c *EmailClient) deliver(ctx context.Context, req *pb.SendMailRequest, reserved int64, record SendRecord) ([]string, error) {
+	if err := c.reserveQuota(ctx, reserved); err != nil {
+		return nil, err
+	}
+
+	callCtx, requestID := captureRequestID(ctx, nil)
+	resp, err := c.client.SendEmail(callCtx, req)
+	c.settleQuota(ctx, reserved, resp, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send email: %w", c.mapSendError(err, ""))
+	}
+	c.trackQuota(resp.EmailsLeft)
+	c.observeSent(resp)
+
+	record.MessageIDs = resp.Message
+	record.RequestID = requestID.get()
+	if err := c.recordSend(ctx, record); err != nil {
+		return resp.Message, err
+	}
+
+	return resp.Message, nil
+}
+
+// buildSendMailRequest validates the options and converts them into the
+// protobuf request sent by SendEmail. EstimateRequestSize uses the same
+// conversion so size estimates always match what is sent.
+//
+// Parameters:
+//   - ctx: Context receiving the warnings of advisory checks
+//   - options: Email configuration including recipients, subject, and content
+//   - additional: Optional advanced settings like attachments and scheduling
+//
+// Returns:
+//   - *pb.SendMailRequest: Request ready to be sent
+//   - error: Validation error
+func (c *EmailClient) buildSendMailRequest(ctx context.Context, options MailOptions, additional *AdditionalOptions) (*pb.SendMailRequest, error) {
 	// Validate required fields
 	if options.From.Email == "" {
-		return nil, fmt.Errorf("from email is required")
+		return nil, newValidationError(CodeMissingFrom, "From", nil)
 	}
 	if len(options.To) == 0 {
-		return nil, fmt.Errorf("at least one recipient is required")
+		return nil, newValidationError(CodeMissingRecipients, "To", nil)
 	}
 	if options.Subject == "" {
-		return nil, fmt.Errorf("subject is required")
+		return nil, newValidationError(CodeMissingSubject, "Subject", nil)
 	}
 	if options.Html == "" && options.Text == "" {
-		return nil, fmt.Errorf("either HTML or text content is required")
+		return nil, newValidationError(CodeMissingContent, "Html", nil)
+	}
+
+	if err := c.checkSender(ctx, options.From, options.ReplyTo); err != nil {
+		return nil, err
+	}
+	if err := c.checkPlaceholders(ctx, "Content", options.Images, options.Subject, options.Html, options.Text); err != nil {
+		return nil, err
+	}
+
+	html, err := c.prepareHTML(ctx, "Html", options.Html)
+	if err != nil {
+		return nil, err
 	}
 
 	// Build mail content
 	mailContent := &pb.MailContent{
-		Html:     options.Html,
+		Html:     html,
 		Text:     options.Text,
 		Tracking: options.Tracking,
 	}
@@ -349,9 +535,18 @@ func (c *EmailClient) SendEmail(ctx context.Context, options MailOptions, additi
 	}
 
 	// Build request
+	from, err := c.convertEmailAddress(ctx, options.From, "From")
+	if err != nil {
+		return nil, err
+	}
+	to, err := c.convertEmailAddressList(ctx, options.To, "To")
+	if err != nil {
+		return nil, err
+	}
+
 	req := &pb.SendMailRequest{
-		From:    convertEmailAddress(options.From),
-		To:      convertEmailAddressList(options.To),
+		From:    from,
+		To:      to,
 		Subject: options.Subject,
 		Body: &pb.SendMailRequest_TextContent{
 			TextContent: mailContent,
@@ -360,27 +555,33 @@ func (c *EmailClient) SendEmail(ctx context.Context, options MailOptions, additi
 
 	// Add optional fields
 	if len(options.CC) > 0 {
-		req.Cc = convertEmailAddressList(options.CC)
+		if req.Cc, err = c.convertEmailAddressList(ctx, options.CC, "CC"); err != nil {
+			return nil, err
+		}
 	}
 	if len(options.BCC) > 0 {
-		req.Bcc = convertEmailAddressList(options.BCC)
+		if req.Bcc, err = c.convertEmailAddressList(ctx, options.BCC, "BCC"); err != nil {
+			return nil, err
+		}
 	}
 	if options.ReplyTo != nil {
-		req.ReplyTo = convertEmailAddress(*options.ReplyTo)
+		if req.ReplyTo, err = c.convertEmailAddress(ctx, *options.ReplyTo, "ReplyTo"); err != nil {
+			return nil, err
+		}
 	}
 
 	// Add additional options
 	if additional != nil {
+		if err := c.validateCategory(additional.Category, "Category"); err != nil {
+			return nil, err
+		}
+		if err := validateAdditionalOptions(additional); err != nil {
+			return nil, err
+		}
 		req.AdditionalInfos = convertAdditionalOptions(additional)
 	}
 
-	// Send request
-	resp, err := c.client.SendEmail(ctx, req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send email: %v", err)
-	}
-
-	return resp.Message, nil
+	return req, nil
 }
 
 // SendEMLEmail sends an email using EML (Email Message Format) data.
@@ -413,6 +614,34 @@ func (c *EmailClient) SendEmail(ctx context.Context, options MailOptions, additi
 // The EML data should be a complete, valid email message including headers
 // and body. Invalid EML format will result in parsing errors.
 func (c *EmailClient) SendEMLEmail(ctx context.Context, emlData []byte, additional *AdditionalOptions) ([]string, error) {
+	additional = c.applyDefaultCategory(additional)
+	if additional != nil {
+		if err := c.validateCategory(additional.Category, "Category"); err != nil {
+			return nil, err
+		}
+		if err := validateAdditionalOptions(additional); err != nil {
+			return nil, err
+		}
+	}
+
+	additional, err := c.applyQuietHours(ctx, additional)
+	if err != nil {
+		return nil, err
+	}
+
+	record := SendRecord{Type: SendTypeEML}
+	if additional != nil {
+		record.Category = additional.Category
+	}
+	if err := c.beforeSend(ctx, record); err != nil {
+		return nil, err
+	}
+
+	additional, uploaded, err := c.uploadAttachments(ctx, additional)
+	if err != nil {
+		return nil, err
+	}
+
 	req := &pb.EmlMailRequest{
 		Mail: emlData,
 	}
@@ -421,9 +650,30 @@ func (c *EmailClient) SendEMLEmail(ctx context.Context, emlData []byte, addition
 		req.AdditionalInfos = convertAdditionalOptions(additional)
 	}
 
-	resp, err := c.client.SendEmlEmail(ctx, req)
+	if err := c.checkRequestSize(req, "emlData"); err != nil {
+		c.cleanupAttachments(ctx, uploaded)
+		return nil, err
+	}
+
+	if err := c.reserveQuota(ctx, 1); err != nil {
+		c.cleanupAttachments(ctx, uploaded)
+		return nil, err
+	}
+
+	callCtx, requestID := captureRequestID(ctx, nil)
+	resp, err := c.client.SendEmlEmail(callCtx, req)
+	c.settleQuota(ctx, 1, resp, err)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send EML email: %v", err)
+		c.cleanupAttachments(ctx, uploaded)
+		return nil, fmt.Errorf("failed to send EML email: %w", c.mapSendError(err, ""))
+	}
+	c.trackQuota(resp.EmailsLeft)
+	c.observeSent(resp)
+
+	record.MessageIDs = resp.Message
+	record.RequestID = requestID.get()
+	if err := c.recordSend(ctx, record); err != nil {
+		return resp.Message, err
 	}
 
 	return resp.Message, nil
@@ -458,87 +708,140 @@ func (c *EmailClient) SendEMLEmail(ctx context.Context, emlData []byte, addition
 //	})
 //
 // The group must exist and contain email addresses before calling this method.
-// Empty groups will not generate an error but will result in zero emails sent.
+// Empty groups will not generate an error but will result in zero emails sent,
+// unless ClientConfig.FailOnEmptyGroup is set, in which case ErrEmptyGroup is
+// returned.
 func (c *EmailClient) SendGroupEmail(ctx context.Context, data GroupMailData) error {
+	if data.From.Email == "" {
+		data.From = c.defaults.From
+	}
+	if data.Category == "" {
+		data.Category = c.defaults.Category
+	}
+
 	if data.GroupID == "" {
-		return fmt.Errorf("group ID is required")
+		return newValidationError(CodeMissingGroupID, "GroupID", nil)
 	}
 	if data.From.Email == "" {
-		return fmt.Errorf("from email is required")
+		return newValidationError(CodeMissingFrom, "From", nil)
 	}
 	if data.Subject == "" {
-		return fmt.Errorf("subject is required")
+		return newValidationError(CodeMissingSubject, "Subject", nil)
 	}
 	if data.Content.HTML == "" && data.Content.Text == "" {
-		return fmt.Errorf("either HTML or text content is required")
+		return newValidationError(CodeMissingContent, "Content", nil)
+	}
+	if err := c.validateCategory(data.Category, "Category"); err != nil {
+		return err
+	}
+	if _, err := c.checkQuietHours(ctx, data.Category, nil, false); err != nil {
+		return err
+	}
+	if err := c.checkSender(ctx, data.From, nil); err != nil {
+		return err
+	}
+	if err := c.checkPlaceholders(ctx, "Content", nil, data.Subject, data.Content.HTML, data.Content.Text); err != nil {
+		return err
+	}
+
+	from, err := c.convertEmailAddress(ctx, data.From, "From")
+	if err != nil {
+		return err
+	}
+
+	html, err := c.prepareHTML(ctx, "Content.HTML", data.Content.HTML)
+	if err != nil {
+		return err
+	}
+
+	record := SendRecord{
+		Type:     SendTypeGroup,
+		GroupID:  data.GroupID,
+		Category: data.Category,
+	}
+	if err := c.beforeSend(ctx, record); err != nil {
+		return err
 	}
 
 	req := &pb.GroupMailData{
 		GroupId:  data.GroupID,
 		Subject:  data.Subject,
-		From:     convertEmailAddress(data.From),
+		From:     from,
 		Category: data.Category,
 		Body: &pb.GroupMailData_TextContent{
 			TextContent: &pb.MailContent{
-				Html:     data.Content.HTML,
+				Html:     html,
 				Text:     data.Content.Text,
 				Tracking: data.Content.Tracking,
 			},
 		},
 	}
 
-	_, err := c.client.SendGroupEmail(ctx, req)
+	callCtx, requestID := captureRequestID(ctx, nil)
+	resp, err := c.client.SendGroupEmail(callCtx, req)
+	c.settleQuota(ctx, 0, resp, err)
 	if err != nil {
-		return fmt.Errorf("failed to send group email: %v", err)
+		return fmt.Errorf("failed to send group email: %w", c.mapSendError(err, data.GroupID))
 	}
+	c.trackQuota(resp.EmailsLeft)
+	c.observeSent(resp)
 
-	return nil
+	if c.config.FailOnEmptyGroup && len(resp.Message) == 0 {
+		return fmt.Errorf("%w: group %q", ErrEmptyGroup, data.GroupID)
+	}
+
+	record.MessageIDs = resp.Message
+	record.RequestID = requestID.get()
+	return c.recordSend(ctx, record)
 }
 
-// Helper functions for converting between SDK types and protobuf types
+// ErrEmptyGroup is returned by SendGroupEmail when ClientConfig.FailOnEmptyGroup
+// is set and the API sent no emails because the group has no members.
+var ErrEmptyGroup = errors.New("group has no members, no emails were sent")
 
-// convertEmailAddress converts an EmailAddress to the protobuf EmailData format.
-// This helper function is used internally to transform SDK types to the format
-// expected by the gRPC API.
-//
-// Parameters:
-//   - addr: EmailAddress to convert
-//
-// Returns:
-//   - *pb.EmailData: Protobuf representation of the email address
-func convertEmailAddress(addr EmailAddress) *pb.EmailData {
-	return &pb.EmailData{
-		Email: addr.Email,
-		Name:  addr.Name,
-	}
-}
+// Helper functions for converting between SDK types and protobuf types
 
-// convertEmailAddressList converts a slice of EmailAddress to protobuf EmailData slice.
-// This helper function transforms multiple email addresses for batch operations.
+// validateAdditionalOptions checks AdditionalOptions for zero values that
+// have no meaningful wire representation.
 //
 // Parameters:
-//   - addrs: Slice of EmailAddress to convert
+//   - opts: AdditionalOptions to validate
 //
 // Returns:
-//   - []*pb.EmailData: Slice of protobuf EmailData representations
-func convertEmailAddressList(addrs []EmailAddress) []*pb.EmailData {
-	result := make([]*pb.EmailData, len(addrs))
-	for i, addr := range addrs {
-		result[i] = convertEmailAddress(addr)
+//   - error: ErrZeroSendAt if SendAt points at the zero time, or
+//     ErrEmptyAttachment for the first attachment without a content URL or
+//     content
+func validateAdditionalOptions(opts *AdditionalOptions) error {
+	if opts.SendAt != nil && opts.SendAt.IsZero() {
+		return newValidationError(CodeZeroSendAt, "SendAt", nil)
+	}
+	for i, att := range opts.Attachments {
+		if att.ContentURL == "" && att.open == nil {
+			return newValidationError(CodeEmptyAttachment, "Attachments", map[string]string{"index": strconv.Itoa(i)})
+		}
 	}
-	return result
+	return nil
 }
 
 // convertAdditionalOptions converts AdditionalOptions to protobuf AdditionalInfos format.
 // This helper function handles the transformation of advanced email options including
-// attachments, scheduling, and categorization settings.
+// attachments, scheduling, and categorization settings. The options must have
+// passed validateAdditionalOptions.
+//
+// Unset fields are left out, and options without any set field produce no
+// AdditionalInfos at all, so equal options always produce the same request.
 //
 // Parameters:
 //   - opts: AdditionalOptions to convert
 //
 // Returns:
-//   - *pb.AdditionalInfos: Protobuf representation of additional options
+//   - *pb.AdditionalInfos: Protobuf representation of additional options, or
+//     nil if no option is set
 func convertAdditionalOptions(opts *AdditionalOptions) *pb.AdditionalInfos {
+	if opts.Category == "" && len(opts.Attachments) == 0 && opts.SendAt == nil {
+		return nil
+	}
+
 	info := &pb.AdditionalInfos{
 		Category: opts.Category,
 	}
@@ -583,20 +886,39 @@ func convertImages(images []Image) []*pb.Images {
 
 // resolveAuth converts an auth parameter to an IAuth implementation.
 // It accepts either an IAuth implementation directly or an API key string.
+// The Auth created for an API key connects according to config and is owned
+// by the client, which closes it.
 //
 // Parameters:
 //   - auth: Either an IAuth implementation or an API key string
+//   - config: Client configuration used for the auth connection (optional)
 //
 // Returns:
 //   - IAuth: Resolved authentication implementation
 //   - error: Error if the auth type is invalid or API key parsing fails
-func resolveAuth(auth interface{}) (IAuth, error) {
+func resolveAuth(auth interface{}, config *ClientConfig) (IAuth, error) {
 	switch v := auth.(type) {
 	case IAuth:
 		return v, nil
 	case string:
-		return NewAuth(v)
+		a, err := NewAuthWithConfig(v, config)
+		if err != nil {
+			return nil, err
+		}
+		return OwnedAuth(a), nil
 	default:
-		return nil, fmt.Errorf("invalid auth type: %T, expected IAuth or string", auth)
+		return nil, newValidationError(CodeInvalidAuthType, "auth", map[string]string{"type": fmt.Sprintf("%T", auth)})
+	}
+}
+
+// closeResolvedAuth closes the Auth that resolveAuth created from an API key
+// string, for constructors failing after resolving auth. An IAuth passed by
+// the caller stays open, as the caller closes it.
+func closeResolvedAuth(auth interface{}, resolved IAuth) {
+	if _, ok := auth.(string); !ok {
+		return
+	}
+	if owned, ok := resolved.(ownedAuth); ok {
+		owned.Close()
 	}
 }
@@ -0,0 +1,161 @@
+package sendlix
+
+import "context"
+
+// Defaults are values applied by a derived EmailClient to every send that
+// does not set them itself. See EmailClient.WithDefaults.
+type Defaults struct {
+	// From is used when a send has no From address
+	From EmailAddress
+	// Category is used when a send has no category
+	Category string
+	// Metadata is added to the SendRecord of every send. Values set with
+	// WithRecordMetadata take precedence for the same key.
+	Metadata map[string]string
+	// BeforeSend is called before every send with the SendRecord the send
+	// will produce, without message and request IDs. Returning an error
+	// aborts the send and is returned to the caller unchanged. Hooks of a
+	// parent client run first, so a derived client cannot bypass them.
+	BeforeSend func(ctx context.Context, record SendRecord) error
+	// SendRecorder receives the records of sends made by the client instead
+	// of ClientConfig.SendRecorder, under the configured RecordFailurePolicy
+	SendRecorder SendRecorder
+}
+
+// WithDefaults returns a derived client that applies the given defaults to
+// every send. Derived clients are cheap to create: they share the
+// connection, authentication, configuration, and quota tracking of the
+// client they were derived from. Defaults of a derived client are combined
+// with those of its parent, the new values taking precedence. BeforeSend
+// hooks are the exception: the hooks of the parent and the derived client
+// both run.
+//
+// A derived client is safe for concurrent use, and its defaults cannot be
+// changed after creation. Closing a derived client has no effect; only the
+// root client created with NewEmailClient closes the shared connection.
+//
+// Parameters:
+//   - defaults: Values applied to sends that do not set them
+//
+// Returns:
+//   - *EmailClient: Derived client sharing this client's connection
+//
+// Example:
+//
+//	payments := client.WithDefaults(sendlix.Defaults{
+//		From:     sendlix.EmailAddress{Email: "billing@example.com", Name: "Billing"},
+//		Category: "receipt",
+//		Metadata: map[string]string{"team": "payments"},
+//		BeforeSend: func(ctx context.Context, record sendlix.SendRecord) error {
+//			if record.Category != "receipt" {
+//				return fmt.Errorf("payments may not send %q emails", record.Category)
+//			}
+//			return nil
+//		},
+//	})
+//
+//	_, err := payments.SendEmail(ctx, sendlix.MailOptions{
+//		To:      []sendlix.EmailAddress{{Email: "customer@example.com"}},
+//		Subject: "Your receipt",
+//		Text:    "Thanks for your order",
+//	}, nil)
+func (c *EmailClient) WithDefaults(defaults Defaults) *EmailClient {
+	merged := Defaults{
+		From:         c.defaults.From,
+		Category:     c.defaults.Category,
+		Metadata:     make(map[string]string, len(c.defaults.Metadata)+len(defaults.Metadata)),
+		BeforeSend:   chainBeforeSend(c.defaults.BeforeSend, defaults.BeforeSend),
+		SendRecorder: c.defaults.SendRecorder,
+	}
+	if defaults.From.Email != "" {
+		merged.From = defaults.From
+	}
+	if defaults.Category != "" {
+		merged.Category = defaults.Category
+	}
+	if defaults.SendRecorder != nil {
+		merged.SendRecorder = defaults.SendRecorder
+	}
+	for k, v := range c.defaults.Metadata {
+		merged.Metadata[k] = v
+	}
+	for k, v := range defaults.Metadata {
+		merged.Metadata[k] = v
+	}
+
+	return &EmailClient{
+		BaseClient: c.BaseClient,
+		client:     c.client,
+		quota:      c.quota,
+		recipients: c.recipients,
+		spool:      c.spool,
+		defaults:   merged,
+		derived:    true,
+	}
+}
+
+// Close closes the gRPC connection of a root client. It does nothing for
+// clients created with WithDefaults, whose connection is owned by the root.
+//
+// Returns:
+//   - error: Error closing the connection
+func (c *EmailClient) Close() error {
+	if c.derived {
+		return nil
+	}
+	if c.spool != nil {
+		c.spool.close()
+	}
+	return c.BaseClient.Close()
+}
+
+// chainBeforeSend returns a hook running parent and then child, stopping at
+// the first error. Either of them may be nil.
+func chainBeforeSend(parent, child func(context.Context, SendRecord) error) func(context.Context, SendRecord) error {
+	if parent == nil {
+		return child
+	}
+	if child == nil {
+		return parent
+	}
+	return func(ctx context.Context, record SendRecord) error {
+		if err := parent(ctx, record); err != nil {
+			return err
+		}
+		return child(ctx, record)
+	}
+}
+
+// beforeSend runs the BeforeSend hooks of the client's defaults, if any.
+func (c *EmailClient) beforeSend(ctx context.Context, record SendRecord) error {
+	if c.defaults.BeforeSend == nil {
+		return nil
+	}
+	return c.defaults.BeforeSend(ctx, c.withRecordContext(ctx, record))
+}
+
+// applyDefaults returns the options of a send with the client's defaults
+// applied. The caller's values are never modified.
+func (c *EmailClient) applyDefaults(options MailOptions, additional *AdditionalOptions) (MailOptions, *AdditionalOptions) {
+	if options.From.Email == "" {
+		options.From = c.defaults.From
+	}
+	return options, c.applyDefaultCategory(additional)
+}
+
+// applyDefaultCategory returns additional options with the default category
+// applied, copying them if they need to change.
+func (c *EmailClient) applyDefaultCategory(additional *AdditionalOptions) *AdditionalOptions {
+	if c.defaults.Category == "" {
+		return additional
+	}
+	if additional == nil {
+		return &AdditionalOptions{Category: c.defaults.Category}
+	}
+	if additional.Category == "" {
+		withCategory := *additional
+		withCategory.Category = c.defaults.Category
+		return &withCategory
+	}
+	return additional
+}
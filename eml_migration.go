@@ -0,0 +1,339 @@
+package sendlix
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// EMLSender is implemented by clients that send EML messages, such as
+// *EmailClient.
+type EMLSender interface {
+	SendEMLEmail(ctx context.Context, emlData []byte, additional *AdditionalOptions) ([]string, error)
+}
+
+// MigrationOptions configures MigrateEMLDirectory.
+type MigrationOptions struct {
+	// Concurrency is the number of files sent at the same time. Default: 4
+	Concurrency int
+
+	// Category is the category of every sent message. Default: "" (the
+	// client's default category)
+	Category string
+
+	// DryRun validates every file and detects duplicates without sending
+	// anything or writing the manifest. Default: false
+	DryRun bool
+
+	// Filter selects the .eml files to migrate by their path relative to
+	// the root directory, with forward slashes. Files it rejects are not
+	// reported. Default: nil (all .eml files)
+	Filter func(path string) bool
+
+	// OnResult is called for every file that was sent or failed, with its
+	// path relative to the root directory and the message IDs or the error.
+	// It is called concurrently from the sending goroutines.
+	// Default: nil (no callback)
+	OnResult func(path string, messageIDs []string, err error)
+
+	// Manifest is the path of a file listing the files already migrated, so
+	// an interrupted migration can be resumed by running it again. Each
+	// sent file is appended as soon as its send succeeds; listed files, and
+	// files with the same content as a listed one, are skipped.
+	// Default: "" (no manifest)
+	Manifest string
+}
+
+// MigrationSkip is a file MigrateEMLDirectory did not send.
+type MigrationSkip struct {
+	// Path is the path of the file relative to the root directory
+	Path string
+	// Reason describes why the file was skipped
+	Reason string
+}
+
+// MigrationFailure is a file MigrateEMLDirectory could not send.
+type MigrationFailure struct {
+	// Path is the path of the file relative to the root directory
+	Path string
+	// Err is the validation or send error
+	Err error
+}
+
+// MigrationReport summarizes a run of MigrateEMLDirectory. Each list is
+// sorted by path.
+type MigrationReport struct {
+	// Sent lists the files that were sent, or in a dry run the files that
+	// would be sent
+	Sent []string
+	// Skipped lists the files that were already migrated or duplicate an
+	// earlier file
+	Skipped []MigrationSkip
+	// Failed lists the files that are invalid or were rejected
+	Failed []MigrationFailure
+}
+
+// migrationFile is a validated file waiting to be sent.
+type migrationFile struct {
+	path   string
+	digest string
+	data   []byte
+}
+
+// MigrateEMLDirectory replays a directory tree of .eml files through
+// SendEMLEmail, for example when moving mail from another provider.
+//
+// Every file is validated before it is sent: it must parse as an RFC 5322
+// message with a From header and at least one To, Cc, or Bcc recipient.
+// Files are identified by the SHA-256 digest of their content, and a file
+// with the same content as an earlier one is skipped as a duplicate, so
+// copies in several folders are sent once. The API offers no idempotency
+// keys: a file whose send failed although the API accepted it is sent again
+// on the next run.
+//
+// Invalid and rejected files are reported in the MigrationReport and do not
+// stop the migration. Canceling ctx stops it after the sends in progress.
+//
+// Parameters:
+//   - ctx: Context for the migration
+//   - client: Client to send with, usually an *EmailClient
+//   - root: Directory to walk
+//   - opts: Migration settings
+//
+// Returns:
+//   - *MigrationReport: Outcome per file, also returned with an error
+//   - error: Error reading the directory or manifest, writing the
+//     manifest, or ctx.Err() if the migration was canceled
+//
+// Example:
+//
+//	report, err := sendlix.MigrateEMLDirectory(ctx, client, "/var/mail/export", sendlix.MigrationOptions{
+//		Concurrency: 8,
+//		Category:    "migration",
+//		Manifest:    "/var/mail/export.manifest",
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, failure := range report.Failed {
+//		log.Printf("%s: %v", failure.Path, failure.Err)
+//	}
+func MigrateEMLDirectory(ctx context.Context, client EMLSender, root string, opts MigrationOptions) (*MigrationReport, error) {
+	report := &MigrationReport{}
+
+	completed, err := readManifest(opts.Manifest)
+	if err != nil {
+		return report, err
+	}
+
+	var manifest *os.File
+	if opts.Manifest != "" && !opts.DryRun {
+		manifest, err = os.OpenFile(opts.Manifest, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+		if err != nil {
+			return report, fmt.Errorf("failed to open migration manifest: %w", err)
+		}
+		defer manifest.Close()
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	var (
+		mu          sync.Mutex
+		manifestErr error
+		wg          sync.WaitGroup
+	)
+	files := make(chan migrationFile)
+
+	var additional *AdditionalOptions
+	if opts.Category != "" {
+		additional = &AdditionalOptions{Category: opts.Category}
+	}
+
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for file := range files {
+				messageIDs, err := client.SendEMLEmail(ctx, file.data, additional)
+				if opts.OnResult != nil {
+					opts.OnResult(file.path, messageIDs, err)
+				}
+
+				mu.Lock()
+				if err != nil {
+					report.Failed = append(report.Failed, MigrationFailure{Path: file.path, Err: err})
+				} else {
+					report.Sent = append(report.Sent, file.path)
+					if manifest != nil && manifestErr == nil {
+						if _, err := fmt.Fprintf(manifest, "%s %s\n", file.digest, file.path); err != nil {
+							manifestErr = fmt.Errorf("failed to write migration manifest: %w", err)
+						}
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	seen := make(map[string]string) // Digest to path of the first file with it
+	walkErr := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(path), ".eml") {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if opts.Filter != nil && !opts.Filter(rel) {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		digest := hex.EncodeToString(sum[:])
+
+		// Duplicates are detected here, before the file reaches a sender
+		var reason string
+		switch {
+		case completed.paths[rel]:
+			reason = "listed in manifest"
+		case completed.digests[digest] != "":
+			reason = "same content as " + completed.digests[digest] + " in manifest"
+		case seen[digest] != "":
+			reason = "duplicate of " + seen[digest]
+		}
+		if reason != "" {
+			mu.Lock()
+			report.Skipped = append(report.Skipped, MigrationSkip{Path: rel, Reason: reason})
+			mu.Unlock()
+			return nil
+		}
+		seen[digest] = rel
+
+		if err := validateEML(rel, data); err != nil || opts.DryRun {
+			mu.Lock()
+			if err != nil {
+				report.Failed = append(report.Failed, MigrationFailure{Path: rel, Err: err})
+			} else {
+				report.Sent = append(report.Sent, rel)
+			}
+			mu.Unlock()
+			return nil
+		}
+
+		select {
+		case files <- migrationFile{path: rel, digest: digest, data: data}:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	close(files)
+	wg.Wait()
+
+	sort.Strings(report.Sent)
+	sort.Slice(report.Skipped, func(i, j int) bool { return report.Skipped[i].Path < report.Skipped[j].Path })
+	sort.Slice(report.Failed, func(i, j int) bool { return report.Failed[i].Path < report.Failed[j].Path })
+
+	if walkErr != nil && !errors.Is(walkErr, ctx.Err()) {
+		walkErr = fmt.Errorf("failed to walk %s: %w", root, walkErr)
+	}
+	return report, errors.Join(walkErr, manifestErr)
+}
+
+// validateEML checks that data is a message SendEMLEmail can deliver.
+//
+// Returns:
+//   - error: ErrInvalidEML naming path and the problem, or nil
+func validateEML(path string, data []byte) error {
+	invalid := func(reason string) error {
+		return newValidationError(CodeInvalidEML, path, map[string]string{"path": path, "reason": reason})
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return invalid(err.Error())
+	}
+	if _, err := msg.Header.AddressList("From"); err != nil {
+		return invalid("From: " + err.Error())
+	}
+
+	recipients := 0
+	for _, header := range []string{"To", "Cc", "Bcc"} {
+		if msg.Header.Get(header) == "" {
+			continue
+		}
+		list, err := msg.Header.AddressList(header)
+		if err != nil {
+			return invalid(header + ": " + err.Error())
+		}
+		recipients += len(list)
+	}
+	if recipients == 0 {
+		return invalid("no To, Cc, or Bcc recipients")
+	}
+	return nil
+}
+
+// migrationManifest holds the files listed in a migration manifest.
+type migrationManifest struct {
+	paths   map[string]bool
+	digests map[string]string // Digest to path
+}
+
+// readManifest reads the manifest at path. A missing manifest is empty.
+func readManifest(path string) (migrationManifest, error) {
+	completed := migrationManifest{paths: make(map[string]bool), digests: make(map[string]string)}
+	if path == "" {
+		return completed, nil
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return completed, nil
+	}
+	if err != nil {
+		return completed, fmt.Errorf("failed to read migration manifest: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		digest, rel, ok := strings.Cut(scanner.Text(), " ")
+		if !ok {
+			continue
+		}
+		completed.paths[rel] = true
+		if completed.digests[digest] == "" {
+			completed.digests[digest] = rel
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return completed, fmt.Errorf("failed to read migration manifest: %w", err)
+	}
+	return completed, nil
+}
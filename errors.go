@@ -0,0 +1,457 @@
+package sendlix
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorCode is a stable, machine-readable identifier for an SDK error.
+// Codes never change between releases, even when the English message does,
+// which makes them suitable for translations, alerting, and error grouping.
+type ErrorCode string
+
+// Validation error codes.
+const (
+	CodeInvalidAPIKeyFormat       ErrorCode = "sendlix.validation.invalid_api_key_format"
+	CodeEmptyAPISecret            ErrorCode = "sendlix.validation.empty_api_secret"
+	CodeInvalidKeyID              ErrorCode = "sendlix.validation.invalid_key_id"
+	CodeMissingAuth               ErrorCode = "sendlix.validation.missing_auth"
+	CodeInvalidAuthType           ErrorCode = "sendlix.validation.invalid_auth_type"
+	CodeInvalidEmailAddressType   ErrorCode = "sendlix.validation.invalid_email_address_type"
+	CodeMissingFrom               ErrorCode = "sendlix.validation.missing_from"
+	CodeMissingRecipients         ErrorCode = "sendlix.validation.missing_recipients"
+	CodeMissingSubject            ErrorCode = "sendlix.validation.missing_subject"
+	CodeMissingContent            ErrorCode = "sendlix.validation.missing_content"
+	CodeMissingGroupID            ErrorCode = "sendlix.validation.missing_group_id"
+	CodeMissingEntries            ErrorCode = "sendlix.validation.missing_entries"
+	CodeMissingEntryEmail         ErrorCode = "sendlix.validation.missing_entry_email"
+	CodeMissingEmail              ErrorCode = "sendlix.validation.missing_email"
+	CodeDisplayNameTooLong        ErrorCode = "sendlix.validation.display_name_too_long"
+	CodeMissingAttachmentUploader ErrorCode = "sendlix.validation.missing_attachment_uploader"
+	CodeUnknownCategory           ErrorCode = "sendlix.validation.unknown_category"
+	CodeUnknownPlaceholder        ErrorCode = "sendlix.validation.unknown_placeholder"
+	CodeInvalidMessageAttachment  ErrorCode = "sendlix.validation.invalid_message_attachment"
+	CodeSenderCheckFailed         ErrorCode = "sendlix.validation.sender_check_failed"
+	CodeEmptyDigest               ErrorCode = "sendlix.validation.empty_digest"
+	CodeDigestTooLarge            ErrorCode = "sendlix.validation.digest_too_large"
+	CodeMissingAPIKeyEnv          ErrorCode = "sendlix.validation.missing_api_key_env"
+	CodeMissingAPIKeyFile         ErrorCode = "sendlix.validation.missing_api_key_file"
+	CodeMissingDomain             ErrorCode = "sendlix.validation.missing_domain"
+	CodeMissingToken              ErrorCode = "sendlix.validation.missing_token"
+	CodeStrictWarning             ErrorCode = "sendlix.validation.strict_warning"
+	CodeInvalidImageType          ErrorCode = "sendlix.validation.invalid_image_type"
+	CodeImagePlaceholderNotFound  ErrorCode = "sendlix.validation.image_placeholder_not_found"
+	CodeDataURITooLarge           ErrorCode = "sendlix.validation.data_uri_too_large"
+	CodeDataURIsTooLarge          ErrorCode = "sendlix.validation.data_uris_too_large"
+	CodeZeroSendAt                ErrorCode = "sendlix.validation.zero_send_at"
+	CodeEmptyAttachment           ErrorCode = "sendlix.validation.empty_attachment"
+	CodeMissingRecipientResolver  ErrorCode = "sendlix.validation.missing_recipient_resolver"
+	CodeInvalidQuietWindow        ErrorCode = "sendlix.validation.invalid_quiet_window"
+	CodeInvalidRetryPolicy        ErrorCode = "sendlix.validation.invalid_retry_policy"
+	CodeMissingDeadLetter         ErrorCode = "sendlix.validation.missing_dead_letter"
+	CodeTooManySubstitutions      ErrorCode = "sendlix.validation.too_many_substitutions"
+	CodeInvalidSubstitutionKey    ErrorCode = "sendlix.validation.invalid_substitution_key"
+	CodeSubstitutionKeyTooLong    ErrorCode = "sendlix.validation.substitution_key_too_long"
+	CodeSubstitutionValueTooLong  ErrorCode = "sendlix.validation.substitution_value_too_long"
+	CodeInvalidEML                ErrorCode = "sendlix.validation.invalid_eml"
+	CodeUnknownCompressor         ErrorCode = "sendlix.validation.unknown_compressor"
+	CodeMessageTooLarge           ErrorCode = "sendlix.validation.message_too_large"
+)
+
+// Client, quota, group, and auth error codes.
+const (
+	CodeReadOnlyMode         ErrorCode = "sendlix.client.read_only"
+	CodeQuietHours           ErrorCode = "sendlix.client.quiet_hours"
+	CodeUseEML               ErrorCode = "sendlix.client.use_eml"
+	CodeSpooled              ErrorCode = "sendlix.client.spooled"
+	CodeSpoolDropped         ErrorCode = "sendlix.client.spool_dropped"
+	CodeRecordFailed         ErrorCode = "sendlix.client.record_failed"
+	CodeUnreachable          ErrorCode = "sendlix.client.unreachable"
+	CodeNotServing           ErrorCode = "sendlix.client.not_serving"
+	CodeQuotaExceeded        ErrorCode = "sendlix.quota.exceeded"
+	CodeQuotaReserved        ErrorCode = "sendlix.quota.reserved"
+	CodeEmptyGroup           ErrorCode = "sendlix.group.empty"
+	CodeInsertStreamClosed   ErrorCode = "sendlix.group.insert_stream_closed"
+	CodeUnresolvedRecipients ErrorCode = "sendlix.recipients.unresolved"
+	CodeAuthFailed           ErrorCode = "sendlix.auth.failed"
+	CodeUnauthorized         ErrorCode = "sendlix.auth.unauthenticated"
+	CodeForbidden            ErrorCode = "sendlix.auth.permission_denied"
+	CodeInsufficientScope    ErrorCode = "sendlix.auth.insufficient_scope"
+	CodeAccountSuspended     ErrorCode = "sendlix.auth.account_suspended"
+	CodeAPIKeyDisabled       ErrorCode = "sendlix.auth.api_key_disabled"
+	CodeInvalidCredentials   ErrorCode = "sendlix.auth.invalid_credentials"
+	CodeAuthUnreachable      ErrorCode = "sendlix.auth.unreachable"
+	CodeMalformedToken       ErrorCode = "sendlix.auth.malformed_token"
+	CodeAccountMismatch      ErrorCode = "sendlix.auth.account_mismatch"
+)
+
+// Transport and API error codes, derived from the gRPC status of failed calls.
+const (
+	CodeUnavailable        ErrorCode = "sendlix.transport.unavailable"
+	CodeDeadlineExceeded   ErrorCode = "sendlix.transport.deadline_exceeded"
+	CodeCanceled           ErrorCode = "sendlix.transport.canceled"
+	CodeInvalidArgument    ErrorCode = "sendlix.api.invalid_argument"
+	CodeNotFound           ErrorCode = "sendlix.api.not_found"
+	CodeAlreadyExists      ErrorCode = "sendlix.api.already_exists"
+	CodeFailedPrecondition ErrorCode = "sendlix.api.failed_precondition"
+	CodeUnimplemented      ErrorCode = "sendlix.api.unimplemented"
+	CodeInternal           ErrorCode = "sendlix.api.internal"
+	CodeResourceExhausted  ErrorCode = "sendlix.api.resource_exhausted"
+	CodeUnknown            ErrorCode = "sendlix.unknown"
+)
+
+// defaultMessages contains the English message templates for every error code.
+// Parameters are referenced as {name} and replaced with values from the
+// error's parameter map.
+var defaultMessages = map[ErrorCode]string{
+	CodeInvalidAPIKeyFormat:       "invalid API key format. Expected format: 'secret.keyID'",
+	CodeEmptyAPISecret:            "invalid API key format. Secret cannot be empty",
+	CodeInvalidKeyID:              "invalid key ID: {reason}",
+	CodeMissingAuth:               "authentication is required",
+	CodeInvalidAuthType:           "invalid auth type: {type}, expected IAuth or string",
+	CodeInvalidEmailAddressType:   "invalid email address type: {type}",
+	CodeMissingFrom:               "from email is required",
+	CodeMissingRecipients:         "at least one recipient is required",
+	CodeMissingSubject:            "subject is required",
+	CodeMissingContent:            "either HTML or text content is required",
+	CodeMissingGroupID:            "group ID is required",
+	CodeMissingEntries:            "at least one entry is required",
+	CodeMissingEntryEmail:         "email address is required for entry at index {index}",
+	CodeMissingEmail:              "email address is required",
+	CodeDisplayNameTooLong:        "display name in {field} is too long: {length} characters exceeds the maximum of {max}",
+	CodeMissingAttachmentUploader: "attachments with content require ClientConfig.AttachmentUploader",
+	CodeUnknownCategory:           "unknown category \"{category}\"; close matches: {suggestions}",
+	CodeUnknownPlaceholder:        "content uses placeholders without substitution keys: {placeholders}",
+	CodeInvalidMessageAttachment:  "invalid message attachment: {reason}",
+	CodeSenderCheckFailed:         "sender check {rule} failed: {message}",
+	CodeEmptyDigest:               "digest has no items",
+	CodeDigestTooLarge:            "digest exceeds its budget: {reason}",
+	CodeMissingAPIKeyEnv:          "environment variable {name} is not set or empty",
+	CodeMissingAPIKeyFile:         "API key file {path} does not exist",
+	CodeMissingDomain:             "domain is required",
+	CodeMissingToken:              "token is required",
+	CodeStrictWarning:             "{warning}: {message}",
+	CodeInvalidImageType:          "unsupported image type {type}",
+	CodeImagePlaceholderNotFound:  "no img src attribute matches placeholder \"{placeholder}\"",
+	CodeDataURITooLarge:           "data URI for \"{placeholder}\" is {size} bytes, exceeding the limit of {max}",
+	CodeDataURIsTooLarge:          "data URIs total {size} bytes, exceeding the message limit of {max}",
+	CodeZeroSendAt:                "send time is the zero time; use nil to send immediately",
+	CodeEmptyAttachment:           "attachment at index {index} has no content URL or content",
+	CodeMissingRecipientResolver:  "recipient references require ClientConfig.RecipientResolver",
+	CodeInvalidQuietWindow:        "quiet window of {weekday} must start before 24h and end after 0h, at most 24h, and not at its start",
+	CodeInvalidRetryPolicy:        "invalid retry policy: {reason}",
+	CodeMissingDeadLetter:         "spooling requires SpoolConfig.DeadLetter",
+	CodeTooManySubstitutions:      "{field} has {count} substitutions, exceeding the limit of {max}",
+	CodeInvalidSubstitutionKey:    "substitution key \"{key}\" in {field} may only contain letters, digits, '_', '-', and '.'",
+	CodeSubstitutionKeyTooLong:    "substitution key \"{key}\" in {field} is {length} characters long, exceeding the limit of {max}",
+	CodeSubstitutionValueTooLong:  "value of substitution \"{key}\" in {field} is {length} characters long, exceeding the limit of {max}",
+	CodeInvalidArgument:           "the API rejected the request: {message}",
+	CodeInvalidEML:                "{path} is not a valid EML message: {reason}",
+	CodeUnknownCompressor:         "compressor \"{name}\" is not registered with gRPC",
+	CodeMessageTooLarge:           "message is {size} bytes, exceeding the size limit of {max}",
+}
+
+// Sentinel validation errors for use with errors.Is.
+// Errors returned by the SDK match these sentinels by code, regardless of
+// their parameters or the active message translator.
+var (
+	ErrInvalidAPIKeyFormat       = &ValidationError{Code: CodeInvalidAPIKeyFormat}
+	ErrEmptyAPISecret            = &ValidationError{Code: CodeEmptyAPISecret}
+	ErrInvalidKeyID              = &ValidationError{Code: CodeInvalidKeyID}
+	ErrMissingAuth               = &ValidationError{Code: CodeMissingAuth}
+	ErrInvalidAuthType           = &ValidationError{Code: CodeInvalidAuthType}
+	ErrInvalidEmailAddressType   = &ValidationError{Code: CodeInvalidEmailAddressType}
+	ErrMissingFrom               = &ValidationError{Code: CodeMissingFrom}
+	ErrMissingRecipients         = &ValidationError{Code: CodeMissingRecipients}
+	ErrMissingSubject            = &ValidationError{Code: CodeMissingSubject}
+	ErrMissingContent            = &ValidationError{Code: CodeMissingContent}
+	ErrMissingGroupID            = &ValidationError{Code: CodeMissingGroupID}
+	ErrMissingEntries            = &ValidationError{Code: CodeMissingEntries}
+	ErrMissingEntryEmail         = &ValidationError{Code: CodeMissingEntryEmail}
+	ErrMissingEmail              = &ValidationError{Code: CodeMissingEmail}
+	ErrDisplayNameTooLong        = &ValidationError{Code: CodeDisplayNameTooLong}
+	ErrMissingAttachmentUploader = &ValidationError{Code: CodeMissingAttachmentUploader}
+	ErrUnknownCategory           = &ValidationError{Code: CodeUnknownCategory}
+	ErrUnknownPlaceholder        = &ValidationError{Code: CodeUnknownPlaceholder}
+	ErrInvalidMessageAttachment  = &ValidationError{Code: CodeInvalidMessageAttachment}
+	ErrSenderCheckFailed         = &ValidationError{Code: CodeSenderCheckFailed}
+	ErrEmptyDigest               = &ValidationError{Code: CodeEmptyDigest}
+	ErrDigestTooLarge            = &ValidationError{Code: CodeDigestTooLarge}
+	ErrMissingAPIKeyEnv          = &ValidationError{Code: CodeMissingAPIKeyEnv}
+	ErrMissingAPIKeyFile         = &ValidationError{Code: CodeMissingAPIKeyFile}
+	ErrMissingDomain             = &ValidationError{Code: CodeMissingDomain}
+	ErrMissingToken              = &ValidationError{Code: CodeMissingToken}
+	ErrStrictWarning             = &ValidationError{Code: CodeStrictWarning}
+	ErrInvalidImageType          = &ValidationError{Code: CodeInvalidImageType}
+	ErrImagePlaceholderNotFound  = &ValidationError{Code: CodeImagePlaceholderNotFound}
+	ErrDataURITooLarge           = &ValidationError{Code: CodeDataURITooLarge}
+	ErrDataURIsTooLarge          = &ValidationError{Code: CodeDataURIsTooLarge}
+	ErrZeroSendAt                = &ValidationError{Code: CodeZeroSendAt}
+	ErrEmptyAttachment           = &ValidationError{Code: CodeEmptyAttachment}
+	ErrMissingRecipientResolver  = &ValidationError{Code: CodeMissingRecipientResolver}
+	ErrInvalidQuietWindow        = &ValidationError{Code: CodeInvalidQuietWindow}
+	ErrInvalidRetryPolicy        = &ValidationError{Code: CodeInvalidRetryPolicy}
+	ErrMissingDeadLetter         = &ValidationError{Code: CodeMissingDeadLetter}
+	ErrTooManySubstitutions      = &ValidationError{Code: CodeTooManySubstitutions}
+	ErrInvalidSubstitutionKey    = &ValidationError{Code: CodeInvalidSubstitutionKey}
+	ErrSubstitutionKeyTooLong    = &ValidationError{Code: CodeSubstitutionKeyTooLong}
+	ErrSubstitutionValueTooLong  = &ValidationError{Code: CodeSubstitutionValueTooLong}
+	ErrInvalidArgument           = &ValidationError{Code: CodeInvalidArgument}
+	ErrInvalidEML                = &ValidationError{Code: CodeInvalidEML}
+	ErrUnknownCompressor         = &ValidationError{Code: CodeUnknownCompressor}
+	ErrMessageTooLarge           = &ValidationError{Code: CodeMessageTooLarge}
+)
+
+// ErrAuthFailed is wrapped by errors of calls whose authentication header
+// could not be obtained, for example because the token exchange failed. The
+// underlying error remains available through errors.Is and errors.As.
+var ErrAuthFailed = errors.New("failed to get auth header")
+
+// ErrRecordFailed is wrapped by send errors under RecordFailureFail when the
+// email was sent but the SendRecorder failed.
+var ErrRecordFailed = errors.New("email sent but recording failed")
+
+// errorRegistry assigns codes to the exported sentinel errors that are not
+// ValidationErrors. Code checks them in order, so wrapping errors such as
+// ErrAuthFailed take precedence over the transport errors they wrap.
+var errorRegistry = []struct {
+	err  error
+	code ErrorCode
+}{
+	{ErrReadOnlyMode, CodeReadOnlyMode},
+	{ErrQuietHours, CodeQuietHours},
+	{ErrUseEML, CodeUseEML},
+	{ErrSpooled, CodeSpooled},
+	{ErrSpoolDropped, CodeSpoolDropped},
+	{ErrRecordFailed, CodeRecordFailed},
+	{ErrUnreachable, CodeUnreachable},
+	{ErrNotServing, CodeNotServing},
+	{ErrQuotaReserved, CodeQuotaReserved},
+	{ErrEmptyGroup, CodeEmptyGroup},
+	{ErrInsertStreamClosed, CodeInsertStreamClosed},
+	{ErrUnresolvedRecipients, CodeUnresolvedRecipients},
+	{ErrAccountMismatch, CodeAccountMismatch},
+	{ErrInsufficientScope, CodeInsufficientScope},
+	{ErrAccountSuspended, CodeAccountSuspended},
+	{ErrAPIKeyDisabled, CodeAPIKeyDisabled},
+	{ErrInvalidCredentials, CodeInvalidCredentials},
+	{ErrAuthUnreachable, CodeAuthUnreachable},
+	{ErrMalformedToken, CodeMalformedToken},
+	{ErrPermissionDenied, CodeForbidden},
+	{ErrAuthFailed, CodeAuthFailed},
+	{ErrQuotaExceeded, CodeQuotaExceeded},
+	{ErrUnauthenticated, CodeUnauthorized},
+	{ErrNotFound, CodeNotFound},
+}
+
+// statusCodes maps gRPC status codes of failed calls to error codes.
+// Unlisted status codes map to CodeUnknown.
+var statusCodes = map[codes.Code]ErrorCode{
+	codes.Unavailable:        CodeUnavailable,
+	codes.DeadlineExceeded:   CodeDeadlineExceeded,
+	codes.Canceled:           CodeCanceled,
+	codes.Unauthenticated:    CodeUnauthorized,
+	codes.PermissionDenied:   CodeForbidden,
+	codes.InvalidArgument:    CodeInvalidArgument,
+	codes.NotFound:           CodeNotFound,
+	codes.AlreadyExists:      CodeAlreadyExists,
+	codes.FailedPrecondition: CodeFailedPrecondition,
+	codes.Unimplemented:      CodeUnimplemented,
+	codes.Internal:           CodeInternal,
+}
+
+// Code returns the stable error code of an error returned by the SDK. Use it
+// instead of the message text to group, alert on, or translate errors; codes
+// are part of the public API and never change between releases.
+//
+// The code is determined in this order:
+//   - the Code of a wrapped ValidationError
+//   - the code of a wrapped sentinel error such as ErrReadOnlyMode
+//   - the code derived from the gRPC status of a failed call
+//   - CodeCanceled or CodeDeadlineExceeded for context errors
+//
+// Parameters:
+//   - err: Error to inspect
+//
+// Returns:
+//   - ErrorCode: Code of the error, "" for nil, or CodeUnknown if the error
+//     has no code
+//
+// Example:
+//
+//	_, err := client.SendEmail(ctx, options, nil)
+//	if err != nil {
+//		metrics.Increment("send_errors", "code", string(sendlix.Code(err)))
+//	}
+func Code(err error) ErrorCode {
+	if err == nil {
+		return ""
+	}
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr.Code
+	}
+
+	for _, entry := range errorRegistry {
+		if errors.Is(err, entry.err) {
+			return entry.code
+		}
+	}
+
+	if s, ok := status.FromError(err); ok {
+		if s.Code() == codes.ResourceExhausted {
+			return resourceExhaustedCode(s)
+		}
+		if code, ok := statusCodes[s.Code()]; ok {
+			return code
+		}
+		return CodeUnknown
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return CodeCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return CodeDeadlineExceeded
+	}
+	return CodeUnknown
+}
+
+// RegisteredErrors returns every exported sentinel error of the SDK, including
+// the validation sentinels. Each has a unique code, as returned by Code.
+//
+// Returns:
+//   - []error: Sentinel errors for use with errors.Is
+func RegisteredErrors() []error {
+	errs := []error{
+		ErrInvalidAPIKeyFormat, ErrEmptyAPISecret, ErrInvalidKeyID, ErrMissingAuth,
+		ErrInvalidAuthType, ErrInvalidEmailAddressType, ErrMissingFrom,
+		ErrMissingRecipients, ErrMissingSubject, ErrMissingContent,
+		ErrMissingGroupID, ErrMissingEntries, ErrMissingEntryEmail, ErrMissingEmail,
+		ErrDisplayNameTooLong, ErrMissingAttachmentUploader, ErrUnknownCategory,
+		ErrUnknownPlaceholder, ErrInvalidMessageAttachment, ErrSenderCheckFailed,
+		ErrEmptyDigest, ErrDigestTooLarge, ErrMissingAPIKeyEnv, ErrMissingDomain,
+		ErrMissingToken, ErrStrictWarning, ErrInvalidImageType,
+		ErrImagePlaceholderNotFound, ErrDataURITooLarge, ErrDataURIsTooLarge,
+		ErrMissingAPIKeyFile, ErrZeroSendAt, ErrEmptyAttachment,
+		ErrMissingRecipientResolver, ErrInvalidQuietWindow, ErrInvalidRetryPolicy,
+		ErrMissingDeadLetter, ErrTooManySubstitutions, ErrInvalidSubstitutionKey,
+		ErrSubstitutionKeyTooLong, ErrSubstitutionValueTooLong, ErrInvalidArgument,
+		ErrInvalidEML,
+		ErrUnknownCompressor,
+		ErrMessageTooLarge,
+	}
+	for _, entry := range errorRegistry {
+		errs = append(errs, entry.err)
+	}
+	return errs
+}
+
+// ValidationError is returned when a request fails client-side validation
+// before it is sent to the Sendlix API, and with CodeInvalidArgument when the
+// API rejects a request with INVALID_ARGUMENT.
+//
+// The Code identifies the problem and is stable across releases; Params holds
+// the values referenced by the message, such as an entry index. The message
+// returned by Error is produced by the registered MessageTranslator, falling
+// back to English.
+//
+// Example:
+//
+//	_, err := client.SendEmail(ctx, options, nil)
+//	if errors.Is(err, sendlix.ErrMissingSubject) {
+//		// ask the user for a subject
+//	}
+type ValidationError struct {
+	// Code identifies the validation failure
+	Code ErrorCode
+	// Field is the name of the offending input field, if applicable
+	Field string
+	// Params contains the values used to format the error message
+	Params map[string]string
+	// Err is the gRPC error of the API for CodeInvalidArgument, or nil for
+	// client-side validation
+	Err error
+}
+
+// Error returns the formatted error message.
+func (e *ValidationError) Error() string {
+	return formatMessage(e.Code, e.Params)
+}
+
+// Unwrap returns the gRPC error of the API, or nil.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is a ValidationError with the same code.
+func (e *ValidationError) Is(target error) bool {
+	t, ok := target.(*ValidationError)
+	return ok && t.Code == e.Code
+}
+
+// newValidationError creates a ValidationError for the given code and field.
+func newValidationError(code ErrorCode, field string, params map[string]string) *ValidationError {
+	return &ValidationError{Code: code, Field: field, Params: params}
+}
+
+// MessageTranslator produces a localized message for an error code.
+// It receives the error's parameters and returns the message to use, or an
+// empty string to fall back to the default English message.
+type MessageTranslator func(code ErrorCode, params map[string]string) string
+
+var (
+	translatorMu sync.RWMutex
+	translator   MessageTranslator
+)
+
+// SetMessageTranslator registers a translator that is consulted whenever an
+// SDK error formats its message. Passing nil restores the default English
+// messages. Error identity (codes and errors.Is matching) is unaffected.
+//
+// Parameters:
+//   - t: Translator to use, or nil for the defaults
+//
+// Example:
+//
+//	sendlix.SetMessageTranslator(func(code sendlix.ErrorCode, params map[string]string) string {
+//		switch code {
+//		case sendlix.CodeMissingSubject:
+//			return "Betreff ist erforderlich"
+//		}
+//		return "" // fall back to English
+//	})
+func SetMessageTranslator(t MessageTranslator) {
+	translatorMu.Lock()
+	defer translatorMu.Unlock()
+	translator = t
+}
+
+// formatMessage returns the message for a code, using the registered
+// translator if it provides one.
+func formatMessage(code ErrorCode, params map[string]string) string {
+	translatorMu.RLock()
+	t := translator
+	translatorMu.RUnlock()
+
+	if t != nil {
+		if msg := t(code, params); msg != "" {
+			return msg
+		}
+	}
+
+	msg, ok := defaultMessages[code]
+	if !ok {
+		msg = string(code)
+	}
+	for name, value := range params {
+		msg = strings.ReplaceAll(msg, "{"+name+"}", value)
+	}
+	return msg
+}
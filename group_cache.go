@@ -0,0 +1,192 @@
+package sendlix
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// MembershipCacheConfig configures the optional client-side cache used by
+// GroupClient.CheckEmailInGroup. Cached results are keyed by group ID and
+// email address as normalized for requests, and are invalidated
+// automatically when the same client inserts or removes that email.
+type MembershipCacheConfig struct {
+	// TTL is how long a cached membership result stays valid.
+	// Default: 30 seconds
+	TTL time.Duration
+
+	// MaxEntries limits the number of cached results. When the limit is
+	// reached, the least recently used entry is evicted.
+	// Default: 1000
+	MaxEntries int
+}
+
+// effective returns the TTL and entry limit with defaults applied.
+func (c *MembershipCacheConfig) effective() (time.Duration, int) {
+	ttl := c.TTL
+	if ttl <= 0 {
+		ttl = 30 * time.Second
+	}
+	maxEntries := c.MaxEntries
+	if maxEntries <= 0 {
+		maxEntries = 1000
+	}
+	return ttl, maxEntries
+}
+
+// CacheStats contains hit and miss counters for the membership cache.
+type CacheStats struct {
+	// Hits is the number of CheckEmailInGroup calls answered from the cache
+	Hits uint64
+	// Misses is the number of CheckEmailInGroup calls that required an RPC
+	Misses uint64
+}
+
+// cacheBypassKey is the context key used by WithCacheBypass.
+type cacheBypassKey struct{}
+
+// WithCacheBypass returns a context that makes CheckEmailInGroup skip the
+// membership cache and always query the server. The fresh result is still
+// stored in the cache for subsequent calls.
+//
+// Example:
+//
+//	exists, err := client.CheckEmailInGroup(sendlix.WithCacheBypass(ctx), "newsletter", "user@example.com")
+func WithCacheBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassKey{}, true)
+}
+
+// bypassCache reports whether the context requests a cache bypass.
+func bypassCache(ctx context.Context) bool {
+	bypass, _ := ctx.Value(cacheBypassKey{}).(bool)
+	return bypass
+}
+
+// membershipKey identifies a cached membership result.
+type membershipKey struct {
+	groupID string
+	email   string
+}
+
+// membershipEntry is a cached membership result.
+type membershipEntry struct {
+	key       membershipKey
+	exists    bool
+	expiresAt time.Time
+}
+
+// membershipCache is a concurrency-safe LRU cache with per-entry expiry.
+type membershipCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	order      *list.List
+	entries    map[membershipKey]*list.Element
+	epoch      uint64 // Incremented by every invalidation
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// newMembershipCache creates a cache from the given configuration,
+// applying defaults for unset values.
+func newMembershipCache(config *MembershipCacheConfig) *membershipCache {
+	ttl, maxEntries := config.effective()
+
+	return &membershipCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[membershipKey]*list.Element),
+	}
+}
+
+// membershipKey builds a cache key from a group ID and email address. The
+// address is normalized like in requests, so addresses the API treats as
+// different members, such as "User@x" and "user@x" by default, are cached
+// separately.
+func (c *GroupClient) membershipKey(groupID, email string) membershipKey {
+	return membershipKey{groupID: groupID, email: c.normalizeEmail(email)}
+}
+
+// get returns the cached result for the key, if present and not expired.
+func (c *membershipCache) get(key membershipKey) (bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		c.misses.Add(1)
+		return false, false
+	}
+
+	entry := elem.Value.(*membershipEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		c.misses.Add(1)
+		return false, false
+	}
+
+	c.order.MoveToFront(elem)
+	c.hits.Add(1)
+	return entry.exists, true
+}
+
+// currentEpoch returns the epoch to pass to set with the result of a
+// lookup starting now.
+func (c *membershipCache) currentEpoch() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.epoch
+}
+
+// set stores the result of a lookup started at epoch, evicting the least
+// recently used entry if needed. The result is dropped if an invalidation
+// happened since, as it may predate the mutation.
+func (c *membershipCache) set(key membershipKey, exists bool, epoch uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if epoch != c.epoch {
+		return
+	}
+
+	expiresAt := time.Now().Add(c.ttl)
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*membershipEntry)
+		entry.exists = exists
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&membershipEntry{key: key, exists: exists, expiresAt: expiresAt})
+
+	for c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*membershipEntry).key)
+	}
+}
+
+// invalidate removes the cached result for the key and discards the
+// results of lookups in progress.
+func (c *membershipCache) invalidate(key membershipKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.epoch++
+
+	if elem, ok := c.entries[key]; ok {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+	}
+}
+
+// stats returns the current hit and miss counters.
+func (c *membershipCache) stats() CacheStats {
+	return CacheStats{Hits: c.hits.Load(), Misses: c.misses.Load()}
+}
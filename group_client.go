@@ -3,6 +3,7 @@ package sendlix
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	pb "github.com/sendlix/go-sdk/internal/proto"
 )
@@ -17,6 +18,7 @@ import (
 type GroupClient struct {
 	*BaseClient
 	client pb.GroupClient
+	cache  *membershipCache
 }
 
 // NewGroupClient creates a new group management client with the provided authentication and configuration.
@@ -51,20 +53,32 @@ type GroupClient struct {
 //	}
 //	defer client.Close()
 func NewGroupClient(auth interface{}, config *ClientConfig) (*GroupClient, error) {
-	resolvedAuth, err := resolveAuth(auth)
+	resolvedAuth, err := resolveAuth(auth, config)
 	if err != nil {
 		return nil, err
 	}
 
 	baseClient, err := NewBaseClient(resolvedAuth, config)
 	if err != nil {
+		closeResolvedAuth(auth, resolvedAuth)
 		return nil, err
 	}
 
-	return &GroupClient{
+	return newGroupClient(baseClient), nil
+}
+
+// newGroupClient creates a group client using the connection of baseClient.
+func newGroupClient(baseClient *BaseClient) *GroupClient {
+	groupClient := &GroupClient{
 		BaseClient: baseClient,
-		client:     pb.NewGroupClient(baseClient.GetConnection()),
-	}, nil
+		client:     pb.NewGroupClient(baseClient.stubConn()),
+	}
+
+	if baseClient.config.MembershipCache != nil {
+		groupClient.cache = newMembershipCache(baseClient.config.MembershipCache)
+	}
+
+	return groupClient
 }
 
 // FailureHandler defines how to handle failures when inserting multiple emails into a group.
@@ -84,7 +98,9 @@ type GroupEntry struct {
 	Email string
 	// Name is the optional display name for the email address
 	Name string
-	// Substitutions contains key-value pairs for email personalization (optional)
+	// Substitutions contains key-value pairs for email personalization
+	// (optional). They are serialized in sorted key order, so requests with
+	// the same substitutions are byte-identical.
 	Substitutions map[string]string
 }
 
@@ -104,6 +120,9 @@ type UpdateResponse struct {
 	Message string
 	// AffectedRows indicates how many entries were successfully processed
 	AffectedRows int64
+	// RequestID is the ID the server assigned to the request, or "" if it
+	// returned none
+	RequestID string
 }
 
 // InsertEmailsToGroup inserts one or multiple email entries into a specified group.
@@ -156,23 +175,28 @@ type UpdateResponse struct {
 //		&sendlix.InsertOptions{OnFailure: sendlix.FailureHandlerAbort})
 func (c *GroupClient) InsertEmailsToGroup(ctx context.Context, groupID string, entries []GroupEntry, options *InsertOptions) (*UpdateResponse, error) {
 	if groupID == "" {
-		return nil, fmt.Errorf("group ID is required")
+		return nil, newValidationError(CodeMissingGroupID, "groupID", nil)
 	}
 	if len(entries) == 0 {
-		return nil, fmt.Errorf("at least one entry is required")
+		return nil, newValidationError(CodeMissingEntries, "entries", nil)
 	}
 
 	// Convert entries to protobuf format
 	pbEntries := make([]*pb.GroupEntry, len(entries))
 	for i, entry := range entries {
+		entry.Email = c.normalizeEmail(entry.Email)
 		if entry.Email == "" {
-			return nil, fmt.Errorf("email address is required for entry at index %d", i)
+			return nil, newValidationError(CodeMissingEntryEmail, "entries", map[string]string{"index": strconv.Itoa(i)})
+		}
+		email, err := c.convertEmailAddress(ctx, EmailAddress{Email: entry.Email, Name: entry.Name}, "entries["+strconv.Itoa(i)+"]")
+		if err != nil {
+			return nil, err
+		}
+		if err := c.checkSubstitutions("entries["+strconv.Itoa(i)+"].Substitutions", entry.Substitutions); err != nil {
+			return nil, err
 		}
 		pbEntries[i] = &pb.GroupEntry{
-			Email: &pb.EmailData{
-				Email: entry.Email,
-				Name:  entry.Name,
-			},
+			Email:         email,
 			Substitutions: entry.Substitutions,
 		}
 	}
@@ -187,15 +211,22 @@ func (c *GroupClient) InsertEmailsToGroup(ctx context.Context, groupID string, e
 		req.OnFailure = pb.FailureHandler(options.OnFailure)
 	}
 
-	resp, err := c.client.InsertEmailToGroup(ctx, req)
+	callCtx, requestID := captureRequestID(ctx, nil)
+	resp, err := c.client.InsertEmailToGroup(callCtx, req)
+	if c.cache != nil {
+		for _, entry := range pbEntries {
+			c.cache.invalidate(c.membershipKey(groupID, entry.Email.Email))
+		}
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to insert emails to group: %v", err)
+		return nil, fmt.Errorf("failed to insert emails to group: %w", mapStatusError(err, statusContext{resource: "group", id: groupID}))
 	}
 
 	return &UpdateResponse{
 		Success:      resp.Success,
 		Message:      resp.Message,
 		AffectedRows: resp.AffectedRows,
+		RequestID:    requestID.get(),
 	}, nil
 }
 
@@ -255,10 +286,11 @@ func (c *GroupClient) InsertEmailToGroup(ctx context.Context, groupID string, en
 //	}
 func (c *GroupClient) RemoveEmailFromGroup(ctx context.Context, groupID string, email string) (*UpdateResponse, error) {
 	if groupID == "" {
-		return nil, fmt.Errorf("group ID is required")
+		return nil, newValidationError(CodeMissingGroupID, "groupID", nil)
 	}
+	email = c.normalizeEmail(email)
 	if email == "" {
-		return nil, fmt.Errorf("email address is required")
+		return nil, newValidationError(CodeMissingEmail, "email", nil)
 	}
 
 	req := &pb.RemoveEmailFromGroupRequest{
@@ -266,15 +298,20 @@ func (c *GroupClient) RemoveEmailFromGroup(ctx context.Context, groupID string,
 		GroupId: groupID,
 	}
 
-	resp, err := c.client.RemoveEmailFromGroup(ctx, req)
+	callCtx, requestID := captureRequestID(ctx, nil)
+	resp, err := c.client.RemoveEmailFromGroup(callCtx, req)
+	if c.cache != nil {
+		c.cache.invalidate(c.membershipKey(groupID, email))
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to remove email from group: %v", err)
+		return nil, fmt.Errorf("failed to remove email from group: %w", mapStatusError(err, statusContext{resource: "group", id: groupID}))
 	}
 
 	return &UpdateResponse{
 		Success:      resp.Success,
 		Message:      resp.Message,
 		AffectedRows: resp.AffectedRows,
+		RequestID:    requestID.get(),
 	}, nil
 }
 
@@ -282,6 +319,10 @@ func (c *GroupClient) RemoveEmailFromGroup(ctx context.Context, groupID string,
 // This method provides a simple way to verify group membership before performing
 // other operations like sending group emails or managing subscriptions.
 //
+// When ClientConfig.MembershipCache is set, results are served from a local cache
+// until they expire or the email is inserted or removed through this client.
+// Use WithCacheBypass to force a fresh lookup.
+//
 // Parameters:
 //   - ctx: Context for the request (supports cancellation and timeouts)
 //   - groupID: Identifier of the target group (required)
@@ -304,10 +345,22 @@ func (c *GroupClient) RemoveEmailFromGroup(ctx context.Context, groupID string,
 //	}
 func (c *GroupClient) CheckEmailInGroup(ctx context.Context, groupID string, email string) (bool, error) {
 	if groupID == "" {
-		return false, fmt.Errorf("group ID is required")
+		return false, newValidationError(CodeMissingGroupID, "groupID", nil)
 	}
+	email = c.normalizeEmail(email)
 	if email == "" {
-		return false, fmt.Errorf("email address is required")
+		return false, newValidationError(CodeMissingEmail, "email", nil)
+	}
+
+	key := c.membershipKey(groupID, email)
+	var epoch uint64
+	if c.cache != nil {
+		epoch = c.cache.currentEpoch()
+		if !bypassCache(ctx) {
+			if exists, ok := c.cache.get(key); ok {
+				return exists, nil
+			}
+		}
 	}
 
 	req := &pb.CheckEmailInGroupRequest{
@@ -317,8 +370,24 @@ func (c *GroupClient) CheckEmailInGroup(ctx context.Context, groupID string, ema
 
 	resp, err := c.client.CheckEmailInGroup(ctx, req)
 	if err != nil {
-		return false, fmt.Errorf("failed to check email in group: %v", err)
+		return false, fmt.Errorf("failed to check email in group: %w", mapStatusError(err, statusContext{resource: "group", id: groupID}))
+	}
+
+	if c.cache != nil {
+		c.cache.set(key, resp.Exists, epoch)
 	}
 
 	return resp.Exists, nil
 }
+
+// CacheStats returns the hit and miss counters of the membership cache.
+// It returns zero values when ClientConfig.MembershipCache is not set.
+//
+// Returns:
+//   - CacheStats: Current cache hit and miss counters
+func (c *GroupClient) CacheStats() CacheStats {
+	if c.cache == nil {
+		return CacheStats{}
+	}
+	return c.cache.stats()
+}
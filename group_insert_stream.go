@@ -0,0 +1,180 @@
+package sendlix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// InsertStreamChunkSize is the number of entries an InsertStream sends per
+// request.
+const InsertStreamChunkSize = 500
+
+// ErrInsertStreamClosed is returned by InsertStream.Add and
+// InsertStream.CloseAndRecv after the stream was closed.
+var ErrInsertStreamClosed = errors.New("insert stream is closed")
+
+// InsertStreamError is returned by an InsertStream whose insert failed. It
+// reports how many entries the API acknowledged before the failure, so a
+// caller can resume with the entries following them.
+type InsertStreamError struct {
+	// Acknowledged is the number of entries inserted before the failure, in
+	// the order they were added
+	Acknowledged int
+	// Err is the error of the failed insert
+	Err error
+}
+
+// Error returns the error message including the acknowledged entry count.
+func (e *InsertStreamError) Error() string {
+	return fmt.Sprintf("insert stream failed after %d acknowledged entries: %v", e.Acknowledged, e.Err)
+}
+
+// Unwrap returns the error of the failed insert.
+func (e *InsertStreamError) Unwrap() error {
+	return e.Err
+}
+
+// InsertStream adds entries to a group incrementally, for imports too large
+// to hold in memory at once. Entries are buffered and sent in requests of
+// InsertStreamChunkSize entries; Add blocks while a request is in flight, so
+// a slow API applies back-pressure to the producer instead of the buffer
+// growing without bound.
+//
+// An InsertStream is not safe for concurrent use. Once an insert fails,
+// every further call returns the same *InsertStreamError.
+type InsertStream struct {
+	client  *GroupClient
+	ctx     context.Context
+	groupID string
+	options *InsertOptions
+
+	pending  []GroupEntry
+	added    int
+	response UpdateResponse
+	acked    int
+	err      error
+	closed   bool
+}
+
+// OpenInsertStream opens a stream adding entries to a group. The stream must
+// be finished with CloseAndRecv, which sends the remaining entries.
+//
+// Parameters:
+//   - ctx: Context for all requests of the stream (supports cancellation
+//     and timeouts)
+//   - groupID: Identifier of the target group (required)
+//   - options: Optional configuration applied to every request, as with
+//     InsertEmailsToGroup
+//
+// Returns:
+//   - *InsertStream: Stream to add entries to
+//   - error: Validation error
+//
+// Example:
+//
+//	stream, err := client.OpenInsertStream(ctx, "newsletter", nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for row := range rows {
+//		if err := stream.Add(sendlix.GroupEntry{Email: row.Email, Name: row.Name}); err != nil {
+//			break
+//		}
+//	}
+//	response, err := stream.CloseAndRecv()
+//	var streamErr *sendlix.InsertStreamError
+//	if errors.As(err, &streamErr) {
+//		log.Printf("resume after row %d", streamErr.Acknowledged)
+//	}
+func (c *GroupClient) OpenInsertStream(ctx context.Context, groupID string, options *InsertOptions) (*InsertStream, error) {
+	if groupID == "" {
+		return nil, newValidationError(CodeMissingGroupID, "groupID", nil)
+	}
+
+	return &InsertStream{
+		client:   c,
+		ctx:      ctx,
+		groupID:  groupID,
+		options:  options,
+		pending:  make([]GroupEntry, 0, InsertStreamChunkSize),
+		response: UpdateResponse{Success: true},
+	}, nil
+}
+
+// Add adds an entry to the stream. It sends the buffered entries when the
+// buffer is full, blocking until the API has acknowledged them.
+//
+// Parameters:
+//   - entry: Group entry to add (required)
+//
+// Returns:
+//   - error: ErrMissingEntryEmail for an entry without email, with the
+//     index of the entry in the stream; a substitution error for an entry
+//     exceeding ClientConfig.SubstitutionLimits; *InsertStreamError if an
+//     insert failed; or ErrInsertStreamClosed
+func (s *InsertStream) Add(entry GroupEntry) error {
+	if s.closed {
+		return ErrInsertStreamClosed
+	}
+	if s.err != nil {
+		return s.err
+	}
+	if s.client.normalizeEmail(entry.Email) == "" {
+		return newValidationError(CodeMissingEntryEmail, "entry", map[string]string{"index": strconv.Itoa(s.added)})
+	}
+	if err := s.client.checkSubstitutions("entries["+strconv.Itoa(s.added)+"].Substitutions", entry.Substitutions); err != nil {
+		return err
+	}
+
+	s.pending = append(s.pending, entry)
+	s.added++
+	if len(s.pending) == InsertStreamChunkSize {
+		return s.flush()
+	}
+	return nil
+}
+
+// Acknowledged returns the number of entries the API has acknowledged so
+// far, in the order they were added.
+func (s *InsertStream) Acknowledged() int {
+	return s.acked
+}
+
+// CloseAndRecv sends the remaining entries and closes the stream.
+//
+// Returns:
+//   - *UpdateResponse: Aggregate result of all requests: Success if every
+//     request succeeded, the total AffectedRows, and the last Message
+//   - error: *InsertStreamError if an insert failed, in which case the
+//     response covers the acknowledged entries; or ErrInsertStreamClosed
+func (s *InsertStream) CloseAndRecv() (*UpdateResponse, error) {
+	if s.closed {
+		return nil, ErrInsertStreamClosed
+	}
+	if s.err == nil && len(s.pending) > 0 {
+		s.flush()
+	}
+	s.closed = true
+
+	response := s.response
+	return &response, s.err
+}
+
+// flush sends the buffered entries.
+func (s *InsertStream) flush() error {
+	resp, err := s.client.InsertEmailsToGroup(s.ctx, s.groupID, s.pending, s.options)
+	if err != nil {
+		s.err = &InsertStreamError{Acknowledged: s.acked, Err: err}
+		return s.err
+	}
+
+	s.acked += len(s.pending)
+	s.pending = s.pending[:0]
+	s.response.Success = s.response.Success && resp.Success
+	s.response.Message = resp.Message
+	s.response.AffectedRows += resp.AffectedRows
+	s.response.RequestID = resp.RequestID
+	return nil
+}
@@ -0,0 +1,145 @@
+package sendlix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// GroupInsertState is the final state of one group in InsertEmailToGroups.
+type GroupInsertState int
+
+const (
+	// GroupInsertSkipped means the insert was not attempted because an
+	// earlier group failed in atomic mode
+	GroupInsertSkipped GroupInsertState = iota
+	// GroupInserted means the email is in the group
+	GroupInserted
+	// GroupInsertFailed means the insert failed
+	GroupInsertFailed
+	// GroupRolledBack means the insert succeeded and was removed again after
+	// another group failed in atomic mode
+	GroupRolledBack
+	// GroupRollbackFailed means the insert succeeded but could not be removed
+	// again, so the email remains in the group
+	GroupRollbackFailed
+)
+
+// String returns the name of the state.
+func (s GroupInsertState) String() string {
+	switch s {
+	case GroupInsertSkipped:
+		return "skipped"
+	case GroupInserted:
+		return "inserted"
+	case GroupInsertFailed:
+		return "insert-failed"
+	case GroupRolledBack:
+		return "rolled-back"
+	case GroupRollbackFailed:
+		return "rollback-failed"
+	default:
+		return fmt.Sprintf("GroupInsertState(%d)", int(s))
+	}
+}
+
+// GroupInsertResult describes the final state of one group of a
+// multi-group insert.
+type GroupInsertResult struct {
+	// GroupID identifies the group
+	GroupID string
+	// State is the final membership state for the group
+	State GroupInsertState
+	// Err is the insert or rollback error, if any
+	Err error
+}
+
+// InsertEmailToGroups inserts one entry into several groups, in order.
+//
+// In atomic mode the first failing insert stops the sequence and the
+// successful inserts are rolled back with best-effort removals. Rollbacks
+// run even if ctx has been canceled. A group whose insert affected no rows
+// already contained the email and is left untouched by the rollback.
+// Without atomic mode every group is attempted and failures are reported
+// per group.
+//
+// Inserts are idempotent, so the whole call can be retried safely after a
+// failure.
+//
+// Parameters:
+//   - ctx: Context for the requests (supports cancellation and timeouts)
+//   - groupIDs: Identifiers of the target groups (required, at least one)
+//   - entry: Group entry to add (required)
+//   - atomic: Whether to roll back successful inserts when any insert fails
+//
+// Returns:
+//   - []GroupInsertResult: Final state per group, in the order of groupIDs
+//   - error: Validation error, or the joined insert and rollback errors
+//
+// Example:
+//
+//	results, err := client.InsertEmailToGroups(ctx,
+//		[]string{"onboarding", "product-updates", "trial-tips"},
+//		sendlix.GroupEntry{Email: "user@example.com", Substitutions: map[string]string{"plan": "trial"}},
+//		true)
+//	if err != nil {
+//		for _, r := range results {
+//			log.Printf("%s: %s", r.GroupID, r.State)
+//		}
+//	}
+func (c *GroupClient) InsertEmailToGroups(ctx context.Context, groupIDs []string, entry GroupEntry, atomic bool) ([]GroupInsertResult, error) {
+	if len(groupIDs) == 0 {
+		return nil, newValidationError(CodeMissingGroupID, "groupIDs", nil)
+	}
+	for _, groupID := range groupIDs {
+		if groupID == "" {
+			return nil, newValidationError(CodeMissingGroupID, "groupIDs", nil)
+		}
+	}
+	if c.normalizeEmail(entry.Email) == "" {
+		return nil, newValidationError(CodeMissingEmail, "entry", nil)
+	}
+	if err := c.checkSubstitutions("entry.Substitutions", entry.Substitutions); err != nil {
+		return nil, err
+	}
+
+	results := make([]GroupInsertResult, len(groupIDs))
+	created := make([]bool, len(groupIDs))
+	var errs []error
+	for i, groupID := range groupIDs {
+		results[i].GroupID = groupID
+		if atomic && len(errs) > 0 {
+			continue
+		}
+
+		resp, err := c.InsertEmailToGroup(ctx, groupID, entry)
+		if err != nil {
+			results[i].State = GroupInsertFailed
+			results[i].Err = err
+			errs = append(errs, fmt.Errorf("group %q: %w", groupID, err))
+			continue
+		}
+		results[i].State = GroupInserted
+		created[i] = resp.AffectedRows > 0
+	}
+
+	if !atomic || len(errs) == 0 {
+		return results, errors.Join(errs...)
+	}
+
+	rollbackCtx := context.WithoutCancel(ctx)
+	for i := range results {
+		if results[i].State != GroupInserted || !created[i] {
+			continue
+		}
+		if _, err := c.RemoveEmailFromGroup(rollbackCtx, results[i].GroupID, entry.Email); err != nil {
+			results[i].State = GroupRollbackFailed
+			results[i].Err = err
+			errs = append(errs, fmt.Errorf("rollback of group %q: %w", results[i].GroupID, err))
+			continue
+		}
+		results[i].State = GroupRolledBack
+	}
+
+	return results, errors.Join(errs...)
+}
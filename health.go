@@ -0,0 +1,281 @@
+package sendlix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// HealthStatus is the status of a client or one of its components.
+type HealthStatus int
+
+const (
+	// HealthOK means the component works as expected
+	HealthOK HealthStatus = iota
+	// HealthDegraded means requests may fail or are failing intermittently
+	HealthDegraded
+	// HealthDown means requests are failing
+	HealthDown
+)
+
+// String returns the name of the status.
+func (s HealthStatus) String() string {
+	switch s {
+	case HealthOK:
+		return "ok"
+	case HealthDegraded:
+		return "degraded"
+	case HealthDown:
+		return "down"
+	default:
+		return fmt.Sprintf("HealthStatus(%d)", int(s))
+	}
+}
+
+// ComponentHealth is the status of one component of a client.
+type ComponentHealth struct {
+	// Status is the status of the component
+	Status HealthStatus
+	// Detail explains the status
+	Detail string
+}
+
+// Health is a snapshot of the health of a client, as returned by Health.
+type Health struct {
+	// Status is the worst status of all components
+	Status HealthStatus
+
+	// Connection is Down in TransientFailure or Shutdown and Degraded while
+	// connecting
+	Connection ComponentHealth
+	// ConnectionState is the state of the gRPC connection
+	ConnectionState connectivity.State
+
+	// Calls is Degraded if the most recent call failed with a server,
+	// transport, or authentication error
+	Calls ComponentHealth
+	// LastSuccess is the time of the last successful call, or the zero time
+	LastSuccess time.Time
+	// LastFailure is the time of the last failed call, or the zero time
+	LastFailure time.Time
+	// LastError is the error of the last failed call, or nil
+	LastError error
+
+	// Auth is Down if the last token exchange failed and no valid token is
+	// cached. It is always OK for IAuth implementations other than Auth.
+	Auth ComponentHealth
+	// TokenExpiry is the expiry of the cached token, or the zero time
+	TokenExpiry time.Time
+
+	// Account is Down if the API key does not belong to
+	// ClientConfig.ExpectedAccountID or is not ClientConfig.ExpectedKeyID.
+	// It is OK if no identity is expected or it has not been checked yet
+	Account ComponentHealth
+
+	// Quota is Down when the quota is exhausted and Degraded at or below
+	// ClientConfig.LowQuotaThreshold. It is only evaluated by EmailClient.
+	Quota ComponentHealth
+	// EmailsLeft is the most recent quota reading, see EmailClient.EmailsLeft
+	EmailsLeft int64
+	// QuotaUpdatedAt is the time of the quota reading, or the zero time
+	QuotaUpdatedAt time.Time
+}
+
+// HealthOptions configures Health.
+type HealthOptions struct {
+	// Active makes Health connect to the server and obtain an authentication
+	// header, as WarmUp does, and check the expected account of the API
+	// key before taking the snapshot. Default: false (no network calls)
+	Active bool
+}
+
+// healthTracker records the outcome of the calls of a client.
+type healthTracker struct {
+	mu          sync.Mutex
+	lastSuccess time.Time
+	lastFailure time.Time
+	lastErr     error
+}
+
+// healthFailureCodes are the error codes counted as failed calls. Other
+// errors, such as invalid arguments or canceled contexts, are caused by the
+// caller rather than the service.
+var healthFailureCodes = map[ErrorCode]bool{
+	CodeUnavailable:      true,
+	CodeDeadlineExceeded: true,
+	CodeInternal:         true,
+	CodeUnimplemented:    true,
+	CodeUnknown:          true,
+	CodeAuthFailed:       true,
+	CodeUnauthorized:     true,
+}
+
+// interceptor creates a gRPC unary interceptor recording every call.
+func (h *healthTracker) interceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil || healthFailureCodes[Code(err)] {
+			h.record(time.Now(), err)
+		}
+		return err
+	}
+}
+
+// record stores the outcome of a call.
+func (h *healthTracker) record(at time.Time, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err == nil {
+		h.lastSuccess = at
+		return
+	}
+	h.lastFailure = at
+	h.lastErr = err
+}
+
+// Health returns a snapshot of the health of the client for health check
+// endpoints. By default it makes no network calls; set HealthOptions.Active
+// to check connectivity and authentication first.
+//
+// Parameters:
+//   - ctx: Context bounding active checks
+//   - options: Optional settings (nil for a passive snapshot)
+//
+// Returns:
+//   - Health: Status of every component and the overall status
+//
+// Example:
+//
+//	http.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+//		health := client.Health(r.Context(), nil)
+//		if health.Status == sendlix.HealthDown {
+//			w.WriteHeader(http.StatusServiceUnavailable)
+//		}
+//		fmt.Fprintln(w, health.Status)
+//	})
+func (c *BaseClient) Health(ctx context.Context, options *HealthOptions) Health {
+	var activeErr error
+	if options != nil && options.Active {
+		activeErr = c.WarmUp(ctx)
+	}
+
+	var health Health
+
+	health.ConnectionState = c.GetConnection().GetState()
+	switch health.ConnectionState {
+	case connectivity.TransientFailure, connectivity.Shutdown:
+		health.Connection = ComponentHealth{Status: HealthDown, Detail: "connection is " + health.ConnectionState.String()}
+	case connectivity.Connecting:
+		health.Connection = ComponentHealth{Status: HealthDegraded, Detail: "connecting"}
+	default:
+		health.Connection = ComponentHealth{Status: HealthOK, Detail: "connection is " + health.ConnectionState.String()}
+	}
+
+	if c.health != nil {
+		c.health.mu.Lock()
+		health.LastSuccess = c.health.lastSuccess
+		health.LastFailure = c.health.lastFailure
+		health.LastError = c.health.lastErr
+		c.health.mu.Unlock()
+	}
+	switch {
+	case health.LastFailure.After(health.LastSuccess):
+		health.Calls = ComponentHealth{Status: HealthDegraded, Detail: "last call failed: " + health.LastError.Error()}
+	case health.LastSuccess.IsZero():
+		health.Calls = ComponentHealth{Status: HealthOK, Detail: "no calls yet"}
+	default:
+		health.Calls = ComponentHealth{Status: HealthOK, Detail: "last call succeeded"}
+	}
+
+	health.Auth = ComponentHealth{Status: HealthOK, Detail: "token state unknown"}
+	if auth := inspectableAuth(c.auth); auth != nil {
+		health.TokenExpiry, _ = auth.TokenExpiry()
+		switch {
+		case auth.HasValidToken():
+			health.Auth = ComponentHealth{Status: HealthOK, Detail: "token valid until " + health.TokenExpiry.Format(time.RFC3339)}
+		case auth.stats.lastFailed.Load():
+			health.Auth = ComponentHealth{Status: HealthDown, Detail: "token exchange failed: " + auth.Stats().LastError.Error()}
+		default:
+			health.Auth = ComponentHealth{Status: HealthOK, Detail: "token is requested on the next call"}
+		}
+	}
+
+	health.Account = ComponentHealth{Status: HealthOK, Detail: "no identity expected"}
+	if c.account != nil {
+		if activeErr == nil && options != nil && options.Active {
+			c.account.check(ctx)
+		}
+		switch checked, err := c.account.result(); {
+		case err != nil:
+			health.Account = ComponentHealth{Status: HealthDown, Detail: err.Error()}
+		case checked:
+			health.Account = ComponentHealth{Status: HealthOK, Detail: "identity matches"}
+		default:
+			health.Account = ComponentHealth{Status: HealthOK, Detail: "identity is checked on the next call"}
+		}
+	}
+
+	health.Quota = ComponentHealth{Status: HealthOK, Detail: "not tracked"}
+
+	if activeErr != nil {
+		if errors.Is(activeErr, ErrAuthFailed) {
+			health.Auth = ComponentHealth{Status: HealthDown, Detail: activeErr.Error()}
+		} else {
+			health.Connection = ComponentHealth{Status: HealthDown, Detail: activeErr.Error()}
+		}
+	}
+
+	health.rollup()
+	return health
+}
+
+// Health returns a snapshot of the health of the client like
+// BaseClient.Health, including the quota reported by the most recent send.
+//
+// Parameters:
+//   - ctx: Context bounding active checks
+//   - options: Optional settings (nil for a passive snapshot)
+//
+// Returns:
+//   - Health: Status of every component and the overall status
+func (c *EmailClient) Health(ctx context.Context, options *HealthOptions) Health {
+	health := c.BaseClient.Health(ctx, options)
+
+	health.EmailsLeft, health.QuotaUpdatedAt = c.EmailsLeft()
+	switch {
+	case health.QuotaUpdatedAt.IsZero():
+		health.Quota = ComponentHealth{Status: HealthOK, Detail: "no quota reading yet"}
+	case health.EmailsLeft <= 0:
+		health.Quota = ComponentHealth{Status: HealthDown, Detail: "quota exhausted"}
+	case health.EmailsLeft <= c.config.LowQuotaThreshold:
+		health.Quota = ComponentHealth{Status: HealthDegraded, Detail: fmt.Sprintf("%d emails left", health.EmailsLeft)}
+	default:
+		health.Quota = ComponentHealth{Status: HealthOK, Detail: fmt.Sprintf("%d emails left", health.EmailsLeft)}
+	}
+
+	health.rollup()
+	return health
+}
+
+// rollup sets Status to the worst status of all components.
+func (h *Health) rollup() {
+	h.Status = max(h.Connection.Status, h.Calls.Status, h.Auth.Status, h.Account.Status, h.Quota.Status)
+}
+
+// inspectableAuth returns the Auth behind auth, or nil if auth is another
+// IAuth implementation.
+func inspectableAuth(auth IAuth) *Auth {
+	switch a := auth.(type) {
+	case *Auth:
+		return a
+	case ownedAuth:
+		return a.Auth
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,675 @@
+package sendlix
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// InlineCSSOptions configures CSS inlining.
+type InlineCSSOptions struct {
+	// Stylesheets contains CSS sources, such as the content of external
+	// stylesheets, applied before the <style> blocks of the document.
+	Stylesheets []string
+
+	// KeepStyleBlocks retains the <style> and <link rel="stylesheet"> elements
+	// of the document after their rules were inlined. Default: false
+	KeepStyleBlocks bool
+
+	// OnWarning is called for every warning produced while inlining, for
+	// example for unsupported selectors. When set as ClientConfig.InlineCSS,
+	// it is called only once the warnings of a send passed
+	// ClientConfig.StrictWarnings. Default: nil (warnings are only returned
+	// in InlineCSSResult)
+	OnWarning func(warning string)
+}
+
+// InlineCSSResult is the outcome of InlineCSSWithOptions.
+type InlineCSSResult struct {
+	// HTML is the document with styles inlined
+	HTML string
+	// Warnings describes rules that could not be inlined
+	Warnings []string
+}
+
+// InlineCSS moves CSS rules into the style attributes of the elements they
+// match. Many email clients ignore <style> blocks and external stylesheets
+// but honor inline styles, so inlining maximizes rendering compatibility.
+//
+// The CSS of the document's <style> blocks and the given stylesheets is
+// inlined; the <style> and <link> elements are removed. See
+// InlineCSSWithOptions for details and for retaining the original blocks.
+//
+// Parameters:
+//   - html: HTML document to transform
+//   - css: Additional CSS sources, e.g. the content of linked stylesheets
+//
+// Returns:
+//   - string: HTML with inlined styles
+//   - error: Error if the CSS is malformed
+//
+// Example:
+//
+//	html, err := sendlix.InlineCSS(template, string(designerCSS))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func InlineCSS(html string, css ...string) (string, error) {
+	result, err := InlineCSSWithOptions(html, InlineCSSOptions{Stylesheets: css})
+	if err != nil {
+		return "", err
+	}
+	return result.HTML, nil
+}
+
+// InlineCSSWithOptions inlines CSS rules into element style attributes.
+//
+// Supported selectors are element names, classes, IDs, the universal
+// selector, combinations of these (e.g. "p.note"), and descendant
+// combinators (e.g. "table td.price"). Rules are applied by specificity and
+// source order; !important declarations win over normal ones, and existing
+// style attributes win over normal stylesheet declarations.
+//
+// Rules that cannot be inlined are kept in a <style> block in the document
+// head so clients supporting it still apply them:
+//   - @media and other block at-rules
+//   - rules with unsupported selectors such as pseudo-classes or attribute
+//     selectors; a warning is reported for each of them
+//
+// Linked stylesheets are not fetched. A warning is reported for every
+// <link rel="stylesheet">; pass its content through Stylesheets instead.
+//
+// The output is always a complete HTML document.
+//
+// Parameters:
+//   - document: HTML document to transform
+//   - options: Inlining options
+//
+// Returns:
+//   - *InlineCSSResult: Transformed HTML and warnings
+//   - error: Error if the HTML cannot be parsed or the CSS is malformed
+func InlineCSSWithOptions(document string, options InlineCSSOptions) (*InlineCSSResult, error) {
+	doc, err := html.Parse(strings.NewReader(document))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	inliner := &cssInliner{options: options}
+
+	for _, css := range options.Stylesheets {
+		if err := inliner.addStylesheet(css, true); err != nil {
+			return nil, err
+		}
+	}
+
+	var blocks []*html.Node
+	var head *html.Node
+	walkElements(doc, func(n *html.Node) {
+		switch n.DataAtom {
+		case atom.Head:
+			if head == nil {
+				head = n
+			}
+		case atom.Style:
+			blocks = append(blocks, n)
+		case atom.Link:
+			if strings.EqualFold(attr(n, "rel"), "stylesheet") {
+				inliner.warn(fmt.Sprintf("linked stylesheet %q was not fetched; pass its content as a stylesheet", attr(n, "href")))
+				blocks = append(blocks, n)
+			}
+		}
+	})
+
+	for _, block := range blocks {
+		if block.DataAtom != atom.Style {
+			continue
+		}
+		if media := strings.ToLower(strings.TrimSpace(attr(block, "media"))); media != "" && media != "all" && media != "screen" {
+			// Media-specific blocks cannot be inlined and stay as they are
+			inliner.keepBlocks = append(inliner.keepBlocks, block)
+			continue
+		}
+		if err := inliner.addStylesheet(textContent(block), !options.KeepStyleBlocks); err != nil {
+			return nil, err
+		}
+	}
+
+	inliner.apply(doc)
+
+	if !options.KeepStyleBlocks {
+		for _, block := range blocks {
+			if !inliner.isKept(block) {
+				block.Parent.RemoveChild(block)
+			}
+		}
+	}
+
+	if len(inliner.retained) > 0 && head != nil {
+		style := &html.Node{Type: html.ElementNode, Data: "style", DataAtom: atom.Style}
+		style.AppendChild(&html.Node{Type: html.TextNode, Data: "\n" + strings.Join(inliner.retained, "\n") + "\n"})
+		head.AppendChild(style)
+	}
+
+	var b strings.Builder
+	if err := html.Render(&b, doc); err != nil {
+		return nil, fmt.Errorf("failed to render HTML: %w", err)
+	}
+
+	return &InlineCSSResult{HTML: b.String(), Warnings: inliner.warnings}, nil
+}
+
+// prepareHTML applies the configured CSS inlining to HTML content, reporting
+// rules that could not be inlined as warnings on field.
+func (c *BaseClient) prepareHTML(ctx context.Context, field, content string) (string, error) {
+	if c.config.InlineCSS == nil || content == "" {
+		return content, nil
+	}
+
+	// Warnings are reported through warn before OnWarning, so a send
+	// rejected by StrictWarnings does not call it
+	options := *c.config.InlineCSS
+	options.OnWarning = nil
+	result, err := InlineCSSWithOptions(content, options)
+	if err != nil {
+		return "", fmt.Errorf("failed to inline CSS: %w", err)
+	}
+	for _, warning := range result.Warnings {
+		if err := c.warn(ctx, Warning{Code: WarningCSSRuleNotInlined, Field: field, Message: warning}); err != nil {
+			return "", err
+		}
+	}
+	if onWarning := c.config.InlineCSS.OnWarning; onWarning != nil {
+		for _, warning := range result.Warnings {
+			onWarning(warning)
+		}
+	}
+	return result.HTML, nil
+}
+
+// cssInliner collects rules from stylesheets and applies them to a document.
+type cssInliner struct {
+	options    InlineCSSOptions
+	rules      []cssRule
+	retained   []string
+	warnings   []string
+	keepBlocks []*html.Node
+	order      int
+}
+
+// cssRule is a single selector with the declarations it applies.
+type cssRule struct {
+	selector     []cssCompound
+	specificity  [3]int
+	declarations []cssDeclaration
+	order        int
+}
+
+// cssCompound is a selector part without combinators, such as "p.note#intro".
+type cssCompound struct {
+	element string
+	id      string
+	classes []string
+}
+
+// cssDeclaration is a single property declaration.
+type cssDeclaration struct {
+	property  string
+	value     string
+	important bool
+}
+
+// compoundPattern matches the supported compound selectors, and
+// compoundPartPattern splits their class and ID parts.
+var (
+	compoundPattern     = regexp.MustCompile(`^(\*|[a-zA-Z][a-zA-Z0-9-]*)?((?:[.#][a-zA-Z_-][a-zA-Z0-9_-]*)*)$`)
+	compoundPartPattern = regexp.MustCompile(`[.#][^.#]+`)
+)
+
+func (in *cssInliner) warn(warning string) {
+	in.warnings = append(in.warnings, warning)
+	if in.options.OnWarning != nil {
+		in.options.OnWarning(warning)
+	}
+}
+
+func (in *cssInliner) isKept(n *html.Node) bool {
+	for _, kept := range in.keepBlocks {
+		if kept == n {
+			return true
+		}
+	}
+	return false
+}
+
+// addStylesheet parses CSS and adds its rules. Rules that cannot be inlined
+// are retained for the output style block if retain is set.
+func (in *cssInliner) addStylesheet(css string, retain bool) error {
+	css, err := stripCSSComments(css)
+	if err != nil {
+		return err
+	}
+
+	keep := func(text string) {
+		if retain {
+			in.retained = append(in.retained, text)
+		}
+	}
+
+	pos := 0
+	for {
+		for pos < len(css) && isCSSSpace(css[pos]) {
+			pos++
+		}
+		if pos >= len(css) {
+			return nil
+		}
+
+		switch css[pos] {
+		case '}':
+			return fmt.Errorf("invalid CSS: unexpected '}' at offset %d", pos)
+		case '@':
+			end, block, err := scanAtRule(css, pos)
+			if err != nil {
+				return err
+			}
+			text := strings.TrimSpace(css[pos:end])
+			switch {
+			case block:
+				keep(text)
+			case strings.HasPrefix(strings.ToLower(text), "@charset"):
+				// Irrelevant once the CSS is inlined
+			default:
+				in.warn(fmt.Sprintf("unsupported at-rule %q was ignored", text))
+			}
+			pos = end
+			continue
+		}
+
+		open := strings.IndexAny(css[pos:], "{}")
+		if open < 0 {
+			return fmt.Errorf("invalid CSS: expected '{' after selector %q", strings.TrimSpace(css[pos:]))
+		}
+		if css[pos+open] == '}' {
+			return fmt.Errorf("invalid CSS: expected '{' after selector %q", strings.TrimSpace(css[pos:pos+open]))
+		}
+		open += pos
+		closing := strings.IndexAny(css[open+1:], "{}")
+		if closing < 0 || css[open+1+closing] == '{' {
+			return fmt.Errorf("invalid CSS: unterminated block for selector %q", strings.TrimSpace(css[pos:open]))
+		}
+		closing += open + 1
+
+		prelude := strings.TrimSpace(css[pos:open])
+		body := css[open+1 : closing]
+		pos = closing + 1
+
+		if prelude == "" {
+			return fmt.Errorf("invalid CSS: missing selector at offset %d", open)
+		}
+
+		declarations := in.parseDeclarations(body)
+		for _, selector := range strings.Split(prelude, ",") {
+			selector = strings.TrimSpace(selector)
+			compounds, specificity, ok := parseSelector(selector)
+			if !ok {
+				in.warn(fmt.Sprintf("unsupported selector %q was not inlined", selector))
+				keep(selector + " { " + strings.TrimSpace(body) + " }")
+				continue
+			}
+			in.rules = append(in.rules, cssRule{
+				selector:     compounds,
+				specificity:  specificity,
+				declarations: declarations,
+				order:        in.order,
+			})
+			in.order++
+		}
+	}
+}
+
+// parseDeclarations parses a declaration block, skipping invalid declarations.
+func (in *cssInliner) parseDeclarations(body string) []cssDeclaration {
+	var declarations []cssDeclaration
+	for _, part := range splitCSSDeclarations(body) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		decl, ok := parseDeclaration(part)
+		if !ok {
+			in.warn(fmt.Sprintf("invalid declaration %q was ignored", part))
+			continue
+		}
+		declarations = append(declarations, decl)
+	}
+	return declarations
+}
+
+// apply inlines the collected rules into all elements outside the head.
+func (in *cssInliner) apply(doc *html.Node) {
+	walkElements(doc, func(n *html.Node) {
+		if n.DataAtom == atom.Head || hasAncestor(n, atom.Head) {
+			return
+		}
+
+		type match struct {
+			decl        cssDeclaration
+			specificity [3]int
+			order       int
+		}
+		var matches []match
+		for _, rule := range in.rules {
+			if !matchSelector(n, rule.selector) {
+				continue
+			}
+			for _, decl := range rule.declarations {
+				matches = append(matches, match{decl, rule.specificity, rule.order})
+			}
+		}
+		if len(matches) == 0 {
+			return
+		}
+
+		sort.SliceStable(matches, func(i, j int) bool {
+			a, b := matches[i], matches[j]
+			if a.decl.important != b.decl.important {
+				return !a.decl.important
+			}
+			if a.specificity != b.specificity {
+				return lessSpecificity(a.specificity, b.specificity)
+			}
+			return a.order < b.order
+		})
+
+		var style cssStyle
+		for _, m := range matches {
+			style.set(m.decl)
+		}
+		for _, part := range splitCSSDeclarations(attr(n, "style")) {
+			decl, ok := parseDeclaration(strings.TrimSpace(part))
+			if !ok {
+				continue
+			}
+			if existing, found := style.get(decl.property); found && existing.important && !decl.important {
+				continue
+			}
+			style.set(decl)
+		}
+
+		setAttr(n, "style", style.String())
+	})
+}
+
+// cssStyle is an ordered set of declarations keyed by property.
+type cssStyle struct {
+	declarations []cssDeclaration
+}
+
+func (s *cssStyle) get(property string) (cssDeclaration, bool) {
+	for _, decl := range s.declarations {
+		if decl.property == property {
+			return decl, true
+		}
+	}
+	return cssDeclaration{}, false
+}
+
+func (s *cssStyle) set(decl cssDeclaration) {
+	for i := range s.declarations {
+		if s.declarations[i].property == decl.property {
+			s.declarations[i] = decl
+			return
+		}
+	}
+	s.declarations = append(s.declarations, decl)
+}
+
+func (s *cssStyle) String() string {
+	parts := make([]string, len(s.declarations))
+	for i, decl := range s.declarations {
+		parts[i] = decl.property + ": " + decl.value
+		if decl.important {
+			parts[i] += " !important"
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// parseSelector parses a selector made of compounds and descendant
+// combinators and computes its specificity.
+func parseSelector(selector string) ([]cssCompound, [3]int, bool) {
+	var specificity [3]int
+	fields := strings.Fields(selector)
+	if len(fields) == 0 {
+		return nil, specificity, false
+	}
+
+	compounds := make([]cssCompound, len(fields))
+	for i, field := range fields {
+		m := compoundPattern.FindStringSubmatch(field)
+		if m == nil {
+			return nil, specificity, false
+		}
+
+		compound := cssCompound{}
+		if m[1] != "" && m[1] != "*" {
+			compound.element = strings.ToLower(m[1])
+			specificity[2]++
+		}
+		for _, part := range compoundPartPattern.FindAllString(m[2], -1) {
+			if part[0] == '#' {
+				if compound.id != "" && compound.id != part[1:] {
+					// An element cannot have two IDs; such a selector never matches
+					return nil, specificity, false
+				}
+				compound.id = part[1:]
+				specificity[0]++
+			} else {
+				compound.classes = append(compound.classes, part[1:])
+				specificity[1]++
+			}
+		}
+		compounds[i] = compound
+	}
+
+	return compounds, specificity, true
+}
+
+// matchSelector reports whether an element matches a descendant selector.
+func matchSelector(n *html.Node, selector []cssCompound) bool {
+	last := len(selector) - 1
+	if !matchCompound(n, selector[last]) {
+		return false
+	}
+
+	i := last - 1
+	for ancestor := n.Parent; ancestor != nil && i >= 0; ancestor = ancestor.Parent {
+		if ancestor.Type == html.ElementNode && matchCompound(ancestor, selector[i]) {
+			i--
+		}
+	}
+	return i < 0
+}
+
+func matchCompound(n *html.Node, compound cssCompound) bool {
+	if compound.element != "" && n.Data != compound.element {
+		return false
+	}
+	if compound.id != "" && attr(n, "id") != compound.id {
+		return false
+	}
+	if len(compound.classes) > 0 {
+		classes := strings.Fields(attr(n, "class"))
+		for _, class := range compound.classes {
+			found := false
+			for _, c := range classes {
+				if c == class {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func lessSpecificity(a, b [3]int) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+// parseDeclaration parses a single "property: value" declaration.
+func parseDeclaration(text string) (cssDeclaration, bool) {
+	property, value, ok := strings.Cut(text, ":")
+	property = strings.ToLower(strings.TrimSpace(property))
+	value = strings.TrimSpace(value)
+	if !ok || property == "" || value == "" || strings.ContainsAny(property, " \t\n") {
+		return cssDeclaration{}, false
+	}
+
+	decl := cssDeclaration{property: property, value: value}
+	if idx := strings.LastIndex(value, "!"); idx >= 0 && strings.EqualFold(strings.TrimSpace(value[idx+1:]), "important") {
+		decl.value = strings.TrimSpace(value[:idx])
+		decl.important = true
+	}
+	return decl, decl.value != ""
+}
+
+// splitCSSDeclarations splits a declaration block at semicolons outside of
+// quotes and parentheses, so values like url(data:...;base64,...) stay intact.
+func splitCSSDeclarations(body string) []string {
+	var parts []string
+	depth, start := 0, 0
+	var quote byte
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			if depth > 0 {
+				depth--
+			}
+		case c == ';' && depth == 0:
+			parts = append(parts, body[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, body[start:])
+}
+
+// stripCSSComments removes /* ... */ comments.
+func stripCSSComments(css string) (string, error) {
+	var b strings.Builder
+	for {
+		start := strings.Index(css, "/*")
+		if start < 0 {
+			b.WriteString(css)
+			return b.String(), nil
+		}
+		end := strings.Index(css[start+2:], "*/")
+		if end < 0 {
+			return "", fmt.Errorf("invalid CSS: unterminated comment")
+		}
+		b.WriteString(css[:start])
+		css = css[start+2+end+2:]
+	}
+}
+
+// scanAtRule returns the end offset of the at-rule starting at pos and
+// whether it has a block.
+func scanAtRule(css string, pos int) (int, bool, error) {
+	for i := pos; i < len(css); i++ {
+		switch css[i] {
+		case ';':
+			return i + 1, false, nil
+		case '{':
+			depth := 0
+			for j := i; j < len(css); j++ {
+				switch css[j] {
+				case '{':
+					depth++
+				case '}':
+					depth--
+					if depth == 0 {
+						return j + 1, true, nil
+					}
+				}
+			}
+			return 0, false, fmt.Errorf("invalid CSS: unterminated block for %q", strings.TrimSpace(css[pos:i]))
+		}
+	}
+	return len(css), false, nil
+}
+
+func isCSSSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+// walkElements calls fn for every element node in document order.
+func walkElements(n *html.Node, fn func(*html.Node)) {
+	if n.Type == html.ElementNode {
+		fn(n)
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		walkElements(c, fn)
+	}
+}
+
+func hasAncestor(n *html.Node, a atom.Atom) bool {
+	for p := n.Parent; p != nil; p = p.Parent {
+		if p.DataAtom == a {
+			return true
+		}
+	}
+	return false
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func setAttr(n *html.Node, key, value string) {
+	for i := range n.Attr {
+		if n.Attr[i].Key == key {
+			n.Attr[i].Val = value
+			return
+		}
+	}
+	n.Attr = append(n.Attr, html.Attribute{Key: key, Val: value})
+}
+
+func textContent(n *html.Node) string {
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.TextNode {
+			b.WriteString(c.Data)
+		}
+	}
+	return b.String()
+}
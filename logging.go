@@ -0,0 +1,81 @@
+package sendlix
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// logInterceptor creates a gRPC unary interceptor that logs every call at
+// debug level: the method, duration, status code, request ID, and message
+// IDs, and for requests the subject, group, and number of recipients or
+// entries. Secrets, addresses, and message bodies are never logged.
+//
+// Parameters:
+//   - logger: Logger receiving one record per call
+//
+// Returns:
+//   - grpc.UnaryClientInterceptor: Configured logging interceptor
+func logInterceptor(logger *slog.Logger) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !logger.Enabled(ctx, slog.LevelDebug) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		callCtx, requestID := captureRequestID(ctx, nil)
+		start := time.Now()
+		err := invoker(callCtx, method, req, reply, cc, opts...)
+
+		attrs := []slog.Attr{
+			slog.String("method", method[strings.LastIndex(method, "/")+1:]),
+			slog.Duration("duration", time.Since(start)),
+			slog.String("code", status.Code(err).String()),
+		}
+		if id := requestID.get(); id != "" {
+			attrs = append(attrs, slog.String("request_id", id))
+		}
+		attrs = append(attrs, requestAttrs(req)...)
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+		} else if resp, ok := reply.(*pb.SendEmailResponse); ok {
+			attrs = append(attrs, slog.Any("message_ids", resp.Message))
+		}
+
+		logger.LogAttrs(ctx, slog.LevelDebug, "sendlix call", attrs...)
+		return err
+	}
+}
+
+// requestAttrs returns the loggable details of a request.
+func requestAttrs(req interface{}) []slog.Attr {
+	switch r := req.(type) {
+	case *pb.SendMailRequest:
+		return []slog.Attr{
+			slog.String("subject", r.Subject),
+			slog.Int("recipients", len(r.To)+len(r.Cc)+len(r.Bcc)),
+		}
+	case *pb.EmlMailRequest:
+		return []slog.Attr{slog.Int("size", len(r.Mail))}
+	case *pb.GroupMailData:
+		return []slog.Attr{
+			slog.String("group_id", r.GroupId),
+			slog.String("subject", r.Subject),
+		}
+	case *pb.InsertEmailToGroupRequest:
+		return []slog.Attr{
+			slog.String("group_id", r.GroupId),
+			slog.Int("entries", len(r.Entries)),
+		}
+	case *pb.RemoveEmailFromGroupRequest:
+		return []slog.Attr{slog.String("group_id", r.GroupId)}
+	case *pb.CheckEmailInGroupRequest:
+		return []slog.Attr{slog.String("group_id", r.GroupId)}
+	default:
+		return nil
+	}
+}
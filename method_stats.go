@@ -0,0 +1,191 @@
+package sendlix
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// DefaultStatsWindow is the number of most recent calls per method used to
+// compute latency percentiles when ClientConfig.CollectStats is set.
+const DefaultStatsWindow = 1024
+
+// MethodStats summarizes the calls of a single API method.
+type MethodStats struct {
+	// Count is the number of calls since creation or the last reset
+	Count uint64
+	// Errors is the number of failed calls since creation or the last reset
+	Errors uint64
+	// ErrorCodes counts the failed calls by the code returned by Code
+	ErrorCodes map[ErrorCode]uint64
+	// P50, P95, and P99 are latency percentiles over the most recent calls
+	P50, P95, P99 time.Duration
+	// Max is the highest latency since creation or the last reset
+	Max time.Duration
+}
+
+// StatsCollector keeps an in-memory latency summary per API method. Count,
+// Errors, and Max cover all observed calls; percentiles are computed over a
+// ring buffer of the most recent calls, so old outliers age out.
+//
+// A StatsCollector is safe for concurrent use. Clients create one
+// automatically when ClientConfig.CollectStats is set.
+type StatsCollector struct {
+	window int
+
+	mu      sync.Mutex
+	methods map[string]*methodSamples
+}
+
+// methodSamples holds the counters and latency ring buffer of one method.
+type methodSamples struct {
+	mu      sync.Mutex
+	count   uint64
+	errors  uint64
+	codes   map[ErrorCode]uint64
+	max     time.Duration
+	samples []time.Duration
+	next    int
+}
+
+// NewStatsCollector creates a collector computing percentiles over the
+// given number of most recent calls per method.
+//
+// Parameters:
+//   - window: Ring buffer size per method; DefaultStatsWindow if <= 0
+//
+// Returns:
+//   - *StatsCollector: Empty collector
+func NewStatsCollector(window int) *StatsCollector {
+	if window <= 0 {
+		window = DefaultStatsWindow
+	}
+	return &StatsCollector{window: window, methods: make(map[string]*methodSamples)}
+}
+
+// Observe records a call of a method with its latency and result.
+//
+// Parameters:
+//   - method: Method name, e.g. "SendEmail"
+//   - latency: Duration of the call
+//   - err: Error returned by the call, or nil
+func (s *StatsCollector) Observe(method string, latency time.Duration, err error) {
+	s.mu.Lock()
+	m, ok := s.methods[method]
+	if !ok {
+		m = &methodSamples{samples: make([]time.Duration, 0, s.window)}
+		s.methods[method] = m
+	}
+	s.mu.Unlock()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.count++
+	if err != nil {
+		m.errors++
+		if m.codes == nil {
+			m.codes = make(map[ErrorCode]uint64)
+		}
+		m.codes[Code(err)]++
+	}
+	if latency > m.max {
+		m.max = latency
+	}
+	if len(m.samples) < s.window {
+		m.samples = append(m.samples, latency)
+	} else {
+		m.samples[m.next] = latency
+	}
+	m.next = (m.next + 1) % s.window
+}
+
+// Stats returns a summary per method name.
+//
+// Returns:
+//   - map[string]MethodStats: Summary of every method called at least once
+func (s *StatsCollector) Stats() map[string]MethodStats {
+	s.mu.Lock()
+	methods := make(map[string]*methodSamples, len(s.methods))
+	for name, m := range s.methods {
+		methods[name] = m
+	}
+	s.mu.Unlock()
+
+	stats := make(map[string]MethodStats, len(methods))
+	for name, m := range methods {
+		m.mu.Lock()
+		sorted := append([]time.Duration(nil), m.samples...)
+		stat := MethodStats{Count: m.count, Errors: m.errors, Max: m.max}
+		if len(m.codes) > 0 {
+			stat.ErrorCodes = make(map[ErrorCode]uint64, len(m.codes))
+			for code, n := range m.codes {
+				stat.ErrorCodes[code] = n
+			}
+		}
+		m.mu.Unlock()
+
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		stat.P50 = percentile(sorted, 50)
+		stat.P95 = percentile(sorted, 95)
+		stat.P99 = percentile(sorted, 99)
+		stats[name] = stat
+	}
+	return stats
+}
+
+// Reset discards all counters and samples.
+func (s *StatsCollector) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.methods = make(map[string]*methodSamples)
+}
+
+// percentile returns the nearest-rank percentile of sorted samples.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+// interceptor creates a gRPC unary interceptor observing every call.
+func (s *StatsCollector) interceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		s.Observe(method[strings.LastIndex(method, "/")+1:], time.Since(start), err)
+		return err
+	}
+}
+
+// Stats returns the per-method latency summary of this client, keyed by
+// method name such as "SendEmail". It returns nil unless
+// ClientConfig.CollectStats is set.
+//
+// Returns:
+//   - map[string]MethodStats: Summary of every method called at least once
+//
+// Example:
+//
+//	for method, stats := range client.Stats() {
+//		fmt.Printf("%s: n=%d p95=%s max=%s\n", method, stats.Count, stats.P95, stats.Max)
+//	}
+func (c *BaseClient) Stats() map[string]MethodStats {
+	if c.stats == nil {
+		return nil
+	}
+	return c.stats.Stats()
+}
+
+// ResetStats discards the collected statistics of this client.
+func (c *BaseClient) ResetStats() {
+	if c.stats != nil {
+		c.stats.Reset()
+	}
+}
@@ -0,0 +1,59 @@
+package sendlix
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MetricsCollector receives the outcome of every call and send of a
+// client, for export to a metrics system. Set it as ClientConfig.Metrics.
+// Its methods are called concurrently and must not block.
+//
+// The sendlixprom package provides an implementation exporting Prometheus
+// metrics.
+type MetricsCollector interface {
+	// ObserveRPC is called after every call with the method name, such as
+	// "SendEmail", its status code, and its duration. Retried calls are
+	// observed once per attempt.
+	ObserveRPC(method string, code codes.Code, duration time.Duration)
+
+	// ObserveEmailsSent is called after every successful send of
+	// EmailClient with the number of messages the API accepted.
+	ObserveEmailsSent(n int)
+
+	// ObserveEmailsLeft is called after every successful send of
+	// EmailClient with the remaining email quota.
+	ObserveEmailsLeft(n int64)
+}
+
+// metricsInterceptor creates a gRPC unary interceptor reporting every call
+// to collector.
+//
+// Parameters:
+//   - collector: Collector receiving the calls
+//
+// Returns:
+//   - grpc.UnaryClientInterceptor: Configured metrics interceptor
+func metricsInterceptor(collector MetricsCollector) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		collector.ObserveRPC(method[strings.LastIndex(method, "/")+1:], status.Code(err), time.Since(start))
+		return err
+	}
+}
+
+// observeSent reports a successful send to ClientConfig.Metrics.
+func (c *EmailClient) observeSent(resp *pb.SendEmailResponse) {
+	if c.config.Metrics == nil {
+		return
+	}
+	c.config.Metrics.ObserveEmailsSent(len(resp.Message))
+	c.config.Metrics.ObserveEmailsLeft(resp.EmailsLeft)
+}
@@ -0,0 +1,362 @@
+package sendlix
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrUseEML is matched by UseEMLError, so errors.Is reports whether
+// FromMIMEHeaderAndBody advises sending the message with SendEMLEmail.
+var ErrUseEML = errors.New("message cannot be converted, send it as EML")
+
+// UseEMLError is returned by FromMIMEHeaderAndBody when a message is valid
+// but its structure cannot be expressed with MailOptions, for example
+// because it has inline images or a charset other than UTF-8. EML holds the
+// message serialized for SendEMLEmail.
+type UseEMLError struct {
+	// Reason describes the part of the message that cannot be converted
+	Reason string
+	// EML is the complete message, ready to pass to SendEMLEmail
+	EML []byte
+}
+
+// Error returns the reason the message cannot be converted.
+func (e *UseEMLError) Error() string {
+	return "message cannot be converted, send it as EML: " + e.Reason
+}
+
+// Is reports whether target is ErrUseEML.
+func (e *UseEMLError) Is(target error) bool {
+	return target == ErrUseEML
+}
+
+// mimeHeader is the header of a message or of a part of it.
+type mimeHeader interface {
+	Get(key string) string
+}
+
+// unsupportedMIME is the reason a message cannot be converted to
+// MailOptions.
+type unsupportedMIME string
+
+func (u unsupportedMIME) Error() string {
+	return string(u)
+}
+
+// FromMIMEHeaderAndBody converts a message built with net/mail, gomail, or
+// similar libraries into MailOptions, so it can be sent with SendEmail.
+//
+// The From, To, Cc, Bcc, Reply-To, and Subject headers are converted;
+// other headers are dropped. Supported bodies are a single text/plain or
+// text/html part, a multipart/alternative of both, and a multipart/mixed
+// whose first part is one of these and whose other parts become
+// content-based attachments, which require ClientConfig.AttachmentUploader.
+// Text must be UTF-8 or US-ASCII.
+//
+// Other messages, such as multipart/related messages with inline images,
+// fail with a *UseEMLError carrying the serialized message for
+// SendEMLEmail.
+//
+// Parameters:
+//   - headers: Message headers, e.g. from mail.ReadMessage
+//   - body: Message body, read to the end
+//
+// Returns:
+//   - MailOptions: Converted message
+//   - *AdditionalOptions: Attachments of the message, or nil if it has none
+//   - error: A *UseEMLError matching ErrUseEML if the message must be sent
+//     as EML, or an error for malformed headers or bodies
+//
+// Example:
+//
+//	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	options, additional, err := sendlix.FromMIMEHeaderAndBody(msg.Header, msg.Body)
+//	var useEML *sendlix.UseEMLError
+//	switch {
+//	case errors.As(err, &useEML):
+//		_, err = client.SendEMLEmail(ctx, useEML.EML, nil)
+//	case err == nil:
+//		_, err = client.SendEmail(ctx, options, additional)
+//	}
+func FromMIMEHeaderAndBody(headers mail.Header, body io.Reader) (MailOptions, *AdditionalOptions, error) {
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		return MailOptions{}, nil, fmt.Errorf("failed to read message body: %w", err)
+	}
+
+	options, additional, err := convertMIMEMessage(headers, raw)
+	var unsupported unsupportedMIME
+	if errors.As(err, &unsupported) {
+		return MailOptions{}, nil, &UseEMLError{Reason: string(unsupported), EML: serializeEML(headers, raw)}
+	}
+	if err != nil {
+		return MailOptions{}, nil, err
+	}
+	return options, additional, nil
+}
+
+// convertMIMEMessage converts the headers and body of a message. It returns
+// an unsupportedMIME error if the message cannot be expressed as
+// MailOptions.
+func convertMIMEMessage(headers mail.Header, raw []byte) (MailOptions, *AdditionalOptions, error) {
+	var options MailOptions
+	var err error
+
+	if headers.Get("From") != "" {
+		from, err := singleAddress(headers, "From")
+		if err != nil {
+			return options, nil, err
+		}
+		options.From = *from
+	}
+	if headers.Get("Reply-To") != "" {
+		if options.ReplyTo, err = singleAddress(headers, "Reply-To"); err != nil {
+			return options, nil, err
+		}
+	}
+	for _, list := range []struct {
+		name string
+		dest *[]EmailAddress
+	}{{"To", &options.To}, {"Cc", &options.CC}, {"Bcc", &options.BCC}} {
+		if *list.dest, err = addressList(headers, list.name); err != nil {
+			return options, nil, err
+		}
+	}
+
+	if options.Subject, err = new(mime.WordDecoder).DecodeHeader(headers.Get("Subject")); err != nil {
+		return options, nil, unsupportedMIME(fmt.Sprintf("Subject header: %v", err))
+	}
+
+	attachments, err := convertMIMEBody(&options, headers, raw)
+	if err != nil {
+		return options, nil, err
+	}
+	if len(attachments) == 0 {
+		return options, nil, nil
+	}
+	return options, &AdditionalOptions{Attachments: attachments}, nil
+}
+
+// convertMIMEBody sets the content of options from a message body and
+// returns the attachments of a multipart/mixed body.
+func convertMIMEBody(options *MailOptions, header mimeHeader, raw []byte) ([]Attachment, error) {
+	mediaType, params, err := parseContentType(header)
+	if err != nil {
+		return nil, err
+	}
+
+	switch mediaType {
+	case "text/plain", "text/html", "multipart/alternative":
+		return nil, convertMIMEContent(options, mediaType, params, header, raw)
+	case "multipart/mixed":
+	default:
+		return nil, unsupportedMIME("content type " + mediaType)
+	}
+
+	var attachments []Attachment
+	err = readMIMEParts(params, raw, func(i int, part *multipart.Part, data []byte) error {
+		partType, partParams, err := parseContentType(part.Header)
+		if err != nil {
+			return err
+		}
+		if i == 0 && !isAttachmentPart(part) {
+			return convertMIMEContent(options, partType, partParams, part.Header, data)
+		}
+		if strings.HasPrefix(partType, "multipart/") {
+			return unsupportedMIME("nested " + partType + " attachment")
+		}
+
+		content, err := decodeMIMEPart(part.Header, data)
+		if err != nil {
+			return err
+		}
+		filename := part.FileName()
+		if filename == "" {
+			filename = partParams["name"]
+		}
+		if filename == "" {
+			filename = fmt.Sprintf("attachment-%d", len(attachments)+1)
+		}
+		attachments = append(attachments, NewAttachmentFromBytes(filename, partType, content))
+		return nil
+	})
+	return attachments, err
+}
+
+// convertMIMEContent sets the content of options from a text part or a
+// multipart/alternative of text parts.
+func convertMIMEContent(options *MailOptions, mediaType string, params map[string]string, header mimeHeader, raw []byte) error {
+	switch mediaType {
+	case "text/plain":
+		text, err := decodeMIMEText(header, params, raw)
+		options.Text = text
+		return err
+	case "text/html":
+		html, err := decodeMIMEText(header, params, raw)
+		options.Html = html
+		return err
+	case "multipart/alternative":
+	default:
+		return unsupportedMIME("content type " + mediaType)
+	}
+
+	seen := make(map[string]bool)
+	return readMIMEParts(params, raw, func(i int, part *multipart.Part, data []byte) error {
+		partType, partParams, err := parseContentType(part.Header)
+		if err != nil {
+			return err
+		}
+		if (partType != "text/plain" && partType != "text/html") || isAttachmentPart(part) {
+			return unsupportedMIME(partType + " alternative")
+		}
+		if seen[partType] {
+			return unsupportedMIME("repeated " + partType + " alternative")
+		}
+		seen[partType] = true
+		return convertMIMEContent(options, partType, partParams, part.Header, data)
+	})
+}
+
+// readMIMEParts calls fn with every part of a multipart body and its raw
+// content.
+func readMIMEParts(params map[string]string, raw []byte, fn func(i int, part *multipart.Part, data []byte) error) error {
+	boundary := params["boundary"]
+	if boundary == "" {
+		return errors.New("multipart body has no boundary")
+	}
+
+	reader := multipart.NewReader(bytes.NewReader(raw), boundary)
+	for i := 0; ; i++ {
+		part, err := reader.NextRawPart()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read multipart body: %w", err)
+		}
+		data, err := io.ReadAll(part)
+		if err != nil {
+			return fmt.Errorf("failed to read multipart body: %w", err)
+		}
+		if err := fn(i, part, data); err != nil {
+			return err
+		}
+	}
+}
+
+// parseContentType returns the lowercase media type and parameters of a
+// header, defaulting to text/plain.
+func parseContentType(header mimeHeader) (string, map[string]string, error) {
+	contentType := header.Get("Content-Type")
+	if contentType == "" {
+		return "text/plain", map[string]string{}, nil
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid Content-Type %q: %w", contentType, err)
+	}
+	return mediaType, params, nil
+}
+
+// isAttachmentPart reports whether a part is marked as an attachment.
+func isAttachmentPart(part *multipart.Part) bool {
+	disposition, _, err := mime.ParseMediaType(part.Header.Get("Content-Disposition"))
+	return err == nil && disposition == "attachment"
+}
+
+// decodeMIMEPart removes the content transfer encoding of a part.
+func decodeMIMEPart(header mimeHeader, raw []byte) ([]byte, error) {
+	switch encoding := strings.ToLower(strings.TrimSpace(header.Get("Content-Transfer-Encoding"))); encoding {
+	case "", "7bit", "8bit", "binary":
+		return raw, nil
+	case "quoted-printable":
+		decoded, err := io.ReadAll(quotedprintable.NewReader(bytes.NewReader(raw)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid quoted-printable content: %w", err)
+		}
+		return decoded, nil
+	case "base64":
+		decoded, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(string(raw)), ""))
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 content: %w", err)
+		}
+		return decoded, nil
+	default:
+		return nil, unsupportedMIME("content transfer encoding " + encoding)
+	}
+}
+
+// decodeMIMEText decodes a text part, which must be UTF-8 or US-ASCII.
+func decodeMIMEText(header mimeHeader, params map[string]string, raw []byte) (string, error) {
+	if charset := strings.ToLower(params["charset"]); charset != "" && charset != "utf-8" && charset != "us-ascii" {
+		return "", unsupportedMIME("charset " + charset)
+	}
+	decoded, err := decodeMIMEPart(header, raw)
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(decoded) {
+		return "", unsupportedMIME("text is not valid UTF-8")
+	}
+	return string(decoded), nil
+}
+
+// singleAddress parses a header holding exactly one address.
+func singleAddress(headers mail.Header, name string) (*EmailAddress, error) {
+	addrs, err := addressList(headers, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) != 1 {
+		return nil, unsupportedMIME(fmt.Sprintf("%s header with %d addresses", name, len(addrs)))
+	}
+	return &addrs[0], nil
+}
+
+// addressList parses an address list header, returning nil if it is absent.
+func addressList(headers mail.Header, name string) ([]EmailAddress, error) {
+	if headers.Get(name) == "" {
+		return nil, nil
+	}
+	parsed, err := headers.AddressList(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s header: %w", name, err)
+	}
+	addrs := make([]EmailAddress, len(parsed))
+	for i, addr := range parsed {
+		addrs[i] = EmailAddress{Email: addr.Address, Name: addr.Name}
+	}
+	return addrs, nil
+}
+
+// serializeEML writes headers, sorted by name, and the raw body as a
+// message for SendEMLEmail.
+func serializeEML(headers mail.Header, raw []byte) []byte {
+	var buf bytes.Buffer
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		for _, value := range headers[name] {
+			buf.WriteString(name + ": " + value + "\r\n")
+		}
+	}
+	buf.WriteString("\r\n")
+	buf.Write(raw)
+	return buf.Bytes()
+}
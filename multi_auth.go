@@ -0,0 +1,240 @@
+package sendlix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultKeyProbeInterval is how often a MultiAuth that fell back to a later
+// key tries the keys preferred over it again.
+const DefaultKeyProbeInterval = 5 * time.Minute
+
+// MultiAuth implements IAuth with several API keys, failing over to the next
+// key when the authentication service rejects one. It allows configuring the
+// old and the new key during a key rotation, so sends keep working while the
+// change propagates.
+type MultiAuth struct {
+	auths         []*Auth       // One Auth per key, in order of preference
+	probeInterval time.Duration // How often preferred keys are tried again
+
+	mu       sync.Mutex // Guards active and probedAt
+	active   int        // Index of the key in use
+	probedAt time.Time  // When the preferred keys were last tried
+}
+
+// WithKeyProbeInterval sets how often a MultiAuth using a fallback key tries
+// the keys preferred over it again. It only affects NewMultiAuthWithOptions.
+//
+// Parameters:
+//   - interval: Minimum time between attempts. Default:
+//     DefaultKeyProbeInterval
+//
+// Returns:
+//   - AuthOption: Option to pass to NewMultiAuthWithOptions
+func WithKeyProbeInterval(interval time.Duration) AuthOption {
+	return func(a *Auth) {
+		a.keyProbeInterval = max(interval, 0)
+	}
+}
+
+// NewMultiAuth creates a MultiAuth trying the API keys in order. Every key
+// must be in the format accepted by NewAuth.
+//
+// Parameters:
+//   - keys: API keys in order of preference, e.g. the new key followed by
+//     the old key
+//
+// Returns:
+//   - *MultiAuth: Configured authentication instance
+//   - error: ErrMissingAuth if no key is given, a validation error naming
+//     the position of a malformed key, or a connection error
+//
+// Example:
+//
+//	auth, err := sendlix.NewMultiAuth(os.Getenv("SENDLIX_NEW_KEY"), os.Getenv("SENDLIX_OLD_KEY"))
+//	if err != nil {
+//		log.Fatal("Failed to create auth:", err)
+//	}
+//	defer auth.Close()
+func NewMultiAuth(keys ...string) (*MultiAuth, error) {
+	return NewMultiAuthWithOptions(keys)
+}
+
+// NewMultiAuthWithOptions creates a MultiAuth like NewMultiAuth, applying
+// opts to the Auth of every key. All keys share one connection to the
+// authentication service.
+//
+// A key rejected by the authentication service with UNAUTHENTICATED or
+// PERMISSION_DENIED falls through to the next key, and the key that
+// succeeds is used until it is rejected in turn. Every
+// DefaultKeyProbeInterval (see WithKeyProbeInterval) the keys preferred
+// over it are tried again, so the MultiAuth returns to the first key once
+// it is accepted. Other errors, such as an unavailable service, are
+// returned without trying further keys.
+//
+// A TokenStore set with WithTokenStore is only used for the first key.
+// With WithVerifyOnCreate, construction fails only if no key is accepted.
+//
+// Parameters:
+//   - keys: API keys in order of preference
+//   - opts: Optional settings applied to every key, such as
+//     WithAuthConnection or WithKeyProbeInterval
+//
+// Returns:
+//   - *MultiAuth: Configured authentication instance
+//   - error: ErrMissingAuth if no key is given, a validation error naming
+//     the position of a malformed key, or a connection error
+func NewMultiAuthWithOptions(keys []string, opts ...AuthOption) (*MultiAuth, error) {
+	if len(keys) == 0 {
+		return nil, newValidationError(CodeMissingAuth, "keys", nil)
+	}
+
+	// Verify the keys together below, so that a rejected fallback key does
+	// not fail construction
+	var verify bool
+	opts = append(opts[:len(opts):len(opts)], func(a *Auth) {
+		verify = verify || a.verifyOnCreate
+		a.verifyOnCreate = false
+	})
+
+	m := &MultiAuth{auths: make([]*Auth, 0, len(keys))}
+	for i, key := range keys {
+		keyOpts := opts
+		if i > 0 {
+			// Share the connection of the first key
+			keyOpts = append(opts[:len(opts):len(opts)], WithAuthConnection(m.auths[0].conn))
+		}
+
+		auth, err := NewAuth(key, keyOpts...)
+		if err != nil {
+			m.Close()
+			var validationErr *ValidationError
+			if errors.As(err, &validationErr) {
+				return nil, fmt.Errorf("API key at index %d: %w", i, err)
+			}
+			return nil, err
+		}
+		if i > 0 {
+			// A shared store would serve the token of another key
+			auth.store = nil
+		}
+		m.auths = append(m.auths, auth)
+	}
+
+	m.probeInterval = m.auths[0].keyProbeInterval
+
+	if verify {
+		if err := m.Verify(context.Background()); err != nil {
+			m.Close()
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// GetAuthHeader returns the authorization header of the key in use,
+// failing over to the next key if it is rejected. This method implements
+// the IAuth interface and is safe for concurrent use.
+//
+// Parameters:
+//   - ctx: Context for the authentication request
+//
+// Returns:
+//   - string: Header key ("authorization")
+//   - string: Header value ("Bearer <token>")
+//   - error: The error of the key in use if it failed for another reason
+//     than a rejection, or an error naming the key ID and failure of every
+//     key if all keys were rejected. Secrets are never included.
+func (m *MultiAuth) GetAuthHeader(ctx context.Context) (string, string, error) {
+	now := m.auths[0].now()
+
+	m.mu.Lock()
+	active, start := m.active, m.active
+	if active > 0 && now.Sub(m.probedAt) >= m.probeInterval {
+		start = 0
+		m.probedAt = now
+	}
+	m.mu.Unlock()
+
+	var failures []error
+	for n := range m.auths {
+		i := (start + n) % len(m.auths)
+		key, value, err := m.auths[i].GetAuthHeader(ctx)
+		if err == nil {
+			if i != active {
+				m.mu.Lock()
+				m.active = i
+				m.probedAt = now
+				m.mu.Unlock()
+			}
+			return key, value, nil
+		}
+
+		// A failed probe of a preferred key must not fail the request
+		if !keyRejected(err) && i == active {
+			return "", "", err
+		}
+		failures = append(failures, fmt.Errorf("API key %d: %w", m.auths[i].currentKeyID(), err))
+	}
+
+	return "", "", fmt.Errorf("all %d API keys failed: %w", len(m.auths), errors.Join(failures...))
+}
+
+// ActiveKeyID returns the key ID of the key in use, which is the key that
+// last succeeded, or the first key before any request.
+//
+// Returns:
+//   - int64: Key ID of the key in use
+func (m *MultiAuth) ActiveKeyID() int64 {
+	m.mu.Lock()
+	active := m.active
+	m.mu.Unlock()
+	return m.auths[active].currentKeyID()
+}
+
+// Close closes the connection used for token exchanges if
+// NewMultiAuthWithOptions dialed it. Connections passed with
+// WithAuthConnection remain open.
+//
+// Returns:
+//   - error: Any error encountered while closing the connection
+func (m *MultiAuth) Close() error {
+	var errs []error
+	for _, auth := range m.auths {
+		errs = append(errs, auth.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// invalidateHeader discards the cached token of the key that issued header,
+// so the client's retry of an UNAUTHENTICATED call exchanges that key again
+// and fails over if it is no longer accepted.
+func (m *MultiAuth) invalidateHeader(header string) {
+	for _, auth := range m.auths {
+		auth.invalidateHeader(header)
+	}
+}
+
+// keyRejected reports whether a token exchange failed because the
+// authentication service did not accept the key.
+func keyRejected(err error) bool {
+	switch status.Code(err) {
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return true
+	default:
+		return false
+	}
+}
+
+// currentKeyID returns the key ID of the API key.
+func (a *Auth) currentKeyID() int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.keyID
+}
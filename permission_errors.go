@@ -0,0 +1,132 @@
+package sendlix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorInfo reasons the Sendlix API attaches to PERMISSION_DENIED and
+// UNAUTHENTICATED errors as google.rpc.ErrorInfo details.
+const (
+	reasonInsufficientScope = "INSUFFICIENT_SCOPE"
+	reasonAccountSuspended  = "ACCOUNT_SUSPENDED"
+	reasonAPIKeyDisabled    = "API_KEY_DISABLED"
+)
+
+// ErrPermissionDenied is wrapped by PERMISSION_DENIED errors that carry no
+// more specific reason.
+var ErrPermissionDenied = errors.New("permission denied")
+
+// ErrInsufficientScope matches InsufficientScopeError with errors.Is.
+var ErrInsufficientScope = errors.New("API key lacks the required scope")
+
+// ErrAccountSuspended is wrapped by errors of calls rejected because the
+// Sendlix account is suspended. Retrying will not help until the account is
+// reinstated.
+var ErrAccountSuspended = errors.New("account is suspended")
+
+// ErrAPIKeyDisabled is wrapped by token exchange and call errors when the API
+// key has been disabled or revoked.
+var ErrAPIKeyDisabled = errors.New("API key is disabled")
+
+// InsufficientScopeError is returned when a scoped API key, such as a
+// send-only key, is used for an operation outside its scope.
+//
+// Example:
+//
+//	_, err := groupClient.InsertEmailToGroup(ctx, "newsletter", entry)
+//	var scopeErr *sendlix.InsufficientScopeError
+//	if errors.As(err, &scopeErr) {
+//		log.Printf("key needs scope %s for %s", scopeErr.RequiredScope, scopeErr.Operation)
+//	}
+type InsufficientScopeError struct {
+	// Operation is the rejected operation, e.g. "InsertEmailToGroup"
+	Operation string
+	// RequiredScope is the scope the operation needs, if reported
+	RequiredScope string
+	// Err is the underlying gRPC error
+	Err error
+}
+
+// Error returns the formatted error message.
+func (e *InsufficientScopeError) Error() string {
+	if e.RequiredScope == "" {
+		return fmt.Sprintf("API key lacks the scope required for %s: %v", e.Operation, e.Err)
+	}
+	return fmt.Sprintf("API key lacks scope %q required for %s: %v", e.RequiredScope, e.Operation, e.Err)
+}
+
+// Unwrap returns the underlying gRPC error.
+func (e *InsufficientScopeError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrInsufficientScope.
+func (e *InsufficientScopeError) Is(target error) bool {
+	return target == ErrInsufficientScope
+}
+
+// mapPermissionError wraps permission-related gRPC errors into the SDK's
+// permission errors based on their google.rpc.ErrorInfo details:
+//   - reason INSUFFICIENT_SCOPE, with optional "operation" and
+//     "required_scope" metadata, becomes an InsufficientScopeError
+//   - reason ACCOUNT_SUSPENDED wraps ErrAccountSuspended
+//   - reason API_KEY_DISABLED wraps ErrAPIKeyDisabled
+//   - any other PERMISSION_DENIED wraps ErrPermissionDenied
+//
+// Other errors are returned unchanged. method is the full gRPC method name,
+// used as the operation when the details do not name one.
+func mapPermissionError(method string, err error) error {
+	s, ok := status.FromError(err)
+	if !ok || (s.Code() != codes.PermissionDenied && s.Code() != codes.Unauthenticated) {
+		return err
+	}
+
+	for _, detail := range s.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok {
+			continue
+		}
+		switch info.GetReason() {
+		case reasonInsufficientScope:
+			operation := info.GetMetadata()["operation"]
+			if operation == "" {
+				operation = method[strings.LastIndex(method, "/")+1:]
+			}
+			return &InsufficientScopeError{
+				Operation:     operation,
+				RequiredScope: info.GetMetadata()["required_scope"],
+				Err:           err,
+			}
+		case reasonAccountSuspended:
+			return fmt.Errorf("%w: %w", ErrAccountSuspended, err)
+		case reasonAPIKeyDisabled:
+			return fmt.Errorf("%w: %w", ErrAPIKeyDisabled, err)
+		}
+	}
+
+	if s.Code() == codes.PermissionDenied {
+		return fmt.Errorf("%w: %w", ErrPermissionDenied, err)
+	}
+	return err
+}
+
+// permissionInterceptor creates a gRPC unary interceptor applying
+// mapPermissionError to the result of every call except token exchanges,
+// whose errors Auth maps itself.
+func permissionInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if method == pb.Auth_GetJwtToken_FullMethodName {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		return mapPermissionError(method, invoker(ctx, method, req, reply, cc, opts...))
+	}
+}
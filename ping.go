@@ -0,0 +1,96 @@
+package sendlix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// ErrUnreachable is wrapped by Ping errors when the Sendlix API or its
+// authentication service could not be reached, for example because of DNS,
+// network, or TLS failures. Such failures are usually transient.
+var ErrUnreachable = errors.New("API could not be reached")
+
+// ErrNotServing is wrapped by Ping errors when the Sendlix API was reached
+// but reported that it is not serving requests.
+var ErrNotServing = errors.New("API is not serving")
+
+// Ping checks that the client can reach the Sendlix API and authenticate,
+// without sending an email, for example in readiness probes. It waits for
+// the connection to become ready, obtains an authentication header from the
+// configured IAuth, and queries the standard gRPC health service if the
+// server provides it.
+//
+// Parameters:
+//   - ctx: Context bounding the check (a deadline is recommended)
+//
+// Returns:
+//   - error: nil if the API is reachable and the credentials are accepted;
+//     an error wrapping ErrUnreachable on network failures, ErrAuthFailed if
+//     the credentials were rejected, or ErrNotServing if the health service
+//     reported a failure
+//
+// Example:
+//
+//	http.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+//		ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
+//		defer cancel()
+//		switch err := client.Ping(ctx); {
+//		case errors.Is(err, sendlix.ErrAuthFailed):
+//			http.Error(w, "credentials rejected", http.StatusInternalServerError)
+//		case err != nil:
+//			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+//		}
+//	})
+func (c *BaseClient) Ping(ctx context.Context) error {
+	if err := waitForReady(ctx, c.GetConnection(), c.config.ServerAddress); err != nil {
+		return fmt.Errorf("%w: %w", ErrUnreachable, err)
+	}
+
+	if _, _, err := c.auth.GetAuthHeader(ctx); err != nil {
+		if unreachable(err) {
+			return fmt.Errorf("%w: %w", ErrUnreachable, err)
+		}
+		return fmt.Errorf("%w: %w", ErrAuthFailed, err)
+	}
+
+	resp, err := healthpb.NewHealthClient(c.stubConn()).Check(ctx, &healthpb.HealthCheckRequest{})
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		// The server has no health service; connection and credentials work
+		return nil
+	case err != nil:
+		return pingError(err)
+	case resp.GetStatus() != healthpb.HealthCheckResponse_SERVING:
+		return fmt.Errorf("%w: health status is %s", ErrNotServing, resp.GetStatus())
+	}
+	return nil
+}
+
+// pingError classifies the error of the health check made by Ping.
+func pingError(err error) error {
+	switch {
+	case errors.Is(err, ErrAuthFailed), errors.Is(err, ErrAccountMismatch):
+		return err
+	case keyRejected(err):
+		return fmt.Errorf("%w: %w", ErrAuthFailed, err)
+	case unreachable(err):
+		return fmt.Errorf("%w: %w", ErrUnreachable, err)
+	default:
+		return fmt.Errorf("%w: %w", ErrNotServing, err)
+	}
+}
+
+// unreachable reports whether err is a network failure or timeout.
+func unreachable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return errors.Is(err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,196 @@
+package sendlix
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PlaceholderReport is the result of comparing the placeholders used in
+// email content with the substitution keys available for it.
+type PlaceholderReport struct {
+	// Placeholders contains all placeholder names found in the content
+	Placeholders []string
+	// Unknown contains placeholders without a matching key. They would be
+	// rendered literally, e.g. "Hello {{first_nme}}".
+	Unknown []string
+	// Unused contains keys that no placeholder refers to
+	Unused []string
+}
+
+// OK reports whether every placeholder has a matching key.
+func (r PlaceholderReport) OK() bool {
+	return len(r.Unknown) == 0
+}
+
+// PlaceholderCheckConfig configures placeholder checking for send methods.
+type PlaceholderCheckConfig struct {
+	// Keys are the substitution keys available to all sends, typically the
+	// keys of the group entries' Substitutions. Image placeholders of a send
+	// are always known.
+	Keys []string
+
+	// Strict rejects sends with unknown placeholders with
+	// ErrUnknownPlaceholder. Default: false (only OnWarning is called)
+	Strict bool
+
+	// OnWarning is called with the report of a send that has unknown
+	// placeholders or unused keys and was not rejected. Default: nil
+	OnWarning func(report PlaceholderReport)
+}
+
+// CheckPlaceholders scans content for {{name}} placeholders and compares
+// them with the available substitution keys. Use it to catch typos like
+// {{first_nme}} before a campaign reaches thousands of recipients.
+//
+// Placeholder syntax:
+//   - names consist of letters, digits, '_', '-', and '.', and may be
+//     surrounded by spaces: {{ first_name }}
+//   - triple braces are accepted: {{{first_name}}}
+//   - a backslash escapes a placeholder: \{{not_a_placeholder}}
+//   - for nested braces such as {{ {{name}} }} the innermost placeholder counts
+//   - placeholders inside HTML attributes are found like any other text
+//
+// Parameters:
+//   - keys: Available substitution keys
+//   - content: Texts to scan, typically subject, HTML, and text content
+//
+// Returns:
+//   - PlaceholderReport: Found, unknown, and unused placeholders, sorted
+//
+// Example:
+//
+//	report := sendlix.CheckPlaceholders([]string{"first_name", "discount"},
+//		"Hi {{first_nme}}", "<p>Save {{discount}}</p>")
+//	fmt.Println(report.Unknown) // [first_nme]
+func CheckPlaceholders(keys []string, content ...string) PlaceholderReport {
+	found := make(map[string]struct{})
+	for _, text := range content {
+		for _, name := range findPlaceholders(text) {
+			found[name] = struct{}{}
+		}
+	}
+
+	known := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		known[key] = struct{}{}
+	}
+
+	var report PlaceholderReport
+	for name := range found {
+		report.Placeholders = append(report.Placeholders, name)
+		if _, ok := known[name]; !ok {
+			report.Unknown = append(report.Unknown, name)
+		}
+	}
+	for key := range known {
+		if _, ok := found[key]; !ok {
+			report.Unused = append(report.Unused, key)
+		}
+	}
+
+	sort.Strings(report.Placeholders)
+	sort.Strings(report.Unknown)
+	sort.Strings(report.Unused)
+	return report
+}
+
+// findPlaceholders returns the placeholder names in text in order of appearance.
+func findPlaceholders(text string) []string {
+	var names []string
+	for i := 0; i < len(text); {
+		start := strings.Index(text[i:], "{{")
+		if start < 0 {
+			break
+		}
+		start += i
+
+		if start > 0 && text[start-1] == '\\' {
+			i = start + 2
+			continue
+		}
+
+		open := start + 2
+		for open < len(text) && text[open] == '{' {
+			open++
+		}
+		end := strings.Index(text[open:], "}}")
+		if end < 0 {
+			break
+		}
+		end += open
+
+		inner := text[open:end]
+		if nested := strings.LastIndex(inner, "{{"); nested >= 0 {
+			// Continue at the innermost opening braces
+			i = open + nested
+			continue
+		}
+
+		if name := strings.TrimSpace(inner); isPlaceholderName(name) {
+			names = append(names, name)
+		}
+		i = end + 2
+	}
+	return names
+}
+
+// isPlaceholderName reports whether s is a valid placeholder name.
+func isPlaceholderName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '_', r == '-', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// checkPlaceholders applies ClientConfig.PlaceholderCheck to the content of
+// a send. Image placeholders are treated as known keys.
+func (c *BaseClient) checkPlaceholders(ctx context.Context, field string, images []Image, content ...string) error {
+	check := c.config.PlaceholderCheck
+	if check == nil {
+		return nil
+	}
+
+	keys := append([]string(nil), check.Keys...)
+	for _, img := range images {
+		keys = append(keys, findPlaceholders(img.Placeholder)...)
+	}
+
+	report := CheckPlaceholders(keys, content...)
+	if !report.OK() && check.Strict {
+		return newValidationError(CodeUnknownPlaceholder, field, map[string]string{
+			"placeholders": strings.Join(report.Unknown, ", "),
+		})
+	}
+	for _, name := range report.Unknown {
+		if err := c.warn(ctx, Warning{
+			Code:    WarningUnknownPlaceholder,
+			Field:   field,
+			Message: fmt.Sprintf("placeholder {{%s}} has no substitution key", name),
+		}); err != nil {
+			return err
+		}
+	}
+	for _, key := range report.Unused {
+		if err := c.warn(ctx, Warning{
+			Code:    WarningUnusedSubstitution,
+			Field:   field,
+			Message: fmt.Sprintf("substitution key %q is not used", key),
+		}); err != nil {
+			return err
+		}
+	}
+	if (!report.OK() || len(report.Unused) > 0) && check.OnWarning != nil {
+		check.OnWarning(report)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package sendlix
+
+import (
+	"context"
+)
+
+// QuickSend sends a single email using only an API key.
+// It is intended for scripts, cron jobs, and command line tools that want to
+// send one message without managing authentication, clients, and cleanup.
+//
+// QuickSend creates an Auth and an EmailClient internally, sends the email, and
+// closes every connection it opened before returning - including on error paths.
+// Applications that send more than a handful of emails should create a long-lived
+// EmailClient instead, so connections and JWT tokens are reused.
+//
+// Parameters:
+//   - ctx: Context for the request (supports cancellation and timeouts)
+//   - apiKey: API key in format "secret.keyID"
+//   - options: Email configuration including recipients, subject, and content
+//   - additional: Optional advanced settings like attachments and scheduling
+//
+// Returns:
+//   - []string: List of message IDs for the sent emails
+//   - error: Authentication, validation, or sending error
+//
+// Example:
+//
+//	messageIDs, err := sendlix.QuickSend(ctx, os.Getenv("SENDLIX_API_KEY"), sendlix.MailOptions{
+//		From:    sendlix.EmailAddress{Email: "cron@example.com"},
+//		To:      []sendlix.EmailAddress{{Email: "ops@example.com"}},
+//		Subject: "Nightly job finished",
+//		Text:    "All tasks completed successfully.",
+//	}, nil)
+func QuickSend(ctx context.Context, apiKey string, options MailOptions, additional *AdditionalOptions) ([]string, error) {
+	return QuickSendWithConfig(ctx, apiKey, nil, options, additional)
+}
+
+// QuickSendWithConfig behaves like QuickSend but uses the provided client
+// configuration for the email connection.
+//
+// Parameters:
+//   - ctx: Context for the request (supports cancellation and timeouts)
+//   - apiKey: API key in format "secret.keyID"
+//   - config: Client configuration (optional, uses defaults if nil)
+//   - options: Email configuration including recipients, subject, and content
+//   - additional: Optional advanced settings like attachments and scheduling
+//
+// Returns:
+//   - []string: List of message IDs for the sent emails
+//   - error: Authentication, validation, or sending error
+func QuickSendWithConfig(ctx context.Context, apiKey string, config *ClientConfig, options MailOptions, additional *AdditionalOptions) ([]string, error) {
+	client, err := NewEmailClient(apiKey, config)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.SendEmail(ctx, options, additional)
+}
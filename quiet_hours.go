@@ -0,0 +1,288 @@
+package sendlix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ErrQuietHours is returned by send methods when QuietHours forbids
+// delivery at the requested time and its Action is QuietHoursReject.
+//
+// Example:
+//
+//	_, err := client.SendEmail(ctx, options, &sendlix.AdditionalOptions{Category: "promotions"})
+//	if errors.Is(err, sendlix.ErrQuietHours) {
+//		log.Println("promotion held back until quiet hours end")
+//	}
+var ErrQuietHours = errors.New("delivery is not permitted during quiet hours")
+
+// QuietHoursAction defines what happens to a send that falls into quiet
+// hours.
+type QuietHoursAction int
+
+const (
+	// QuietHoursReject fails the send with ErrQuietHours
+	QuietHoursReject QuietHoursAction = iota
+	// QuietHoursReschedule schedules the email for the end of the quiet
+	// hours via AdditionalOptions.SendAt. SendGroupEmail cannot be
+	// scheduled, so group sends are rejected with ErrQuietHours instead
+	QuietHoursReschedule
+	// QuietHoursWarn sends the email as requested and reports a
+	// WarningQuietHours warning
+	QuietHoursWarn
+)
+
+// maxQuietWindowChain bounds how many adjoining windows are followed to
+// find the end of the quiet hours, e.g. a weekend made of daily windows.
+const maxQuietWindowChain = 8
+
+// QuietWindow is a daily period during which delivery is not permitted.
+// Times are offsets from midnight in QuietHours.Location and are applied as
+// wall-clock times, so a window from 22:00 to 07:00 ends at 07:00 local time
+// on days with a daylight saving time transition, too. A window boundary
+// falling into an hour skipped by such a transition moves forward by the
+// length of the gap, and one falling into a repeated hour refers to its
+// second occurrence.
+type QuietWindow struct {
+	// Start is the time of day the window starts, e.g. 22*time.Hour
+	// (required, less than 24h)
+	Start time.Duration
+	// End is the time of day the window ends, e.g. 7*time.Hour (required,
+	// at most 24h). A window whose End is not after its Start ends on the
+	// following day
+	End time.Duration
+}
+
+// QuietHours is a policy that holds back sends during configured periods,
+// such as promotional email overnight. Set it as ClientConfig.QuietHours.
+//
+// It applies to SendEmail, SendEMLEmail, and SendGroupEmail. The delivery
+// time checked is AdditionalOptions.SendAt if set, and the current time
+// otherwise.
+//
+// Example:
+//
+//	berlin, _ := time.LoadLocation("Europe/Berlin")
+//	night := sendlix.QuietWindow{Start: 22 * time.Hour, End: 7 * time.Hour}
+//	config := sendlix.DefaultClientConfig()
+//	config.QuietHours = &sendlix.QuietHours{
+//		Location:         berlin,
+//		Windows:          map[time.Weekday]sendlix.QuietWindow{time.Friday: night, time.Saturday: night},
+//		ExemptCategories: []string{"transactional"},
+//		Action:           sendlix.QuietHoursReschedule,
+//	}
+type QuietHours struct {
+	// Location is the time zone of the windows. Default: nil (UTC)
+	Location *time.Location
+
+	// Windows holds the window starting on each weekday. Weekdays without
+	// an entry have no quiet hours, except for a window of the previous day
+	// extending past midnight
+	Windows map[time.Weekday]QuietWindow
+
+	// Categories lists the categories the policy applies to.
+	// Default: nil (applies to all sends, including uncategorized ones)
+	Categories []string
+
+	// ExemptCategories lists categories the policy never applies to, such
+	// as transactional email. Exemptions take precedence over Categories.
+	// Default: nil
+	ExemptCategories []string
+
+	// Action defines how sends during quiet hours are handled.
+	// Default: QuietHoursReject
+	Action QuietHoursAction
+
+	// Now returns the current time. Default: nil (time.Now)
+	Now func() time.Time
+}
+
+// QuietUntil reports whether t falls into quiet hours, and if so, when they
+// end. Adjoining windows are treated as one, so a Saturday window following
+// a Friday window until midnight ends with the Saturday window.
+//
+// Parameters:
+//   - t: Delivery time to check
+//
+// Returns:
+//   - time.Time: End of the quiet hours in the policy's location, or the
+//     zero time if t is not within quiet hours
+//   - bool: true if t falls into quiet hours
+//
+// Example:
+//
+//	if until, quiet := policy.QuietUntil(time.Now()); quiet {
+//		fmt.Println("quiet until", until.Format(time.Kitchen))
+//	}
+func (q *QuietHours) QuietUntil(t time.Time) (time.Time, bool) {
+	t = t.In(q.location())
+	var found bool
+	for range maxQuietWindowChain {
+		end, ok := q.windowEnd(t)
+		if !ok {
+			break
+		}
+		t, found = end, true
+	}
+	if !found {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// appliesTo reports whether the policy covers sends of a category.
+func (q *QuietHours) appliesTo(category string) bool {
+	if slices.Contains(q.ExemptCategories, category) {
+		return false
+	}
+	return len(q.Categories) == 0 || slices.Contains(q.Categories, category)
+}
+
+// windowEnd returns the end of the window containing t, which is in the
+// policy's location. It checks the window of t's day and the window of the
+// previous day, which may extend past midnight.
+func (q *QuietHours) windowEnd(t time.Time) (time.Time, bool) {
+	var end time.Time
+	var found bool
+	year, month, day := t.Date()
+	for back := 1; back >= 0; back-- {
+		date := time.Date(year, month, day-back, 0, 0, 0, 0, t.Location())
+		window, ok := q.Windows[date.Weekday()]
+		if !ok {
+			continue
+		}
+
+		start := wallClock(date, window.Start)
+		endDate := date
+		if window.End <= window.Start {
+			endDate = date.AddDate(0, 0, 1)
+		}
+		windowEnd := wallClock(endDate, window.End)
+		if !t.Before(start) && t.Before(windowEnd) && windowEnd.After(end) {
+			end, found = windowEnd, true
+		}
+	}
+	return end, found
+}
+
+// location returns the time zone of the windows.
+func (q *QuietHours) location() *time.Location {
+	if q.Location == nil {
+		return time.UTC
+	}
+	return q.Location
+}
+
+// now returns the current time.
+func (q *QuietHours) now() time.Time {
+	if q.Now == nil {
+		return time.Now()
+	}
+	return q.Now()
+}
+
+// wallClock returns the time of day offset on the date of midnight as a
+// wall-clock time in midnight's location. An offset of 24h is midnight of
+// the following day.
+func wallClock(midnight time.Time, offset time.Duration) time.Time {
+	year, month, day := midnight.Date()
+	hour := int(offset / time.Hour)
+	minute := int(offset % time.Hour / time.Minute)
+	nsec := int(offset % time.Minute)
+	return time.Date(year, month, day, hour, minute, 0, nsec, midnight.Location())
+}
+
+// validateQuietHours checks the windows of a QuietHours policy.
+//
+// Returns:
+//   - error: ErrInvalidQuietWindow naming the weekday of an invalid window,
+//     or nil
+func validateQuietHours(q *QuietHours) error {
+	if q == nil {
+		return nil
+	}
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		window, ok := q.Windows[weekday]
+		if !ok {
+			continue
+		}
+		if window.Start < 0 || window.Start >= 24*time.Hour || window.End <= 0 || window.End > 24*time.Hour || window.End == window.Start {
+			return newValidationError(CodeInvalidQuietWindow, "QuietHours.Windows", map[string]string{"weekday": weekday.String()})
+		}
+	}
+	return nil
+}
+
+// checkQuietHours applies ClientConfig.QuietHours to a send of a category
+// scheduled for sendAt, or for now if sendAt is nil.
+//
+// Parameters:
+//   - ctx: Context receiving the warning under QuietHoursWarn
+//   - category: Category of the send
+//   - sendAt: Requested delivery time, or nil
+//   - schedulable: Whether the send can be rescheduled
+//
+// Returns:
+//   - *time.Time: New delivery time under QuietHoursReschedule, or nil if
+//     the delivery time is unchanged
+//   - error: ErrQuietHours, a strict warning error, or nil
+func (c *EmailClient) checkQuietHours(ctx context.Context, category string, sendAt *time.Time, schedulable bool) (*time.Time, error) {
+	policy := c.config.QuietHours
+	if policy == nil || !policy.appliesTo(category) {
+		return nil, nil
+	}
+	// A zero send time is rejected by validateAdditionalOptions
+	if sendAt != nil && sendAt.IsZero() {
+		return nil, nil
+	}
+
+	deliverAt := policy.now()
+	if sendAt != nil {
+		deliverAt = *sendAt
+	}
+	until, quiet := policy.QuietUntil(deliverAt)
+	if !quiet {
+		return nil, nil
+	}
+
+	switch {
+	case policy.Action == QuietHoursReschedule && schedulable:
+		return &until, nil
+	case policy.Action == QuietHoursWarn:
+		return nil, c.warn(ctx, Warning{
+			Code:    WarningQuietHours,
+			Field:   "SendAt",
+			Message: fmt.Sprintf("delivery falls into quiet hours ending %s", until.Format(time.RFC3339)),
+		})
+	default:
+		return nil, fmt.Errorf("%w: quiet hours end %s", ErrQuietHours, until.Format(time.RFC3339))
+	}
+}
+
+// applyQuietHours applies ClientConfig.QuietHours to the additional options
+// of a send, copying them if the send is rescheduled.
+func (c *EmailClient) applyQuietHours(ctx context.Context, additional *AdditionalOptions) (*AdditionalOptions, error) {
+	if c.config.QuietHours == nil {
+		return additional, nil
+	}
+
+	var category string
+	var sendAt *time.Time
+	if additional != nil {
+		category, sendAt = additional.Category, additional.SendAt
+	}
+	until, err := c.checkQuietHours(ctx, category, sendAt, true)
+	if err != nil || until == nil {
+		return additional, err
+	}
+
+	var rescheduled AdditionalOptions
+	if additional != nil {
+		rescheduled = *additional
+	}
+	rescheduled.SendAt = until
+	return &rescheduled, nil
+}
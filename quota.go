@@ -0,0 +1,189 @@
+package sendlix
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pb "github.com/sendlix/go-sdk/internal/proto"
+)
+
+// quotaSnapshot is the remaining quota reported by a send response.
+type quotaSnapshot struct {
+	emailsLeft int64
+	updatedAt  time.Time
+}
+
+// quotaTracker keeps the most recent EmailsLeft value reported by the API.
+type quotaTracker struct {
+	latest atomic.Pointer[quotaSnapshot]
+	low    atomic.Bool
+}
+
+// trackQuota stores the EmailsLeft value of a send response and invokes
+// ClientConfig.OnLowQuota when the value crosses the configured threshold.
+// The callback is invoked once per crossing; it is armed again when the
+// quota rises above the threshold, for example after the quota period resets.
+func (c *EmailClient) trackQuota(emailsLeft int64) {
+	c.quota.latest.Store(&quotaSnapshot{emailsLeft: emailsLeft, updatedAt: time.Now()})
+
+	if emailsLeft > c.config.LowQuotaThreshold {
+		c.quota.low.Store(false)
+		return
+	}
+	if c.quota.low.CompareAndSwap(false, true) && c.config.OnLowQuota != nil {
+		c.config.OnLowQuota(emailsLeft)
+	}
+}
+
+// EmailsLeft returns the remaining email quota as reported by the most recent
+// successful send of this client, including EML and group sends.
+//
+// Returns:
+//   - int64: Number of emails left in the current quota period
+//   - time.Time: Time the value was received, or the zero time if no send
+//     has completed yet
+//
+// Example:
+//
+//	left, updatedAt := client.EmailsLeft()
+//	if !updatedAt.IsZero() {
+//		fmt.Printf("%d emails left as of %s\n", left, updatedAt.Format(time.RFC3339))
+//	}
+func (c *EmailClient) EmailsLeft() (int64, time.Time) {
+	snapshot := c.quota.latest.Load()
+	if snapshot == nil {
+		return 0, time.Time{}
+	}
+	return snapshot.emailsLeft, snapshot.updatedAt
+}
+
+// ErrQuotaReserved is returned when a QuotaCoordinator cannot reserve quota
+// for a send because the remaining emails are used or reserved by other
+// sends. The email is not sent.
+var ErrQuotaReserved = errors.New("insufficient quota: remaining emails are reserved by other sends")
+
+// QuotaCoordinator coordinates the email quota between concurrent senders,
+// so that several goroutines, processes, or pods do not all believe they
+// have quota left. EmailClient consults it when ClientConfig.QuotaCoordinator
+// is set:
+//   - before SendEmail, Reserve is called with the number of recipients
+//     (To, CC, and BCC); before SendEMLEmail with 1
+//   - after the send, Observe is called with the EmailsLeft value of the
+//     response if it succeeded, and Release is called with the reserved
+//     amount in any case
+//   - group sends cannot know their size in advance, so they only Observe
+//
+// Implementations must be safe for concurrent use. A distributed
+// implementation, for example backed by Redis, would keep the remaining
+// quota and the sum of reservations in shared keys and update them
+// atomically (e.g. with a Lua script or MULTI/EXEC): Reserve fails unless
+// remaining minus reserved covers n, Release subtracts from the reserved sum,
+// and Observe stores the server-reported value.
+type QuotaCoordinator interface {
+	// Reserve reserves quota for n emails. It returns ErrQuotaReserved if
+	// the quota is insufficient.
+	Reserve(ctx context.Context, n int64) error
+
+	// Release ends a reservation made with Reserve, after the send
+	// succeeded or failed.
+	Release(ctx context.Context, n int64)
+
+	// Observe reports the remaining quota returned by the API.
+	Observe(ctx context.Context, remaining int64)
+}
+
+// MemoryQuotaCoordinator is an in-memory QuotaCoordinator for clients
+// sharing a process. Share one instance between all clients using the same
+// API key.
+//
+// Until the first Observe call the quota is unknown and every reservation
+// succeeds. While sends are in flight, observed values can only lower the
+// remaining quota, because responses may arrive out of order; increases,
+// such as a quota reset, are accepted once no sends are in flight.
+type MemoryQuotaCoordinator struct {
+	mu        sync.Mutex
+	known     bool
+	remaining int64
+	reserved  int64
+}
+
+// NewMemoryQuotaCoordinator creates an in-memory coordinator with an
+// unknown quota.
+//
+// Returns:
+//   - *MemoryQuotaCoordinator: Coordinator ready for use
+//
+// Example:
+//
+//	config := sendlix.DefaultClientConfig()
+//	config.QuotaCoordinator = sendlix.NewMemoryQuotaCoordinator()
+func NewMemoryQuotaCoordinator() *MemoryQuotaCoordinator {
+	return &MemoryQuotaCoordinator{}
+}
+
+// Reserve reserves quota for n emails.
+func (q *MemoryQuotaCoordinator) Reserve(ctx context.Context, n int64) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.known && q.remaining-q.reserved < n {
+		return ErrQuotaReserved
+	}
+	q.reserved += n
+	return nil
+}
+
+// Release ends a reservation of n emails.
+func (q *MemoryQuotaCoordinator) Release(ctx context.Context, n int64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.reserved = max(q.reserved-n, 0)
+}
+
+// Observe records the remaining quota reported by the API.
+func (q *MemoryQuotaCoordinator) Observe(ctx context.Context, remaining int64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.known && q.reserved > 0 && remaining > q.remaining {
+		return
+	}
+	q.remaining = remaining
+	q.known = true
+}
+
+// Remaining returns the last accepted remaining quota and the amount
+// currently reserved. known is false until the first Observe call.
+func (q *MemoryQuotaCoordinator) Remaining() (remaining, reserved int64, known bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.remaining, q.reserved, q.known
+}
+
+// reserveQuota reserves quota for n emails with the configured coordinator.
+func (c *EmailClient) reserveQuota(ctx context.Context, n int64) error {
+	if c.config.QuotaCoordinator == nil || n == 0 {
+		return nil
+	}
+	return c.config.QuotaCoordinator.Reserve(ctx, n)
+}
+
+// settleQuota ends the reservation of a send and reports the remaining
+// quota of a successful response to the configured coordinator.
+func (c *EmailClient) settleQuota(ctx context.Context, n int64, resp *pb.SendEmailResponse, err error) {
+	coordinator := c.config.QuotaCoordinator
+	if coordinator == nil {
+		return
+	}
+	if err == nil {
+		coordinator.Observe(ctx, resp.EmailsLeft)
+	}
+	if n > 0 {
+		coordinator.Release(ctx, n)
+	}
+}
@@ -0,0 +1,57 @@
+package sendlix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// ErrReadOnlyMode is returned by every mutating call of a client created with
+// ClientConfig.ReadOnly. The call is rejected before any request is sent.
+//
+// Example:
+//
+//	_, err := client.SendEmail(ctx, options, nil)
+//	if errors.Is(err, sendlix.ErrReadOnlyMode) {
+//		log.Println("skipped send during drill")
+//	}
+var ErrReadOnlyMode = errors.New("client is in read-only mode")
+
+// mutatingMethods classifies every API method as mutating (true) or read-only
+// (false). Methods missing from this map are treated as mutating, so new
+// methods are denied in read-only mode until they are classified.
+var mutatingMethods = map[string]bool{
+	pb.Email_SendEmail_FullMethodName:            true,
+	pb.Email_SendEmlEmail_FullMethodName:         true,
+	pb.Email_SendGroupEmail_FullMethodName:       true,
+	pb.Group_InsertEmailToGroup_FullMethodName:   true,
+	pb.Group_RemoveEmailFromGroup_FullMethodName: true,
+	pb.Group_CheckEmailInGroup_FullMethodName:    false,
+	pb.Auth_GetJwtToken_FullMethodName:           false,
+	healthpb.Health_Check_FullMethodName:         false,
+}
+
+// isMutatingMethod reports whether a gRPC method changes state on the server.
+func isMutatingMethod(method string) bool {
+	mutating, ok := mutatingMethods[method]
+	return mutating || !ok
+}
+
+// readOnlyInterceptor creates a gRPC unary interceptor that rejects all
+// mutating methods with ErrReadOnlyMode. It runs before the authentication
+// interceptor, so rejected calls do not request a token either.
+//
+// Returns:
+//   - grpc.UnaryClientInterceptor: Configured read-only interceptor
+func readOnlyInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if isMutatingMethod(method) {
+			return fmt.Errorf("%w: %s is not permitted", ErrReadOnlyMode, method)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,239 @@
+package sendlix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRecipientCacheTTL is how long EmailClient caches the addresses a
+// RecipientResolver returned for a reference.
+const DefaultRecipientCacheTTL = time.Minute
+
+// maxRecipientCacheEntries bounds the recipient cache. Expired entries are
+// dropped when it is full, and the cache is cleared if none expired.
+const maxRecipientCacheEntries = 10000
+
+// RecipientResolver resolves logical recipient references, such as
+// "billing-contact:42", to email addresses at send time. Set it as
+// ClientConfig.RecipientResolver and address recipients with RecipientRef.
+//
+// Implementations must be safe for concurrent use.
+type RecipientResolver interface {
+	// Resolve returns the addresses a reference stands for. Returning no
+	// addresses and no error marks the reference as unresolvable.
+	Resolve(ctx context.Context, ref string) ([]EmailAddress, error)
+}
+
+// RecipientRef returns a recipient resolved by ClientConfig.RecipientResolver
+// when the email is sent. A reference may resolve to several addresses,
+// which all take its place in the recipient list.
+//
+// Parameters:
+//   - ref: Reference understood by the resolver, e.g. "billing-contact:42"
+//
+// Returns:
+//   - EmailAddress: Recipient to use in MailOptions.To, CC, or BCC
+//
+// Example:
+//
+//	_, err := client.SendEmail(ctx, sendlix.MailOptions{
+//		From:    sendlix.EmailAddress{Email: "billing@example.com"},
+//		To:      []sendlix.EmailAddress{sendlix.RecipientRef("billing-contact:" + accountID)},
+//		CC:      []sendlix.EmailAddress{{Email: "audit@example.com"}},
+//		Subject: "Your invoice",
+//		Text:    "...",
+//	}, nil)
+func RecipientRef(ref string) EmailAddress {
+	return EmailAddress{Ref: ref}
+}
+
+// ErrUnresolvedRecipients is matched by RecipientResolutionError, so
+// errors.Is reports whether a send failed because references could not be
+// resolved.
+var ErrUnresolvedRecipients = errors.New("recipient references could not be resolved")
+
+// RecipientResolutionError is returned by SendEmail when recipient
+// references could not be resolved. Nothing is sent in that case.
+type RecipientResolutionError struct {
+	// Refs lists the unresolvable references in order of appearance
+	Refs []string
+	// Errs holds the resolver error for each entry of Refs, or nil if the
+	// resolver returned no addresses
+	Errs []error
+}
+
+// Error lists the unresolvable references with their errors.
+func (e *RecipientResolutionError) Error() string {
+	parts := make([]string, len(e.Refs))
+	for i, ref := range e.Refs {
+		if e.Errs[i] != nil {
+			parts[i] = fmt.Sprintf("%s (%v)", ref, e.Errs[i])
+		} else {
+			parts[i] = ref + " (no addresses)"
+		}
+	}
+	return "failed to resolve recipients: " + strings.Join(parts, ", ")
+}
+
+// Is reports whether target is ErrUnresolvedRecipients.
+func (e *RecipientResolutionError) Is(target error) bool {
+	return target == ErrUnresolvedRecipients
+}
+
+// Unwrap returns the resolver errors.
+func (e *RecipientResolutionError) Unwrap() []error {
+	var errs []error
+	for _, err := range e.Errs {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// recipientEntry is a cached resolution result.
+type recipientEntry struct {
+	addrs     []EmailAddress
+	expiresAt time.Time
+}
+
+// recipientCache caches resolved references of an EmailClient.
+type recipientCache struct {
+	mu      sync.Mutex
+	entries map[string]recipientEntry
+}
+
+// get returns the cached addresses of a reference, if present and not
+// expired.
+func (c *recipientCache) get(ref string, now time.Time) ([]EmailAddress, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[ref]
+	if !ok || !now.Before(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.addrs, true
+}
+
+// set caches the addresses of a reference.
+func (c *recipientCache) set(ref string, addrs []EmailAddress, expiresAt time.Time, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]recipientEntry)
+	}
+	if len(c.entries) >= maxRecipientCacheEntries {
+		for key, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, key)
+			}
+		}
+		if len(c.entries) >= maxRecipientCacheEntries {
+			clear(c.entries)
+		}
+	}
+	c.entries[ref] = recipientEntry{addrs: addrs, expiresAt: expiresAt}
+}
+
+// resolveRecipients replaces the recipient references in To, CC, and BCC
+// with the addresses they resolve to, so the result goes through the same
+// validation as literal addresses. Each distinct reference is resolved
+// once per send. The caller's options are never modified.
+func (c *EmailClient) resolveRecipients(ctx context.Context, options MailOptions) (MailOptions, error) {
+	if !hasRecipientRefs(options.To, options.CC, options.BCC) {
+		return options, nil
+	}
+
+	resolver := c.config.RecipientResolver
+	if resolver == nil {
+		return options, newValidationError(CodeMissingRecipientResolver, "To", nil)
+	}
+
+	resolved := make(map[string][]EmailAddress)
+	failure := &RecipientResolutionError{}
+	resolve := func(list []EmailAddress) []EmailAddress {
+		if !hasRecipientRefs(list) {
+			return list
+		}
+
+		result := make([]EmailAddress, 0, len(list))
+		for _, addr := range list {
+			if addr.Ref == "" {
+				result = append(result, addr)
+				continue
+			}
+
+			addrs, ok := resolved[addr.Ref]
+			if !ok {
+				var err error
+				addrs, err = c.resolveRecipient(ctx, resolver, addr.Ref)
+				resolved[addr.Ref] = addrs
+				if err != nil || len(addrs) == 0 {
+					failure.Refs = append(failure.Refs, addr.Ref)
+					failure.Errs = append(failure.Errs, err)
+				}
+			}
+			result = append(result, addrs...)
+		}
+		return result
+	}
+
+	options.To = resolve(options.To)
+	options.CC = resolve(options.CC)
+	options.BCC = resolve(options.BCC)
+
+	if len(failure.Refs) > 0 {
+		return options, failure
+	}
+	return options, nil
+}
+
+// resolveRecipient resolves a single reference, using the cache unless
+// ClientConfig.RecipientCacheTTL is negative. Failures are not cached.
+func (c *EmailClient) resolveRecipient(ctx context.Context, resolver RecipientResolver, ref string) ([]EmailAddress, error) {
+	ttl := c.config.RecipientCacheTTL
+	if ttl == 0 {
+		ttl = DefaultRecipientCacheTTL
+	}
+
+	now := time.Now()
+	if ttl > 0 {
+		if addrs, ok := c.recipients.get(ref, now); ok {
+			return addrs, nil
+		}
+	}
+
+	addrs, err := resolver.Resolve(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	// References are resolved once, not recursively
+	for _, addr := range addrs {
+		if addr.Ref != "" {
+			return nil, fmt.Errorf("resolved to reference %q", addr.Ref)
+		}
+	}
+
+	if ttl > 0 && len(addrs) > 0 {
+		c.recipients.set(ref, addrs, now.Add(ttl), now)
+	}
+	return addrs, nil
+}
+
+// hasRecipientRefs reports whether any address in the lists is a reference.
+func hasRecipientRefs(lists ...[]EmailAddress) bool {
+	for _, list := range lists {
+		for _, addr := range list {
+			if addr.Ref != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
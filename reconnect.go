@@ -0,0 +1,133 @@
+package sendlix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// errClientClosed is returned by Reconnect after Close.
+var errClientClosed = errors.New("client is closed")
+
+// reconnectableConn is the connection of the service stubs of a client. It
+// sends every call on the current connection of the client, so the stubs of
+// all clients sharing a BaseClient, including those created with
+// WithDefaults, follow Reconnect without being rebuilt.
+type reconnectableConn struct {
+	client *BaseClient
+}
+
+// Invoke sends a unary call on the current connection, which Reconnect does
+// not close before the call returns.
+func (r reconnectableConn) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	conn, done := r.client.acquireConn()
+	defer done()
+	return conn.Invoke(ctx, method, args, reply, opts...)
+}
+
+// NewStream opens a stream on the current connection. The Sendlix services
+// have no streaming calls, so streams are not waited for by Reconnect.
+func (r reconnectableConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return r.client.GetConnection().NewStream(ctx, desc, method, opts...)
+}
+
+// stubConn returns the connection to create service stubs with.
+func (c *BaseClient) stubConn() grpc.ClientConnInterface {
+	return reconnectableConn{client: c}
+}
+
+// acquireConn returns the current connection and registers a call on it.
+// The returned function ends the call.
+func (c *BaseClient) acquireConn() (*grpc.ClientConn, func()) {
+	c.connMu.RLock()
+	defer c.connMu.RUnlock()
+	c.calls.Add(1)
+	return c.conn, c.calls.Done
+}
+
+// Reconnect replaces the connection of the client with a newly dialed one
+// using the same configuration, for example after credential rotation at a
+// proxy or a DNS failover. All clients sharing the connection, such as
+// those created with WithDefaults or NewClientsWithSharedConn, switch to
+// the new connection at once, without being rebuilt.
+//
+// Calls started before the switch complete on the old connection, which is
+// closed once they have returned; Reconnect waits for that. If the new
+// connection is not ready before ctx is done, it is discarded and the
+// client keeps the old one.
+//
+// Parameters:
+//   - ctx: Context bounding the connection attempt (a deadline is
+//     recommended)
+//
+// Returns:
+//   - error: Connection error, or an error closing the old connection
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//
+//	if err := client.Reconnect(ctx); err != nil {
+//		log.Printf("reconnect failed, keeping the old connection: %v", err)
+//	}
+func (c *BaseClient) Reconnect(ctx context.Context) error {
+	c.reconnectMu.Lock()
+	defer c.reconnectMu.Unlock()
+
+	conn, err := grpc.NewClient(c.config.ServerAddress, c.dialOptions...)
+	if err != nil {
+		return fmt.Errorf("failed to connect to server: %v", err)
+	}
+	if err := waitForReady(ctx, conn, c.config.ServerAddress); err != nil {
+		conn.Close()
+		return err
+	}
+
+	c.connMu.Lock()
+	if c.closed {
+		c.connMu.Unlock()
+		conn.Close()
+		return errClientClosed
+	}
+	old, oldCalls := c.conn, c.calls
+	c.conn, c.calls = conn, &sync.WaitGroup{}
+	c.connMu.Unlock()
+
+	if c.sharedAuth != nil {
+		c.sharedAuth.useConnection(conn)
+	}
+
+	oldCalls.Wait()
+	return old.Close()
+}
+
+// waitForReady connects conn and waits until it is ready.
+//
+// Parameters:
+//   - ctx: Context bounding the wait
+//   - conn: Connection to wait for
+//   - address: Server address for error messages
+//
+// Returns:
+//   - error: Error if the connection fails or ctx is done first
+func waitForReady(ctx context.Context, conn *grpc.ClientConn, address string) error {
+	conn.Connect()
+
+	for {
+		state := conn.GetState()
+		if state == connectivity.Ready {
+			return nil
+		}
+		if state == connectivity.TransientFailure || state == connectivity.Shutdown {
+			return fmt.Errorf("failed to connect to %s: connection is %s", address, state)
+		}
+		if !conn.WaitForStateChange(ctx, state) {
+			return fmt.Errorf("failed to connect to %s: %w", address, ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package sendlix
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDHeader is the response metadata key carrying the ID the Sendlix
+// API assigns to every request. Sendlix support uses it to find a request
+// in their logs.
+const RequestIDHeader = "x-request-id"
+
+// requestIDKey is the context key of the request ID collector.
+type requestIDKey struct{}
+
+// requestIDCollector receives the request IDs of calls made with a context.
+// Collectors of nested contexts pass IDs on to their parent.
+type requestIDCollector struct {
+	mu     sync.Mutex
+	id     string
+	dest   *string
+	parent *requestIDCollector
+}
+
+// requestIDError attaches the request ID of a failed call to its error.
+type requestIDError struct {
+	requestID string
+	err       error
+}
+
+func (e *requestIDError) Error() string { return e.err.Error() }
+func (e *requestIDError) Unwrap() error { return e.err }
+
+// WithRequestID returns a context that stores the request ID of calls made
+// with it in dest. After each call, dest holds the ID of the most recent
+// call, or "" if the server did not return one. Include it in support
+// requests to Sendlix.
+//
+// Parameters:
+//   - ctx: Parent context
+//   - dest: String the request ID is written to
+//
+// Returns:
+//   - context.Context: Context to pass to client methods
+//
+// Example:
+//
+//	var requestID string
+//	ids, err := client.SendEmail(sendlix.WithRequestID(ctx, &requestID), options, nil)
+//	log.Printf("sent %v (request ID %s)", ids, requestID)
+func WithRequestID(ctx context.Context, dest *string) context.Context {
+	ctx, _ = captureRequestID(ctx, dest)
+	return ctx
+}
+
+// RequestID returns the request ID of the failed call that caused err.
+//
+// Parameters:
+//   - err: Error returned by a client method
+//
+// Returns:
+//   - string: Request ID assigned by the server, or "" if err is nil, did
+//     not come from a call, or the server returned no ID
+//
+// Example:
+//
+//	if _, err := client.SendEmail(ctx, options, nil); err != nil {
+//		log.Printf("send failed (request ID %s): %v", sendlix.RequestID(err), err)
+//	}
+func RequestID(err error) string {
+	var idErr *requestIDError
+	if errors.As(err, &idErr) {
+		return idErr.requestID
+	}
+	return ""
+}
+
+// captureRequestID returns a context whose calls report their request ID
+// to the returned collector and to any collector of ctx.
+func captureRequestID(ctx context.Context, dest *string) (context.Context, *requestIDCollector) {
+	parent, _ := ctx.Value(requestIDKey{}).(*requestIDCollector)
+	collector := &requestIDCollector{dest: dest, parent: parent}
+	return context.WithValue(ctx, requestIDKey{}, collector), collector
+}
+
+// set stores the request ID of a call in the collector and its parents.
+func (c *requestIDCollector) set(id string) {
+	for ; c != nil; c = c.parent {
+		c.mu.Lock()
+		c.id = id
+		if c.dest != nil {
+			*c.dest = id
+		}
+		c.mu.Unlock()
+	}
+}
+
+// get returns the request ID of the most recent call.
+func (c *requestIDCollector) get() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.id
+}
+
+// requestIDInterceptor creates a gRPC unary interceptor reading the request
+// ID from the response headers or trailers. It reports the ID to the
+// collector of the context and attaches it to errors.
+func requestIDInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		var header, trailer metadata.MD
+		opts = append(opts, grpc.Header(&header), grpc.Trailer(&trailer))
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		id := firstValue(header, RequestIDHeader)
+		if id == "" {
+			id = firstValue(trailer, RequestIDHeader)
+		}
+		if collector, ok := ctx.Value(requestIDKey{}).(*requestIDCollector); ok {
+			collector.set(id)
+		}
+		if err != nil && id != "" {
+			return &requestIDError{requestID: id, err: err}
+		}
+		return err
+	}
+}
+
+// firstValue returns the first value of key in md, or "".
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
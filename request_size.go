@@ -0,0 +1,72 @@
+package sendlix
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// EstimateRequestSize returns the serialized size in bytes of the request
+// SendEmail would send for the given options, without sending anything.
+//
+// The estimate is computed from the same protobuf request SendEmail builds
+// with the client's configuration, including its defaults (see
+// WithDefaults) and display name handling, so it reflects every field
+// exactly as it goes over the wire, including embedded images. It does not
+// include gRPC framing or compression, which add only a few bytes, nor the
+// URLs of attachments that are uploaded when sending. SendEmail checks the
+// same size against ClientConfig.MaxSendMsgSize before sending; use it to
+// check whether a message fits before batching it.
+//
+// Parameters:
+//   - ctx: Context receiving the warnings of advisory checks
+//   - options: Email configuration including recipients, subject, and content
+//   - additional: Optional advanced settings like attachments and scheduling
+//
+// Returns:
+//   - int: Serialized request size in bytes
+//   - error: Validation error if the options could not be sent
+//
+// Example:
+//
+//	size, err := client.EstimateRequestSize(ctx, options, nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	if size > 4*1024*1024 {
+//		log.Printf("email is too large to send: %d bytes", size)
+//	}
+func (c *EmailClient) EstimateRequestSize(ctx context.Context, options MailOptions, additional *AdditionalOptions) (int, error) {
+	options, additional = c.applyDefaults(options, additional)
+
+	req, err := c.buildSendMailRequest(ctx, options, additional)
+	if err != nil {
+		return 0, err
+	}
+
+	return proto.Size(messageV2Of(req)), nil
+}
+
+// checkRequestSize fails with ErrMessageTooLarge if req exceeds
+// ClientConfig.MaxSendMsgSize, before the request is transferred.
+//
+// Without MaxSendMsgSize there is no limit to check against: the gRPC
+// default for sending is unlimited, and the limit of the server is unknown
+// to the client. Oversized requests then fail with the server's
+// RESOURCE_EXHAUSTED status, which mapStatusError translates into
+// ErrMessageTooLarge as well.
+func (c *BaseClient) checkRequestSize(req protoadapt.MessageV1, field string) error {
+	limit := c.config.MaxSendMsgSize
+	if limit <= 0 {
+		return nil
+	}
+	if size := proto.Size(messageV2Of(req)); size > limit {
+		return newValidationError(CodeMessageTooLarge, field, map[string]string{
+			"size": strconv.Itoa(size),
+			"max":  strconv.Itoa(limit),
+		})
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package sendlix
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// nonRetryableCodes are status codes reporting that the server rejected the
+// request itself. Repeating the request cannot succeed, so they are never
+// retried.
+var nonRetryableCodes = []codes.Code{
+	codes.InvalidArgument,
+	codes.NotFound,
+	codes.AlreadyExists,
+	codes.PermissionDenied,
+	codes.Unauthenticated,
+	codes.FailedPrecondition,
+	codes.OutOfRange,
+	codes.Unimplemented,
+}
+
+// RetryPolicy configures the retry of calls failing with transient errors,
+// such as UNAVAILABLE while a load balancer restarts. Set it as
+// ClientConfig.RetryPolicy.
+//
+// Retries apply to every unary call, including sends. UNAVAILABLE is
+// normally reported before the server processes a request, but a send whose
+// connection broke after the server accepted it is delivered twice when
+// retried.
+//
+// ClientConfig.RequestTimeout bounds all attempts of a call together, and no
+// attempt is made once the caller's context has ended.
+//
+// Example:
+//
+//	config := sendlix.DefaultClientConfig()
+//	config.RetryPolicy = &sendlix.RetryPolicy{
+//		MaxAttempts:    4,
+//		InitialBackoff: 200 * time.Millisecond,
+//	}
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts of a call, including
+	// the first one. Default: 3
+	MaxAttempts int
+
+	// InitialBackoff is the wait before the first retry. It doubles with
+	// every further retry, and a random jitter of up to half of it is
+	// subtracted. Default: 100 milliseconds
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the wait between two attempts. A RESOURCE_EXHAUSTED
+	// error asking for a longer wait is not retried. Default: 5 seconds
+	MaxBackoff time.Duration
+
+	// RetryableCodes lists the status codes that are retried.
+	// RESOURCE_EXHAUSTED is only retried if the server attaches RetryInfo
+	// details, whose delay replaces the backoff; without them it reports an
+	// exhausted quota. Codes reporting that the request was rejected, such
+	// as INVALID_ARGUMENT, cannot be listed.
+	// Default: nil (UNAVAILABLE and RESOURCE_EXHAUSTED)
+	RetryableCodes []codes.Code
+}
+
+// effective returns the attempt limit, initial backoff, and backoff limit
+// with defaults applied.
+func (p *RetryPolicy) effective() (int, time.Duration, time.Duration) {
+	maxAttempts := p.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+	initialBackoff := p.InitialBackoff
+	if initialBackoff <= 0 {
+		initialBackoff = 100 * time.Millisecond
+	}
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = 5 * time.Second
+	}
+	return maxAttempts, initialBackoff, max(initialBackoff, maxBackoff)
+}
+
+// retryableCodes returns the retried status codes with defaults applied.
+func (p *RetryPolicy) retryableCodes() []codes.Code {
+	if p.RetryableCodes == nil {
+		return []codes.Code{codes.Unavailable, codes.ResourceExhausted}
+	}
+	return p.RetryableCodes
+}
+
+// validateRetryPolicy checks the settings of a RetryPolicy.
+//
+// Returns:
+//   - error: ErrInvalidRetryPolicy naming the invalid setting, or nil
+func validateRetryPolicy(p *RetryPolicy) error {
+	if p == nil {
+		return nil
+	}
+	if p.MaxAttempts < 0 || p.InitialBackoff < 0 || p.MaxBackoff < 0 {
+		return newValidationError(CodeInvalidRetryPolicy, "RetryPolicy", map[string]string{"reason": "attempts and backoffs must not be negative"})
+	}
+	for _, code := range p.RetryableCodes {
+		if code == codes.OK || slices.Contains(nonRetryableCodes, code) {
+			return newValidationError(CodeInvalidRetryPolicy, "RetryPolicy.RetryableCodes", map[string]string{"reason": fmt.Sprintf("%s cannot be retried", code)})
+		}
+	}
+	return nil
+}
+
+// retryInterceptor creates a gRPC unary interceptor that repeats calls
+// failing with a retryable status code, waiting with exponential backoff
+// between attempts. It runs inside the timeout interceptor, so
+// ClientConfig.RequestTimeout covers all attempts, and outside the
+// authentication interceptor, so every attempt is authenticated.
+//
+// Parameters:
+//   - policy: Retry settings
+//
+// Returns:
+//   - grpc.UnaryClientInterceptor: Configured retry interceptor
+func retryInterceptor(policy *RetryPolicy) grpc.UnaryClientInterceptor {
+	maxAttempts, initialBackoff, maxBackoff := policy.effective()
+	retryable := slices.Clone(policy.retryableCodes())
+
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		backoff := initialBackoff
+		for attempt := 1; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || attempt >= maxAttempts || ctx.Err() != nil {
+				return err
+			}
+
+			delay, ok := retryDelay(err, retryable, backoff)
+			if !ok || delay > maxBackoff {
+				return err
+			}
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+				return err
+			}
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+			backoff = min(2*backoff, maxBackoff)
+		}
+	}
+}
+
+// retryDelay returns how long to wait before retrying a call that failed
+// with err, or false if err is not retryable.
+func retryDelay(err error, retryable []codes.Code, backoff time.Duration) (time.Duration, bool) {
+	s, _ := status.FromError(err)
+	if !slices.Contains(retryable, s.Code()) {
+		return 0, false
+	}
+
+	if s.Code() == codes.ResourceExhausted {
+		for _, detail := range s.Details() {
+			if info, ok := detail.(*errdetails.RetryInfo); ok && info.GetRetryDelay() != nil {
+				return info.GetRetryDelay().AsDuration(), true
+			}
+		}
+		return 0, false
+	}
+
+	return backoff - rand.N(backoff/2+1), true
+}
@@ -0,0 +1,265 @@
+package sendlix
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// SendType identifies which kind of send produced a SendRecord.
+type SendType int
+
+const (
+	// SendTypeEmail is an individual email sent with SendEmail
+	SendTypeEmail SendType = iota
+	// SendTypeEML is an email sent with SendEMLEmail
+	SendTypeEML
+	// SendTypeGroup is a group email sent with SendGroupEmail
+	SendTypeGroup
+)
+
+// SendRecord describes a successful send. It is passed to the configured
+// SendRecorder so message IDs can be correlated with recipients and
+// application jobs later, for example when processing webhook events.
+type SendRecord struct {
+	// Type is the kind of send that produced this record
+	Type SendType
+	// JobID is the application job ID set with WithJobID, if any
+	JobID string
+	// MessageIDs contains the message IDs returned by the API
+	MessageIDs []string
+	// Recipients contains the email addresses of To, CC, and BCC recipients.
+	// It is empty for EML sends, whose recipients are defined by the message
+	// headers, and for group sends.
+	Recipients []string
+	// GroupID is the target group of a group send
+	GroupID string
+	// Category is the email category, if any
+	Category string
+	// Metadata contains the values set with WithRecordMetadata and the
+	// client's Defaults, if any
+	Metadata map[string]string
+	// RequestID is the ID the server assigned to the send request, or "" if
+	// it returned none
+	RequestID string
+}
+
+// SendRecorder persists SendRecords. EmailClient calls Record after every
+// successful send when ClientConfig.SendRecorder or Defaults.SendRecorder is
+// set.
+//
+// Implementations must be safe for concurrent use. Record is called
+// synchronously, so slow storage directly adds to send latency.
+type SendRecorder interface {
+	// Record stores the record of a successful send.
+	Record(ctx context.Context, record SendRecord) error
+}
+
+// RecordFailurePolicy defines how a failing SendRecorder affects the send.
+type RecordFailurePolicy int
+
+const (
+	// RecordFailureWarn reports recorder errors through ClientConfig.OnRecordError
+	// and returns the send result as if recording had succeeded
+	RecordFailureWarn RecordFailurePolicy = iota
+	// RecordFailureFail returns recorder errors to the caller. The email has
+	// still been sent, so the message IDs are returned alongside the error,
+	// which wraps ErrRecordFailed
+	RecordFailureFail
+)
+
+// jobIDKey and recordMetadataKey are the context keys for SendRecord values.
+type (
+	jobIDKey          struct{}
+	recordMetadataKey struct{}
+)
+
+// WithJobID returns a context carrying an application job ID. Sends made with
+// this context include the job ID in their SendRecord.
+//
+// Example:
+//
+//	ctx = sendlix.WithJobID(ctx, "invoice-run-2024-06")
+//	_, err := client.SendEmail(ctx, options, nil)
+func WithJobID(ctx context.Context, jobID string) context.Context {
+	return context.WithValue(ctx, jobIDKey{}, jobID)
+}
+
+// JobIDFromContext returns the job ID set with WithJobID, if any.
+func JobIDFromContext(ctx context.Context) (string, bool) {
+	jobID, ok := ctx.Value(jobIDKey{}).(string)
+	return jobID, ok
+}
+
+// WithRecordMetadata returns a context carrying metadata that is included in
+// the SendRecord of sends made with this context.
+func WithRecordMetadata(ctx context.Context, metadata map[string]string) context.Context {
+	return context.WithValue(ctx, recordMetadataKey{}, metadata)
+}
+
+// recordSend passes a record to the SendRecorder of the client's defaults or
+// configuration and applies the configured failure policy.
+func (c *EmailClient) recordSend(ctx context.Context, record SendRecord) error {
+	recorder := c.defaults.SendRecorder
+	if recorder == nil {
+		recorder = c.config.SendRecorder
+	}
+	if recorder == nil {
+		return nil
+	}
+
+	record = c.withRecordContext(ctx, record)
+	err := recorder.Record(ctx, record)
+	if err == nil {
+		return nil
+	}
+
+	if c.config.RecordFailurePolicy == RecordFailureFail {
+		return fmt.Errorf("%w: %w", ErrRecordFailed, err)
+	}
+	if c.config.OnRecordError != nil {
+		c.config.OnRecordError(record, err)
+	}
+	return nil
+}
+
+// withRecordContext returns record with the job ID and metadata of ctx and
+// the metadata of the client's defaults.
+func (c *EmailClient) withRecordContext(ctx context.Context, record SendRecord) SendRecord {
+	record.JobID, _ = JobIDFromContext(ctx)
+	record.Metadata, _ = ctx.Value(recordMetadataKey{}).(map[string]string)
+	if len(c.defaults.Metadata) > 0 {
+		metadata := make(map[string]string, len(c.defaults.Metadata)+len(record.Metadata))
+		for k, v := range c.defaults.Metadata {
+			metadata[k] = v
+		}
+		for k, v := range record.Metadata {
+			metadata[k] = v
+		}
+		record.Metadata = metadata
+	}
+	return record
+}
+
+// recipientEmails returns the addresses of all recipients of an email.
+func recipientEmails(options MailOptions) []string {
+	recipients := make([]string, 0, len(options.To)+len(options.CC)+len(options.BCC))
+	for _, list := range [][]EmailAddress{options.To, options.CC, options.BCC} {
+		for _, addr := range list {
+			recipients = append(recipients, addr.Email)
+		}
+	}
+	return recipients
+}
+
+// MemorySendRecorder is an in-memory SendRecorder that indexes records by
+// message ID. It is intended for tests and single-process applications;
+// records are lost when the process exits.
+type MemorySendRecorder struct {
+	mu        sync.RWMutex
+	records   []SendRecord
+	byMessage map[string]int
+}
+
+// NewMemorySendRecorder creates an empty in-memory recorder.
+//
+// Returns:
+//   - *MemorySendRecorder: Recorder ready for use
+func NewMemorySendRecorder() *MemorySendRecorder {
+	return &MemorySendRecorder{byMessage: make(map[string]int)}
+}
+
+// Record stores a record and indexes its message IDs.
+func (r *MemorySendRecorder) Record(ctx context.Context, record SendRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.records = append(r.records, record)
+	for _, id := range record.MessageIDs {
+		r.byMessage[id] = len(r.records) - 1
+	}
+	return nil
+}
+
+// Lookup returns the record containing the given message ID.
+//
+// Parameters:
+//   - messageID: Message ID returned by a send
+//
+// Returns:
+//   - SendRecord: Record of the send that produced the message
+//   - bool: false if no record contains the message ID
+func (r *MemorySendRecorder) Lookup(messageID string) (SendRecord, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i, ok := r.byMessage[messageID]
+	if !ok {
+		return SendRecord{}, false
+	}
+	return r.records[i], true
+}
+
+// Records returns a copy of all stored records in the order they were recorded.
+func (r *MemorySendRecorder) Records() []SendRecord {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]SendRecord(nil), r.records...)
+}
+
+// SQLExecer executes statements without returning rows. It is implemented
+// by *sql.DB, *sql.Tx, and *sql.Conn.
+type SQLExecer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// SQLSendRecorder is a SendRecorder that stores one row per message ID with
+// database/sql. It works with any database: the table layout and the
+// placeholder style are defined by the INSERT statement it is created with.
+//
+// The statement receives five arguments in this order: the message ID, the
+// job ID, the category, the comma-separated recipients, and the group ID.
+// Values that do not apply to a send are empty strings.
+type SQLSendRecorder struct {
+	db    SQLExecer
+	query string
+}
+
+// NewSQLSendRecorder creates a recorder that executes query for every
+// message ID of a send.
+//
+// Parameters:
+//   - db: Database handle, such as a *sql.DB
+//   - query: INSERT statement taking the five arguments of SQLSendRecorder
+//
+// Returns:
+//   - *SQLSendRecorder: Recorder ready for use
+//
+// Example:
+//
+//	db, err := sql.Open("postgres", dsn)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	config := sendlix.DefaultClientConfig()
+//	config.SendRecorder = sendlix.NewSQLSendRecorder(db,
+//		"INSERT INTO sends (message_id, job_id, category, recipients, group_id) VALUES ($1, $2, $3, $4, $5)")
+func NewSQLSendRecorder(db SQLExecer, query string) *SQLSendRecorder {
+	return &SQLSendRecorder{db: db, query: query}
+}
+
+// Record inserts one row per message ID of the record. It stops at the
+// first failing statement; use a *sql.Tx to make the rows of a send atomic.
+func (r *SQLSendRecorder) Record(ctx context.Context, record SendRecord) error {
+	recipients := strings.Join(record.Recipients, ",")
+	for _, id := range record.MessageIDs {
+		if _, err := r.db.ExecContext(ctx, r.query, id, record.JobID, record.Category, recipients, record.GroupID); err != nil {
+			return fmt.Errorf("failed to record message %s: %w", id, err)
+		}
+	}
+	return nil
+}
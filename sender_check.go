@@ -0,0 +1,219 @@
+package sendlix
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// LintRule identifies a sender check performed by CheckSender.
+type LintRule string
+
+const (
+	// LintUnverifiedDomain reports a From domain missing from the verified
+	// domains. It only runs when SenderCheckConfig.VerifiedDomains is set.
+	LintUnverifiedDomain LintRule = "unverified_domain"
+	// LintReplyToMismatch reports a ReplyTo address whose organizational
+	// domain differs from the From domain, e.g. "example.com" and "other.net".
+	// Subdomains of the same domain, such as "mail.example.com", are aligned.
+	LintReplyToMismatch LintRule = "reply_to_mismatch"
+	// LintFreemailFrom reports a From address at a freemail provider such as
+	// gmail.com, which fails DMARC alignment when sent through Sendlix.
+	LintFreemailFrom LintRule = "freemail_from"
+)
+
+// LintIssue is an advisory finding of a sender check.
+type LintIssue struct {
+	// Rule is the check that produced the issue
+	Rule LintRule
+	// Field is the checked field, "From" or "ReplyTo"
+	Field string
+	// Message describes the issue
+	Message string
+}
+
+// String returns the issue in the form "rule: message".
+func (i LintIssue) String() string {
+	return string(i.Rule) + ": " + i.Message
+}
+
+//go:embed freemail_domains.txt
+var freemailDomainList string
+
+// DefaultFreemailDomains returns the embedded list of freemail provider
+// domains used when SenderCheckConfig.FreemailDomains is nil.
+//
+// Returns:
+//   - []string: Lowercase domains, e.g. "gmail.com"
+func DefaultFreemailDomains() []string {
+	var domains []string
+	for _, line := range strings.Split(freemailDomainList, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			domains = append(domains, line)
+		}
+	}
+	return domains
+}
+
+// SenderCheckConfig configures the sender checks of CheckSender and of send
+// methods when set as ClientConfig.SenderCheck.
+type SenderCheckConfig struct {
+	// VerifiedDomains are the sending domains verified in your Sendlix
+	// account. Default: nil (LintUnverifiedDomain is skipped)
+	VerifiedDomains []string
+
+	// FreemailDomains overrides the freemail provider list.
+	// Default: nil (DefaultFreemailDomains is used)
+	FreemailDomains []string
+
+	// Disabled lists rules that are not checked. Default: nil (all rules run)
+	Disabled []LintRule
+
+	// Strict lists rules whose issues reject the send with
+	// ErrSenderCheckFailed. Default: nil (issues are only reported)
+	Strict []LintRule
+
+	// OnWarning is called with the issues of a send that was not rejected.
+	// Default: nil
+	OnWarning func(issues []LintIssue)
+}
+
+// CheckSender checks the From and ReplyTo addresses of an email for common
+// deliverability problems. The checks are advisory; see LintRule for the
+// individual rules. Addresses without a domain are ignored, as they fail
+// validation elsewhere.
+//
+// Parameters:
+//   - from: Sender address
+//   - replyTo: Reply-To address, or nil
+//   - config: Rules and domain lists to use
+//
+// Returns:
+//   - []LintIssue: Issues found, in rule order; nil if none
+//
+// Example:
+//
+//	issues := sendlix.CheckSender(
+//		sendlix.EmailAddress{Email: "news@gmail.com"},
+//		&sendlix.EmailAddress{Email: "support@example.com"},
+//		sendlix.SenderCheckConfig{VerifiedDomains: []string{"example.com"}},
+//	)
+//	for _, issue := range issues {
+//		fmt.Println(issue) // unverified_domain: ..., reply_to_mismatch: ..., freemail_from: ...
+//	}
+func CheckSender(from EmailAddress, replyTo *EmailAddress, config SenderCheckConfig) []LintIssue {
+	fromDomain := emailDomain(from.Email)
+	if fromDomain == "" {
+		return nil
+	}
+
+	enabled := func(rule LintRule) bool {
+		for _, r := range config.Disabled {
+			if r == rule {
+				return false
+			}
+		}
+		return true
+	}
+
+	var issues []LintIssue
+	if len(config.VerifiedDomains) > 0 && enabled(LintUnverifiedDomain) && !containsDomain(config.VerifiedDomains, fromDomain) {
+		issues = append(issues, LintIssue{
+			Rule:    LintUnverifiedDomain,
+			Field:   "From",
+			Message: fmt.Sprintf("domain %q is not a verified sending domain", fromDomain),
+		})
+	}
+
+	if replyTo != nil && enabled(LintReplyToMismatch) {
+		if replyDomain := emailDomain(replyTo.Email); replyDomain != "" && organizationalDomain(replyDomain) != organizationalDomain(fromDomain) {
+			issues = append(issues, LintIssue{
+				Rule:    LintReplyToMismatch,
+				Field:   "ReplyTo",
+				Message: fmt.Sprintf("reply-to domain %q differs from sender domain %q", replyDomain, fromDomain),
+			})
+		}
+	}
+
+	freemail := config.FreemailDomains
+	if freemail == nil {
+		freemail = DefaultFreemailDomains()
+	}
+	if enabled(LintFreemailFrom) && containsDomain(freemail, fromDomain) {
+		issues = append(issues, LintIssue{
+			Rule:    LintFreemailFrom,
+			Field:   "From",
+			Message: fmt.Sprintf("sender domain %q is a freemail provider", fromDomain),
+		})
+	}
+
+	return issues
+}
+
+// emailDomain returns the lowercase domain of an email address, or "".
+func emailDomain(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(email[at+1:]))
+}
+
+// organizationalDomain returns the registrable domain, such as "example.co.uk"
+// for "mail.example.co.uk", or domain itself if it cannot be determined.
+func organizationalDomain(domain string) string {
+	if org, err := publicsuffix.EffectiveTLDPlusOne(domain); err == nil {
+		return org
+	}
+	return domain
+}
+
+// containsDomain reports whether domains contains domain, ignoring case.
+func containsDomain(domains []string, domain string) bool {
+	for _, d := range domains {
+		if strings.EqualFold(d, domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// lintWarnings maps lint rules to the codes of the warnings they produce.
+var lintWarnings = map[LintRule]ErrorCode{
+	LintUnverifiedDomain: WarningUnverifiedDomain,
+	LintReplyToMismatch:  WarningReplyToMismatch,
+	LintFreemailFrom:     WarningFreemailFrom,
+}
+
+// checkSender applies ClientConfig.SenderCheck to the addresses of a send.
+func (c *BaseClient) checkSender(ctx context.Context, from EmailAddress, replyTo *EmailAddress) error {
+	check := c.config.SenderCheck
+	if check == nil {
+		return nil
+	}
+
+	issues := CheckSender(from, replyTo, *check)
+	for _, issue := range issues {
+		for _, rule := range check.Strict {
+			if issue.Rule == rule {
+				return newValidationError(CodeSenderCheckFailed, issue.Field, map[string]string{
+					"rule":    string(issue.Rule),
+					"message": issue.Message,
+				})
+			}
+		}
+	}
+	for _, issue := range issues {
+		if err := c.warn(ctx, Warning{Code: lintWarnings[issue.Rule], Field: issue.Field, Message: issue.Message}); err != nil {
+			return err
+		}
+	}
+	if len(issues) > 0 && check.OnWarning != nil {
+		check.OnWarning(issues)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Package sendlixprom exports the metrics of Sendlix Go SDK clients to
+// Prometheus.
+//
+// A Collector implements both sendlix.MetricsCollector and
+// prometheus.Collector, so it is set on the client configuration and
+// registered with a Prometheus registry:
+//
+//	collector := sendlixprom.NewCollector()
+//	prometheus.MustRegister(collector)
+//
+//	config := sendlix.DefaultClientConfig()
+//	config.Metrics = collector
+//	client, err := sendlix.NewEmailClient(auth, config)
+//
+// It exports these metrics:
+//   - sendlix_rpc_requests_total: Calls by method and gRPC status code
+//   - sendlix_rpc_duration_seconds: Histogram of call latency by method
+//   - sendlix_emails_sent_total: Messages accepted by the API
+//   - sendlix_emails_left: Remaining email quota reported by the last send
+package sendlixprom
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	sendlix "github.com/sendlix/go-sdk"
+	"google.golang.org/grpc/codes"
+)
+
+// Collector records the calls and sends of Sendlix clients as Prometheus
+// metrics. One collector can be shared by several clients.
+type Collector struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	sent     prometheus.Counter
+	left     prometheus.Gauge
+}
+
+// Collector implements both interfaces.
+var (
+	_ sendlix.MetricsCollector = (*Collector)(nil)
+	_ prometheus.Collector     = (*Collector)(nil)
+)
+
+// NewCollector creates a collector with the default histogram buckets of
+// Prometheus. Register it with a prometheus.Registerer to export its
+// metrics.
+//
+// Returns:
+//   - *Collector: Collector to set as sendlix.ClientConfig.Metrics
+func NewCollector() *Collector {
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "sendlix",
+			Name:      "rpc_requests_total",
+			Help:      "Sendlix API calls by method and gRPC status code.",
+		}, []string{"method", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "sendlix",
+			Name:      "rpc_duration_seconds",
+			Help:      "Latency of Sendlix API calls by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		sent: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "sendlix",
+			Name:      "emails_sent_total",
+			Help:      "Messages accepted by the Sendlix API.",
+		}),
+		left: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "sendlix",
+			Name:      "emails_left",
+			Help:      "Remaining email quota reported by the most recent send.",
+		}),
+	}
+}
+
+// ObserveRPC counts a call and records its duration.
+func (c *Collector) ObserveRPC(method string, code codes.Code, duration time.Duration) {
+	c.requests.WithLabelValues(method, code.String()).Inc()
+	c.duration.WithLabelValues(method).Observe(duration.Seconds())
+}
+
+// ObserveEmailsSent adds the messages of a send.
+func (c *Collector) ObserveEmailsSent(n int) {
+	c.sent.Add(float64(n))
+}
+
+// ObserveEmailsLeft records the remaining email quota.
+func (c *Collector) ObserveEmailsLeft(n int64) {
+	c.left.Set(float64(n))
+}
+
+// Describe sends the descriptors of the collector's metrics to ch.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.duration.Describe(ch)
+	c.sent.Describe(ch)
+	c.left.Describe(ch)
+}
+
+// Collect sends the current values of the collector's metrics to ch.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.duration.Collect(ch)
+	c.sent.Collect(ch)
+	c.left.Collect(ch)
+}
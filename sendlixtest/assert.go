@@ -0,0 +1,140 @@
+package sendlixtest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// AssertSent reports a test error unless at least one captured send matches.
+// The failure message lists every captured send with the conditions it did
+// not satisfy.
+//
+// Parameters:
+//   - t: Test to report to
+//   - matcher: Condition the send must satisfy, e.g. a Match
+//
+// Returns:
+//   - bool: Whether a matching send was found
+//
+// Example:
+//
+//	server.AssertSent(t, sendlixtest.Match{To: "x@y.com", SubjectContains: "reset", Category: "auth"})
+func (s *Server) AssertSent(t testing.TB, matcher Matcher) bool {
+	t.Helper()
+
+	sent := s.Sent()
+	for _, email := range sent {
+		if len(matcher.Mismatches(email)) == 0 {
+			return true
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "sendlixtest: no sent email matches %s\n", matcher)
+	if len(sent) == 0 {
+		b.WriteString("no emails were sent")
+	} else {
+		fmt.Fprintf(&b, "%d sent email(s):", len(sent))
+		for i, email := range sent {
+			fmt.Fprintf(&b, "\n  [%d] %s", i, summarize(email))
+			for _, mismatch := range matcher.Mismatches(email) {
+				fmt.Fprintf(&b, "\n      %s", mismatch)
+			}
+		}
+	}
+	t.Errorf("%s", b.String())
+	return false
+}
+
+// AssertNotSent reports a test error if any captured send matches. The
+// failure message lists the matching sends.
+//
+// Parameters:
+//   - t: Test to report to
+//   - matcher: Condition no send may satisfy
+//
+// Returns:
+//   - bool: Whether no matching send was found
+//
+// Example:
+//
+//	server.AssertNotSent(t, sendlixtest.Match{To: "unsubscribed@example.com"})
+func (s *Server) AssertNotSent(t testing.TB, matcher Matcher) bool {
+	t.Helper()
+
+	var matches []string
+	for i, email := range s.Sent() {
+		if len(matcher.Mismatches(email)) == 0 {
+			matches = append(matches, fmt.Sprintf("\n  [%d] %s", i, summarize(email)))
+		}
+	}
+	if len(matches) == 0 {
+		return true
+	}
+
+	t.Errorf("sendlixtest: expected no sent email to match %s, but %d did:%s",
+		matcher, len(matches), strings.Join(matches, ""))
+	return false
+}
+
+// AssertGroupInserted reports a test error unless email was inserted into
+// the group. Addresses are compared case-insensitively. The failure message
+// lists every captured insert.
+//
+// Parameters:
+//   - t: Test to report to
+//   - groupID: Expected group
+//   - email: Expected address
+//
+// Returns:
+//   - bool: Whether a matching insert was found
+//
+// Example:
+//
+//	server.AssertGroupInserted(t, "newsletter", "user@example.com")
+func (s *Server) AssertGroupInserted(t testing.TB, groupID, email string) bool {
+	t.Helper()
+
+	inserts := s.GroupInserts()
+	for _, insert := range inserts {
+		if insert.GroupID == groupID && containsAddress(insert.Emails, email) {
+			return true
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "sendlixtest: %q was not inserted into group %q\n", email, groupID)
+	if len(inserts) == 0 {
+		b.WriteString("no group inserts were made")
+	} else {
+		fmt.Fprintf(&b, "%d group insert(s):", len(inserts))
+		for i, insert := range inserts {
+			fmt.Fprintf(&b, "\n  [%d] group=%q emails=%s", i, insert.GroupID, format(insert.Emails))
+		}
+	}
+	t.Errorf("%s", b.String())
+	return false
+}
+
+// summarize describes a captured send on one line.
+func summarize(email SentEmail) string {
+	parts := []string{email.Method, "from=" + format(email.From)}
+	if len(email.To) > 0 {
+		parts = append(parts, "to="+format(email.To))
+	}
+	if len(email.CC) > 0 {
+		parts = append(parts, "cc="+format(email.CC))
+	}
+	if len(email.BCC) > 0 {
+		parts = append(parts, "bcc="+format(email.BCC))
+	}
+	if email.GroupID != "" {
+		parts = append(parts, "group="+format(email.GroupID))
+	}
+	parts = append(parts, "subject="+format(email.Subject))
+	if email.Category != "" {
+		parts = append(parts, "category="+format(email.Category))
+	}
+	return strings.Join(parts, " ")
+}
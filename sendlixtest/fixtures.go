@@ -0,0 +1,242 @@
+package sendlixtest
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+)
+
+// ValidMailOptions returns MailOptions that pass validation with the
+// default client configuration. Every call returns a new value, so tests
+// can modify the result freely.
+//
+// Returns:
+//   - sendlix.MailOptions: Options with a sender, one recipient, a subject,
+//     and HTML and text content
+//
+// Example:
+//
+//	options := sendlixtest.ValidMailOptions()
+//	options.Subject = "Password reset"
+//	_, err := client.SendEmail(ctx, options, nil)
+func ValidMailOptions() sendlix.MailOptions {
+	return sendlix.MailOptions{
+		From:    sendlix.EmailAddress{Email: "sender@example.com", Name: "Example Sender"},
+		To:      []sendlix.EmailAddress{{Email: "recipient@example.com", Name: "Example Recipient"}},
+		Subject: "Fixture subject",
+		Html:    "<p>Fixture content</p>",
+		Text:    "Fixture content",
+	}
+}
+
+// MailOptionsWith returns ValidMailOptions with the mutators applied in
+// order.
+//
+// Parameters:
+//   - mutators: Functions modifying the options
+//
+// Returns:
+//   - sendlix.MailOptions: Modified options
+//
+// Example:
+//
+//	options := sendlixtest.MailOptionsWith(func(o *sendlix.MailOptions) {
+//		o.CC = []sendlix.EmailAddress{sendlixtest.RandomEmailAddress(1)}
+//	})
+func MailOptionsWith(mutators ...func(*sendlix.MailOptions)) sendlix.MailOptions {
+	options := ValidMailOptions()
+	for _, mutate := range mutators {
+		mutate(&options)
+	}
+	return options
+}
+
+// ValidGroupMailData returns GroupMailData that passes validation with the
+// default client configuration. Every call returns a new value.
+//
+// Returns:
+//   - sendlix.GroupMailData: Data with a group, a sender, a subject, and
+//     HTML and text content
+func ValidGroupMailData() sendlix.GroupMailData {
+	return sendlix.GroupMailData{
+		GroupID: "fixture-group",
+		From:    sendlix.EmailAddress{Email: "sender@example.com", Name: "Example Sender"},
+		Subject: "Fixture subject",
+		Content: sendlix.MailContent{
+			HTML: "<p>Fixture content</p>",
+			Text: "Fixture content",
+		},
+	}
+}
+
+// GroupMailDataWith returns ValidGroupMailData with the mutators applied in
+// order.
+//
+// Parameters:
+//   - mutators: Functions modifying the data
+//
+// Returns:
+//   - sendlix.GroupMailData: Modified data
+func GroupMailDataWith(mutators ...func(*sendlix.GroupMailData)) sendlix.GroupMailData {
+	data := ValidGroupMailData()
+	for _, mutate := range mutators {
+		mutate(&data)
+	}
+	return data
+}
+
+var (
+	firstNames = []string{"Ada", "Alan", "Grace", "Edsger", "Barbara", "Donald", "Frances", "Ken"}
+	lastNames  = []string{"Lovelace", "Turing", "Hopper", "Dijkstra", "Liskov", "Knuth", "Allen", "Thompson"}
+	domains    = []string{"example.com", "example.org", "example.net"}
+)
+
+// RandomEmailAddress returns a plausible address with a display name,
+// derived deterministically from seed. Equal seeds return equal addresses,
+// so failures are reproducible; the domains are reserved for examples and
+// never receive mail.
+//
+// Parameters:
+//   - seed: Seed of the address
+//
+// Returns:
+//   - sendlix.EmailAddress: Address such as "ada.turing.3f2a@example.org"
+//     named "Ada Turing"
+//
+// Example:
+//
+//	for seed := range int64(100) {
+//		options.To = append(options.To, sendlixtest.RandomEmailAddress(seed))
+//	}
+func RandomEmailAddress(seed int64) sendlix.EmailAddress {
+	rng := rand.New(rand.NewPCG(uint64(seed), 0x5e9d11c))
+	first := firstNames[rng.IntN(len(firstNames))]
+	last := lastNames[rng.IntN(len(lastNames))]
+	domain := domains[rng.IntN(len(domains))]
+
+	return sendlix.EmailAddress{
+		Email: fmt.Sprintf("%s.%s.%04x@%s", strings.ToLower(first), strings.ToLower(last), rng.IntN(0x10000), domain),
+		Name:  first + " " + last,
+	}
+}
+
+// InvalidVariant is MailOptions failing validation in one specific way.
+type InvalidVariant struct {
+	// Options are ValidMailOptions with a single defect, unless the defect
+	// is in Additional
+	Options sendlix.MailOptions
+	// Additional are the AdditionalOptions to send Options with, or nil
+	Additional *sendlix.AdditionalOptions
+	// Code is the code of the expected ValidationError
+	Code sendlix.ErrorCode
+	// Field is the Field of the expected ValidationError
+	Field string
+}
+
+// InvalidGroupVariant is GroupMailData failing validation in one specific
+// way.
+type InvalidGroupVariant struct {
+	// Data is ValidGroupMailData with a single defect
+	Data sendlix.GroupMailData
+	// Code is the code of the expected ValidationError
+	Code sendlix.ErrorCode
+	// Field is the Field of the expected ValidationError
+	Field string
+}
+
+// longName exceeds sendlix.MaxDisplayNameLength.
+var longName = strings.Repeat("n", sendlix.MaxDisplayNameLength+1)
+
+// InvalidVariants returns every way MailOptions and AdditionalOptions can
+// fail the validation of SendEmail with the default client configuration,
+// keyed by a descriptive name. Checks enabled through ClientConfig, such as
+// SenderCheck, are not covered.
+//
+// Returns:
+//   - map[string]InvalidVariant: Variants by name
+//
+// Example:
+//
+//	for name, variant := range sendlixtest.InvalidVariants() {
+//		t.Run(name, func(t *testing.T) {
+//			_, err := client.SendEmail(ctx, variant.Options, variant.Additional)
+//			assert.Equal(t, variant.Code, sendlix.Code(err))
+//		})
+//	}
+func InvalidVariants() map[string]InvalidVariant {
+	variant := func(code sendlix.ErrorCode, field string, mutate func(*sendlix.MailOptions)) InvalidVariant {
+		return InvalidVariant{Options: MailOptionsWith(mutate), Code: code, Field: field}
+	}
+	additional := func(code sendlix.ErrorCode, field string, opts *sendlix.AdditionalOptions) InvalidVariant {
+		return InvalidVariant{Options: ValidMailOptions(), Additional: opts, Code: code, Field: field}
+	}
+
+	return map[string]InvalidVariant{
+		"missing from": variant(sendlix.CodeMissingFrom, "From", func(o *sendlix.MailOptions) {
+			o.From = sendlix.EmailAddress{}
+		}),
+		"missing recipients": variant(sendlix.CodeMissingRecipients, "To", func(o *sendlix.MailOptions) {
+			o.To = nil
+		}),
+		"missing subject": variant(sendlix.CodeMissingSubject, "Subject", func(o *sendlix.MailOptions) {
+			o.Subject = ""
+		}),
+		"missing content": variant(sendlix.CodeMissingContent, "Html", func(o *sendlix.MailOptions) {
+			o.Html, o.Text = "", ""
+		}),
+		"from name too long": variant(sendlix.CodeDisplayNameTooLong, "From", func(o *sendlix.MailOptions) {
+			o.From.Name = longName
+		}),
+		"to name too long": variant(sendlix.CodeDisplayNameTooLong, "To[1]", func(o *sendlix.MailOptions) {
+			o.To = append(o.To, sendlix.EmailAddress{Email: "long@example.com", Name: longName})
+		}),
+		"cc name too long": variant(sendlix.CodeDisplayNameTooLong, "CC[0]", func(o *sendlix.MailOptions) {
+			o.CC = []sendlix.EmailAddress{{Email: "cc@example.com", Name: longName}}
+		}),
+		"bcc name too long": variant(sendlix.CodeDisplayNameTooLong, "BCC[0]", func(o *sendlix.MailOptions) {
+			o.BCC = []sendlix.EmailAddress{{Email: "bcc@example.com", Name: longName}}
+		}),
+		"reply-to name too long": variant(sendlix.CodeDisplayNameTooLong, "ReplyTo", func(o *sendlix.MailOptions) {
+			o.ReplyTo = &sendlix.EmailAddress{Email: "reply@example.com", Name: longName}
+		}),
+		"zero send at": additional(sendlix.CodeZeroSendAt, "SendAt", &sendlix.AdditionalOptions{
+			SendAt: &time.Time{},
+		}),
+		"empty attachment": additional(sendlix.CodeEmptyAttachment, "Attachments", &sendlix.AdditionalOptions{
+			Attachments: []sendlix.Attachment{{Filename: "empty.txt", ContentType: "text/plain"}},
+		}),
+	}
+}
+
+// InvalidGroupVariants returns every way GroupMailData can fail the
+// validation of SendGroupEmail with the default client configuration and no
+// sender defaults, keyed by a descriptive name.
+//
+// Returns:
+//   - map[string]InvalidGroupVariant: Variants by name
+func InvalidGroupVariants() map[string]InvalidGroupVariant {
+	variant := func(code sendlix.ErrorCode, field string, mutate func(*sendlix.GroupMailData)) InvalidGroupVariant {
+		return InvalidGroupVariant{Data: GroupMailDataWith(mutate), Code: code, Field: field}
+	}
+
+	return map[string]InvalidGroupVariant{
+		"missing group ID": variant(sendlix.CodeMissingGroupID, "GroupID", func(d *sendlix.GroupMailData) {
+			d.GroupID = ""
+		}),
+		"missing from": variant(sendlix.CodeMissingFrom, "From", func(d *sendlix.GroupMailData) {
+			d.From = sendlix.EmailAddress{}
+		}),
+		"missing subject": variant(sendlix.CodeMissingSubject, "Subject", func(d *sendlix.GroupMailData) {
+			d.Subject = ""
+		}),
+		"missing content": variant(sendlix.CodeMissingContent, "Content", func(d *sendlix.GroupMailData) {
+			d.Content.HTML, d.Content.Text = "", ""
+		}),
+		"from name too long": variant(sendlix.CodeDisplayNameTooLong, "From", func(d *sendlix.GroupMailData) {
+			d.From.Name = longName
+		}),
+	}
+}
@@ -0,0 +1,203 @@
+package sendlixtest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Matcher selects captured sends. Mismatches explains why a send does not
+// match; the explanations are shown in assertion failures so near misses are
+// easy to spot.
+type Matcher interface {
+	// Mismatches returns a description of every condition email does not
+	// satisfy, or nil if it matches.
+	Mismatches(email SentEmail) []string
+
+	// String describes the matcher in failure messages.
+	String() string
+}
+
+// Match matches sends by common fields. Empty fields match anything; all
+// set fields must match. Addresses are compared case-insensitively.
+type Match struct {
+	// Method matches SentEmail.Method, e.g. MethodSendEmail
+	Method string
+	// From matches the sender address
+	From string
+	// To matches if the address is one of the To recipients
+	To string
+	// CC matches if the address is one of the CC recipients
+	CC string
+	// BCC matches if the address is one of the BCC recipients
+	BCC string
+	// Subject matches the exact subject
+	Subject string
+	// SubjectContains matches if the subject contains the text
+	SubjectContains string
+	// BodyContains matches if the HTML or text content contains the text
+	BodyContains string
+	// Category matches the exact category
+	Category string
+	// GroupID matches the target group of group sends
+	GroupID string
+}
+
+// Mismatches implements Matcher.
+func (m Match) Mismatches(email SentEmail) []string {
+	var result []string
+	check := func(field string, ok bool, want string, got any) {
+		if !ok {
+			result = append(result, fmt.Sprintf("%s: want %q, got %s", field, want, format(got)))
+		}
+	}
+
+	if m.Method != "" {
+		check("Method", m.Method == email.Method, m.Method, email.Method)
+	}
+	if m.From != "" {
+		check("From", strings.EqualFold(m.From, email.From), m.From, email.From)
+	}
+	if m.To != "" {
+		check("To", containsAddress(email.To, m.To), m.To, email.To)
+	}
+	if m.CC != "" {
+		check("CC", containsAddress(email.CC, m.CC), m.CC, email.CC)
+	}
+	if m.BCC != "" {
+		check("BCC", containsAddress(email.BCC, m.BCC), m.BCC, email.BCC)
+	}
+	if m.Subject != "" {
+		check("Subject", m.Subject == email.Subject, m.Subject, email.Subject)
+	}
+	if m.SubjectContains != "" {
+		check("SubjectContains", strings.Contains(email.Subject, m.SubjectContains), m.SubjectContains, email.Subject)
+	}
+	if m.BodyContains != "" {
+		if !strings.Contains(email.HTML, m.BodyContains) && !strings.Contains(email.Text, m.BodyContains) {
+			result = append(result, fmt.Sprintf("BodyContains: %q not found in HTML or text content", m.BodyContains))
+		}
+	}
+	if m.Category != "" {
+		check("Category", m.Category == email.Category, m.Category, email.Category)
+	}
+	if m.GroupID != "" {
+		check("GroupID", m.GroupID == email.GroupID, m.GroupID, email.GroupID)
+	}
+	return result
+}
+
+// String implements Matcher.
+func (m Match) String() string {
+	var fields []string
+	add := func(name, value string) {
+		if value != "" {
+			fields = append(fields, fmt.Sprintf("%s: %q", name, value))
+		}
+	}
+	add("Method", m.Method)
+	add("From", m.From)
+	add("To", m.To)
+	add("CC", m.CC)
+	add("BCC", m.BCC)
+	add("Subject", m.Subject)
+	add("SubjectContains", m.SubjectContains)
+	add("BodyContains", m.BodyContains)
+	add("Category", m.Category)
+	add("GroupID", m.GroupID)
+	return "{" + strings.Join(fields, ", ") + "}"
+}
+
+// All returns a matcher that matches sends matched by every matcher.
+//
+// Example:
+//
+//	server.AssertSent(t, sendlixtest.All(
+//		sendlixtest.Match{To: "user@example.com"},
+//		sendlixtest.Match{SubjectContains: "reset"},
+//	))
+func All(matchers ...Matcher) Matcher {
+	return allMatcher(matchers)
+}
+
+// Any returns a matcher that matches sends matched by at least one matcher.
+//
+// Example:
+//
+//	server.AssertNotSent(t, sendlixtest.Any(
+//		sendlixtest.Match{To: "admin@example.com"},
+//		sendlixtest.Match{Category: "internal"},
+//	))
+func Any(matchers ...Matcher) Matcher {
+	return anyMatcher(matchers)
+}
+
+type allMatcher []Matcher
+
+func (a allMatcher) Mismatches(email SentEmail) []string {
+	var result []string
+	for _, m := range a {
+		result = append(result, m.Mismatches(email)...)
+	}
+	return result
+}
+
+func (a allMatcher) String() string {
+	return "All(" + join(a) + ")"
+}
+
+type anyMatcher []Matcher
+
+func (a anyMatcher) Mismatches(email SentEmail) []string {
+	if len(a) == 0 {
+		return []string{"Any: no matchers"}
+	}
+
+	var result []string
+	for _, m := range a {
+		mismatches := m.Mismatches(email)
+		if len(mismatches) == 0 {
+			return nil
+		}
+		result = append(result, fmt.Sprintf("%s: %s", m, strings.Join(mismatches, "; ")))
+	}
+	return result
+}
+
+func (a anyMatcher) String() string {
+	return "Any(" + join(a) + ")"
+}
+
+// join describes a list of matchers.
+func join(matchers []Matcher) string {
+	parts := make([]string, len(matchers))
+	for i, m := range matchers {
+		parts[i] = m.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// containsAddress reports whether list contains addr, ignoring case.
+func containsAddress(list []string, addr string) bool {
+	for _, a := range list {
+		if strings.EqualFold(a, addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// format renders a captured value for failure messages.
+func format(v any) string {
+	switch v := v.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case []string:
+		quoted := make([]string, len(v))
+		for i, s := range v {
+			quoted[i] = fmt.Sprintf("%q", s)
+		}
+		return "[" + strings.Join(quoted, ", ") + "]"
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,545 @@
+package sendlixtest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Mode selects whether a Recorder records or replays interactions.
+type Mode int
+
+const (
+	// ModeReplay serves responses from the fixture file
+	ModeReplay Mode = iota
+	// ModeRecord proxies calls to the Sendlix API and writes the fixture
+	// file when the test finishes
+	ModeRecord
+)
+
+// RecordEnvVar is the environment variable read by ModeFromEnv.
+const RecordEnvVar = "SENDLIX_RECORD"
+
+// ModeFromEnv returns ModeRecord if the SENDLIX_RECORD environment variable
+// is set to a value other than "0" or "false", and ModeReplay otherwise.
+//
+// Returns:
+//   - Mode: Mode selected by the environment
+func ModeFromEnv() Mode {
+	switch strings.ToLower(os.Getenv(RecordEnvVar)) {
+	case "", "0", "false":
+		return ModeReplay
+	default:
+		return ModeRecord
+	}
+}
+
+// Redaction placeholders written to fixtures.
+const (
+	// RedactedValue replaces API secrets, tokens, and recipient names
+	RedactedValue = "REDACTED"
+	// RedactedDomain is the domain of mapped recipient addresses
+	RedactedDomain = "redacted.invalid"
+)
+
+// fixtureVersion is the version of the fixture file format.
+const fixtureVersion = 1
+
+// fixture is the JSON document stored in a fixture file.
+type fixture struct {
+	Version      int           `json:"version"`
+	Interactions []interaction `json:"interactions"`
+}
+
+// interaction is one recorded call. Requests and responses are stored
+// sanitized, as protobuf JSON.
+type interaction struct {
+	Method      string          `json:"method"`
+	RequestHash string          `json:"requestHash"`
+	Request     json.RawMessage `json:"request"`
+	Response    json.RawMessage `json:"response,omitempty"`
+	Error       *fixtureError   `json:"error,omitempty"`
+}
+
+// fixtureError is the status of a recorded failed call.
+type fixtureError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Recorder records calls to the Sendlix API into a JSON fixture file and
+// replays them, so integration tests can run against the live API once and
+// deterministically afterwards.
+//
+// In ModeRecord the recorder proxies every call to the upstream server and
+// captures it. Fixtures are sanitized: API secrets and tokens are replaced
+// with RedactedValue, and recipient addresses are mapped to stable
+// placeholders such as "user-1a2b3c4d@redacted.invalid", derived from the
+// address, so that replays of the same test match.
+//
+// In ModeReplay calls are matched on their method and a hash of the
+// canonical sanitized request. Identical calls are answered in recorded
+// order, repeating the last answer when the recording is exhausted. Calls
+// without a match fail with codes.FailedPrecondition and report a test error
+// showing how the request differs from the closest recorded one. Tokens
+// replayed from the fixture are valid for an hour.
+//
+// Example:
+//
+//	func TestWelcomeEmail(t *testing.T) {
+//		rec := sendlixtest.NewRecorder(t, "testdata/welcome.json", sendlixtest.ModeFromEnv(), nil)
+//
+//		apiKey := os.Getenv("SENDLIX_API_KEY") // may be any valid key when replaying
+//		if apiKey == "" {
+//			apiKey = "replay.1"
+//		}
+//		auth, err := sendlix.NewAuthWithConfig(apiKey, rec.Config())
+//		if err != nil {
+//			t.Fatal(err)
+//		}
+//		client, err := sendlix.NewEmailClient(sendlix.OwnedAuth(auth), rec.Config())
+//		...
+//	}
+type Recorder struct {
+	t        testing.TB
+	mode     Mode
+	path     string
+	addr     string
+	upstream *grpc.ClientConn
+
+	mu           sync.Mutex
+	interactions []interaction
+	served       map[string]int // Replayed calls per method and request hash
+}
+
+// NewRecorder starts a recorder on a random local port and stops it when the
+// test finishes. In ModeRecord the fixture is written at that point; in
+// ModeReplay it is read immediately and the test fails if it is missing.
+//
+// Parameters:
+//   - t: Test the recorder belongs to
+//   - path: Fixture file, e.g. "testdata/welcome.json"
+//   - mode: ModeRecord or ModeReplay, e.g. ModeFromEnv()
+//   - upstream: Configuration of the server to record (optional, uses
+//     sendlix.DefaultClientConfig if nil; ignored in ModeReplay)
+//
+// Returns:
+//   - *Recorder: Running recorder
+func NewRecorder(t testing.TB, path string, mode Mode, upstream *sendlix.ClientConfig) *Recorder {
+	t.Helper()
+
+	r := &Recorder{t: t, mode: mode, path: path, served: make(map[string]int)}
+	if mode == ModeRecord {
+		if upstream == nil {
+			upstream = sendlix.DefaultClientConfig()
+		}
+		tlsConfig := upstream.TLSConfig
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{InsecureSkipVerify: upstream.Insecure}
+		}
+		conn, err := grpc.NewClient(upstream.ServerAddress,
+			grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig.Clone())),
+			grpc.WithUserAgent(upstream.UserAgent),
+		)
+		if err != nil {
+			t.Fatalf("sendlixtest: failed to connect to %s: %v", upstream.ServerAddress, err)
+		}
+		r.upstream = conn
+	} else {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("sendlixtest: failed to read fixture (record it with %s=1): %v", RecordEnvVar, err)
+		}
+		var f fixture
+		if err := json.Unmarshal(data, &f); err != nil {
+			t.Fatalf("sendlixtest: failed to parse fixture %s: %v", path, err)
+		}
+		if f.Version != fixtureVersion {
+			t.Fatalf("sendlixtest: fixture %s has unsupported version %d", path, f.Version)
+		}
+		r.interactions = f.Interactions
+	}
+
+	tlsConfig, err := selfSignedTLSConfig()
+	if err != nil {
+		t.Fatalf("sendlixtest: failed to create certificate: %v", err)
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("sendlixtest: failed to listen: %v", err)
+	}
+	r.addr = lis.Addr().String()
+
+	srv := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)), grpc.UnknownServiceHandler(r.handle))
+	go srv.Serve(lis)
+	t.Cleanup(func() {
+		srv.Stop()
+		if r.upstream != nil {
+			r.upstream.Close()
+		}
+		if mode == ModeRecord {
+			if err := r.save(); err != nil {
+				t.Errorf("sendlixtest: failed to write fixture: %v", err)
+			}
+		}
+	})
+
+	return r
+}
+
+// Config returns a client configuration pointing at the recorder. Use it for
+// both the clients and the Auth, so the token exchange is recorded as well.
+//
+// Returns:
+//   - *sendlix.ClientConfig: Configuration to pass to NewAuthWithConfig and
+//     the client constructors
+func (r *Recorder) Config() *sendlix.ClientConfig {
+	config := sendlix.DefaultClientConfig()
+	config.ServerAddress = r.addr
+	config.UserAgent = "sendlix-go-sdk-sendlixtest/1.0.0"
+	config.TLSConfig = &tls.Config{InsecureSkipVerify: true}
+	return config
+}
+
+// save writes the recorded interactions to the fixture file.
+func (r *Recorder) save() error {
+	r.mu.Lock()
+	f := fixture{Version: fixtureVersion, Interactions: r.interactions}
+	r.mu.Unlock()
+	if f.Interactions == nil {
+		f.Interactions = []interaction{}
+	}
+
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+// recordedMethods creates the request and response messages of the methods
+// the recorder supports.
+var recordedMethods = map[string]func() (req, resp protoadapt.MessageV1){
+	pb.Auth_GetJwtToken_FullMethodName: func() (protoadapt.MessageV1, protoadapt.MessageV1) {
+		return &pb.AuthRequest{}, &pb.AuthResponse{}
+	},
+	pb.Email_SendEmail_FullMethodName: func() (protoadapt.MessageV1, protoadapt.MessageV1) {
+		return &pb.SendMailRequest{}, &pb.SendEmailResponse{}
+	},
+	pb.Email_SendEmlEmail_FullMethodName: func() (protoadapt.MessageV1, protoadapt.MessageV1) {
+		return &pb.EmlMailRequest{}, &pb.SendEmailResponse{}
+	},
+	pb.Email_SendGroupEmail_FullMethodName: func() (protoadapt.MessageV1, protoadapt.MessageV1) {
+		return &pb.GroupMailData{}, &pb.SendEmailResponse{}
+	},
+	pb.Group_InsertEmailToGroup_FullMethodName: func() (protoadapt.MessageV1, protoadapt.MessageV1) {
+		return &pb.InsertEmailToGroupRequest{}, &pb.UpdateResponse{}
+	},
+	pb.Group_RemoveEmailFromGroup_FullMethodName: func() (protoadapt.MessageV1, protoadapt.MessageV1) {
+		return &pb.RemoveEmailFromGroupRequest{}, &pb.UpdateResponse{}
+	},
+	pb.Group_CheckEmailInGroup_FullMethodName: func() (protoadapt.MessageV1, protoadapt.MessageV1) {
+		return &pb.CheckEmailInGroupRequest{}, &pb.CheckEmailInGroupResponse{}
+	},
+}
+
+// handle serves every call to the recorder.
+func (r *Recorder) handle(srv any, stream grpc.ServerStream) error {
+	method, _ := grpc.MethodFromServerStream(stream)
+	newMessages, ok := recordedMethods[method]
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "sendlixtest: method %s is not supported", method)
+	}
+
+	req, resp := newMessages()
+	if err := stream.RecvMsg(req); err != nil {
+		return err
+	}
+	reqJSON, err := canonicalJSON(sanitize(req))
+	if err != nil {
+		return status.Errorf(codes.Internal, "sendlixtest: %v", err)
+	}
+	hash := sha256.Sum256(reqJSON)
+	rec := interaction{Method: method, RequestHash: hex.EncodeToString(hash[:]), Request: reqJSON}
+
+	if r.mode == ModeRecord {
+		return r.record(stream, rec, req, resp)
+	}
+	return r.replay(stream, rec, resp)
+}
+
+// record forwards a call upstream and captures it.
+func (r *Recorder) record(stream grpc.ServerStream, rec interaction, req, resp protoadapt.MessageV1) error {
+	ctx := stream.Context()
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if auth := md.Get("authorization"); len(auth) > 0 {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", auth[0])
+		}
+	}
+
+	callErr := r.upstream.Invoke(ctx, rec.Method, req, resp)
+	if callErr != nil {
+		s := status.Convert(callErr)
+		rec.Error = &fixtureError{Code: s.Code().String(), Message: s.Message()}
+	} else {
+		respJSON, err := canonicalJSON(sanitize(resp))
+		if err != nil {
+			return status.Errorf(codes.Internal, "sendlixtest: %v", err)
+		}
+		rec.Response = respJSON
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, rec)
+	r.mu.Unlock()
+
+	if callErr != nil {
+		return callErr
+	}
+	return stream.SendMsg(resp)
+}
+
+// replay answers a call from the fixture.
+func (r *Recorder) replay(stream grpc.ServerStream, rec interaction, resp protoadapt.MessageV1) error {
+	key := rec.Method + " " + rec.RequestHash
+
+	r.mu.Lock()
+	var matches []interaction
+	for _, candidate := range r.interactions {
+		if candidate.Method == rec.Method && candidate.RequestHash == rec.RequestHash {
+			matches = append(matches, candidate)
+		}
+	}
+	var match interaction
+	if len(matches) > 0 {
+		match = matches[min(r.served[key], len(matches)-1)]
+		r.served[key]++
+	}
+	r.mu.Unlock()
+
+	if len(matches) == 0 {
+		msg := r.unmatched(rec)
+		r.t.Errorf("%s", msg)
+		return status.Error(codes.FailedPrecondition, msg)
+	}
+
+	if match.Error != nil {
+		return status.Error(parseCode(match.Error.Code), match.Error.Message)
+	}
+	if err := protojson.Unmarshal(match.Response, protoadapt.MessageV2Of(resp)); err != nil {
+		return status.Errorf(codes.Internal, "sendlixtest: invalid recorded response: %v", err)
+	}
+	if auth, ok := resp.(*pb.AuthResponse); ok {
+		auth.Expires = timestamppb.New(time.Now().Add(time.Hour))
+	}
+	return stream.SendMsg(resp)
+}
+
+// unmatched describes a request without a recorded match, comparing it with
+// the closest recorded request of the same method.
+func (r *Recorder) unmatched(rec interaction) string {
+	got := flattenJSON(rec.Request)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var closest []string
+	for _, candidate := range r.interactions {
+		if candidate.Method != rec.Method {
+			continue
+		}
+		diff := diffFlat(flattenJSON(candidate.Request), got)
+		if closest == nil || len(diff) < len(closest) {
+			closest = diff
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "sendlixtest: no recorded interaction in %s matches %s request", r.path, rec.Method)
+	if closest == nil {
+		b.WriteString("\nno interactions were recorded for this method")
+	} else {
+		b.WriteString("\nclosest recorded request differs in:")
+		for _, line := range closest {
+			fmt.Fprintf(&b, "\n  %s", line)
+		}
+	}
+	return b.String()
+}
+
+// sanitize returns a copy of msg with secrets and recipients redacted.
+func sanitize(msg protoadapt.MessageV1) protoadapt.MessageV1 {
+	msg = protoadapt.MessageV1Of(proto.Clone(protoadapt.MessageV2Of(msg)))
+	switch m := msg.(type) {
+	case *pb.AuthRequest:
+		if m.GetApiKey() != nil {
+			m.Key = &pb.AuthRequest_ApiKey{ApiKey: &pb.ApiKey{Secret: RedactedValue}}
+		}
+	case *pb.AuthResponse:
+		m.Token = RedactedValue
+		m.Expires = nil
+	case *pb.SendMailRequest:
+		for _, list := range [][]*pb.EmailData{m.To, m.Cc, m.Bcc} {
+			for _, data := range list {
+				redactEmailData(data)
+			}
+		}
+	case *pb.EmlMailRequest:
+		m.Mail = redactEML(m.Mail)
+	case *pb.InsertEmailToGroupRequest:
+		for _, entry := range m.Entries {
+			redactEmailData(entry.Email)
+		}
+	case *pb.RemoveEmailFromGroupRequest:
+		m.Email = redactAddress(m.Email)
+	case *pb.CheckEmailInGroupRequest:
+		m.Email = redactAddress(m.Email)
+	}
+	return msg
+}
+
+// redactAddress maps a recipient address to a stable placeholder.
+func redactAddress(addr string) string {
+	if addr == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(addr))))
+	return "user-" + hex.EncodeToString(sum[:4]) + "@" + RedactedDomain
+}
+
+// redactEmailData redacts the address and name of a recipient.
+func redactEmailData(data *pb.EmailData) {
+	if data == nil {
+		return
+	}
+	data.Email = redactAddress(data.Email)
+	if data.Name != "" {
+		data.Name = RedactedValue
+	}
+}
+
+// redactEML replaces the recipient addresses of a raw message.
+func redactEML(raw []byte) []byte {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return raw
+	}
+	for _, key := range []string{"To", "Cc", "Bcc"} {
+		for _, addr := range headerAddresses(msg.Header, key) {
+			raw = bytes.ReplaceAll(raw, []byte(addr), []byte(redactAddress(addr)))
+		}
+	}
+	return raw
+}
+
+// canonicalJSON encodes msg as protobuf JSON with sorted keys and no
+// insignificant whitespace, so equal messages always encode identically.
+func canonicalJSON(msg protoadapt.MessageV1) (json.RawMessage, error) {
+	data, err := protojson.Marshal(protoadapt.MessageV2Of(msg))
+	if err != nil {
+		return nil, err
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// flattenJSON maps the leaf paths of a JSON document, such as "to[0].email",
+// to their encoded values.
+func flattenJSON(data json.RawMessage) map[string]string {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return map[string]string{"": string(data)}
+	}
+	result := make(map[string]string)
+	var walk func(path string, v any)
+	walk = func(path string, v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			for key, value := range v {
+				if path == "" {
+					walk(key, value)
+				} else {
+					walk(path+"."+key, value)
+				}
+			}
+		case []any:
+			for i, value := range v {
+				walk(fmt.Sprintf("%s[%d]", path, i), value)
+			}
+		default:
+			encoded, _ := json.Marshal(v)
+			result[path] = string(encoded)
+		}
+	}
+	walk("", v)
+	return result
+}
+
+// diffFlat describes the differences between a recorded and a received
+// flattened request, sorted by path.
+func diffFlat(recorded, got map[string]string) []string {
+	paths := make(map[string]bool)
+	for path := range recorded {
+		paths[path] = true
+	}
+	for path := range got {
+		paths[path] = true
+	}
+
+	var diff []string
+	for path := range paths {
+		want, inRecorded := recorded[path]
+		value, inGot := got[path]
+		switch {
+		case !inGot:
+			diff = append(diff, fmt.Sprintf("%s: recorded %s, got nothing", path, want))
+		case !inRecorded:
+			diff = append(diff, fmt.Sprintf("%s: recorded nothing, got %s", path, value))
+		case want != value:
+			diff = append(diff, fmt.Sprintf("%s: recorded %s, got %s", path, want, value))
+		}
+	}
+	sort.Strings(diff)
+	return diff
+}
+
+// parseCode returns the gRPC code with the given name, e.g.
+// "PermissionDenied", or codes.Unknown.
+func parseCode(name string) codes.Code {
+	for c := codes.OK; c <= codes.Unauthenticated; c++ {
+		if c.String() == name {
+			return c
+		}
+	}
+	return codes.Unknown
+}
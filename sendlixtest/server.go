@@ -0,0 +1,350 @@
+// Package sendlixtest provides a fake Sendlix server and assertion helpers
+// for testing code that uses the Sendlix Go SDK.
+//
+// The fake server implements the Auth, Email, and Group services over TLS on
+// a local port. It accepts every request, captures sends and group inserts,
+// and answers with synthetic message IDs, so tests can assert on what their
+// code sent without reaching the Sendlix API:
+//
+//	func TestPasswordReset(t *testing.T) {
+//		server := sendlixtest.NewServer(t)
+//		client, err := sendlix.NewEmailClient(server.Auth(), server.Config())
+//		if err != nil {
+//			t.Fatal(err)
+//		}
+//		defer client.Close()
+//
+//		resetPassword(client, "user@example.com")
+//
+//		server.AssertSent(t, sendlixtest.Match{
+//			To:              "user@example.com",
+//			SubjectContains: "reset",
+//			Category:        "auth",
+//		})
+//	}
+package sendlixtest
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"net/mail"
+	"sync"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Send methods recorded in SentEmail.Method.
+const (
+	MethodSendEmail      = "SendEmail"
+	MethodSendEmlEmail   = "SendEmlEmail"
+	MethodSendGroupEmail = "SendGroupEmail"
+)
+
+// SentEmail is a send captured by the fake server.
+type SentEmail struct {
+	// Method is the RPC used for the send, e.g. MethodSendEmail
+	Method string
+	// From is the sender address
+	From string
+	// To, CC, and BCC are the recipient addresses. For EML sends they are
+	// read from the message headers; BCC is usually empty there.
+	To  []string
+	CC  []string
+	BCC []string
+	// Subject is the email subject
+	Subject string
+	// HTML and Text are the email content. EML sends leave them empty.
+	HTML string
+	Text string
+	// Category is the email category
+	Category string
+	// GroupID is the target group of group sends
+	GroupID string
+	// MessageIDs are the IDs returned to the client
+	MessageIDs []string
+}
+
+// GroupInsert is an InsertEmailToGroup request captured by the fake server.
+type GroupInsert struct {
+	// GroupID is the target group
+	GroupID string
+	// Emails are the inserted addresses in request order
+	Emails []string
+}
+
+// Server is a fake Sendlix server for tests. It is safe for concurrent use.
+type Server struct {
+	addr string
+
+	mu      sync.Mutex
+	sent    []SentEmail
+	inserts []GroupInsert
+	nextID  int
+}
+
+// NewServer starts a fake server on a random local port and stops it when
+// the test finishes.
+//
+// Parameters:
+//   - t: Test the server belongs to
+//
+// Returns:
+//   - *Server: Running fake server
+//
+// Example:
+//
+//	server := sendlixtest.NewServer(t)
+//	client, err := sendlix.NewEmailClient(server.Auth(), server.Config())
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	tlsConfig, err := selfSignedTLSConfig()
+	if err != nil {
+		t.Fatalf("sendlixtest: failed to create certificate: %v", err)
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("sendlixtest: failed to listen: %v", err)
+	}
+
+	s := &Server{addr: lis.Addr().String()}
+	srv := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	pb.RegisterAuthServer(srv, authService{})
+	pb.RegisterEmailServer(srv, emailService{s: s})
+	pb.RegisterGroupServer(srv, groupService{s: s})
+
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	return s
+}
+
+// Config returns a client configuration pointing at the fake server.
+//
+// Returns:
+//   - *sendlix.ClientConfig: Configuration to pass to the client constructors
+func (s *Server) Config() *sendlix.ClientConfig {
+	config := sendlix.DefaultClientConfig()
+	config.ServerAddress = s.addr
+	config.UserAgent = "sendlix-go-sdk-sendlixtest/1.0.0"
+	config.TLSConfig = &tls.Config{InsecureSkipVerify: true}
+	return config
+}
+
+// Auth returns an authentication implementation accepted by the fake server.
+//
+// Returns:
+//   - sendlix.IAuth: Authentication to pass to the client constructors
+func (s *Server) Auth() sendlix.IAuth {
+	return staticAuth{}
+}
+
+// Sent returns the captured sends in the order they were received.
+func (s *Server) Sent() []SentEmail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SentEmail(nil), s.sent...)
+}
+
+// GroupInserts returns the captured group inserts in the order they were
+// received.
+func (s *Server) GroupInserts() []GroupInsert {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]GroupInsert(nil), s.inserts...)
+}
+
+// Reset discards all captured requests.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = nil
+	s.inserts = nil
+}
+
+// recordSend captures a send, assigning one message ID per recipient.
+func (s *Server) recordSend(email SentEmail, recipients int) *pb.SendEmailResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := 0; i < recipients; i++ {
+		s.nextID++
+		email.MessageIDs = append(email.MessageIDs, fmt.Sprintf("msg-%d", s.nextID))
+	}
+	s.sent = append(s.sent, email)
+
+	return &pb.SendEmailResponse{Message: email.MessageIDs, EmailsLeft: math.MaxInt32}
+}
+
+// staticAuth authenticates with a fixed token.
+type staticAuth struct{}
+
+func (staticAuth) GetAuthHeader(ctx context.Context) (string, string, error) {
+	return "authorization", "Bearer sendlixtest", nil
+}
+
+type authService struct {
+	pb.UnimplementedAuthServer
+}
+
+type emailService struct {
+	pb.UnimplementedEmailServer
+	s *Server
+}
+
+type groupService struct {
+	pb.UnimplementedGroupServer
+	s *Server
+}
+
+func (authService) GetJwtToken(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error) {
+	return &pb.AuthResponse{Token: "sendlixtest", Expires: timestamppb.New(time.Now().Add(time.Hour))}, nil
+}
+
+func (e emailService) SendEmail(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+	email := SentEmail{
+		Method:   MethodSendEmail,
+		From:     req.GetFrom().GetEmail(),
+		To:       addresses(req.GetTo()),
+		CC:       addresses(req.GetCc()),
+		BCC:      addresses(req.GetBcc()),
+		Subject:  req.GetSubject(),
+		HTML:     req.GetTextContent().GetHtml(),
+		Text:     req.GetTextContent().GetText(),
+		Category: req.GetAdditionalInfos().GetCategory(),
+	}
+	return e.s.recordSend(email, len(email.To)+len(email.CC)+len(email.BCC)), nil
+}
+
+func (e emailService) SendEmlEmail(ctx context.Context, req *pb.EmlMailRequest) (*pb.SendEmailResponse, error) {
+	email := SentEmail{
+		Method:   MethodSendEmlEmail,
+		Category: req.GetAdditionalInfos().GetCategory(),
+	}
+	if msg, err := mail.ReadMessage(bytes.NewReader(req.GetMail())); err == nil {
+		if from := headerAddresses(msg.Header, "From"); len(from) > 0 {
+			email.From = from[0]
+		}
+		email.To = headerAddresses(msg.Header, "To")
+		email.CC = headerAddresses(msg.Header, "Cc")
+		email.BCC = headerAddresses(msg.Header, "Bcc")
+		email.Subject = msg.Header.Get("Subject")
+	}
+	return e.s.recordSend(email, 1), nil
+}
+
+func (e emailService) SendGroupEmail(ctx context.Context, req *pb.GroupMailData) (*pb.SendEmailResponse, error) {
+	email := SentEmail{
+		Method:   MethodSendGroupEmail,
+		From:     req.GetFrom().GetEmail(),
+		Subject:  req.GetSubject(),
+		HTML:     req.GetTextContent().GetHtml(),
+		Text:     req.GetTextContent().GetText(),
+		Category: req.GetCategory(),
+		GroupID:  req.GetGroupId(),
+	}
+	return e.s.recordSend(email, 1), nil
+}
+
+func (g groupService) InsertEmailToGroup(ctx context.Context, req *pb.InsertEmailToGroupRequest) (*pb.UpdateResponse, error) {
+	insert := GroupInsert{GroupID: req.GetGroupId()}
+	for _, entry := range req.GetEntries() {
+		insert.Emails = append(insert.Emails, entry.GetEmail().GetEmail())
+	}
+
+	g.s.mu.Lock()
+	g.s.inserts = append(g.s.inserts, insert)
+	g.s.mu.Unlock()
+
+	return &pb.UpdateResponse{Success: true, AffectedRows: int64(len(insert.Emails))}, nil
+}
+
+func (g groupService) RemoveEmailFromGroup(ctx context.Context, req *pb.RemoveEmailFromGroupRequest) (*pb.UpdateResponse, error) {
+	return &pb.UpdateResponse{Success: true, AffectedRows: 1}, nil
+}
+
+func (g groupService) CheckEmailInGroup(ctx context.Context, req *pb.CheckEmailInGroupRequest) (*pb.CheckEmailInGroupResponse, error) {
+	g.s.mu.Lock()
+	defer g.s.mu.Unlock()
+
+	for _, insert := range g.s.inserts {
+		if insert.GroupID != req.GetGroupId() {
+			continue
+		}
+		for _, email := range insert.Emails {
+			if email == req.GetEmail() {
+				return &pb.CheckEmailInGroupResponse{Exists: true}, nil
+			}
+		}
+	}
+	return &pb.CheckEmailInGroupResponse{Exists: false}, nil
+}
+
+// addresses returns the email addresses of protobuf email data.
+func addresses(data []*pb.EmailData) []string {
+	var result []string
+	for _, d := range data {
+		result = append(result, d.GetEmail())
+	}
+	return result
+}
+
+// headerAddresses returns the addresses of an address list header, or nil
+// if the header is missing or malformed.
+func headerAddresses(header mail.Header, key string) []string {
+	list, err := header.AddressList(key)
+	if err != nil {
+		return nil
+	}
+	var result []string
+	for _, addr := range list {
+		result = append(result, addr.Address)
+	}
+	return result
+}
+
+// selfSignedTLSConfig creates a server TLS configuration with a freshly
+// generated self-signed certificate for 127.0.0.1.
+func selfSignedTLSConfig() (*tls.Config, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sendlixtest"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+	}, nil
+}
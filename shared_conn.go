@@ -0,0 +1,132 @@
+package sendlix
+
+// NewClientsWithSharedConn creates an EmailClient and a GroupClient that
+// share a single gRPC connection with auth, so that one TLS connection to
+// the Sendlix API serves token exchanges, sends, and group operations.
+//
+// It is NewClients with token exchanges moved onto the shared connection
+// as well, which is only possible for an *Auth created with NewAuth. Prefer
+// NewClients unless the extra connection of auth matters: it accepts any
+// IAuth and returns a Client with a single Close.
+//
+// The connection NewAuth dialed for auth is closed and replaced by the
+// shared one, so auth should not be in use by other clients. Token
+// exchanges on the shared connection bypass the authentication interceptor
+// and are permitted in read-only mode.
+//
+// Both clients use the same connection: closing either of them closes it
+// for both and for auth, so close exactly one of them when done. Likewise,
+// Reconnect on either of them switches both and auth to a new connection.
+//
+// Parameters:
+//   - auth: Authentication created with NewAuth (required)
+//   - config: Client configuration (optional, uses defaults if nil)
+//
+// Returns:
+//   - *EmailClient: Email client using the shared connection
+//   - *GroupClient: Group client using the shared connection
+//   - error: Validation or connection error
+//
+// Example:
+//
+//	auth, err := sendlix.NewAuth("secret.keyid")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	emails, groups, err := sendlix.NewClientsWithSharedConn(auth, nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer emails.Close()
+//
+//	err = emails.SendGroupEmail(ctx, data)
+//	exists, err := groups.CheckEmailInGroup(ctx, "newsletter", "user@example.com")
+func NewClientsWithSharedConn(auth *Auth, config *ClientConfig) (*EmailClient, *GroupClient, error) {
+	if auth == nil {
+		return nil, nil, newValidationError(CodeMissingAuth, "auth", nil)
+	}
+
+	clients, err := NewClients(auth, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	baseClient := clients.email.BaseClient
+	auth.useConnection(baseClient.GetConnection())
+	baseClient.sharedAuth = auth
+	return clients.Email(), clients.Groups(), nil
+}
+
+// Client bundles an EmailClient and a GroupClient sharing one gRPC
+// connection. Create it with NewClients.
+type Client struct {
+	email  *EmailClient
+	groups *GroupClient
+}
+
+// NewClients creates an EmailClient and a GroupClient over a single gRPC
+// connection, instead of the two connections NewEmailClient and
+// NewGroupClient open. This is the recommended way to use both clients.
+// Token exchanges keep using the connection of auth, so any IAuth can be
+// used; NewClientsWithSharedConn additionally moves them onto the shared
+// connection for an *Auth.
+//
+// Close the returned Client when done, not the clients it returns, so the
+// connection is closed once.
+//
+// Parameters:
+//   - auth: Authentication implementation (required)
+//   - config: Client configuration (optional, uses defaults if nil)
+//
+// Returns:
+//   - *Client: Clients sharing one connection
+//   - error: Validation or connection error
+//
+// Example:
+//
+//	clients, err := sendlix.NewClients(auth, nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer clients.Close()
+//
+//	_, err = clients.Groups().InsertEmailToGroup(ctx, "newsletter", sendlix.GroupEntry{Email: "user@example.com"})
+//	err = clients.Email().SendGroupEmail(ctx, data)
+func NewClients(auth IAuth, config *ClientConfig) (*Client, error) {
+	if err := validateEmailConfig(config); err != nil {
+		return nil, err
+	}
+
+	baseClient, err := NewBaseClient(auth, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{email: newEmailClient(baseClient), groups: newGroupClient(baseClient)}, nil
+}
+
+// Email returns the email client.
+//
+// Returns:
+//   - *EmailClient: Email client using the shared connection
+func (c *Client) Email() *EmailClient {
+	return c.email
+}
+
+// Groups returns the group client.
+//
+// Returns:
+//   - *GroupClient: Group client using the shared connection
+func (c *Client) Groups() *GroupClient {
+	return c.groups
+}
+
+// Close closes the shared connection, the send spool of the email client,
+// and an Auth owned through OwnedAuth.
+//
+// Returns:
+//   - error: Any error encountered while closing the connection
+func (c *Client) Close() error {
+	return c.email.Close()
+}
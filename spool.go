@@ -0,0 +1,340 @@
+package sendlix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+)
+
+// ErrSpooled is returned by SendEmail when the API was unavailable and the
+// email was retained by ClientConfig.SpoolOnUnavailable. The email is
+// delivered later, or passed to SpoolConfig.DeadLetter; callers must not
+// send it again.
+//
+// Example:
+//
+//	_, err := client.SendEmail(ctx, options, nil)
+//	switch {
+//	case errors.Is(err, sendlix.ErrSpooled):
+//		log.Println("Sendlix unavailable, email will be sent when it is back")
+//	case err != nil:
+//		log.Fatal(err)
+//	}
+var ErrSpooled = errors.New("API unavailable, email spooled for later delivery")
+
+// ErrSpoolDropped is wrapped by the errors SpoolConfig.DeadLetter receives
+// for emails the spool gives up on because they exceed MaxAge or MaxMessages,
+// or because the client was closed.
+var ErrSpoolDropped = errors.New("email dropped from spool")
+
+// errSpoolBacklog is the reason sends are spooled without being attempted
+// while earlier emails wait for delivery.
+var errSpoolBacklog = errors.New("earlier emails are waiting for delivery")
+
+// SpoolConfig configures the in-memory spool of ClientConfig.SpoolOnUnavailable,
+// which retains emails whose SendEmail call failed with UNAVAILABLE and
+// delivers them once the API is reachable again.
+//
+// Every spooled email ends in exactly one of OnFlushed and DeadLetter. While
+// the spool holds emails, new sends are appended to it rather than sent, so
+// emails are delivered in the order they were sent.
+//
+// The spool lives in memory only, so it smooths over brief outages but does
+// not survive the process. The API offers no idempotency keys: an email
+// whose UNAVAILABLE error was reported after the API accepted it is
+// delivered twice.
+//
+// Example:
+//
+//	config := sendlix.DefaultClientConfig()
+//	config.SpoolOnUnavailable = &sendlix.SpoolConfig{
+//		MaxMessages: 500,
+//		MaxAge:      2 * time.Minute,
+//		DeadLetter: func(email sendlix.SpooledEmail, err error) {
+//			log.Printf("email to %v lost: %v", email.Options.To, err)
+//		},
+//	}
+type SpoolConfig struct {
+	// MaxMessages is the number of emails the spool holds. Sends failing
+	// while it is full are passed to DeadLetter and fail with an error
+	// wrapping ErrSpoolDropped. Default: 100
+	MaxMessages int
+
+	// MaxAge is how long an email is retained before it is passed to
+	// DeadLetter. Default: 5 minutes
+	MaxAge time.Duration
+
+	// RetryInterval is how often delivery is attempted while the connection
+	// is ready but the API keeps reporting UNAVAILABLE. Delivery is also
+	// attempted whenever the connection becomes ready. Default: 1 second
+	RetryInterval time.Duration
+
+	// OnSpooled is called when an email is added to the spool.
+	// Default: nil (no callback)
+	OnSpooled func(email SpooledEmail)
+
+	// OnFlushed is called with the message IDs of a spooled email once it
+	// was delivered. Default: nil (no callback)
+	OnFlushed func(email SpooledEmail, messageIDs []string)
+
+	// DeadLetter is called with every email the spool gives up on, and the
+	// reason: an error wrapping ErrSpoolDropped, or the error the API
+	// rejected the email with on delivery (required)
+	DeadLetter func(email SpooledEmail, err error)
+}
+
+// SpooledEmail is an email retained by the spool, as passed to the
+// callbacks of SpoolConfig.
+type SpooledEmail struct {
+	// Options are the options of the send, with client defaults applied
+	Options MailOptions
+	// Additional are the additional options of the send, or nil
+	Additional *AdditionalOptions
+	// SpooledAt is when the email was added to the spool
+	SpooledAt time.Time
+}
+
+// effective returns the message limit, maximum age, and retry interval with
+// defaults applied.
+func (c *SpoolConfig) effective() (int, time.Duration, time.Duration) {
+	maxMessages := c.MaxMessages
+	if maxMessages <= 0 {
+		maxMessages = 100
+	}
+	maxAge := c.MaxAge
+	if maxAge <= 0 {
+		maxAge = 5 * time.Minute
+	}
+	retryInterval := c.RetryInterval
+	if retryInterval <= 0 {
+		retryInterval = time.Second
+	}
+	return maxMessages, maxAge, retryInterval
+}
+
+// validateSpool checks that a spool has a DeadLetter callback.
+//
+// Returns:
+//   - error: ErrMissingDeadLetter, or nil
+func validateSpool(c *SpoolConfig) error {
+	if c != nil && c.DeadLetter == nil {
+		return newValidationError(CodeMissingDeadLetter, "SpoolOnUnavailable.DeadLetter", nil)
+	}
+	return nil
+}
+
+// spoolEntry is an email held by a sendSpool.
+type spoolEntry struct {
+	email    SpooledEmail
+	client   *EmailClient    // Client the email was sent with, for its defaults
+	ctx      context.Context // Context of the send, without its cancellation
+	req      *pb.SendMailRequest
+	reserved int64
+	uploaded []string
+	record   SendRecord
+	lastErr  error // Most recent delivery error, guarded by sendSpool.mu
+}
+
+// sendSpool retains emails while the API is unavailable and delivers them
+// from a background goroutine that runs while it holds emails.
+type sendSpool struct {
+	config        *SpoolConfig
+	maxMessages   int
+	maxAge        time.Duration
+	retryInterval time.Duration
+
+	ctx     context.Context // Canceled by close
+	cancel  context.CancelFunc
+	stopped chan struct{} // Closed when the running goroutine exits
+
+	mu      sync.Mutex
+	entries []*spoolEntry
+	running bool
+	closed  bool
+}
+
+// newSendSpool returns the spool configured by config, or nil.
+func newSendSpool(config *SpoolConfig) *sendSpool {
+	if config == nil {
+		return nil
+	}
+	maxMessages, maxAge, retryInterval := config.effective()
+	ctx, cancel := context.WithCancel(context.Background())
+	return &sendSpool{
+		config:        config,
+		maxMessages:   maxMessages,
+		maxAge:        maxAge,
+		retryInterval: retryInterval,
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
+
+// holding reports whether the spool holds emails, which later sends must
+// queue behind.
+func (s *sendSpool) holding() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries) > 0
+}
+
+// add retains an email whose send failed with cause, or passes it to
+// DeadLetter if the spool is full.
+//
+// Returns:
+//   - error: An error wrapping ErrSpooled and cause, an error wrapping
+//     ErrSpoolDropped and cause if the spool is full, or cause if the
+//     client is closed
+func (s *sendSpool) add(entry *spoolEntry, cause error) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return cause
+	}
+	if len(s.entries) >= s.maxMessages {
+		s.mu.Unlock()
+		err := fmt.Errorf("%w: spool is full with %d emails: %w", ErrSpoolDropped, s.maxMessages, cause)
+		entry.client.cleanupAttachments(entry.ctx, entry.uploaded)
+		s.config.DeadLetter(entry.email, err)
+		return err
+	}
+
+	entry.email.SpooledAt = time.Now()
+	entry.lastErr = cause
+	s.entries = append(s.entries, entry)
+	if !s.running {
+		s.running = true
+		s.stopped = make(chan struct{})
+		go s.run(entry.client.BaseClient, s.stopped)
+	}
+	s.mu.Unlock()
+
+	if s.config.OnSpooled != nil {
+		s.config.OnSpooled(entry.email)
+	}
+	return fmt.Errorf("%w: %w", ErrSpooled, cause)
+}
+
+// run delivers the spooled emails whenever the connection of client is
+// ready and expires old ones, until the spool is empty or closed. The
+// connection is looked up on every pass, so it follows Reconnect.
+func (s *sendSpool) run(client *BaseClient, stopped chan struct{}) {
+	defer close(stopped)
+	for {
+		s.expire(time.Now())
+		conn := client.GetConnection()
+		state := conn.GetState()
+		switch state {
+		case connectivity.Ready:
+			s.flush()
+		case connectivity.Idle:
+			conn.Connect()
+		}
+
+		s.mu.Lock()
+		if len(s.entries) == 0 || s.closed {
+			s.running = false
+			s.mu.Unlock()
+			return
+		}
+		wait := min(s.retryInterval, time.Until(s.entries[0].email.SpooledAt.Add(s.maxAge)))
+		s.mu.Unlock()
+
+		// A state change, the next retry or expiry, or close ends the wait
+		ctx, cancel := context.WithTimeout(s.ctx, max(wait, 0))
+		conn.WaitForStateChange(ctx, state)
+		cancel()
+	}
+}
+
+// flush delivers spooled emails in order until the spool is empty or the
+// API is unavailable again. Emails the API rejects are passed to
+// DeadLetter.
+func (s *sendSpool) flush() {
+	for {
+		s.mu.Lock()
+		if len(s.entries) == 0 || s.closed {
+			s.mu.Unlock()
+			return
+		}
+		entry := s.entries[0]
+		s.mu.Unlock()
+
+		ctx, cancel := context.WithCancel(entry.ctx)
+		stop := context.AfterFunc(s.ctx, cancel)
+		messageIDs, err := entry.client.deliver(ctx, entry.req, entry.reserved, entry.record)
+		stop()
+		cancel()
+
+		s.mu.Lock()
+		if err != nil && (status.Code(err) == codes.Unavailable || s.ctx.Err() != nil) {
+			entry.lastErr = err
+			s.mu.Unlock()
+			return
+		}
+		s.entries = s.entries[1:]
+		s.mu.Unlock()
+
+		if err != nil && messageIDs == nil {
+			entry.client.cleanupAttachments(entry.ctx, entry.uploaded)
+			s.config.DeadLetter(entry.email, err)
+		} else if s.config.OnFlushed != nil {
+			s.config.OnFlushed(entry.email, messageIDs)
+		}
+	}
+}
+
+// expire passes emails spooled longer than MaxAge to DeadLetter.
+func (s *sendSpool) expire(now time.Time) {
+	s.mu.Lock()
+	var expired []*spoolEntry
+	for len(s.entries) > 0 && now.Sub(s.entries[0].email.SpooledAt) >= s.maxAge {
+		expired = append(expired, s.entries[0])
+		s.entries = s.entries[1:]
+	}
+	s.mu.Unlock()
+
+	for _, entry := range expired {
+		s.drop(entry, fmt.Sprintf("not delivered within %s", s.maxAge))
+	}
+}
+
+// close stops delivery and passes all spooled emails to DeadLetter.
+func (s *sendSpool) close() {
+	s.mu.Lock()
+	s.closed = true
+	stopped := s.stopped
+	s.mu.Unlock()
+
+	s.cancel()
+	if stopped != nil {
+		<-stopped
+	}
+
+	s.mu.Lock()
+	entries := s.entries
+	s.entries = nil
+	s.mu.Unlock()
+
+	for _, entry := range entries {
+		s.drop(entry, "client closed")
+	}
+}
+
+// drop passes an entry to DeadLetter with an error wrapping ErrSpoolDropped
+// and its last delivery error.
+func (s *sendSpool) drop(entry *spoolEntry, reason string) {
+	s.mu.Lock()
+	lastErr := entry.lastErr
+	s.mu.Unlock()
+
+	entry.client.cleanupAttachments(entry.ctx, entry.uploaded)
+	s.config.DeadLetter(entry.email, fmt.Errorf("%w: %s: %w", ErrSpoolDropped, reason, lastErr))
+}
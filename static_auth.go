@@ -0,0 +1,88 @@
+package sendlix
+
+import (
+	"context"
+	"strings"
+)
+
+// StaticTokenAuth implements IAuth with a pre-issued JWT token. It never
+// contacts the authentication service, so workloads can use tokens issued
+// centrally without access to the API key. The token is not refreshed;
+// requests fail once it expires.
+type StaticTokenAuth struct {
+	token string
+}
+
+// NewStaticTokenAuth creates an IAuth that authenticates every request with
+// the given JWT token.
+//
+// Parameters:
+//   - token: Pre-issued JWT token, without the "Bearer " prefix (required)
+//
+// Returns:
+//   - *StaticTokenAuth: Authentication to pass to a client constructor
+//   - error: ErrMissingToken if token is empty
+//
+// Example:
+//
+//	auth, err := sendlix.NewStaticTokenAuth(os.Getenv("SENDLIX_JWT"))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	client, err := sendlix.NewEmailClient(auth, nil)
+func NewStaticTokenAuth(token string) (*StaticTokenAuth, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, newValidationError(CodeMissingToken, "token", nil)
+	}
+	return &StaticTokenAuth{token: token}, nil
+}
+
+// GetAuthHeader returns the authorization header with the static token.
+//
+// Parameters:
+//   - ctx: Context for the request (unused)
+//
+// Returns:
+//   - string: Header key ("authorization")
+//   - string: Header value ("Bearer <token>")
+//   - error: Always nil
+func (a *StaticTokenAuth) GetAuthHeader(ctx context.Context) (string, string, error) {
+	return "authorization", "Bearer " + a.token, nil
+}
+
+// TokenFunc implements IAuth by calling a function for the JWT token of
+// every request, allowing callers to plug in their own token fetcher, for
+// example one reading a token file rotated by a sidecar. The function is
+// called concurrently and should cache tokens itself.
+//
+// Example:
+//
+//	auth := sendlix.TokenFunc(func(ctx context.Context) (string, error) {
+//		return tokenIssuer.Token(ctx, "sendlix")
+//	})
+//	client, err := sendlix.NewEmailClient(auth, nil)
+type TokenFunc func(ctx context.Context) (string, error)
+
+// GetAuthHeader returns the authorization header with the token returned by
+// f.
+//
+// Parameters:
+//   - ctx: Context passed to f
+//
+// Returns:
+//   - string: Header key ("authorization")
+//   - string: Header value ("Bearer <token>")
+//   - error: Error returned by f, or ErrMissingToken if f returned an empty
+//     token
+func (f TokenFunc) GetAuthHeader(ctx context.Context) (string, string, error) {
+	token, err := f(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", "", newValidationError(CodeMissingToken, "token", nil)
+	}
+	return "authorization", "Bearer " + token, nil
+}
@@ -0,0 +1,252 @@
+package sendlix
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrQuotaExceeded matches QuotaExceededError with errors.Is.
+var ErrQuotaExceeded = errors.New("email quota exceeded")
+
+// ErrUnauthenticated matches AuthenticationError with errors.Is.
+var ErrUnauthenticated = errors.New("request was not authenticated")
+
+// ErrNotFound matches NotFoundError with errors.Is.
+var ErrNotFound = errors.New("resource not found")
+
+// QuotaExceededError is returned when the API rejects a send with
+// RESOURCE_EXHAUSTED because the email quota is used up or the account
+// sends too fast, which the API signals with RetryInfo or QuotaFailure
+// details.
+//
+// Example:
+//
+//	_, err := client.SendEmail(ctx, options, nil)
+//	var quotaErr *sendlix.QuotaExceededError
+//	if errors.As(err, &quotaErr) {
+//		log.Printf("quota exceeded, %d emails left, retry after %s", quotaErr.EmailsLeft, quotaErr.RetryAfter)
+//	}
+type QuotaExceededError struct {
+	// EmailsLeft is the remaining quota according to the most recent
+	// successful send of the client, see EmailClient.EmailsLeft, or 0 if
+	// there was none
+	EmailsLeft int64
+	// RetryAfter is the wait the API asked for with RetryInfo details, or 0
+	// if it did not ask for one
+	RetryAfter time.Duration
+	// Err is the underlying gRPC error
+	Err error
+}
+
+// Error returns the formatted error message.
+func (e *QuotaExceededError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("email quota exceeded, retry after %s: %v", e.RetryAfter, e.Err)
+	}
+	return fmt.Sprintf("email quota exceeded: %v", e.Err)
+}
+
+// Unwrap returns the underlying gRPC error.
+func (e *QuotaExceededError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrQuotaExceeded.
+func (e *QuotaExceededError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
+
+// AuthenticationError is returned when the API rejects a call with
+// UNAUTHENTICATED even after a new token was requested, for example because
+// the API key was revoked meanwhile.
+type AuthenticationError struct {
+	// Err is the underlying gRPC error
+	Err error
+}
+
+// Error returns the formatted error message.
+func (e *AuthenticationError) Error() string {
+	return fmt.Sprintf("request was not authenticated: %v", e.Err)
+}
+
+// Unwrap returns the underlying gRPC error.
+func (e *AuthenticationError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrUnauthenticated.
+func (e *AuthenticationError) Is(target error) bool {
+	return target == ErrUnauthenticated
+}
+
+// NotFoundError is returned when the API rejects a call with NOT_FOUND,
+// for example because a group does not exist.
+//
+// Example:
+//
+//	_, err := groupClient.InsertEmailToGroup(ctx, "newsletter", entry)
+//	var notFound *sendlix.NotFoundError
+//	if errors.As(err, &notFound) {
+//		log.Printf("%s %q does not exist", notFound.Resource, notFound.ID)
+//	}
+type NotFoundError struct {
+	// Resource is the kind of the missing resource, e.g. "group", or "" if
+	// unknown
+	Resource string
+	// ID is the identifier of the missing resource, or "" if unknown
+	ID string
+	// Err is the underlying gRPC error
+	Err error
+}
+
+// Error returns the formatted error message.
+func (e *NotFoundError) Error() string {
+	if e.Resource == "" {
+		return fmt.Sprintf("not found: %v", e.Err)
+	}
+	return fmt.Sprintf("%s %q not found: %v", e.Resource, e.ID, e.Err)
+}
+
+// Unwrap returns the underlying gRPC error.
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrNotFound.
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// statusContext describes the call whose error mapStatusError maps.
+type statusContext struct {
+	resource   string // Kind of resource the call refers to, e.g. "group"
+	id         string // Identifier of that resource
+	emailsLeft int64  // Most recent quota reading of the client
+}
+
+// mapStatusError wraps the gRPC status of a failed call into the SDK's
+// error types, keeping the status available to errors.As and
+// status.FromError:
+//   - RESOURCE_EXHAUSTED becomes a ValidationError with CodeMessageTooLarge
+//     if a message exceeded a gRPC size limit, or a QuotaExceededError if it
+//     carries RetryInfo or QuotaFailure details; other RESOURCE_EXHAUSTED
+//     errors are returned unchanged
+//   - UNAUTHENTICATED becomes an AuthenticationError
+//   - INVALID_ARGUMENT becomes a ValidationError with CodeInvalidArgument,
+//     naming the field of the first google.rpc.BadRequest violation
+//   - NOT_FOUND becomes a NotFoundError for the resource of call
+//
+// Other errors, and errors already wrapping an SDK sentinel such as
+// ErrAuthFailed, are returned unchanged.
+func mapStatusError(err error, call statusContext) error {
+	if err == nil {
+		return nil
+	}
+	for _, entry := range errorRegistry {
+		if errors.Is(err, entry.err) {
+			return err
+		}
+	}
+	s, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	switch s.Code() {
+	case codes.ResourceExhausted:
+		switch resourceExhaustedCode(s) {
+		case CodeMessageTooLarge:
+			return messageTooLarge(s, err)
+		case CodeResourceExhausted:
+			return err
+		}
+		quotaErr := &QuotaExceededError{EmailsLeft: call.emailsLeft, Err: err}
+		for _, detail := range s.Details() {
+			if info, ok := detail.(*errdetails.RetryInfo); ok {
+				quotaErr.RetryAfter = info.GetRetryDelay().AsDuration()
+			}
+		}
+		return quotaErr
+	case codes.Unauthenticated:
+		return &AuthenticationError{Err: err}
+	case codes.InvalidArgument:
+		validationErr := &ValidationError{Code: CodeInvalidArgument, Params: map[string]string{"message": s.Message()}, Err: err}
+		for _, detail := range s.Details() {
+			if badRequest, ok := detail.(*errdetails.BadRequest); ok && len(badRequest.GetFieldViolations()) > 0 {
+				violation := badRequest.GetFieldViolations()[0]
+				validationErr.Field = violation.GetField()
+				validationErr.Params["message"] = violation.GetField() + ": " + violation.GetDescription()
+				break
+			}
+		}
+		return validationErr
+	case codes.NotFound:
+		return &NotFoundError{Resource: call.resource, ID: call.id, Err: err}
+	default:
+		return err
+	}
+}
+
+// messageSizePattern matches the status messages gRPC fails calls with when
+// a request or response exceeds a message size limit of the client or the
+// server. The sizes are only part of some of them.
+var messageSizePattern = regexp.MustCompile(`message (?:after decompression )?larger than max(?: \((\d+) vs\. (\d+)\)| (\d+))?`)
+
+// resourceExhaustedCode tells apart the causes of a RESOURCE_EXHAUSTED status,
+// which gRPC also uses for messages exceeding a size limit.
+//
+// Returns:
+//   - ErrorCode: CodeMessageTooLarge for size limit errors, CodeQuotaExceeded
+//     if the status carries RetryInfo or QuotaFailure details, or
+//     CodeResourceExhausted otherwise
+func resourceExhaustedCode(s *status.Status) ErrorCode {
+	if messageSizePattern.MatchString(s.Message()) {
+		return CodeMessageTooLarge
+	}
+	for _, detail := range s.Details() {
+		switch detail.(type) {
+		case *errdetails.RetryInfo, *errdetails.QuotaFailure:
+			return CodeQuotaExceeded
+		}
+	}
+	return CodeResourceExhausted
+}
+
+// messageTooLarge converts a gRPC size limit error into ErrMessageTooLarge,
+// with the sizes of the status message if it has them.
+func messageTooLarge(s *status.Status, err error) *ValidationError {
+	params := map[string]string{"size": "?", "max": "?"}
+	if m := messageSizePattern.FindStringSubmatch(s.Message()); m != nil {
+		switch {
+		case m[1] != "":
+			params["size"], params["max"] = m[1], m[2]
+		case m[3] != "":
+			params["size"], params["max"] = "more than "+m[3], m[3]
+		}
+	}
+	return &ValidationError{Code: CodeMessageTooLarge, Params: params, Err: err}
+}
+
+// mapSendError maps the error of a failed send with the client's latest
+// quota reading.
+//
+// Parameters:
+//   - err: Error of the send
+//   - groupID: Group the email was sent to, or "" for other sends
+//
+// Returns:
+//   - error: Error as mapped by mapStatusError
+func (c *EmailClient) mapSendError(err error, groupID string) error {
+	call := statusContext{}
+	call.emailsLeft, _ = c.EmailsLeft()
+	if groupID != "" {
+		call.resource, call.id = "group", groupID
+	}
+	return mapStatusError(err, call)
+}
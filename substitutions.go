@@ -0,0 +1,97 @@
+package sendlix
+
+import (
+	"sort"
+	"strconv"
+	"unicode/utf8"
+)
+
+// SubstitutionLimits bounds the Substitutions of group entries, so that
+// entries the API would reject with INVALID_ARGUMENT fail before any
+// request is made, with an error naming the offending key. Keys must also
+// be valid placeholder names (see CheckPlaceholders), since other keys can
+// never be referenced from content. Set it as ClientConfig.SubstitutionLimits.
+//
+// Example:
+//
+//	config := sendlix.DefaultClientConfig()
+//	config.SubstitutionLimits = &sendlix.SubstitutionLimits{
+//		MaxKeys:        50,
+//		MaxKeyLength:   64,
+//		MaxValueLength: 1024,
+//	}
+type SubstitutionLimits struct {
+	// MaxKeys is the maximum number of substitutions per entry.
+	// Default: 0 (not limited)
+	MaxKeys int
+
+	// MaxKeyLength is the maximum length of a key in characters.
+	// Default: 0 (not limited)
+	MaxKeyLength int
+
+	// MaxValueLength is the maximum length of a value in characters.
+	// Default: 0 (not limited)
+	MaxValueLength int
+}
+
+// check validates the substitutions of an entry. Keys are checked in
+// sorted order, so the same entry always reports the same key.
+//
+// Parameters:
+//   - field: Field of the entry, e.g. "entries[3].Substitutions"
+//   - substitutions: Substitutions of the entry
+//
+// Returns:
+//   - error: ErrTooManySubstitutions, ErrInvalidSubstitutionKey,
+//     ErrSubstitutionKeyTooLong, or ErrSubstitutionValueTooLong, or nil
+func (l *SubstitutionLimits) check(field string, substitutions map[string]string) error {
+	if l == nil || len(substitutions) == 0 {
+		return nil
+	}
+
+	if l.MaxKeys > 0 && len(substitutions) > l.MaxKeys {
+		return newValidationError(CodeTooManySubstitutions, field, map[string]string{
+			"field": field,
+			"count": strconv.Itoa(len(substitutions)),
+			"max":   strconv.Itoa(l.MaxKeys),
+		})
+	}
+
+	keys := make([]string, 0, len(substitutions))
+	for key := range substitutions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !isPlaceholderName(key) {
+			return newValidationError(CodeInvalidSubstitutionKey, field, map[string]string{
+				"field": field,
+				"key":   key,
+			})
+		}
+		if length := utf8.RuneCountInString(key); l.MaxKeyLength > 0 && length > l.MaxKeyLength {
+			return newValidationError(CodeSubstitutionKeyTooLong, field, map[string]string{
+				"field":  field,
+				"key":    key,
+				"length": strconv.Itoa(length),
+				"max":    strconv.Itoa(l.MaxKeyLength),
+			})
+		}
+		if length := utf8.RuneCountInString(substitutions[key]); l.MaxValueLength > 0 && length > l.MaxValueLength {
+			return newValidationError(CodeSubstitutionValueTooLong, field, map[string]string{
+				"field":  field,
+				"key":    key,
+				"length": strconv.Itoa(length),
+				"max":    strconv.Itoa(l.MaxValueLength),
+			})
+		}
+	}
+	return nil
+}
+
+// checkSubstitutions applies ClientConfig.SubstitutionLimits to the
+// substitutions of an entry.
+func (c *BaseClient) checkSubstitutions(field string, substitutions map[string]string) error {
+	return c.config.SubstitutionLimits.check(field, substitutions)
+}
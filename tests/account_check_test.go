@@ -0,0 +1,99 @@
+package sendlix_test
+
+import (
+	"context"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	"github.com/sendlix/go-sdk/sendlixtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpectedAccount(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+
+	newClient := func(t *testing.T, auth sendlix.IAuth, accountID string, keyID int64) *sendlix.EmailClient {
+		config := server.config()
+		config.ExpectedAccountID = accountID
+		config.ExpectedKeyID = keyID
+		client, err := sendlix.NewEmailClient(auth, config)
+		require.NoError(t, err)
+		t.Cleanup(func() { client.Close() })
+		return client
+	}
+	send := func(client *sendlix.EmailClient) error {
+		_, err := client.SendEmail(ctx, sendlixtest.ValidMailOptions(), nil)
+		return err
+	}
+	accountToken := func(t *testing.T, accountID string) string {
+		return fakeJWT(t, map[string]any{"account_id": accountID})
+	}
+
+	t.Run("Unset", func(t *testing.T) {
+		client := newClient(t, &MockAuth{Token: "test"}, "", 0)
+		require.NoError(t, send(client))
+		assert.Equal(t, "no identity expected", client.Health(ctx, nil).Account.Detail)
+	})
+
+	t.Run("Matching account", func(t *testing.T) {
+		client := newClient(t, &MockAuth{Token: accountToken(t, "acct-1")}, "acct-1", 0)
+		assert.Equal(t, "identity is checked on the next call", client.Health(ctx, nil).Account.Detail)
+
+		require.NoError(t, send(client))
+		require.NoError(t, send(client))
+		health := client.Health(ctx, nil)
+		assert.Equal(t, sendlix.HealthOK, health.Account.Status)
+		assert.Equal(t, "identity matches", health.Account.Detail)
+	})
+
+	t.Run("Mismatching account", func(t *testing.T) {
+		auth := &MockAuth{Token: accountToken(t, "acct-staging")}
+		client := newClient(t, auth, "acct-1", 0)
+		before := server.count("SendEmail")
+
+		err := send(client)
+		assert.ErrorIs(t, err, sendlix.ErrAccountMismatch)
+		assert.Equal(t, sendlix.CodeAccountMismatch, sendlix.Code(err))
+		assert.Contains(t, err.Error(), `expected account "acct-1", API key belongs to "acct-staging"`)
+
+		auth.Token = accountToken(t, "acct-1")
+		assert.ErrorIs(t, send(client), sendlix.ErrAccountMismatch, "a mismatch fails all later calls")
+		assert.Equal(t, before, server.count("SendEmail"), "no request is sent")
+
+		health := client.Health(ctx, nil)
+		assert.Equal(t, sendlix.HealthDown, health.Account.Status)
+		assert.Equal(t, sendlix.HealthDown, health.Status)
+	})
+
+	t.Run("Token without account", func(t *testing.T) {
+		client := newClient(t, &MockAuth{Token: "opaque-token"}, "acct-1", 0)
+		assert.ErrorIs(t, send(client), sendlix.ErrAccountMismatch)
+	})
+
+	t.Run("Key ID", func(t *testing.T) {
+		newAuth := func(t *testing.T) *sendlix.Auth {
+			auth, err := sendlix.NewAuth("secret.7", sendlix.WithAuthConnection(server.dial(t)))
+			require.NoError(t, err)
+			return auth
+		}
+
+		require.NoError(t, send(newClient(t, newAuth(t), "", 7)))
+
+		err := send(newClient(t, newAuth(t), "", 8))
+		assert.ErrorIs(t, err, sendlix.ErrAccountMismatch)
+		assert.Contains(t, err.Error(), "expected API key 8, got 7")
+
+		assert.ErrorIs(t, send(newClient(t, &MockAuth{Token: "test"}, "", 8)), sendlix.ErrAccountMismatch, "key ID of other IAuth implementations is unknown")
+	})
+
+	t.Run("Active health check", func(t *testing.T) {
+		client := newClient(t, &MockAuth{Token: accountToken(t, "acct-staging")}, "acct-1", 0)
+		assert.Equal(t, sendlix.HealthOK, client.Health(ctx, nil).Account.Status)
+
+		health := client.Health(ctx, &sendlix.HealthOptions{Active: true})
+		assert.Equal(t, sendlix.HealthDown, health.Account.Status)
+		assert.Contains(t, health.Account.Detail, "acct-staging")
+	})
+}
@@ -0,0 +1,150 @@
+package sendlix_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/sendlix/go-sdk/sendlixtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestAdditionalOptions(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+
+	var mu sync.Mutex
+	var infos []*pb.AdditionalInfos
+	server.update(func(h *fakeHandlers) {
+		h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			infos = append(infos, req.AdditionalInfos)
+			return &pb.SendEmailResponse{Message: []string{"msg-1"}}, nil
+		}
+		h.sendEmlEmail = func(ctx context.Context, req *pb.EmlMailRequest) (*pb.SendEmailResponse, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			infos = append(infos, req.AdditionalInfos)
+			return &pb.SendEmailResponse{Message: []string{"msg-1"}}, nil
+		}
+	})
+
+	client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, server.config())
+	require.NoError(t, err)
+	defer client.Close()
+
+	sendAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600))
+	var zero time.Time
+	attachment := sendlix.Attachment{ContentURL: "https://files.example.com/a.pdf", Filename: "a.pdf", ContentType: "application/pdf"}
+
+	send := func(t *testing.T, additional *sendlix.AdditionalOptions) (*pb.AdditionalInfos, *pb.AdditionalInfos) {
+		t.Helper()
+		infos = nil
+		_, err := client.SendEmail(ctx, sendlixtest.ValidMailOptions(), additional)
+		require.NoError(t, err)
+		_, err = client.SendEMLEmail(ctx, []byte("Subject: Hi\r\n\r\nHi"), additional)
+		require.NoError(t, err)
+		require.Len(t, infos, 2)
+		return infos[0], infos[1]
+	}
+
+	t.Run("Valid combinations", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			additional *sendlix.AdditionalOptions
+			expected   *pb.AdditionalInfos
+		}{
+			{"nil", nil, nil},
+			{"zero value", &sendlix.AdditionalOptions{}, nil},
+			{"empty attachment list", &sendlix.AdditionalOptions{Attachments: []sendlix.Attachment{}}, nil},
+			{"category only", &sendlix.AdditionalOptions{Category: "news"}, &pb.AdditionalInfos{Category: "news"}},
+			{"send time only", &sendlix.AdditionalOptions{SendAt: &sendAt}, &pb.AdditionalInfos{SendAt: timestamppb.New(sendAt)}},
+			{
+				"attachment only",
+				&sendlix.AdditionalOptions{Attachments: []sendlix.Attachment{attachment}},
+				&pb.AdditionalInfos{Attachments: []*pb.AttachmentData{{ContentUrl: attachment.ContentURL, Type: "application/pdf", Filename: "a.pdf"}}},
+			},
+			{
+				"attachment with URL only",
+				&sendlix.AdditionalOptions{Attachments: []sendlix.Attachment{{ContentURL: attachment.ContentURL}}},
+				&pb.AdditionalInfos{Attachments: []*pb.AttachmentData{{ContentUrl: attachment.ContentURL}}},
+			},
+			{
+				"all fields",
+				&sendlix.AdditionalOptions{Category: "news", SendAt: &sendAt, Attachments: []sendlix.Attachment{attachment}},
+				&pb.AdditionalInfos{
+					Category:    "news",
+					SendAt:      timestamppb.New(sendAt),
+					Attachments: []*pb.AttachmentData{{ContentUrl: attachment.ContentURL, Type: "application/pdf", Filename: "a.pdf"}},
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				email, eml := send(t, tt.additional)
+				assert.True(t, proto.Equal(protoadapt.MessageV2Of(tt.expected), protoadapt.MessageV2Of(email)), "SendEmail: %v", email)
+				assert.True(t, proto.Equal(protoadapt.MessageV2Of(tt.expected), protoadapt.MessageV2Of(eml)), "SendEMLEmail: %v", eml)
+			})
+		}
+	})
+
+	t.Run("Send time is sent in UTC", func(t *testing.T) {
+		email, _ := send(t, &sendlix.AdditionalOptions{SendAt: &sendAt})
+		assert.Equal(t, sendAt.Unix(), email.SendAt.Seconds)
+		assert.Equal(t, "2030-01-02T02:04:05Z", email.SendAt.AsTime().Format(time.RFC3339))
+	})
+
+	t.Run("Invalid combinations", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			additional *sendlix.AdditionalOptions
+			code       sendlix.ErrorCode
+			field      string
+			index      string
+		}{
+			{"zero send time", &sendlix.AdditionalOptions{SendAt: &zero}, sendlix.CodeZeroSendAt, "SendAt", ""},
+			{"zero send time with category", &sendlix.AdditionalOptions{Category: "news", SendAt: &zero}, sendlix.CodeZeroSendAt, "SendAt", ""},
+			{"empty attachment", &sendlix.AdditionalOptions{Attachments: []sendlix.Attachment{{}}}, sendlix.CodeEmptyAttachment, "Attachments", "0"},
+			{
+				"attachment without URL",
+				&sendlix.AdditionalOptions{Attachments: []sendlix.Attachment{attachment, {Filename: "b.pdf", ContentType: "application/pdf"}}},
+				sendlix.CodeEmptyAttachment,
+				"Attachments",
+				"1",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				infos = nil
+				_, err := client.SendEmail(ctx, sendlixtest.ValidMailOptions(), tt.additional)
+				assertValidationError(t, err, tt.code, tt.field)
+
+				var validationErr *sendlix.ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, tt.index, validationErr.Params["index"])
+
+				_, err = client.SendEMLEmail(ctx, []byte("Subject: Hi\r\n\r\nHi"), tt.additional)
+				assertValidationError(t, err, tt.code, tt.field)
+				assert.Empty(t, infos, "invalid options are not sent")
+			})
+		}
+	})
+
+	t.Run("Error messages", func(t *testing.T) {
+		_, err := client.SendEmail(ctx, sendlixtest.ValidMailOptions(), &sendlix.AdditionalOptions{SendAt: &zero})
+		assert.Equal(t, "send time is the zero time; use nil to send immediately", err.Error())
+
+		_, err = client.SendEmail(ctx, sendlixtest.ValidMailOptions(), &sendlix.AdditionalOptions{Attachments: []sendlix.Attachment{attachment, {}}})
+		assert.Equal(t, "attachment at index 1 has no content URL or content", err.Error())
+	})
+}
@@ -0,0 +1,344 @@
+package sendlix_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// httpUploader is a storage-agnostic AttachmentUploader backed by an
+// httptest server that accepts PUT and DELETE requests.
+type httpUploader struct {
+	server *httptest.Server
+
+	mu      sync.Mutex
+	objects map[string]string
+	deleted []string
+	uploads int
+	failOn  string
+}
+
+func newHTTPUploader(t *testing.T) *httpUploader {
+	u := &httpUploader{objects: make(map[string]string)}
+	u.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u.mu.Lock()
+		defer u.mu.Unlock()
+
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			u.objects[r.URL.Path] = r.Header.Get("Content-Type") + ":" + string(body)
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodDelete:
+			delete(u.objects, r.URL.Path)
+			u.deleted = append(u.deleted, r.URL.Path)
+		}
+	}))
+	t.Cleanup(u.server.Close)
+	return u
+}
+
+func (u *httpUploader) Upload(ctx context.Context, filename, contentType string, r io.Reader) (string, error) {
+	u.mu.Lock()
+	u.uploads++
+	u.mu.Unlock()
+
+	if filename == u.failOn {
+		return "", errors.New("storage quota exceeded")
+	}
+
+	url := u.server.URL + "/attachments/" + filename
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, r)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return url, nil
+}
+
+func (u *httpUploader) Delete(ctx context.Context, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func TestAttachmentUploader(t *testing.T) {
+	ctx := context.Background()
+	options := sendlix.MailOptions{
+		From:    sendlix.EmailAddress{Email: "sender@example.com"},
+		To:      []sendlix.EmailAddress{{Email: "recipient@example.com"}},
+		Subject: "Your report",
+		Text:    "See attachment",
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "report.csv")
+	require.NoError(t, os.WriteFile(path, []byte("a,b\n1,2\n"), 0o600))
+
+	fileAttachment, err := sendlix.NewAttachmentFromFile(path, "text/csv")
+	require.NoError(t, err)
+
+	t.Run("Content attachments are uploaded and sent as URLs", func(t *testing.T) {
+		server := newFakeServer(t)
+		var captured *pb.SendMailRequest
+		server.update(func(h *fakeHandlers) {
+			h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+				captured = req
+				return &pb.SendEmailResponse{Message: []string{"msg-1"}}, nil
+			}
+		})
+
+		uploader := newHTTPUploader(t)
+		config := server.config()
+		config.AttachmentUploader = uploader
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		additional := &sendlix.AdditionalOptions{
+			Attachments: []sendlix.Attachment{
+				{ContentURL: "https://cdn.example.com/terms.pdf", Filename: "terms.pdf", ContentType: "application/pdf"},
+				sendlix.NewAttachmentFromBytes("hello.txt", "text/plain", []byte("hello")),
+				sendlix.NewAttachmentFromReader("notes.md", "text/markdown", strings.NewReader("# Notes")),
+				fileAttachment,
+			},
+		}
+
+		_, err = client.SendEmail(ctx, options, additional)
+		require.NoError(t, err)
+
+		require.NotNil(t, captured)
+		attachments := captured.AdditionalInfos.Attachments
+		require.Len(t, attachments, 4)
+		assert.Equal(t, "https://cdn.example.com/terms.pdf", attachments[0].ContentUrl)
+		assert.Equal(t, uploader.server.URL+"/attachments/hello.txt", attachments[1].ContentUrl)
+		assert.Equal(t, "text/plain", attachments[1].Type)
+		assert.Equal(t, uploader.server.URL+"/attachments/notes.md", attachments[2].ContentUrl)
+		assert.Equal(t, uploader.server.URL+"/attachments/report.csv", attachments[3].ContentUrl)
+		assert.Equal(t, "report.csv", attachments[3].Filename)
+
+		assert.Equal(t, map[string]string{
+			"/attachments/hello.txt":  "text/plain:hello",
+			"/attachments/notes.md":   "text/markdown:# Notes",
+			"/attachments/report.csv": "text/csv:a,b\n1,2\n",
+		}, uploader.objects)
+
+		// The caller's options are not modified
+		assert.Empty(t, additional.Attachments[1].ContentURL)
+	})
+
+	t.Run("Uploads are cleaned up when the send fails", func(t *testing.T) {
+		server := newFakeServer(t)
+		server.update(func(h *fakeHandlers) {
+			h.sendEmlEmail = func(ctx context.Context, req *pb.EmlMailRequest) (*pb.SendEmailResponse, error) {
+				return nil, status.Error(codes.Unavailable, "try again later")
+			}
+		})
+
+		uploader := newHTTPUploader(t)
+		config := server.config()
+		config.AttachmentUploader = uploader
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.SendEMLEmail(ctx, []byte("Subject: Test\r\n\r\nHello"), &sendlix.AdditionalOptions{
+			Attachments: []sendlix.Attachment{sendlix.NewAttachmentFromBytes("hello.txt", "text/plain", []byte("hello"))},
+		})
+		require.Error(t, err)
+
+		assert.Empty(t, uploader.objects)
+		assert.Equal(t, []string{"/attachments/hello.txt"}, uploader.deleted)
+	})
+
+	t.Run("Upload failure aborts the send", func(t *testing.T) {
+		server := newFakeServer(t)
+		uploader := newHTTPUploader(t)
+		uploader.failOn = "second.txt"
+
+		config := server.config()
+		config.AttachmentUploader = uploader
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.SendEmail(ctx, options, &sendlix.AdditionalOptions{
+			Attachments: []sendlix.Attachment{
+				sendlix.NewAttachmentFromBytes("first.txt", "text/plain", []byte("1")),
+				sendlix.NewAttachmentFromBytes("second.txt", "text/plain", []byte("2")),
+			},
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "storage quota exceeded")
+		assert.Contains(t, err.Error(), "second.txt")
+		assert.Equal(t, 0, server.count("SendEmail"))
+		assert.Equal(t, []string{"/attachments/first.txt"}, uploader.deleted)
+	})
+
+	t.Run("Invalid emails are rejected before uploading", func(t *testing.T) {
+		server := newFakeServer(t)
+		uploader := newHTTPUploader(t)
+
+		config := server.config()
+		config.AttachmentUploader = uploader
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		missingFrom := options
+		missingFrom.From = sendlix.EmailAddress{}
+		noRecipients := options
+		noRecipients.To = nil
+		noSubject := options
+		noSubject.Subject = ""
+
+		for name, invalid := range map[string]sendlix.MailOptions{
+			"missing sender":  missingFrom,
+			"no recipients":   noRecipients,
+			"missing subject": noSubject,
+		} {
+			_, err := client.SendEmail(ctx, invalid, &sendlix.AdditionalOptions{
+				Attachments: []sendlix.Attachment{sendlix.NewAttachmentFromBytes("hello.txt", "text/plain", []byte("hello"))},
+			})
+
+			var validationErr *sendlix.ValidationError
+			assert.True(t, errors.As(err, &validationErr), name)
+		}
+
+		_, err = client.SendEmail(ctx, options, &sendlix.AdditionalOptions{
+			SendAt:      &time.Time{},
+			Attachments: []sendlix.Attachment{sendlix.NewAttachmentFromBytes("hello.txt", "text/plain", []byte("hello"))},
+		})
+		assert.True(t, errors.Is(err, sendlix.ErrZeroSendAt))
+
+		assert.Zero(t, uploader.uploads)
+		assert.Empty(t, uploader.objects)
+		assert.Zero(t, server.count("SendEmail"))
+	})
+
+	t.Run("Content attachments require an uploader", func(t *testing.T) {
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, nil)
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.SendEmail(ctx, options, &sendlix.AdditionalOptions{
+			Attachments: []sendlix.Attachment{sendlix.NewAttachmentFromBytes("hello.txt", "text/plain", []byte("hello"))},
+		})
+
+		assert.True(t, errors.Is(err, sendlix.ErrMissingAttachmentUploader))
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		_, err := sendlix.NewAttachmentFromFile(filepath.Join(dir, "missing.pdf"), "application/pdf")
+
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, os.ErrNotExist))
+	})
+}
+
+func TestNewMessageAttachment(t *testing.T) {
+	original := "From: Jane Doe <jane@example.com>\r\n" +
+		"To: support@example.com\r\n" +
+		"Subject: Broken invoice\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n" +
+		"Content-Transfer-Encoding: 8bit\r\n" +
+		"\r\n" +
+		"Grüße, the invoice total is wrong.\r\n"
+
+	t.Run("Message is uploaded unmodified as message/rfc822", func(t *testing.T) {
+		server := newFakeServer(t)
+		var captured *pb.SendMailRequest
+		server.update(func(h *fakeHandlers) {
+			h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+				captured = req
+				return &pb.SendEmailResponse{Message: []string{"msg-1"}}, nil
+			}
+		})
+
+		uploader := newHTTPUploader(t)
+		config := server.config()
+		config.AttachmentUploader = uploader
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		attachment, err := sendlix.NewMessageAttachment([]byte(original), "")
+		require.NoError(t, err)
+		assert.Equal(t, "message.eml", attachment.Filename)
+		assert.Equal(t, "message/rfc822", attachment.ContentType)
+
+		_, err = client.SendEmail(context.Background(), sendlix.MailOptions{
+			From:    sendlix.EmailAddress{Email: "support@example.com"},
+			To:      []sendlix.EmailAddress{{Email: "billing@example.com"}},
+			Subject: "Fwd: Broken invoice",
+			Text:    "See the attached message",
+		}, &sendlix.AdditionalOptions{Attachments: []sendlix.Attachment{attachment}})
+		require.NoError(t, err)
+
+		require.Len(t, captured.AdditionalInfos.Attachments, 1)
+		assert.Equal(t, "message/rfc822", captured.AdditionalInfos.Attachments[0].Type)
+
+		stored := uploader.objects["/attachments/message.eml"]
+		body, ok := strings.CutPrefix(stored, "message/rfc822:")
+		require.True(t, ok)
+		assert.Equal(t, original, body, "message must not be re-encoded")
+
+		msg, err := mail.ReadMessage(strings.NewReader(body))
+		require.NoError(t, err)
+		assert.Equal(t, "Broken invoice", msg.Header.Get("Subject"))
+		text, err := io.ReadAll(msg.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "Grüße, the invoice total is wrong.\r\n", string(text))
+	})
+
+	t.Run("Invalid messages are rejected", func(t *testing.T) {
+		tests := []struct {
+			name string
+			eml  string
+		}{
+			{"Empty", ""},
+			{"Malformed header", "From jane@example.com\r\n\r\nHello"},
+			{"Missing From", "Subject: Hello\r\n\r\nHello"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := sendlix.NewMessageAttachment([]byte(tt.eml), "forwarded.eml")
+				assert.ErrorIs(t, err, sendlix.ErrInvalidMessageAttachment)
+			})
+		}
+	})
+}
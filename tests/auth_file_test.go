@@ -0,0 +1,136 @@
+package sendlix_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestNewAuthFromFile(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+
+	var mu sync.Mutex
+	var secrets []string
+	server.update(func(h *fakeHandlers) {
+		h.getJwtToken = func(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			secrets = append(secrets, req.GetApiKey().GetSecret())
+			return &pb.AuthResponse{Token: "token-" + req.GetApiKey().GetSecret(), Expires: timestamppb.New(time.Now().Add(time.Hour))}, nil
+		}
+	})
+
+	writeKey := func(t *testing.T, path, key string, modTime time.Time) {
+		require.NoError(t, os.WriteFile(path, []byte(key), 0o600))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+	header := func(t *testing.T, auth *sendlix.Auth) string {
+		_, value, err := auth.GetAuthHeader(ctx)
+		require.NoError(t, err)
+		return value
+	}
+	start := time.Now().Add(-time.Hour)
+
+	t.Run("Missing file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "api-key")
+		_, err := sendlix.NewAuthFromFile(path)
+
+		assert.ErrorIs(t, err, sendlix.ErrMissingAPIKeyFile)
+		assert.NotErrorIs(t, err, sendlix.ErrInvalidAPIKeyFormat)
+		assert.Equal(t, "API key file "+path+" does not exist", err.Error())
+	})
+
+	t.Run("Malformed key", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "api-key")
+		writeKey(t, path, "topsecret\n", start)
+		_, err := sendlix.NewAuthFromFile(path)
+
+		assert.ErrorIs(t, err, sendlix.ErrInvalidAPIKeyFormat)
+		assert.NotErrorIs(t, err, sendlix.ErrMissingAPIKeyFile)
+		assert.Contains(t, err.Error(), path)
+		assert.NotContains(t, err.Error(), "topsecret")
+
+		writeKey(t, path, "  \n", start)
+		_, err = sendlix.NewAuthFromFile(path)
+		assert.ErrorIs(t, err, sendlix.ErrInvalidAPIKeyFormat)
+	})
+
+	t.Run("Reload", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "api-key")
+		writeKey(t, path, "first.1\n", start)
+		auth, err := sendlix.NewAuthFromFile(path, sendlix.WithAuthConnection(server.dial(t)))
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer token-first", header(t, auth))
+
+		writeKey(t, path, "second.1\n", start.Add(time.Minute))
+		assert.Equal(t, "Bearer token-first", header(t, auth), "the file is not watched without WithKeyFileReload")
+
+		require.NoError(t, auth.Reload())
+		assert.Equal(t, "Bearer token-second", header(t, auth))
+
+		before := auth.Stats().Refreshes
+		require.NoError(t, auth.Reload())
+		header(t, auth)
+		assert.Equal(t, before, auth.Stats().Refreshes, "an unchanged key keeps the token")
+	})
+
+	t.Run("Failed reload keeps the key", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "api-key")
+		writeKey(t, path, "first.1", start)
+		auth, err := sendlix.NewAuthFromFile(path, sendlix.WithAuthConnection(server.dial(t)))
+		require.NoError(t, err)
+
+		writeKey(t, path, "first.x", start.Add(time.Minute))
+		assert.ErrorIs(t, auth.Reload(), sendlix.ErrInvalidKeyID)
+		require.NoError(t, os.Remove(path))
+		assert.ErrorIs(t, auth.Reload(), sendlix.ErrMissingAPIKeyFile)
+		assert.Equal(t, "Bearer token-first", header(t, auth))
+	})
+
+	t.Run("Reload on modification", func(t *testing.T) {
+		var clockMu sync.Mutex
+		now := time.Now()
+		clock := func() time.Time {
+			clockMu.Lock()
+			defer clockMu.Unlock()
+			return now
+		}
+		advance := func(d time.Duration) {
+			clockMu.Lock()
+			defer clockMu.Unlock()
+			now = now.Add(d)
+		}
+
+		path := filepath.Join(t.TempDir(), "api-key")
+		writeKey(t, path, "first.1", start)
+		auth, err := sendlix.NewAuthFromFile(path, sendlix.WithAuthConnection(server.dial(t)),
+			sendlix.WithKeyFileReload(time.Minute), sendlix.WithAuthClock(clock))
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer token-first", header(t, auth))
+
+		writeKey(t, path, "second.1", start.Add(time.Minute))
+		advance(30 * time.Second)
+		assert.Equal(t, "Bearer token-first", header(t, auth), "checked at most once per interval")
+
+		advance(30 * time.Second)
+		assert.Equal(t, "Bearer token-second", header(t, auth))
+
+		writeKey(t, path, "broken", start.Add(2*time.Minute))
+		advance(time.Minute)
+		assert.Equal(t, "Bearer token-second", header(t, auth), "a malformed key is ignored")
+
+		writeKey(t, path, "third.1", start.Add(3*time.Minute))
+		advance(time.Minute)
+		assert.Equal(t, "Bearer token-third", header(t, auth))
+	})
+}
@@ -0,0 +1,110 @@
+package sendlix_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// recordingObserver records the sequence of AuthObserver callbacks.
+type recordingObserver struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (o *recordingObserver) record(event string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, event)
+}
+
+func (o *recordingObserver) OnTokenRefreshStart() { o.record("start") }
+
+func (o *recordingObserver) OnTokenRefreshSuccess(duration time.Duration, expiresAt time.Time) {
+	o.record("success")
+}
+
+func (o *recordingObserver) OnTokenRefreshFailure(duration time.Duration, err error) {
+	o.record("failure")
+}
+
+func (o *recordingObserver) OnTokenServedFromCache(tokenAge time.Duration) { o.record("cache") }
+
+func TestAuthStats(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Counters and callback sequence", func(t *testing.T) {
+		server := newFakeServer(t)
+		calls := 0
+		server.update(func(h *fakeHandlers) {
+			h.getJwtToken = func(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error) {
+				calls++
+				switch calls {
+				case 1:
+					return nil, status.Error(codes.Unavailable, "auth service down")
+				case 2:
+					// Already expired, so the next call refreshes again
+					return &pb.AuthResponse{Token: "expired", Expires: timestamppb.New(time.Now().Add(-time.Second))}, nil
+				default:
+					return &pb.AuthResponse{Token: "valid", Expires: timestamppb.New(time.Now().Add(time.Hour))}, nil
+				}
+			}
+		})
+
+		observer := &recordingObserver{}
+		auth, err := sendlix.NewAuth("secret.123", sendlix.WithAuthConnection(server.dial(t)), sendlix.WithAuthObserver(observer))
+		require.NoError(t, err)
+
+		assert.Equal(t, sendlix.AuthStats{}, auth.Stats())
+
+		_, _, err = auth.GetAuthHeader(ctx)
+		require.Error(t, err)
+
+		for i := 0; i < 4; i++ {
+			_, value, err := auth.GetAuthHeader(ctx)
+			require.NoError(t, err)
+			if i > 0 {
+				assert.Equal(t, "Bearer valid", value)
+			}
+		}
+
+		stats := auth.Stats()
+		assert.Equal(t, uint64(2), stats.Refreshes)
+		assert.Equal(t, uint64(2), stats.CacheHits)
+		assert.Equal(t, uint64(1), stats.Failures)
+		require.Error(t, stats.LastError)
+		assert.Contains(t, stats.LastError.Error(), "auth service down")
+
+		assert.Equal(t, []string{"start", "failure", "start", "success", "start", "success", "cache", "cache"}, observer.events)
+	})
+
+	t.Run("Concurrent reads", func(t *testing.T) {
+		server := newFakeServer(t)
+		auth, err := sendlix.NewAuth("secret.123", sendlix.WithAuthConnection(server.dial(t)))
+		require.NoError(t, err)
+
+		_, _, err = auth.GetAuthHeader(ctx)
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = auth.Stats()
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, uint64(1), auth.Stats().Refreshes)
+	})
+}
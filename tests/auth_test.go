@@ -2,10 +2,23 @@ package sendlix_test
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestNewAuth(t *testing.T) {
@@ -44,10 +57,21 @@ func TestNewAuth(t *testing.T) {
 			errorMsg:    "invalid key ID",
 		},
 		{
-			name:        "Invalid format - multiple dots",
+			name:        "Valid API key - dots in secret",
 			apiKey:      "secret.123.456",
+			expectError: false,
+		},
+		{
+			name:        "Invalid format - dots in secret, non-numeric keyID",
+			apiKey:      "secret.123.abc",
 			expectError: true,
-			errorMsg:    "invalid API key format",
+			errorMsg:    "invalid key ID",
+		},
+		{
+			name:        "Invalid format - dots in secret, empty keyID",
+			apiKey:      "secret.123.",
+			expectError: true,
+			errorMsg:    "invalid key ID",
 		},
 	}
 
@@ -114,3 +138,930 @@ func TestAuthInterface(t *testing.T) {
 	assert.Equal(t, "authorization", key)
 	assert.Equal(t, "Bearer test", value)
 }
+
+func TestAuthConcurrentRefresh(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+
+	var mu sync.Mutex
+	lifetime := time.Hour
+	server.update(func(h *fakeHandlers) {
+		h.getJwtToken = func(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error) {
+			// Keep the exchange in flight long enough for all goroutines to pile up
+			time.Sleep(50 * time.Millisecond)
+			mu.Lock()
+			defer mu.Unlock()
+			return &pb.AuthResponse{Token: "shared", Expires: timestamppb.New(time.Now().Add(lifetime))}, nil
+		}
+	})
+
+	auth, err := sendlix.NewAuth("secret.123", sendlix.WithAuthConnection(server.dial(t)), sendlix.WithRefreshMargin(0))
+	require.NoError(t, err)
+
+	getHeaders := func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, value, err := auth.GetAuthHeader(ctx)
+				assert.NoError(t, err)
+				assert.Equal(t, "Bearer shared", value)
+			}()
+		}
+		wg.Wait()
+	}
+
+	t.Run("One exchange for concurrent callers", func(t *testing.T) {
+		mu.Lock()
+		lifetime = 200 * time.Millisecond
+		mu.Unlock()
+
+		getHeaders()
+		assert.Equal(t, 1, server.count("GetJwtToken"))
+		assert.Equal(t, uint64(1), auth.Stats().Refreshes)
+		assert.Equal(t, uint64(99), auth.Stats().CacheHits)
+	})
+
+	t.Run("One exchange per expiry window", func(t *testing.T) {
+		time.Sleep(250 * time.Millisecond)
+
+		getHeaders()
+		assert.Equal(t, 2, server.count("GetJwtToken"))
+	})
+
+	t.Run("Shared clients", func(t *testing.T) {
+		time.Sleep(250 * time.Millisecond)
+		mu.Lock()
+		lifetime = time.Hour
+		mu.Unlock()
+
+		emailClient, err := sendlix.NewEmailClient(auth, server.config())
+		require.NoError(t, err)
+		defer emailClient.Close()
+		groupClient, err := sendlix.NewGroupClient(auth, server.config())
+		require.NoError(t, err)
+		defer groupClient.Close()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_, err := groupClient.CheckEmailInGroup(ctx, "group-1", "user@example.com")
+				assert.NoError(t, err)
+			}()
+			go func() {
+				defer wg.Done()
+				_, err := emailClient.SendEmail(ctx, sendlix.MailOptions{
+					From:    sendlix.EmailAddress{Email: "sender@example.com"},
+					To:      []sendlix.EmailAddress{{Email: "recipient@example.com"}},
+					Subject: "Hello",
+					Text:    "Hello",
+				}, nil)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, 3, server.count("GetJwtToken"))
+	})
+
+	t.Run("Waiting callers honor their context", func(t *testing.T) {
+		fresh, err := sendlix.NewAuth("secret.123", sendlix.WithAuthConnection(server.dial(t)))
+		require.NoError(t, err)
+
+		go fresh.GetAuthHeader(ctx)
+		time.Sleep(10 * time.Millisecond)
+
+		waitCtx, cancel := context.WithTimeout(ctx, 5*time.Millisecond)
+		defer cancel()
+		_, _, err = fresh.GetAuthHeader(waitCtx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("Waiting callers outlive the caller that started the exchange", func(t *testing.T) {
+		blocked := newFakeServer(t)
+		started, release := make(chan struct{}), make(chan struct{})
+		blocked.update(func(h *fakeHandlers) {
+			h.getJwtToken = func(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error) {
+				close(started)
+				<-release
+				return &pb.AuthResponse{Token: "shared", Expires: timestamppb.New(time.Now().Add(time.Hour))}, nil
+			}
+		})
+		fresh, err := sendlix.NewAuth("secret.123", sendlix.WithAuthConnection(blocked.dial(t)))
+		require.NoError(t, err)
+
+		leaderCtx, cancelLeader := context.WithCancel(ctx)
+		leader := make(chan error)
+		go func() {
+			_, _, err := fresh.GetAuthHeader(leaderCtx)
+			leader <- err
+		}()
+		<-started
+
+		waiter := make(chan string)
+		go func() {
+			_, value, err := fresh.GetAuthHeader(ctx)
+			assert.NoError(t, err)
+			waiter <- value
+		}()
+
+		cancelLeader()
+		assert.ErrorIs(t, <-leader, context.Canceled)
+
+		close(release)
+		assert.Equal(t, "Bearer shared", <-waiter)
+		assert.Equal(t, 1, blocked.count("GetJwtToken"))
+		assert.True(t, fresh.HasValidToken())
+	})
+}
+
+func TestAuthRefreshMargin(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+
+	var mu sync.Mutex
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	lifetime := 10 * time.Minute
+	clock := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	advance := func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = now.Add(d)
+	}
+	server.update(func(h *fakeHandlers) {
+		h.getJwtToken = func(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			return &pb.AuthResponse{Token: "token", Expires: timestamppb.New(now.Add(lifetime))}, nil
+		}
+	})
+
+	tests := []struct {
+		name     string
+		opts     []sendlix.AuthOption
+		lifetime time.Duration
+		cached   time.Duration // Latest age at which the token is reused
+		stale    time.Duration // Earliest age at which the token is refreshed
+	}{
+		{name: "Default margin", lifetime: 10 * time.Minute, cached: 9*time.Minute + 29*time.Second, stale: 9*time.Minute + 30*time.Second},
+		{name: "Custom margin", opts: []sendlix.AuthOption{sendlix.WithRefreshMargin(2 * time.Minute)}, lifetime: 10 * time.Minute, cached: 7*time.Minute + 59*time.Second, stale: 8 * time.Minute},
+		{name: "No margin", opts: []sendlix.AuthOption{sendlix.WithRefreshMargin(0)}, lifetime: 10 * time.Minute, cached: 10*time.Minute - time.Second, stale: 10 * time.Minute},
+		{name: "Margin capped for short-lived tokens", lifetime: 40 * time.Second, cached: 19 * time.Second, stale: 20 * time.Second},
+		{name: "Clock skew", opts: []sendlix.AuthOption{sendlix.WithRefreshMargin(0), sendlix.WithClockSkew(2 * time.Minute)}, lifetime: 10 * time.Minute, cached: 7*time.Minute + 59*time.Second, stale: 8 * time.Minute},
+		{name: "Clock skew and margin", opts: []sendlix.AuthOption{sendlix.WithClockSkew(time.Minute)}, lifetime: 10 * time.Minute, cached: 8*time.Minute + 29*time.Second, stale: 8*time.Minute + 30*time.Second},
+		{name: "Clock skew not capped", opts: []sendlix.AuthOption{sendlix.WithClockSkew(15 * time.Second)}, lifetime: 40 * time.Second, cached: 4 * time.Second, stale: 5 * time.Second},
+		{name: "Negative clock skew", opts: []sendlix.AuthOption{sendlix.WithRefreshMargin(0), sendlix.WithClockSkew(-time.Minute)}, lifetime: 10 * time.Minute, cached: 10*time.Minute - time.Second, stale: 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu.Lock()
+			lifetime = tt.lifetime
+			mu.Unlock()
+
+			opts := append([]sendlix.AuthOption{sendlix.WithAuthConnection(server.dial(t)), sendlix.WithAuthClock(clock)}, tt.opts...)
+			auth, err := sendlix.NewAuth("secret.123", opts...)
+			require.NoError(t, err)
+
+			_, _, err = auth.GetAuthHeader(ctx)
+			require.NoError(t, err)
+			start := server.count("GetJwtToken")
+
+			advance(tt.cached)
+			_, _, err = auth.GetAuthHeader(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, start, server.count("GetJwtToken"))
+
+			advance(tt.stale - tt.cached)
+			_, _, err = auth.GetAuthHeader(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, start+1, server.count("GetJwtToken"))
+			assert.Equal(t, uint64(2), auth.Stats().Refreshes)
+		})
+	}
+}
+
+func TestAuthInvalidateToken(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+
+	var mu sync.Mutex
+	issued := 0
+	accepted := ""
+	server.update(func(h *fakeHandlers) {
+		h.getJwtToken = func(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			issued++
+			return &pb.AuthResponse{Token: "token-" + strconv.Itoa(issued), Expires: timestamppb.New(time.Now().Add(time.Hour))}, nil
+		}
+		h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			md, _ := metadata.FromIncomingContext(ctx)
+			if accepted != "" && md.Get("authorization")[0] != "Bearer "+accepted {
+				return nil, status.Error(codes.Unauthenticated, "token revoked")
+			}
+			return &pb.SendEmailResponse{Message: []string{"msg-1"}}, nil
+		}
+	})
+	accept := func(token string) {
+		mu.Lock()
+		defer mu.Unlock()
+		accepted = token
+	}
+
+	options := sendlix.MailOptions{
+		From:    sendlix.EmailAddress{Email: "sender@example.com"},
+		To:      []sendlix.EmailAddress{{Email: "recipient@example.com"}},
+		Subject: "Hello",
+		Text:    "Hello",
+	}
+
+	t.Run("Next header uses a new token", func(t *testing.T) {
+		auth, err := sendlix.NewAuth("secret.123", sendlix.WithAuthConnection(server.dial(t)))
+		require.NoError(t, err)
+
+		_, first, err := auth.GetAuthHeader(ctx)
+		require.NoError(t, err)
+		auth.InvalidateToken()
+		_, second, err := auth.GetAuthHeader(ctx)
+		require.NoError(t, err)
+		assert.NotEqual(t, first, second)
+		assert.Equal(t, uint64(2), auth.Stats().Refreshes)
+	})
+
+	t.Run("Store is bypassed", func(t *testing.T) {
+		store := sendlix.NewMemoryTokenStore()
+		store.Set(ctx, "stored", time.Now().Add(time.Hour))
+		auth, err := sendlix.NewAuth("secret.123", sendlix.WithAuthConnection(server.dial(t)), sendlix.WithTokenStore(store))
+		require.NoError(t, err)
+
+		_, value, err := auth.GetAuthHeader(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer stored", value)
+
+		auth.InvalidateToken()
+		_, value, err = auth.GetAuthHeader(ctx)
+		require.NoError(t, err)
+		assert.NotEqual(t, "Bearer stored", value)
+		token, _, _ := store.Get(ctx)
+		assert.Equal(t, value, "Bearer "+token)
+	})
+
+	t.Run("Rejected calls are retried once", func(t *testing.T) {
+		auth, err := sendlix.NewAuth("secret.123", sendlix.WithAuthConnection(server.dial(t)))
+		require.NoError(t, err)
+		client, err := sendlix.NewEmailClient(auth, server.config())
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.SendEmail(ctx, options, nil)
+		require.NoError(t, err)
+		mu.Lock()
+		next := "token-" + strconv.Itoa(issued+1)
+		mu.Unlock()
+
+		accept(next)
+		before := server.count("SendEmail")
+		_, err = client.SendEmail(ctx, options, nil)
+		require.NoError(t, err)
+		assert.Equal(t, before+2, server.count("SendEmail"))
+
+		_, value, err := auth.GetAuthHeader(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer "+next, value)
+	})
+
+	t.Run("Persistent rejection", func(t *testing.T) {
+		auth, err := sendlix.NewAuth("secret.123", sendlix.WithAuthConnection(server.dial(t)))
+		require.NoError(t, err)
+		client, err := sendlix.NewEmailClient(auth, server.config())
+		require.NoError(t, err)
+		defer client.Close()
+
+		accept("never-issued")
+		before := server.count("SendEmail")
+		_, err = client.SendEmail(ctx, options, nil)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		assert.Equal(t, before+2, server.count("SendEmail"))
+	})
+
+	t.Run("Static tokens are not retried", func(t *testing.T) {
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "static"}, server.config())
+		require.NoError(t, err)
+		defer client.Close()
+
+		before := server.count("SendEmail")
+		_, err = client.SendEmail(ctx, options, nil)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		assert.Equal(t, before+1, server.count("SendEmail"))
+	})
+}
+
+func TestAuthTokenRefreshHook(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+
+	type refresh struct {
+		expiresAt time.Time
+		err       error
+	}
+	var mu sync.Mutex
+	var refreshes []refresh
+	record := func(expiresAt time.Time, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		refreshes = append(refreshes, refresh{expiresAt, err})
+	}
+	reset := func() []refresh {
+		mu.Lock()
+		defer mu.Unlock()
+		result := refreshes
+		refreshes = nil
+		return result
+	}
+
+	expires := time.Now().Add(time.Hour).Truncate(time.Second)
+	server.update(func(h *fakeHandlers) {
+		h.getJwtToken = func(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error) {
+			if req.GetApiKey().GetSecret() == "wrong" {
+				return nil, status.Error(codes.Unauthenticated, "invalid key")
+			}
+			return &pb.AuthResponse{Token: "token", Expires: timestamppb.New(expires)}, nil
+		}
+	})
+
+	t.Run("Called for exchanges only", func(t *testing.T) {
+		auth, err := sendlix.NewAuth("secret.123", sendlix.WithAuthConnection(server.dial(t)), sendlix.WithTokenRefreshHook(record))
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			_, _, err = auth.GetAuthHeader(ctx)
+			require.NoError(t, err)
+		}
+		got := reset()
+		require.Len(t, got, 1)
+		assert.True(t, expires.Equal(got[0].expiresAt))
+		assert.NoError(t, got[0].err)
+	})
+
+	t.Run("Failed exchanges", func(t *testing.T) {
+		auth, err := sendlix.NewAuth("wrong.123", sendlix.WithAuthConnection(server.dial(t)), sendlix.WithTokenRefreshHook(record))
+		require.NoError(t, err)
+
+		_, _, err = auth.GetAuthHeader(ctx)
+		require.Error(t, err)
+		got := reset()
+		require.Len(t, got, 1)
+		assert.True(t, got[0].expiresAt.IsZero())
+		assert.Equal(t, err, got[0].err)
+	})
+
+	t.Run("Store hits", func(t *testing.T) {
+		store := sendlix.NewMemoryTokenStore()
+		store.Set(ctx, "stored", time.Now().Add(time.Hour))
+		auth, err := sendlix.NewAuth("secret.123", sendlix.WithAuthConnection(server.dial(t)),
+			sendlix.WithTokenStore(store), sendlix.WithTokenRefreshHook(record))
+		require.NoError(t, err)
+
+		_, _, err = auth.GetAuthHeader(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, reset())
+	})
+
+	t.Run("Slow hooks do not block other callers", func(t *testing.T) {
+		release := make(chan struct{})
+		called := make(chan struct{})
+		auth, err := sendlix.NewAuth("secret.123", sendlix.WithAuthConnection(server.dial(t)),
+			sendlix.WithTokenRefreshHook(func(time.Time, error) {
+				close(called)
+				<-release
+			}))
+		require.NoError(t, err)
+
+		done := make(chan error)
+		go func() {
+			_, _, err := auth.GetAuthHeader(ctx)
+			done <- err
+		}()
+		<-called
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, value, err := auth.GetAuthHeader(ctx)
+				assert.NoError(t, err)
+				assert.Equal(t, "Bearer token", value)
+			}()
+		}
+		wg.Wait()
+
+		select {
+		case <-done:
+			t.Fatal("caller returned before its hook")
+		default:
+		}
+		close(release)
+		assert.NoError(t, <-done)
+	})
+}
+
+func TestAuthTokenFetchTimeout(t *testing.T) {
+	server := newFakeServer(t)
+	server.update(func(h *fakeHandlers) {
+		h.getJwtToken = func(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error) {
+			// Never respond
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+	})
+
+	t.Run("Bounded without deadline", func(t *testing.T) {
+		auth, err := sendlix.NewAuth("secret.123", sendlix.WithAuthConnection(server.dial(t)), sendlix.WithTokenFetchTimeout(50*time.Millisecond))
+		require.NoError(t, err)
+
+		start := time.Now()
+		_, _, err = auth.GetAuthHeader(context.Background())
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		assert.Equal(t, sendlix.CodeDeadlineExceeded, sendlix.Code(err))
+	})
+
+	t.Run("Earlier parent cancellation", func(t *testing.T) {
+		auth, err := sendlix.NewAuth("secret.123", sendlix.WithAuthConnection(server.dial(t)))
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(20*time.Millisecond, cancel)
+		start := time.Now()
+		_, _, err = auth.GetAuthHeader(ctx)
+		assert.Less(t, time.Since(start), time.Second)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, sendlix.CodeCanceled, sendlix.Code(err))
+	})
+
+	t.Run("Sends fail instead of hanging", func(t *testing.T) {
+		auth, err := sendlix.NewAuth("secret.123", sendlix.WithAuthConnection(server.dial(t)), sendlix.WithTokenFetchTimeout(50*time.Millisecond))
+		require.NoError(t, err)
+		client, err := sendlix.NewEmailClient(auth, server.config())
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.SendEmail(context.Background(), sendlix.MailOptions{
+			From:    sendlix.EmailAddress{Email: "sender@example.com"},
+			To:      []sendlix.EmailAddress{{Email: "recipient@example.com"}},
+			Subject: "Hello",
+			Text:    "Hello",
+		}, nil)
+		assert.ErrorIs(t, err, sendlix.ErrAuthFailed)
+		assert.Zero(t, server.count("SendEmail"))
+	})
+}
+
+func TestAuthVerify(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+	server.update(func(h *fakeHandlers) {
+		h.getJwtToken = func(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error) {
+			switch req.GetApiKey().GetKeyID() {
+			case 2:
+				return nil, status.Error(codes.Unauthenticated, "invalid key")
+			case 3:
+				return nil, status.Error(codes.Unavailable, "down")
+			}
+			return &pb.AuthResponse{Token: "token", Expires: timestamppb.New(time.Now().Add(time.Hour))}, nil
+		}
+	})
+	conn := server.dial(t)
+
+	t.Run("Accepted key", func(t *testing.T) {
+		auth, err := sendlix.NewAuth("secret.1", sendlix.WithAuthConnection(conn))
+		require.NoError(t, err)
+		calls := server.count("GetJwtToken")
+
+		require.NoError(t, auth.Verify(ctx))
+		require.NoError(t, auth.Verify(ctx))
+		assert.Equal(t, calls+2, server.count("GetJwtToken"), "Verify always exchanges the key")
+
+		_, value, err := auth.GetAuthHeader(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer token", value)
+		assert.Equal(t, calls+2, server.count("GetJwtToken"), "the verified token is cached")
+	})
+
+	t.Run("Rejected key", func(t *testing.T) {
+		auth, err := sendlix.NewAuth("secret.2", sendlix.WithAuthConnection(conn))
+		require.NoError(t, err)
+
+		err = auth.Verify(ctx)
+		assert.ErrorIs(t, err, sendlix.ErrInvalidCredentials)
+		assert.NotErrorIs(t, err, sendlix.ErrAuthUnreachable)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		assert.Equal(t, sendlix.CodeInvalidCredentials, sendlix.Code(err))
+	})
+
+	t.Run("Unreachable service", func(t *testing.T) {
+		auth, err := sendlix.NewAuth("secret.3", sendlix.WithAuthConnection(conn))
+		require.NoError(t, err)
+
+		err = auth.Verify(ctx)
+		assert.ErrorIs(t, err, sendlix.ErrAuthUnreachable)
+		assert.NotErrorIs(t, err, sendlix.ErrInvalidCredentials)
+		assert.Equal(t, sendlix.CodeAuthUnreachable, sendlix.Code(err))
+	})
+
+	t.Run("Verify on create", func(t *testing.T) {
+		auth, err := sendlix.NewAuth("secret.1", sendlix.WithAuthConnection(conn), sendlix.WithVerifyOnCreate())
+		require.NoError(t, err)
+		assert.True(t, auth.HasValidToken())
+
+		_, err = sendlix.NewAuth("secret.2", sendlix.WithAuthConnection(conn), sendlix.WithVerifyOnCreate())
+		assert.ErrorIs(t, err, sendlix.ErrInvalidCredentials)
+
+		_, err = sendlix.NewAuth("secret.3", sendlix.WithAuthConnection(conn), sendlix.WithVerifyOnCreate())
+		assert.ErrorIs(t, err, sendlix.ErrAuthUnreachable)
+	})
+
+	t.Run("MultiAuth", func(t *testing.T) {
+		multi, err := sendlix.NewMultiAuthWithOptions([]string{"secret.2", "secret.1"}, sendlix.WithAuthConnection(conn), sendlix.WithVerifyOnCreate())
+		require.NoError(t, err, "a rejected fallback key does not fail construction")
+		defer multi.Close()
+		assert.Equal(t, int64(1), multi.ActiveKeyID())
+
+		_, err = sendlix.NewMultiAuthWithOptions([]string{"secret.2", "secret.2"}, sendlix.WithAuthConnection(conn), sendlix.WithVerifyOnCreate())
+		assert.ErrorIs(t, err, sendlix.ErrInvalidCredentials)
+	})
+}
+
+func TestAuthTokenExpiry(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	var mu sync.Mutex
+	clock := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	expires := now.Add(10 * time.Minute)
+	server.update(func(h *fakeHandlers) {
+		h.getJwtToken = func(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error) {
+			return &pb.AuthResponse{Token: "token", Expires: timestamppb.New(expires)}, nil
+		}
+	})
+
+	auth, err := sendlix.NewAuth("secret.123", sendlix.WithAuthConnection(server.dial(t)), sendlix.WithAuthClock(clock))
+	require.NoError(t, err)
+
+	expiry, ok := auth.TokenExpiry()
+	assert.False(t, ok)
+	assert.True(t, expiry.IsZero())
+	assert.False(t, auth.HasValidToken())
+	assert.Zero(t, server.count("GetJwtToken"), "no token is requested")
+
+	_, _, err = auth.GetAuthHeader(ctx)
+	require.NoError(t, err)
+	expiry, ok = auth.TokenExpiry()
+	assert.True(t, ok)
+	assert.True(t, expires.Equal(expiry))
+	assert.True(t, auth.HasValidToken())
+
+	mu.Lock()
+	now = expires.Add(-sendlix.DefaultRefreshMargin)
+	mu.Unlock()
+	assert.False(t, auth.HasValidToken(), "tokens within the refresh margin are not valid")
+	_, ok = auth.TokenExpiry()
+	assert.True(t, ok)
+
+	auth.InvalidateToken()
+	_, ok = auth.TokenExpiry()
+	assert.False(t, ok)
+	assert.Equal(t, 1, server.count("GetJwtToken"))
+}
+
+func TestAuthRedaction(t *testing.T) {
+	const secret = "s3cr3t-value-xyz"
+	auth, err := sendlix.NewAuth(secret + ".123")
+	require.NoError(t, err)
+	defer auth.Close()
+
+	t.Run("Formatting", func(t *testing.T) {
+		assert.Equal(t, "Auth(keyID=123, secret=s3cr…)", auth.String())
+		assert.Equal(t, `&sendlix.Auth{keyID:123, secret:"s3cr…"}`, fmt.Sprintf("%#v", auth))
+
+		owned := sendlix.OwnedAuth(auth)
+		for _, verb := range []string{"%v", "%+v", "%#v", "%s", "%q"} {
+			for _, value := range []any{auth, owned, []*sendlix.Auth{auth}, struct{ Auth *sendlix.Auth }{auth}} {
+				out := fmt.Sprintf(verb, value)
+				assert.NotContains(t, out, secret, "%s of %T", verb, value)
+				assert.NotContains(t, out, "cr3t", "%s of %T", verb, value)
+			}
+		}
+	})
+
+	t.Run("Short secrets are fully masked", func(t *testing.T) {
+		short, err := sendlix.NewAuth("abc.7")
+		require.NoError(t, err)
+		defer short.Close()
+		assert.Equal(t, "Auth(keyID=7, secret=…)", short.String())
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		server := newFakeServer(t)
+		server.update(func(h *fakeHandlers) {
+			h.getJwtToken = func(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error) {
+				return nil, status.Error(codes.Unauthenticated, "invalid API key")
+			}
+		})
+		auth, err := sendlix.NewAuth(secret+".123", sendlix.WithAuthConnection(server.dial(t)))
+		require.NoError(t, err)
+
+		_, _, err = auth.GetAuthHeader(context.Background())
+		require.Error(t, err)
+		assert.NotContains(t, fmt.Sprintf("%+v", err), secret)
+		assert.NotContains(t, auth.Stats().LastError.Error(), secret)
+
+		_, err = sendlix.NewAuth("my.secret.value")
+		assert.ErrorIs(t, err, sendlix.ErrInvalidKeyID)
+		assert.Equal(t, "invalid key ID: invalid syntax", err.Error())
+	})
+}
+
+func TestAuthClose(t *testing.T) {
+	t.Run("Closes its own connection once", func(t *testing.T) {
+		auth, err := sendlix.NewAuth("secret.123")
+		require.NoError(t, err)
+
+		require.NoError(t, auth.Close())
+		assert.Equal(t, connectivity.Shutdown, auth.GetConnection().GetState())
+		assert.NoError(t, auth.Close(), "second Close")
+	})
+
+	t.Run("Leaves a provided connection open", func(t *testing.T) {
+		server := newFakeServer(t)
+		conn := server.dial(t)
+		auth, err := sendlix.NewAuth("secret.123", sendlix.WithAuthConnection(conn))
+		require.NoError(t, err)
+
+		require.NoError(t, auth.Close())
+		assert.NotEqual(t, connectivity.Shutdown, conn.GetState())
+	})
+
+	t.Run("Owned auth is closed with the client", func(t *testing.T) {
+		auth, err := sendlix.NewAuth("secret.123")
+		require.NoError(t, err)
+
+		client, err := sendlix.NewEmailClient(sendlix.OwnedAuth(auth), nil)
+		require.NoError(t, err)
+		assert.NotEqual(t, connectivity.Shutdown, auth.GetConnection().GetState())
+
+		require.NoError(t, client.Close())
+		assert.Equal(t, connectivity.Shutdown, auth.GetConnection().GetState())
+	})
+
+	t.Run("Shared auth is not closed with the client", func(t *testing.T) {
+		auth, err := sendlix.NewAuth("secret.123")
+		require.NoError(t, err)
+		defer auth.Close()
+
+		client, err := sendlix.NewGroupClient(auth, nil)
+		require.NoError(t, err)
+		require.NoError(t, client.Close())
+		assert.NotEqual(t, connectivity.Shutdown, auth.GetConnection().GetState())
+	})
+
+	t.Run("No goroutine growth", func(t *testing.T) {
+		runtime.GC()
+		before := runtime.NumGoroutine()
+		for i := 0; i < 200; i++ {
+			auth, err := sendlix.NewAuth("secret.123")
+			require.NoError(t, err)
+			require.NoError(t, auth.Close())
+		}
+
+		// Connection goroutines exit asynchronously after Close
+		assert.Eventually(t, func() bool {
+			return runtime.NumGoroutine() <= before+5
+		}, 2*time.Second, 20*time.Millisecond)
+	})
+
+	t.Run("Failed constructors close the auth created from a key", func(t *testing.T) {
+		badCompression := &sendlix.ClientConfig{Compression: "nope"}
+		badRetryPolicy := &sendlix.ClientConfig{RetryPolicy: &sendlix.RetryPolicy{MaxAttempts: -1}}
+
+		runtime.GC()
+		before := runtime.NumGoroutine()
+		for i := 0; i < 20; i++ {
+			for _, config := range []*sendlix.ClientConfig{badCompression, badRetryPolicy} {
+				_, err := sendlix.NewEmailClient("secret.123", config)
+				require.Error(t, err)
+				_, err = sendlix.NewGroupClient("secret.123", config)
+				require.Error(t, err)
+			}
+		}
+
+		assert.Eventually(t, func() bool {
+			return runtime.NumGoroutine() <= before+5
+		}, 2*time.Second, 20*time.Millisecond, "%d goroutines leaked", runtime.NumGoroutine()-before)
+	})
+
+	t.Run("Failed constructors leave auth of the caller open", func(t *testing.T) {
+		auth, err := sendlix.NewAuth("secret.123")
+		require.NoError(t, err)
+		defer auth.Close()
+
+		_, err = sendlix.NewEmailClient(sendlix.OwnedAuth(auth), &sendlix.ClientConfig{Compression: "nope"})
+		require.Error(t, err)
+		assert.NotEqual(t, connectivity.Shutdown, auth.GetConnection().GetState())
+	})
+}
+
+func TestNewAuthWithConfig(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+
+	t.Run("Token exchange uses the configured server", func(t *testing.T) {
+		auth, err := sendlix.NewAuthWithConfig("secret.123", server.config())
+		require.NoError(t, err)
+		defer auth.Close()
+
+		_, value, err := auth.GetAuthHeader(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer fake-token", value)
+		assert.Equal(t, 1, server.count("GetJwtToken"))
+	})
+
+	t.Run("API key clients use the client configuration", func(t *testing.T) {
+		before := server.count("GetJwtToken")
+
+		client, err := sendlix.NewGroupClient("secret.123", server.config())
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.CheckEmailInGroup(ctx, "group-1", "user@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, before+1, server.count("GetJwtToken"))
+	})
+
+	t.Run("Secrets with dots", func(t *testing.T) {
+		var got *pb.ApiKey
+		server.update(func(h *fakeHandlers) {
+			h.getJwtToken = func(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error) {
+				got = req.GetApiKey()
+				return &pb.AuthResponse{Token: "fake-token", Expires: timestamppb.New(time.Now().Add(time.Hour))}, nil
+			}
+		})
+
+		auth, err := sendlix.NewAuthWithConfig("s3cr.et.42", server.config())
+		require.NoError(t, err)
+		defer auth.Close()
+
+		_, _, err = auth.GetAuthHeader(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, "s3cr.et", got.Secret)
+		assert.Equal(t, int64(42), got.KeyID)
+	})
+
+	t.Run("TLS settings apply to both connections", func(t *testing.T) {
+		// The fake server's certificate is not signed by a trusted CA
+		verifying := server.config()
+		verifying.Insecure = false
+
+		auth, err := sendlix.NewAuthWithConfig("secret.123", verifying)
+		require.NoError(t, err)
+		defer auth.Close()
+		_, _, err = auth.GetAuthHeader(ctx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "certificate")
+
+		client, err := sendlix.NewGroupClient(&MockAuth{Token: "test"}, verifying)
+		require.NoError(t, err)
+		defer client.Close()
+		_, err = client.CheckEmailInGroup(ctx, "group-1", "user@example.com")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "certificate")
+
+		// Both connections succeed with the same configuration once
+		// verification is skipped
+		auth, err = sendlix.NewAuthWithConfig("secret.123", server.config())
+		require.NoError(t, err)
+		defer auth.Close()
+		client, err = sendlix.NewGroupClient(auth, server.config())
+		require.NoError(t, err)
+		defer client.Close()
+		_, err = client.CheckEmailInGroup(ctx, "group-1", "user@example.com")
+		require.NoError(t, err)
+	})
+
+	t.Run("Validation errors", func(t *testing.T) {
+		_, err := sendlix.NewAuthWithConfig("invalid", server.config())
+		assert.ErrorIs(t, err, sendlix.ErrInvalidAPIKeyFormat)
+	})
+}
+
+func TestNewAuthFromEnv(t *testing.T) {
+	t.Run("Unset", func(t *testing.T) {
+		t.Setenv(sendlix.APIKeyEnvVar, "")
+		os.Unsetenv(sendlix.APIKeyEnvVar)
+
+		auth, err := sendlix.NewAuthFromEnv()
+		assert.Nil(t, auth)
+		assert.ErrorIs(t, err, sendlix.ErrMissingAPIKeyEnv)
+		assert.Contains(t, err.Error(), "SENDLIX_API_KEY")
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		t.Setenv(sendlix.APIKeyEnvVar, "  \n")
+
+		_, err := sendlix.NewAuthFromEnv()
+		assert.ErrorIs(t, err, sendlix.ErrMissingAPIKeyEnv)
+	})
+
+	t.Run("Whitespace-padded", func(t *testing.T) {
+		t.Setenv(sendlix.APIKeyEnvVar, " secret.123\n")
+
+		auth, err := sendlix.NewAuthFromEnv()
+		require.NoError(t, err)
+		defer auth.Close()
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		t.Setenv("MAILER_SENDLIX_KEY", "top-secret")
+
+		_, err := sendlix.NewAuthFromEnvVar("MAILER_SENDLIX_KEY")
+		assert.ErrorIs(t, err, sendlix.ErrInvalidAPIKeyFormat)
+		assert.Contains(t, err.Error(), "MAILER_SENDLIX_KEY")
+		assert.NotContains(t, err.Error(), "top-secret")
+	})
+}
+
+func TestStaticTokenAuth(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+
+	t.Run("Static token", func(t *testing.T) {
+		auth, err := sendlix.NewStaticTokenAuth(" issued-jwt\n")
+		require.NoError(t, err)
+
+		key, value, err := auth.GetAuthHeader(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "authorization", key)
+		assert.Equal(t, "Bearer issued-jwt", value)
+	})
+
+	t.Run("Empty token", func(t *testing.T) {
+		auth, err := sendlix.NewStaticTokenAuth(" ")
+		assert.Nil(t, auth)
+		assert.ErrorIs(t, err, sendlix.ErrMissingToken)
+	})
+
+	t.Run("Token function", func(t *testing.T) {
+		calls := 0
+		var auth sendlix.IAuth = sendlix.TokenFunc(func(ctx context.Context) (string, error) {
+			calls++
+			return "fetched-jwt", nil
+		})
+
+		_, value, err := auth.GetAuthHeader(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer fetched-jwt", value)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Token function errors", func(t *testing.T) {
+		_, _, err := sendlix.TokenFunc(func(ctx context.Context) (string, error) {
+			return "", assert.AnError
+		}).GetAuthHeader(ctx)
+		assert.ErrorIs(t, err, assert.AnError)
+
+		_, _, err = sendlix.TokenFunc(func(ctx context.Context) (string, error) {
+			return "", nil
+		}).GetAuthHeader(ctx)
+		assert.ErrorIs(t, err, sendlix.ErrMissingToken)
+	})
+
+	t.Run("No token exchange", func(t *testing.T) {
+		auth, err := sendlix.NewStaticTokenAuth("issued-jwt")
+		require.NoError(t, err)
+		client, err := sendlix.NewGroupClient(auth, server.config())
+		require.NoError(t, err)
+		defer client.Close()
+
+		before := server.count("GetJwtToken")
+		_, err = client.CheckEmailInGroup(ctx, "group-1", "user@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, before, server.count("GetJwtToken"))
+	})
+}
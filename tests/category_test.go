@@ -0,0 +1,124 @@
+package sendlix_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategoryRegistry(t *testing.T) {
+	registry := sendlix.NewCategoryRegistry("otp", "receipt", "marketing", "recipe", "")
+
+	t.Run("Names are sorted and exclude empty names", func(t *testing.T) {
+		assert.Equal(t, []string{"marketing", "otp", "receipt", "recipe"}, registry.Names())
+		assert.True(t, registry.Contains("otp"))
+		assert.False(t, registry.Contains("OTP"))
+		assert.False(t, registry.Contains(""))
+	})
+
+	t.Run("Near-match suggestions", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			input    string
+			expected []string
+		}{
+			{"Transposition", "reciept", []string{"receipt", "recipe"}},
+			{"Missing letter", "marketng", []string{"marketing"}},
+			{"Case difference", "OTP", []string{"otp"}},
+			{"Closest match first", "recip", []string{"recipe", "receipt"}},
+			{"No match", "newsletter", []string{}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.expected, registry.Suggest(tt.input))
+			})
+		}
+	})
+}
+
+func TestCategoryValidation(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+
+	newClient := func(t *testing.T, allowUnregistered bool) *sendlix.EmailClient {
+		config := server.config()
+		config.CategoryRegistry = sendlix.NewCategoryRegistry("otp", "receipt", "marketing")
+		config.AllowUnregisteredCategories = allowUnregistered
+
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test-token"}, config)
+		require.NoError(t, err)
+		t.Cleanup(func() { client.Close() })
+		return client
+	}
+
+	options := sendlix.MailOptions{
+		From:    sendlix.EmailAddress{Email: "sender@example.com"},
+		To:      []sendlix.EmailAddress{{Email: "recipient@example.com"}},
+		Subject: "Your receipt",
+		Text:    "Thanks for your order",
+	}
+	group := sendlix.GroupMailData{
+		GroupID: "group-1",
+		From:    sendlix.EmailAddress{Email: "sender@example.com"},
+		Subject: "News",
+		Content: sendlix.MailContent{Text: "News"},
+	}
+
+	t.Run("Registered categories are accepted", func(t *testing.T) {
+		client := newClient(t, false)
+
+		_, err := client.SendEmail(ctx, options, &sendlix.AdditionalOptions{Category: "receipt"})
+		require.NoError(t, err)
+
+		group.Category = "marketing"
+		require.NoError(t, client.SendGroupEmail(ctx, group))
+
+		_, err = client.SendEMLEmail(ctx, []byte("Subject: Code\r\n\r\n1234"), &sendlix.AdditionalOptions{Category: "otp"})
+		require.NoError(t, err)
+	})
+
+	t.Run("Unknown categories are rejected with suggestions", func(t *testing.T) {
+		client := newClient(t, false)
+		before := server.count("SendEmail")
+
+		_, err := client.SendEmail(ctx, options, &sendlix.AdditionalOptions{Category: "reciept"})
+
+		var validationErr *sendlix.ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.True(t, errors.Is(err, sendlix.ErrUnknownCategory))
+		assert.Equal(t, "Category", validationErr.Field)
+		assert.Equal(t, `unknown category "reciept"; close matches: receipt`, err.Error())
+		assert.Equal(t, before, server.count("SendEmail"))
+
+		group.Category = "newsletter"
+		err = client.SendGroupEmail(ctx, group)
+		assert.ErrorIs(t, err, sendlix.ErrUnknownCategory)
+		assert.EqualError(t, err, `unknown category "newsletter"; close matches: none`)
+
+		_, err = client.SendEMLEmail(ctx, []byte("Subject: Code\r\n\r\n1234"), &sendlix.AdditionalOptions{Category: "OTP"})
+		assert.ErrorIs(t, err, sendlix.ErrUnknownCategory)
+	})
+
+	t.Run("Escape hatch accepts unregistered categories", func(t *testing.T) {
+		client := newClient(t, true)
+
+		_, err := client.SendEmail(ctx, options, &sendlix.AdditionalOptions{Category: "experimental"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"marketing", "otp", "receipt"}, client.Categories())
+	})
+
+	t.Run("Without registry any category is accepted", func(t *testing.T) {
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test-token"}, server.config())
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.SendEmail(ctx, options, &sendlix.AdditionalOptions{Category: "anything"})
+		require.NoError(t, err)
+		assert.Nil(t, client.Categories())
+	})
+}
@@ -1,11 +1,24 @@
 package sendlix_test
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"sync"
 	"testing"
+	"time"
 
 	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/sendlix/go-sdk/sendlixtest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func TestDefaultClientConfig(t *testing.T) {
@@ -83,3 +96,246 @@ func TestClientConfig(t *testing.T) {
 		assert.False(t, config.Insecure)
 	})
 }
+
+func TestTLSSessionResumption(t *testing.T) {
+	send := func(t *testing.T, client *sendlix.EmailClient) {
+		_, err := client.SendEmail(context.Background(), sendlix.MailOptions{
+			From:    sendlix.EmailAddress{Email: "sender@example.com"},
+			To:      []sendlix.EmailAddress{{Email: "recipient@example.com"}},
+			Subject: "Hello",
+			Text:    "Hello",
+		}, nil)
+		require.NoError(t, err)
+	}
+
+	// The server closes connections shortly after they are established,
+	// so every send after the pause needs a new TLS handshake.
+	newServer := func(t *testing.T) *fakeServer {
+		return newFakeServer(t, grpc.KeepaliveParams(keepalive.ServerParameters{
+			MaxConnectionAge:      100 * time.Millisecond,
+			MaxConnectionAgeGrace: 50 * time.Millisecond,
+		}))
+	}
+
+	tests := []struct {
+		name          string
+		configure     func(config *sendlix.ClientConfig)
+		expectResumed bool
+	}{
+		{
+			name:          "Session cache enables resumption",
+			configure:     func(config *sendlix.ClientConfig) { config.ClientSessionCacheSize = 8 },
+			expectResumed: true,
+		},
+		{
+			name: "Session tickets disabled",
+			configure: func(config *sendlix.ClientConfig) {
+				config.ClientSessionCacheSize = 8
+				config.SessionTicketsDisabled = true
+			},
+			expectResumed: false,
+		},
+		{
+			name:          "Default has no session cache",
+			configure:     func(config *sendlix.ClientConfig) {},
+			expectResumed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newServer(t)
+			config := server.config()
+			tt.configure(config)
+
+			client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+			require.NoError(t, err)
+			defer client.Close()
+
+			send(t, client)
+			time.Sleep(300 * time.Millisecond)
+			send(t, client)
+
+			resumed := server.resumptions()
+			require.Len(t, resumed, 2)
+			assert.False(t, resumed[0])
+			assert.Equal(t, tt.expectResumed, resumed[1])
+		})
+	}
+}
+
+func TestTLSConfig(t *testing.T) {
+	ctx := context.Background()
+	ca := newTestCA(t)
+	serverCert := ca.issue(t, x509.ExtKeyUsageServerAuth)
+
+	newServer := func(t *testing.T, clientAuth tls.ClientAuthType) *fakeServer {
+		return newFakeServer(t, grpc.Creds(credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{serverCert},
+			ClientCAs:    ca.pool,
+			ClientAuth:   clientAuth,
+		})))
+	}
+	send := func(config *sendlix.ClientConfig) error {
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+		require.NoError(t, err)
+		defer client.Close()
+		_, err = client.SendEmail(ctx, sendlixtest.ValidMailOptions(), nil)
+		return err
+	}
+
+	t.Run("Private CA", func(t *testing.T) {
+		server := newServer(t, tls.NoClientCert)
+		config := server.config()
+		config.Insecure = false
+		config.TLSConfig = &tls.Config{RootCAs: ca.pool}
+
+		require.NoError(t, send(config))
+
+		// The token exchange uses the same TLS configuration
+		auth, err := sendlix.NewAuthWithConfig("secret.123", config)
+		require.NoError(t, err)
+		defer auth.Close()
+		_, _, err = auth.GetAuthHeader(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("Unknown CA", func(t *testing.T) {
+		server := newServer(t, tls.NoClientCert)
+		config := server.config()
+		config.Insecure = false
+		config.TLSConfig = &tls.Config{}
+
+		err := send(config)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "certificate")
+	})
+
+	t.Run("TLSConfig takes precedence over Insecure", func(t *testing.T) {
+		server := newServer(t, tls.NoClientCert)
+		config := server.config()
+		config.Insecure = true
+		config.TLSConfig = &tls.Config{}
+
+		err := send(config)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "certificate")
+	})
+
+	t.Run("Client certificate", func(t *testing.T) {
+		server := newServer(t, tls.RequireAndVerifyClientCert)
+		config := server.config()
+		config.Insecure = false
+		config.TLSConfig = &tls.Config{RootCAs: ca.pool}
+
+		require.Error(t, send(config))
+
+		config.TLSConfig.Certificates = []tls.Certificate{ca.issue(t, x509.ExtKeyUsageClientAuth)}
+		require.NoError(t, send(config))
+	})
+}
+
+func TestRequestTimeout(t *testing.T) {
+	server := newFakeServer(t)
+	server.update(func(h *fakeHandlers) {
+		h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(200 * time.Millisecond):
+				return &pb.SendEmailResponse{Message: []string{"msg-1"}}, nil
+			}
+		}
+	})
+
+	newClient := func(t *testing.T, timeout time.Duration) *sendlix.EmailClient {
+		config := server.config()
+		config.RequestTimeout = timeout
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+		require.NoError(t, err)
+		t.Cleanup(func() { client.Close() })
+		return client
+	}
+	send := func(ctx context.Context, client *sendlix.EmailClient) (time.Duration, error) {
+		start := time.Now()
+		_, err := client.SendEmail(ctx, sendlixtest.ValidMailOptions(), nil)
+		return time.Since(start), err
+	}
+
+	t.Run("Slow server", func(t *testing.T) {
+		elapsed, err := send(context.Background(), newClient(t, 50*time.Millisecond))
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		assert.Equal(t, sendlix.CodeDeadlineExceeded, sendlix.Code(err))
+		assert.Less(t, elapsed, 150*time.Millisecond)
+	})
+
+	t.Run("Earlier caller deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		elapsed, err := send(ctx, newClient(t, time.Second))
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		assert.Less(t, elapsed, 150*time.Millisecond)
+	})
+
+	t.Run("Later caller deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		elapsed, err := send(ctx, newClient(t, 50*time.Millisecond))
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		assert.Less(t, elapsed, 150*time.Millisecond)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		_, err := send(context.Background(), newClient(t, 0))
+		assert.NoError(t, err)
+	})
+}
+
+func TestKeepalive(t *testing.T) {
+	server := newFakeServer(t)
+	config := server.config()
+	config.KeepaliveTime = 30 * time.Second
+	config.KeepaliveTimeout = 5 * time.Second
+	config.PermitWithoutStream = true
+
+	client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+	require.NoError(t, err)
+	defer client.Close()
+
+	_, err = client.SendEmail(context.Background(), sendlixtest.ValidMailOptions(), nil)
+	require.NoError(t, err)
+
+	settings := client.EffectiveConfig()["client"].(map[string]any)
+	assert.Equal(t, "30s", settings["KeepaliveTime"])
+	assert.Equal(t, "5s", settings["KeepaliveTimeout"])
+	assert.Equal(t, true, settings["PermitWithoutStream"])
+}
+
+func TestDialOptions(t *testing.T) {
+	server := newFakeServer(t)
+
+	var mu sync.Mutex
+	var methods, headers []string
+	observe := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		mu.Lock()
+		methods = append(methods, method)
+		headers = append(headers, md.Get("authorization")...)
+		mu.Unlock()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	config := server.config()
+	config.DialOptions = []grpc.DialOption{grpc.WithChainUnaryInterceptor(observe)}
+	client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+	require.NoError(t, err)
+	defer client.Close()
+
+	_, err = client.SendEmail(context.Background(), sendlixtest.ValidMailOptions(), nil)
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{pb.Email_SendEmail_FullMethodName}, methods)
+	assert.Equal(t, []string{"Bearer test"}, headers, "user interceptors run after authentication")
+}
@@ -0,0 +1,101 @@
+package sendlix_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
+)
+
+// compressionRecorder is a server stats handler recording the compression
+// and payload sizes of incoming calls.
+type compressionRecorder struct {
+	mu       sync.Mutex
+	encoding []string
+	payloads []*stats.InPayload
+}
+
+func (r *compressionRecorder) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (r *compressionRecorder) HandleRPC(_ context.Context, s stats.RPCStats) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch s := s.(type) {
+	case *stats.InHeader:
+		r.encoding = append(r.encoding, s.Compression)
+	case *stats.InPayload:
+		r.payloads = append(r.payloads, s)
+	}
+}
+
+func (r *compressionRecorder) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (r *compressionRecorder) HandleConn(context.Context, stats.ConnStats) {}
+
+func TestCompression(t *testing.T) {
+	ctx := context.Background()
+	// A large, compressible message, like one with a base64 attachment
+	eml := []byte("Subject: Report\r\nContent-Type: text/plain\r\n\r\n" + strings.Repeat("UmVwb3J0IGRhdGEgZm9yIFEzLg==\r\n", 64*1024))
+
+	send := func(t *testing.T, compression string) *compressionRecorder {
+		recorder := &compressionRecorder{}
+		server := newFakeServer(t, grpc.StatsHandler(recorder), grpc.MaxRecvMsgSize(len(eml)+1024))
+		var received []byte
+		server.update(func(h *fakeHandlers) {
+			h.sendEmlEmail = func(ctx context.Context, req *pb.EmlMailRequest) (*pb.SendEmailResponse, error) {
+				received = req.Mail
+				return &pb.SendEmailResponse{Message: []string{"eml-1"}, EmailsLeft: 100}, nil
+			}
+		})
+
+		config := server.config()
+		config.Compression = compression
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		ids, err := client.SendEMLEmail(ctx, eml, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"eml-1"}, ids)
+		assert.True(t, bytes.Equal(eml, received), "EML message arrives unchanged")
+		return recorder
+	}
+
+	t.Run("Gzip", func(t *testing.T) {
+		recorder := send(t, "gzip")
+
+		require.Len(t, recorder.encoding, 1)
+		assert.Equal(t, "gzip", recorder.encoding[0])
+		require.Len(t, recorder.payloads, 1)
+		assert.Less(t, recorder.payloads[0].CompressedLength, recorder.payloads[0].Length/10)
+	})
+
+	t.Run("Default is uncompressed", func(t *testing.T) {
+		recorder := send(t, "")
+
+		require.Len(t, recorder.encoding, 1)
+		assert.Empty(t, recorder.encoding[0])
+		require.Len(t, recorder.payloads, 1)
+		assert.Equal(t, recorder.payloads[0].Length, recorder.payloads[0].CompressedLength)
+	})
+
+	t.Run("Unknown compressor", func(t *testing.T) {
+		config := sendlix.DefaultClientConfig()
+		config.Compression = "brotli"
+
+		_, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+		assertValidationError(t, err, sendlix.CodeUnknownCompressor, "Compression")
+	})
+}
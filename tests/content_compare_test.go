@@ -0,0 +1,153 @@
+package sendlix_test
+
+import (
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentEqual(t *testing.T) {
+	all := sendlix.CompareOptions{IgnoreWhitespace: true, IgnoreComments: true, NormalizeAttributes: true}
+
+	tests := []struct {
+		name  string
+		a, b  sendlix.MailContent
+		opts  sendlix.CompareOptions
+		equal bool
+	}{
+		{
+			name:  "Identical",
+			a:     sendlix.MailContent{HTML: "<p>Hi</p>", Text: "Hi"},
+			b:     sendlix.MailContent{HTML: "<p>Hi</p>", Text: "Hi"},
+			equal: true,
+		},
+		{
+			name:  "Source spelling is ignored without options",
+			a:     sendlix.MailContent{HTML: `<P CLASS="x">Tom &amp; Jerry<br/></P>`},
+			b:     sendlix.MailContent{HTML: `<p class=x>Tom &#38; Jerry<br></p>`},
+			equal: true,
+		},
+		{
+			name:  "Attribute order matters without NormalizeAttributes",
+			a:     sendlix.MailContent{HTML: `<a href="https://example.com" class="button">Go</a>`},
+			b:     sendlix.MailContent{HTML: `<a class="button" href="https://example.com">Go</a>`},
+			equal: false,
+		},
+		{
+			name:  "Attribute reordering",
+			a:     sendlix.MailContent{HTML: `<a href="https://example.com" class="button">Go</a>`},
+			b:     sendlix.MailContent{HTML: `<a class=" button " href="https://example.com">Go</a>`},
+			opts:  sendlix.CompareOptions{NormalizeAttributes: true},
+			equal: true,
+		},
+		{
+			name:  "Different attribute values",
+			a:     sendlix.MailContent{HTML: `<a href="https://example.com/a">Go</a>`},
+			b:     sendlix.MailContent{HTML: `<a href="https://example.com/b">Go</a>`},
+			opts:  all,
+			equal: false,
+		},
+		{
+			name:  "Comment changes",
+			a:     sendlix.MailContent{HTML: "<p>Hello <!-- v1 -->world</p>"},
+			b:     sendlix.MailContent{HTML: "<p>Hello <!-- v2, built 2026-10-17 -->world</p>"},
+			opts:  sendlix.CompareOptions{IgnoreComments: true},
+			equal: true,
+		},
+		{
+			name:  "Comments matter without IgnoreComments",
+			a:     sendlix.MailContent{HTML: "<p>Hi<!-- v1 --></p>"},
+			b:     sendlix.MailContent{HTML: "<p>Hi<!-- v2 --></p>"},
+			equal: false,
+		},
+		{
+			name:  "Conditional comments are kept",
+			a:     sendlix.MailContent{HTML: "<!--[if mso]><table><tr><td><![endif]--><p>Hi</p>"},
+			b:     sendlix.MailContent{HTML: "<!--[if mso]><table width=600><tr><td><![endif]--><p>Hi</p>"},
+			opts:  all,
+			equal: false,
+		},
+		{
+			name: "Whitespace-only differences",
+			a:    sendlix.MailContent{HTML: "<table><tr><td>Hello   world</td></tr></table>", Text: "Hello world"},
+			b: sendlix.MailContent{
+				HTML: "<table>\n  <tr>\n    <td>\n      Hello\n      world\n    </td>\n  </tr>\n</table>\n",
+				Text: "Hello\n  world\n",
+			},
+			opts:  sendlix.CompareOptions{IgnoreWhitespace: true},
+			equal: true,
+		},
+		{
+			name:  "Whitespace matters without IgnoreWhitespace",
+			a:     sendlix.MailContent{HTML: "<p>Hello world</p>"},
+			b:     sendlix.MailContent{HTML: "<p>Hello  world</p>"},
+			equal: false,
+		},
+		{
+			name:  "Preformatted whitespace is kept",
+			a:     sendlix.MailContent{HTML: "<pre>a  b</pre>"},
+			b:     sendlix.MailContent{HTML: "<pre>a b</pre>"},
+			opts:  all,
+			equal: false,
+		},
+		{
+			name:  "Different text",
+			a:     sendlix.MailContent{HTML: "<p>Your order has shipped</p>"},
+			b:     sendlix.MailContent{HTML: "<p>Your order was cancelled</p>"},
+			opts:  all,
+			equal: false,
+		},
+		{
+			name:  "Different structure",
+			a:     sendlix.MailContent{HTML: "<p><b>Sale</b></p>"},
+			b:     sendlix.MailContent{HTML: "<p><i>Sale</i></p>"},
+			opts:  all,
+			equal: false,
+		},
+		{
+			name:  "Different plain text",
+			a:     sendlix.MailContent{HTML: "<p>Hi</p>", Text: "Hi"},
+			b:     sendlix.MailContent{HTML: "<p>Hi</p>", Text: "Bye"},
+			opts:  all,
+			equal: false,
+		},
+		{
+			name:  "Different tracking",
+			a:     sendlix.MailContent{HTML: "<p>Hi</p>", Tracking: true},
+			b:     sendlix.MailContent{HTML: "<p>Hi</p>"},
+			opts:  all,
+			equal: false,
+		},
+		{
+			name:  "Text cannot move between HTML and plain text",
+			a:     sendlix.MailContent{HTML: "Hi", Text: ""},
+			b:     sendlix.MailContent{HTML: "", Text: "Hi"},
+			equal: false,
+		},
+		{
+			name:  "Malformed markup",
+			a:     sendlix.MailContent{HTML: "<p>Unclosed <b>bold"},
+			b:     sendlix.MailContent{HTML: "<p>Unclosed  <b>bold"},
+			opts:  all,
+			equal: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.equal, sendlix.ContentEqual(tt.a, tt.b, tt.opts))
+			assert.Equal(t, tt.equal, sendlix.ContentEqual(tt.b, tt.a, tt.opts), "symmetric")
+			assert.Equal(t, tt.equal, sendlix.ContentHash(tt.a, tt.opts) == sendlix.ContentHash(tt.b, tt.opts), "hash agrees")
+		})
+	}
+}
+
+func TestContentHash(t *testing.T) {
+	content := sendlix.MailContent{HTML: "<p>Hi</p>", Text: "Hi"}
+
+	hash := sendlix.ContentHash(content, sendlix.CompareOptions{})
+
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, sendlix.ContentHash(content, sendlix.CompareOptions{}), "stable")
+}
@@ -0,0 +1,79 @@
+package sendlix_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbedImageDataURI(t *testing.T) {
+	ctx := context.Background()
+	logo := []byte("\x89PNG\r\n\x1a\nlogo")
+	logoURI := "data:image/png;base64," + base64.StdEncoding.EncodeToString(logo)
+
+	t.Run("Injects into img src attributes", func(t *testing.T) {
+		content := `<p class=intro>Hi {{logo}}</p><IMG SRC='{{logo}}' alt="A &amp; B"><img src="{{other}}"/><img alt=x src={{logo}} />`
+
+		result, err := sendlix.EmbedImageDataURI(ctx, content, "{{logo}}", logo, sendlix.MimeTypePNG)
+		require.NoError(t, err)
+		assert.Equal(t, `<p class=intro>Hi {{logo}}</p><img src="`+logoURI+`" alt="A &amp; B"><img src="{{other}}"/><img alt="x" src="`+logoURI+`"/>`, result)
+	})
+
+	t.Run("Escaped placeholders", func(t *testing.T) {
+		result, err := sendlix.EmbedImageDataURI(ctx, `<img src="logo?a=1&amp;b=2">`, "logo?a=1&b=2", logo, sendlix.MimeTypeGIF)
+		require.NoError(t, err)
+		assert.Equal(t, `<img src="data:image/gif;base64,`+base64.StdEncoding.EncodeToString(logo)+`">`, result)
+	})
+
+	t.Run("Placeholder not found", func(t *testing.T) {
+		_, err := sendlix.EmbedImageDataURI(ctx, `<p>{{logo}}</p><a href="{{logo}}">x</a>`, "{{logo}}", logo, sendlix.MimeTypePNG)
+		assert.ErrorIs(t, err, sendlix.ErrImagePlaceholderNotFound)
+		assert.Equal(t, `no img src attribute matches placeholder "{{logo}}"`, err.Error())
+
+		_, err = sendlix.EmbedImageDataURI(ctx, `<img src="">`, "", logo, sendlix.MimeTypePNG)
+		assert.ErrorIs(t, err, sendlix.ErrImagePlaceholderNotFound)
+	})
+
+	t.Run("Invalid image type", func(t *testing.T) {
+		_, err := sendlix.EmbedImageDataURI(ctx, `<img src="x">`, "x", logo, sendlix.MimeType(7))
+		assert.ErrorIs(t, err, sendlix.ErrInvalidImageType)
+	})
+
+	t.Run("Per-image limit", func(t *testing.T) {
+		image := bytes.Repeat([]byte{0}, sendlix.MaxDataURISize)
+		_, err := sendlix.EmbedImageDataURI(ctx, `<img src="x">`, "x", image, sendlix.MimeTypePNG)
+		assert.ErrorIs(t, err, sendlix.ErrDataURITooLarge)
+		assert.Contains(t, err.Error(), "exceeding the limit of 32768")
+	})
+
+	t.Run("Per-message limit", func(t *testing.T) {
+		image := bytes.Repeat([]byte{0}, 20*1024)
+		content := `<img src="a"><img src="b">`
+
+		var warnings []sendlix.Warning
+		warnCtx := sendlix.WithWarnings(ctx, &warnings)
+		content, err := sendlix.EmbedImageDataURI(warnCtx, content, "a", image, sendlix.MimeTypePNG)
+		require.NoError(t, err)
+		content, err = sendlix.EmbedImageDataURI(warnCtx, content, "b", image, sendlix.MimeTypePNG)
+		require.NoError(t, err)
+		_, err = sendlix.EmbedImageDataURI(warnCtx, content+`<img src="c">`, "c", image, sendlix.MimeTypePNG)
+		assert.ErrorIs(t, err, sendlix.ErrDataURIsTooLarge)
+
+		require.Len(t, warnings, 2)
+		assert.Equal(t, sendlix.WarningLargeDataURI, warnings[0].Code)
+		assert.True(t, strings.HasPrefix(warnings[0].Message, `data URI for "a" is 27330 bytes`), warnings[0].Message)
+	})
+
+	t.Run("No warning for small images", func(t *testing.T) {
+		var warnings []sendlix.Warning
+		_, err := sendlix.EmbedImageDataURI(sendlix.WithWarnings(ctx, &warnings), `<img src="x">`, "x", logo, sendlix.MimeTypePNG)
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+	})
+}
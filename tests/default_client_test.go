@@ -0,0 +1,106 @@
+package sendlix_test
+
+import (
+	"context"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/sendlix/go-sdk/sendlixtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSender records the sends of the package-level functions.
+type fakeSender struct {
+	emails []sendlix.MailOptions
+	groups []sendlix.GroupMailData
+}
+
+func (f *fakeSender) SendEmail(ctx context.Context, options sendlix.MailOptions, additional *sendlix.AdditionalOptions) ([]string, error) {
+	f.emails = append(f.emails, options)
+	return []string{"fake-1"}, nil
+}
+
+func (f *fakeSender) SendGroupEmail(ctx context.Context, data sendlix.GroupMailData) error {
+	f.groups = append(f.groups, data)
+	return nil
+}
+
+func TestDefaultClient(t *testing.T) {
+	ctx := context.Background()
+	t.Cleanup(func() { sendlix.CloseDefault() })
+
+	t.Run("Missing API key", func(t *testing.T) {
+		t.Setenv(sendlix.APIKeyEnvVar, "")
+		require.NoError(t, sendlix.CloseDefault())
+
+		ids, err := sendlix.Send(ctx, sendlixtest.ValidMailOptions(), nil)
+		assert.Nil(t, ids)
+		assertValidationError(t, err, sendlix.CodeMissingAPIKeyEnv, sendlix.APIKeyEnvVar)
+		assert.Contains(t, err.Error(), "default client")
+
+		err = sendlix.SendGroup(ctx, sendlix.GroupMailData{GroupID: "newsletter"})
+		assert.ErrorIs(t, err, sendlix.ErrMissingAPIKeyEnv)
+	})
+
+	t.Run("Lazy initialization", func(t *testing.T) {
+		require.NoError(t, sendlix.CloseDefault())
+		// The environment is read on first use, not at package initialization
+		t.Setenv(sendlix.APIKeyEnvVar, "secret.123")
+
+		first, err := sendlix.DefaultClient()
+		require.NoError(t, err)
+		assert.IsType(t, &sendlix.EmailClient{}, first)
+		second, err := sendlix.DefaultClient()
+		require.NoError(t, err)
+		assert.Same(t, first, second, "created once")
+
+		require.NoError(t, sendlix.CloseDefault())
+		third, err := sendlix.DefaultClient()
+		require.NoError(t, err)
+		assert.NotSame(t, first, third, "created again after CloseDefault")
+	})
+
+	t.Run("Injected fake", func(t *testing.T) {
+		t.Setenv(sendlix.APIKeyEnvVar, "")
+		fake := &fakeSender{}
+		sendlix.SetDefaultClient(fake)
+		t.Cleanup(func() { sendlix.SetDefaultClient(nil) })
+
+		ids, err := sendlix.Send(ctx, sendlixtest.ValidMailOptions(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"fake-1"}, ids)
+		require.NoError(t, sendlix.SendGroup(ctx, sendlix.GroupMailData{GroupID: "newsletter"}))
+
+		require.Len(t, fake.emails, 1)
+		assert.Equal(t, "Fixture subject", fake.emails[0].Subject)
+		require.Len(t, fake.groups, 1)
+		assert.Equal(t, "newsletter", fake.groups[0].GroupID)
+	})
+
+	t.Run("Injected client", func(t *testing.T) {
+		server := newFakeServer(t)
+		var subject string
+		server.update(func(h *fakeHandlers) {
+			h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+				subject = req.Subject
+				return &pb.SendEmailResponse{Message: []string{"msg-1"}, EmailsLeft: 100}, nil
+			}
+		})
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, server.config())
+		require.NoError(t, err)
+		sendlix.SetDefaultClient(client)
+
+		ids, err := sendlix.Send(ctx, sendlixtest.ValidMailOptions(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"msg-1"}, ids)
+		assert.Equal(t, "Fixture subject", subject)
+
+		// The caller owns an injected client
+		require.NoError(t, sendlix.CloseDefault())
+		_, err = client.SendEmail(ctx, sendlixtest.ValidMailOptions(), nil)
+		require.NoError(t, err)
+		require.NoError(t, client.Close())
+	})
+}
@@ -0,0 +1,69 @@
+package sendlix_test
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// rawCodec is a server codec recording the serialized requests it receives.
+type rawCodec struct {
+	mu       sync.Mutex
+	requests [][]byte
+}
+
+func (c *rawCodec) Marshal(v any) ([]byte, error) {
+	return proto.Marshal(protoadapt.MessageV2Of(v.(protoadapt.MessageV1)))
+}
+
+func (c *rawCodec) Unmarshal(data []byte, v any) error {
+	c.mu.Lock()
+	c.requests = append(c.requests, append([]byte(nil), data...))
+	c.mu.Unlock()
+	return proto.Unmarshal(data, protoadapt.MessageV2Of(v.(protoadapt.MessageV1)))
+}
+
+func (c *rawCodec) Name() string {
+	return "proto"
+}
+
+func TestDeterministicSerialization(t *testing.T) {
+	ctx := context.Background()
+	codec := &rawCodec{}
+	server := newFakeServer(t, grpc.ForceServerCodec(codec))
+
+	client, err := sendlix.NewGroupClient(&MockAuth{Token: "test"}, server.config())
+	require.NoError(t, err)
+	defer client.Close()
+
+	newEntries := func() []sendlix.GroupEntry {
+		entries := make([]sendlix.GroupEntry, 3)
+		for i := range entries {
+			substitutions := make(map[string]string)
+			for j := 0; j < 200; j++ {
+				substitutions["key_"+strconv.Itoa(j)] = strings.Repeat("v", j%7)
+			}
+			entries[i] = sendlix.GroupEntry{Email: "user" + strconv.Itoa(i) + "@example.com", Substitutions: substitutions}
+		}
+		return entries
+	}
+
+	for i := 0; i < 10; i++ {
+		_, err := client.InsertEmailsToGroup(ctx, "newsletter", newEntries(), nil)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, codec.requests, 10)
+	for i, request := range codec.requests[1:] {
+		assert.Equal(t, codec.requests[0], request, "request %d differs", i+1)
+	}
+}
@@ -0,0 +1,103 @@
+package sendlix_test
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigest(t *testing.T) {
+	item := template.Must(template.New("item").Parse(`<h2><a href="{{.URL}}">{{.Title}}</a></h2><p>{{.Summary}}</p>`))
+	type post struct{ Title, URL, Summary string }
+
+	t.Run("Items and text rendering", func(t *testing.T) {
+		content, err := sendlix.NewDigest("<h1>2 new items</h1>", "<p>You receive this <b>weekly</b>.<br>Unsubscribe anytime.</p>").
+			AddTemplateItem(item, post{"Go 1.22 released", "https://example.com/go", "Range over   integers\nand more."}).
+			AddItem("<ul><li>First</li><li>Second</li></ul>").
+			Build()
+		require.NoError(t, err)
+
+		assert.Equal(t, `<h1>2 new items</h1>`+
+			`<h2><a href="https://example.com/go">Go 1.22 released</a></h2><p>Range over   integers
+and more.</p>`+
+			`<ul><li>First</li><li>Second</li></ul>`+
+			`<p>You receive this <b>weekly</b>.<br>Unsubscribe anytime.</p>`, content.HTML)
+		assert.Equal(t, "2 new items\n\n"+
+			"Go 1.22 released (https://example.com/go)\n\n"+
+			"Range over integers and more.\n\n"+
+			"- First\n- Second\n\n"+
+			"You receive this weekly.\nUnsubscribe anytime.\n", content.Text)
+	})
+
+	t.Run("Template data is escaped", func(t *testing.T) {
+		content, err := sendlix.NewDigest("", "").
+			AddTemplateItem(item, post{Title: "<script>", URL: "https://example.com"}).
+			Build()
+		require.NoError(t, err)
+		assert.NotContains(t, content.HTML, "<script>")
+		assert.Contains(t, content.Text, "<script> (https://example.com)")
+	})
+
+	t.Run("Empty digest", func(t *testing.T) {
+		_, err := sendlix.NewDigest("<h1>Header</h1>", "").Build()
+		assert.ErrorIs(t, err, sendlix.ErrEmptyDigest)
+	})
+
+	t.Run("Template error", func(t *testing.T) {
+		_, err := sendlix.NewDigest("", "").AddTemplateItem(item, 42).Build()
+		assert.Error(t, err)
+	})
+
+	t.Run("Too many items without view more", func(t *testing.T) {
+		_, err := sendlix.NewDigest("", "").MaxItems(1).AddItem("<p>a</p>").AddItem("<p>b</p>").Build()
+		assert.ErrorIs(t, err, sendlix.ErrDigestTooLarge)
+	})
+
+	t.Run("Too large without view more", func(t *testing.T) {
+		_, err := sendlix.NewDigest("", "").MaxSize(10).AddItem("<p>a</p>").AddItem("<p>b</p>").Build()
+		assert.ErrorIs(t, err, sendlix.ErrDigestTooLarge)
+	})
+
+	t.Run("Truncated by item count", func(t *testing.T) {
+		content, err := sendlix.NewDigest("", "<p>Footer</p>").
+			MaxItems(2).
+			ViewMore("https://example.com/all?a=1&b=2", "").
+			AddItem("<p>one</p>").AddItem("<p>two</p>").AddItem("<p>three</p>").
+			Build()
+		require.NoError(t, err)
+		assert.Equal(t, `<p>one</p><p>two</p><p><a href="https://example.com/all?a=1&amp;b=2">View more</a></p><p>Footer</p>`, content.HTML)
+		assert.Equal(t, "one\n\ntwo\n\nView more (https://example.com/all?a=1&b=2)\n\nFooter\n", content.Text)
+	})
+
+	t.Run("Truncated by size", func(t *testing.T) {
+		items := strings.Repeat("x", 40)
+		digest := sendlix.NewDigest("", "").MaxSize(150).ViewMore("https://example.com/all", "All items")
+		for i := 0; i < 5; i++ {
+			digest.AddItem("<p>" + items + "</p>")
+		}
+		content, err := digest.Build()
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(content.HTML), 150)
+		assert.Equal(t, 2, strings.Count(content.HTML, items))
+		assert.True(t, strings.HasSuffix(content.Text, "All items (https://example.com/all)\n"))
+	})
+
+	t.Run("Last item fits without link", func(t *testing.T) {
+		content, err := sendlix.NewDigest("", "").MaxSize(22).ViewMore("https://example.com/all", "").
+			AddItem("<p>one</p>").AddItem("<p>two</p>").
+			Build()
+		require.NoError(t, err)
+		assert.Equal(t, "<p>one</p><p>two</p>", content.HTML)
+	})
+
+	t.Run("Budget too small for any item", func(t *testing.T) {
+		_, err := sendlix.NewDigest("<h1>Header</h1>", "").MaxSize(20).ViewMore("https://example.com/all", "").
+			AddItem("<p>one</p>").AddItem("<p>two</p>").
+			Build()
+		assert.ErrorIs(t, err, sendlix.ErrDigestTooLarge)
+	})
+}
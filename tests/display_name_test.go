@@ -0,0 +1,128 @@
+package sendlix_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/sendlix/go-sdk/sendlixtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeDisplayName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		bidi     bool
+	}{
+		{name: "Already clean", input: "Jane Doe", expected: "Jane Doe"},
+		{name: "Leading and trailing whitespace", input: "  Jane Doe \n", expected: "Jane Doe"},
+		{name: "Tabs and internal runs", input: "Jane\t\t  Doe", expected: "Jane Doe"},
+		{name: "Zero-width space", input: "Jane\u200bDoe", expected: "JaneDoe"},
+		{name: "Zero-width joiner", input: "Jane\u200d Doe", expected: "Jane Doe"},
+		{name: "Byte order mark", input: "\ufeffJane Doe", expected: "Jane Doe"},
+		{name: "Control characters", input: "Jane\x00\x07 Doe", expected: "Jane Doe"},
+		{name: "RTL override", input: "Jane \u202eeoD", expected: "Jane eoD", bidi: true},
+		{name: "Bidi isolate", input: "\u2067Jane Doe\u2069", expected: "Jane Doe", bidi: true},
+		{name: "Non-ASCII letters preserved", input: " Jürgen   Müller ", expected: "Jürgen Müller"},
+		{name: "Only invisible characters", input: "\u200b \u200d\t", expected: ""},
+		{name: "Empty", input: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalized := sendlix.NormalizeDisplayName(tt.input)
+
+			assert.Equal(t, tt.expected, normalized)
+			assert.Equal(t, tt.bidi, sendlix.ContainsBidiControl(tt.input))
+			assert.False(t, sendlix.ContainsBidiControl(normalized))
+			assert.Equal(t, normalized, sendlix.NormalizeDisplayName(normalized))
+		})
+	}
+}
+
+func TestDisplayNameNormalizationOnSend(t *testing.T) {
+	options := sendlix.MailOptions{
+		From:    sendlix.EmailAddress{Email: "sender@example.com", Name: "  Acme\t\tSupport\u200b "},
+		To:      []sendlix.EmailAddress{{Email: "recipient@example.com", Name: "\u202eJane"}},
+		Subject: "Hello",
+		Text:    "Hello",
+	}
+
+	t.Run("Names are normalized by default", func(t *testing.T) {
+		server := newFakeServer(t)
+		var captured *pb.SendMailRequest
+		server.update(func(h *fakeHandlers) {
+			h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+				captured = req
+				return &pb.SendEmailResponse{Message: []string{"msg-1"}}, nil
+			}
+		})
+
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, server.config())
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.SendEmail(context.Background(), options, nil)
+		require.NoError(t, err)
+
+		require.NotNil(t, captured)
+		assert.Equal(t, "Acme Support", captured.From.Name)
+		assert.Equal(t, "Jane", captured.To[0].Name)
+	})
+
+	t.Run("Normalization can be disabled", func(t *testing.T) {
+		server := newFakeServer(t)
+		var captured *pb.SendMailRequest
+		server.update(func(h *fakeHandlers) {
+			h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+				captured = req
+				return &pb.SendEmailResponse{Message: []string{"msg-1"}}, nil
+			}
+		})
+
+		config := server.config()
+		config.DisableNameNormalization = true
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.SendEmail(context.Background(), options, nil)
+		require.NoError(t, err)
+
+		require.NotNil(t, captured)
+		assert.Equal(t, options.From.Name, captured.From.Name)
+	})
+
+	t.Run("Long names are rejected", func(t *testing.T) {
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, nil)
+		require.NoError(t, err)
+		defer client.Close()
+
+		variant := sendlixtest.InvalidVariants()["cc name too long"]
+		_, err = client.SendEmail(context.Background(), variant.Options, nil)
+
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, sendlix.ErrDisplayNameTooLong))
+		assertValidationError(t, err, variant.Code, variant.Field)
+		assert.Contains(t, err.Error(), "257 characters")
+	})
+
+	t.Run("Whitespace padding does not count towards the limit", func(t *testing.T) {
+		server := newFakeServer(t)
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, server.config())
+		require.NoError(t, err)
+		defer client.Close()
+
+		padded := options
+		padded.From.Name = "  " + strings.Repeat("a", sendlix.MaxDisplayNameLength) + "\u200b\u200b  "
+
+		_, err = client.SendEmail(context.Background(), padded, nil)
+		assert.NoError(t, err)
+	})
+}
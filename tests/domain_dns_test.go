@@ -0,0 +1,203 @@
+package sendlix_test
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// stubResolver returns a resolver answering TXT queries from records, keyed
+// by lowercase host name; "|" separates the strings of one record. CNAME
+// chains are followed as a recursive resolver would. Unknown names yield
+// NXDOMAIN; names in failing yield SERVFAIL.
+func stubResolver(t *testing.T, records map[string][]string, cnames map[string]string, failing ...string) *net.Resolver {
+	answer := func(query []byte) []byte {
+		var parser dnsmessage.Parser
+		header, err := parser.Start(query)
+		require.NoError(t, err)
+		question, err := parser.Question()
+		require.NoError(t, err)
+
+		name := strings.ToLower(strings.TrimSuffix(question.Name.String(), "."))
+		builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: header.ID, Response: true, RecursionAvailable: true})
+		builder.EnableCompression()
+		require.NoError(t, builder.StartQuestions())
+		require.NoError(t, builder.Question(question))
+		require.NoError(t, builder.StartAnswers())
+
+		for _, f := range failing {
+			if name == f {
+				builder = dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: header.ID, Response: true, RCode: dnsmessage.RCodeServerFailure})
+				require.NoError(t, builder.StartQuestions())
+				require.NoError(t, builder.Question(question))
+				msg, err := builder.Finish()
+				require.NoError(t, err)
+				return msg
+			}
+		}
+
+		owner := question.Name
+		for target, ok := cnames[name]; ok; target, ok = cnames[name] {
+			targetName := dnsmessage.MustNewName(target + ".")
+			require.NoError(t, builder.CNAMEResource(dnsmessage.ResourceHeader{Name: owner, Class: dnsmessage.ClassINET, TTL: 60}, dnsmessage.CNAMEResource{CNAME: targetName}))
+			owner, name = targetName, target
+		}
+
+		txt, ok := records[name]
+		if !ok {
+			builder = dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: header.ID, Response: true, RCode: dnsmessage.RCodeNameError})
+			require.NoError(t, builder.StartQuestions())
+			require.NoError(t, builder.Question(question))
+		} else if question.Type == dnsmessage.TypeTXT {
+			for _, record := range txt {
+				require.NoError(t, builder.TXTResource(dnsmessage.ResourceHeader{Name: owner, Class: dnsmessage.ClassINET, TTL: 60}, dnsmessage.TXTResource{TXT: strings.Split(record, "|")}))
+			}
+		}
+		msg, err := builder.Finish()
+		require.NoError(t, err)
+		return msg
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			client, server := net.Pipe()
+			go func() {
+				defer server.Close()
+				for {
+					// net.Pipe is not a PacketConn, so queries are length-prefixed
+					var length uint16
+					if err := binary.Read(server, binary.BigEndian, &length); err != nil {
+						return
+					}
+					query := make([]byte, length)
+					if _, err := io.ReadFull(server, query); err != nil {
+						return
+					}
+					response := answer(query)
+					if err := binary.Write(server, binary.BigEndian, uint16(len(response))); err != nil {
+						return
+					}
+					if _, err := server.Write(response); err != nil {
+						return
+					}
+				}
+			}()
+			return client, nil
+		},
+	}
+}
+
+func TestCheckDomainDNS(t *testing.T) {
+	ctx := context.Background()
+	dkimKey := "v=DKIM1; k=rsa; p=MIGfMA0GCSqGSIb3DQEBAQUAA4GNADCBiQKBgQC"
+
+	resolver := stubResolver(t, map[string][]string{
+		"example.com":                          {"google-site-verification=abc", "v=spf1 include:_spf.google.com include:spf.sendlix.com ~all"},
+		"_dmarc.example.com":                   {"v=DMARC1; p=none; rua=mailto:dmarc@example.com"},
+		"flat._domainkey.example.com":          {dkimKey},
+		"s1.dkim.sendlix.com":                  {dkimKey},
+		"s2.dkim.sendlix.com":                  {"v=DKIM1; k=rsa; p=OTHER"},
+		"old._domainkey.example.com":           {"v=DKIM1; k=rsa; p=OLDKEY"},
+		"double.example.com":                   {"v=spf1 -all", "v=spf1 include:spf.sendlix.com -all"},
+		"partial.example.com":                  {"v=spf1 include:_spf.google.com ~all"},
+		"value._domainkey.partial.example.com": {"v=DKIM1; k=rsa; p=MIGfMA0GCSqG|SIb3DQEBAQUAA4GNADCBiQKBgQC"},
+	}, map[string]string{
+		"s1._domainkey.example.com": "s1.dkim.sendlix.com",
+		"s2._domainkey.example.com": "s2.dkim.sendlix.com",
+	}, "servfail.example.com")
+
+	t.Run("Present records", func(t *testing.T) {
+		checks, err := sendlix.CheckDomainDNS(ctx, "example.com", sendlix.DNSRecords{
+			SPF: "include:spf.sendlix.com",
+			DKIM: []sendlix.DKIMRecord{
+				{Selector: "s1", CNAME: "s1.dkim.sendlix.com"},
+				{Selector: "flat", CNAME: "s1.dkim.sendlix.com"},
+				{Selector: "s1", Value: dkimKey},
+			},
+			DMARC: "v=DMARC1",
+		}, resolver)
+		require.NoError(t, err)
+		require.Len(t, checks, 5)
+
+		for _, check := range checks {
+			assert.Equal(t, sendlix.DNSRecordFound, check.Status, "%s %s: %s %v", check.Type, check.Name, check.Detail, check.Err)
+		}
+		assert.Equal(t, "SPF", checks[0].Type)
+		assert.Equal(t, []string{"v=spf1 include:_spf.google.com include:spf.sendlix.com ~all"}, checks[0].Actual)
+		assert.Equal(t, "s1._domainkey.example.com", checks[1].Name)
+		assert.Equal(t, "_dmarc.example.com", checks[4].Name)
+	})
+
+	t.Run("Absent records", func(t *testing.T) {
+		checks, err := sendlix.CheckDomainDNS(ctx, "absent.example.com", sendlix.DNSRecords{
+			SPF:   "include:spf.sendlix.com",
+			DKIM:  []sendlix.DKIMRecord{{Selector: "s1", CNAME: "s1.dkim.sendlix.com"}},
+			DMARC: "v=DMARC1; p=quarantine",
+		}, resolver)
+		require.NoError(t, err)
+		require.Len(t, checks, 3)
+		for _, check := range checks {
+			assert.Equal(t, sendlix.DNSRecordMissing, check.Status, check.Type)
+			assert.NoError(t, check.Err)
+			assert.Empty(t, check.Actual)
+		}
+	})
+
+	t.Run("Partially matching records", func(t *testing.T) {
+		checks, err := sendlix.CheckDomainDNS(ctx, "example.com", sendlix.DNSRecords{
+			SPF: "v=spf1 include:spf.sendlix.com ~all",
+			DKIM: []sendlix.DKIMRecord{
+				{Selector: "s2", CNAME: "s1.dkim.sendlix.com"},
+				{Selector: "old", Value: dkimKey},
+			},
+			DMARC: "v=DMARC1; p=quarantine; rua=mailto:dmarc@example.com; pct=100",
+		}, resolver)
+		require.NoError(t, err)
+		require.Len(t, checks, 4)
+		for _, check := range checks {
+			assert.Equal(t, sendlix.DNSRecordMismatch, check.Status, check.Type)
+		}
+		assert.Equal(t, []string{"v=DKIM1; k=rsa; p=OLDKEY"}, checks[2].Actual)
+		assert.Equal(t, `p is "none", expected "quarantine"; pct is missing, expected "100"`, checks[3].Detail)
+	})
+
+	t.Run("SPF mechanism missing and split DKIM strings", func(t *testing.T) {
+		checks, err := sendlix.CheckDomainDNS(ctx, "partial.example.com", sendlix.DNSRecords{
+			SPF:  "include:spf.sendlix.com",
+			DKIM: []sendlix.DKIMRecord{{Selector: "value", Value: dkimKey}},
+		}, resolver)
+		require.NoError(t, err)
+		require.Len(t, checks, 2)
+		assert.Equal(t, sendlix.DNSRecordMismatch, checks[0].Status)
+		assert.Equal(t, `SPF record does not contain "include:spf.sendlix.com"`, checks[0].Detail)
+		assert.Equal(t, sendlix.DNSRecordFound, checks[1].Status)
+	})
+
+	t.Run("Multiple SPF records", func(t *testing.T) {
+		checks, err := sendlix.CheckDomainDNS(ctx, "double.example.com", sendlix.DNSRecords{SPF: "include:spf.sendlix.com"}, resolver)
+		require.NoError(t, err)
+		assert.Equal(t, sendlix.DNSRecordMismatch, checks[0].Status)
+		assert.Len(t, checks[0].Actual, 2)
+	})
+
+	t.Run("Lookup failure", func(t *testing.T) {
+		checks, err := sendlix.CheckDomainDNS(ctx, "servfail.example.com", sendlix.DNSRecords{SPF: "include:spf.sendlix.com"}, resolver)
+		require.NoError(t, err)
+		assert.Equal(t, sendlix.DNSRecordLookupFailed, checks[0].Status)
+		assert.Error(t, checks[0].Err)
+	})
+
+	t.Run("Missing domain", func(t *testing.T) {
+		_, err := sendlix.CheckDomainDNS(ctx, " ", sendlix.DNSRecords{SPF: "include:spf.sendlix.com"}, resolver)
+		assert.ErrorIs(t, err, sendlix.ErrMissingDomain)
+	})
+}
@@ -0,0 +1,104 @@
+package sendlix_test
+
+import (
+	"context"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		expected        string
+		expectedLowered string
+	}{
+		{"Already normalized", "user@example.com", "user@example.com", "user@example.com"},
+		{"Mixed case", "User@Example.COM", "User@example.com", "user@example.com"},
+		{"Surrounding whitespace", " \tuser@example.com\n", "user@example.com", "user@example.com"},
+		{"Angle brackets", "<User@Example.com>", "User@example.com", "user@example.com"},
+		{"Whitespace around brackets", "  < user@example.com >  ", "user@example.com", "user@example.com"},
+		{"Quoted local part with at sign", `"a@b"@Example.com`, `"a@b"@example.com`, `"a@b"@example.com`},
+		{"No at sign", " Invalid ", "Invalid", "invalid"},
+		{"Empty", "   ", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalized := sendlix.NormalizeEmail(tt.input, false)
+			assert.Equal(t, tt.expected, normalized)
+			assert.Equal(t, normalized, sendlix.NormalizeEmail(normalized, false), "normalization is not idempotent")
+
+			lowered := sendlix.NormalizeEmail(tt.input, true)
+			assert.Equal(t, tt.expectedLowered, lowered)
+			assert.Equal(t, lowered, sendlix.NormalizeEmail(lowered, true), "normalization is not idempotent")
+		})
+	}
+}
+
+func TestGroupClientNormalizesEmails(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+
+	var emails []string
+	server.update(func(h *fakeHandlers) {
+		h.insertEmailToGroup = func(ctx context.Context, req *pb.InsertEmailToGroupRequest) (*pb.UpdateResponse, error) {
+			for _, entry := range req.Entries {
+				emails = append(emails, entry.Email.Email)
+			}
+			return &pb.UpdateResponse{Success: true, AffectedRows: int64(len(req.Entries))}, nil
+		}
+		h.removeEmailFromGroup = func(ctx context.Context, req *pb.RemoveEmailFromGroupRequest) (*pb.UpdateResponse, error) {
+			emails = append(emails, req.Email)
+			return &pb.UpdateResponse{Success: true, AffectedRows: 1}, nil
+		}
+		h.checkEmailInGroup = func(ctx context.Context, req *pb.CheckEmailInGroupRequest) (*pb.CheckEmailInGroupResponse, error) {
+			emails = append(emails, req.Email)
+			return &pb.CheckEmailInGroupResponse{Exists: req.Email == "user@example.com"}, nil
+		}
+	})
+
+	run := func(t *testing.T, config *sendlix.ClientConfig) bool {
+		emails = nil
+		client, err := sendlix.NewGroupClient(&MockAuth{Token: "test-token"}, config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.InsertEmailToGroup(ctx, "group-1", sendlix.GroupEntry{Email: " <User@Example.COM>"})
+		require.NoError(t, err)
+		_, err = client.RemoveEmailFromGroup(ctx, "group-1", "User@EXAMPLE.com ")
+		require.NoError(t, err)
+		exists, err := client.CheckEmailInGroup(ctx, "group-1", "<User@Example.COM>")
+		require.NoError(t, err)
+		return exists
+	}
+
+	t.Run("Domain is lowercased by default", func(t *testing.T) {
+		exists := run(t, server.config())
+
+		assert.Equal(t, []string{"User@example.com", "User@example.com", "User@example.com"}, emails)
+		assert.False(t, exists)
+	})
+
+	t.Run("Local part is lowercased when enabled", func(t *testing.T) {
+		config := server.config()
+		config.LowercaseEmailLocalPart = true
+		exists := run(t, config)
+
+		assert.Equal(t, []string{"user@example.com", "user@example.com", "user@example.com"}, emails)
+		assert.True(t, exists)
+	})
+
+	t.Run("Whitespace-only email is rejected", func(t *testing.T) {
+		client, err := sendlix.NewGroupClient(&MockAuth{Token: "test-token"}, server.config())
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.CheckEmailInGroup(ctx, "group-1", " <> ")
+		assert.ErrorIs(t, err, sendlix.ErrMissingEmail)
+	})
+}
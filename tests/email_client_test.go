@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	sendlix "github.com/sendlix/go-sdk"
+	"github.com/sendlix/go-sdk/sendlixtest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -130,47 +131,41 @@ func TestMailOptions(t *testing.T) {
 		replyTo := sendlix.EmailAddress{Email: "reply@example.com"}
 		imageData := []byte{0x89, 0x50, 0x4E, 0x47}
 
-		options := sendlix.MailOptions{
-			From:     sendlix.EmailAddress{Email: "sender@example.com", Name: "Sender"},
-			To:       []sendlix.EmailAddress{{Email: "recipient@example.com"}},
-			CC:       []sendlix.EmailAddress{{Email: "cc@example.com"}},
-			BCC:      []sendlix.EmailAddress{{Email: "bcc@example.com"}},
-			Subject:  "Test Subject",
-			ReplyTo:  &replyTo,
-			Html:     "<h1>Hello</h1>",
-			Text:     "Hello",
-			Tracking: true,
-			Images: []sendlix.Image{
+		options := sendlixtest.MailOptionsWith(func(o *sendlix.MailOptions) {
+			o.From.Name = "Sender"
+			o.CC = []sendlix.EmailAddress{{Email: "cc@example.com"}}
+			o.BCC = []sendlix.EmailAddress{{Email: "bcc@example.com"}}
+			o.ReplyTo = &replyTo
+			o.Tracking = true
+			o.Images = []sendlix.Image{
 				{Placeholder: "{{logo}}", Data: imageData, Type: sendlix.MimeTypePNG},
-			},
-		}
+			}
+		})
 
 		assert.Equal(t, "sender@example.com", options.From.Email)
 		assert.Equal(t, "Sender", options.From.Name)
 		assert.Len(t, options.To, 1)
 		assert.Len(t, options.CC, 1)
 		assert.Len(t, options.BCC, 1)
-		assert.Equal(t, "Test Subject", options.Subject)
+		assert.Equal(t, "Fixture subject", options.Subject)
 		assert.Equal(t, "reply@example.com", options.ReplyTo.Email)
-		assert.Equal(t, "<h1>Hello</h1>", options.Html)
-		assert.Equal(t, "Hello", options.Text)
+		assert.Equal(t, "<p>Fixture content</p>", options.Html)
+		assert.Equal(t, "Fixture content", options.Text)
 		assert.True(t, options.Tracking)
 		assert.Len(t, options.Images, 1)
 		assert.Equal(t, "{{logo}}", options.Images[0].Placeholder)
 	})
 
 	t.Run("Create minimal MailOptions", func(t *testing.T) {
-		options := sendlix.MailOptions{
-			From:    sendlix.EmailAddress{Email: "sender@example.com"},
-			To:      []sendlix.EmailAddress{{Email: "recipient@example.com"}},
-			Subject: "Test",
-			Html:    "<p>Test</p>",
-		}
+		options := sendlixtest.MailOptionsWith(func(o *sendlix.MailOptions) {
+			o.From.Name, o.To[0].Name = "", ""
+			o.Text = ""
+		})
 
 		assert.Equal(t, "sender@example.com", options.From.Email)
 		assert.Len(t, options.To, 1)
-		assert.Equal(t, "Test", options.Subject)
-		assert.Equal(t, "<p>Test</p>", options.Html)
+		assert.Equal(t, "Fixture subject", options.Subject)
+		assert.Equal(t, "<p>Fixture content</p>", options.Html)
 		assert.Empty(t, options.Text)
 		assert.False(t, options.Tracking)
 		assert.Nil(t, options.Images)
@@ -0,0 +1,237 @@
+package sendlix_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDefaults(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+
+	var mu sync.Mutex
+	var requests []*pb.SendMailRequest
+	var groupRequests []*pb.GroupMailData
+	server.update(func(h *fakeHandlers) {
+		h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			requests = append(requests, req)
+			return &pb.SendEmailResponse{Message: []string{"msg-1"}, EmailsLeft: 42}, nil
+		}
+		h.sendGroupEmail = func(ctx context.Context, req *pb.GroupMailData) (*pb.SendEmailResponse, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			groupRequests = append(groupRequests, req)
+			return &pb.SendEmailResponse{Message: []string{"group-1"}}, nil
+		}
+	})
+
+	recorder := sendlix.NewMemorySendRecorder()
+	config := server.config()
+	config.SendRecorder = recorder
+
+	root, err := sendlix.NewEmailClient(&MockAuth{Token: "test-token"}, config)
+	require.NoError(t, err)
+	defer root.Close()
+
+	payments := root.WithDefaults(sendlix.Defaults{
+		From:     sendlix.EmailAddress{Email: "billing@example.com", Name: "Billing"},
+		Category: "receipt",
+		Metadata: map[string]string{"team": "payments", "source": "checkout"},
+	})
+	marketing := root.WithDefaults(sendlix.Defaults{
+		From:     sendlix.EmailAddress{Email: "news@example.com"},
+		Category: "marketing",
+	})
+
+	options := sendlix.MailOptions{
+		To:      []sendlix.EmailAddress{{Email: "customer@example.com"}},
+		Subject: "Hello",
+		Text:    "Hello",
+	}
+
+	t.Run("Defaults fill missing values", func(t *testing.T) {
+		requests = nil
+		additional := &sendlix.AdditionalOptions{}
+
+		_, err := payments.SendEmail(sendlix.WithRecordMetadata(ctx, map[string]string{"source": "retry"}), options, additional)
+		require.NoError(t, err)
+
+		require.Len(t, requests, 1)
+		assert.Equal(t, "billing@example.com", requests[0].From.Email)
+		assert.Equal(t, "Billing", requests[0].From.Name)
+		assert.Equal(t, "receipt", requests[0].AdditionalInfos.Category)
+		assert.Empty(t, additional.Category, "caller options must not be modified")
+		assert.Empty(t, options.From.Email, "caller options must not be modified")
+
+		record, ok := recorder.Lookup("msg-1")
+		require.True(t, ok)
+		assert.Equal(t, map[string]string{"team": "payments", "source": "retry"}, record.Metadata)
+	})
+
+	t.Run("Explicit values win over defaults", func(t *testing.T) {
+		requests = nil
+		explicit := options
+		explicit.From = sendlix.EmailAddress{Email: "support@example.com"}
+
+		_, err := payments.SendEmail(ctx, explicit, &sendlix.AdditionalOptions{Category: "refund"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "support@example.com", requests[0].From.Email)
+		assert.Equal(t, "refund", requests[0].AdditionalInfos.Category)
+	})
+
+	t.Run("Siblings are isolated", func(t *testing.T) {
+		requests, groupRequests = nil, nil
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_, err := payments.SendEmail(ctx, options, nil)
+				assert.NoError(t, err)
+			}()
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, marketing.SendGroupEmail(ctx, sendlix.GroupMailData{
+					GroupID: "group-1",
+					Subject: "News",
+					Content: sendlix.MailContent{Text: "News"},
+				}))
+			}()
+		}
+		wg.Wait()
+
+		require.Len(t, requests, 10)
+		for _, req := range requests {
+			assert.Equal(t, "billing@example.com", req.From.Email)
+			assert.Equal(t, "receipt", req.AdditionalInfos.Category)
+		}
+		require.Len(t, groupRequests, 10)
+		for _, req := range groupRequests {
+			assert.Equal(t, "news@example.com", req.From.Email)
+			assert.Equal(t, "marketing", req.Category)
+		}
+
+		_, err := root.SendEmail(ctx, options, nil)
+		assert.ErrorIs(t, err, sendlix.ErrMissingFrom, "root client has no defaults")
+	})
+
+	t.Run("Nested defaults are merged", func(t *testing.T) {
+		requests = nil
+		refunds := payments.WithDefaults(sendlix.Defaults{
+			Category: "refund",
+			Metadata: map[string]string{"source": "refunds"},
+		})
+
+		_, err := refunds.SendEmail(ctx, options, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, "billing@example.com", requests[0].From.Email)
+		assert.Equal(t, "refund", requests[0].AdditionalInfos.Category)
+		records := recorder.Records()
+		assert.Equal(t, map[string]string{"team": "payments", "source": "refunds"}, records[len(records)-1].Metadata)
+	})
+
+	t.Run("Quota is shared with the root", func(t *testing.T) {
+		left, _ := root.EmailsLeft()
+		assert.Equal(t, int64(42), left)
+	})
+
+	t.Run("BeforeSend hooks of every scope run", func(t *testing.T) {
+		rejected := errors.New("not allowed")
+		var calls []string
+		var records []sendlix.SendRecord
+		guarded := root.WithDefaults(sendlix.Defaults{
+			From: sendlix.EmailAddress{Email: "billing@example.com"},
+			BeforeSend: func(ctx context.Context, record sendlix.SendRecord) error {
+				calls = append(calls, "parent")
+				records = append(records, record)
+				if record.Category == "marketing" {
+					return rejected
+				}
+				return nil
+			},
+		})
+		child := guarded.WithDefaults(sendlix.Defaults{
+			Metadata: map[string]string{"team": "refunds"},
+			BeforeSend: func(ctx context.Context, record sendlix.SendRecord) error {
+				calls = append(calls, "child")
+				return nil
+			},
+		})
+
+		_, err := child.SendEmail(sendlix.WithJobID(ctx, "job-1"), options, &sendlix.AdditionalOptions{Category: "refund"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"parent", "child"}, calls)
+		require.Len(t, records, 1)
+		assert.Equal(t, sendlix.SendTypeEmail, records[0].Type)
+		assert.Equal(t, []string{"customer@example.com"}, records[0].Recipients)
+		assert.Equal(t, "refund", records[0].Category)
+		assert.Equal(t, "job-1", records[0].JobID)
+		assert.Equal(t, map[string]string{"team": "refunds"}, records[0].Metadata)
+		assert.Empty(t, records[0].MessageIDs)
+
+		sent, groupSent := server.count("SendEmail"), server.count("SendGroupEmail")
+		_, err = child.SendEmail(ctx, options, &sendlix.AdditionalOptions{Category: "marketing"})
+		assert.ErrorIs(t, err, rejected)
+		_, err = child.SendEMLEmail(ctx, []byte("Subject: Hello\r\n\r\nHello"), &sendlix.AdditionalOptions{Category: "marketing"})
+		assert.ErrorIs(t, err, rejected)
+		err = child.SendGroupEmail(ctx, sendlix.GroupMailData{
+			GroupID:  "group-1",
+			Subject:  "News",
+			Content:  sendlix.MailContent{Text: "News"},
+			Category: "marketing",
+		})
+		assert.ErrorIs(t, err, rejected)
+		assert.Equal(t, sent, server.count("SendEmail"))
+		assert.Zero(t, server.count("SendEmlEmail"))
+		assert.Equal(t, groupSent, server.count("SendGroupEmail"))
+		assert.Equal(t, []string{"parent", "child", "parent", "parent", "parent"}, calls, "a rejecting parent stops the chain")
+		assert.Equal(t, sendlix.SendTypeEML, records[2].Type)
+		assert.Equal(t, sendlix.SendTypeGroup, records[3].Type)
+		assert.Equal(t, "group-1", records[3].GroupID)
+	})
+
+	t.Run("SendRecorder of a scope replaces the configured one", func(t *testing.T) {
+		scoped := sendlix.NewMemorySendRecorder()
+		team := root.WithDefaults(sendlix.Defaults{
+			From:         sendlix.EmailAddress{Email: "team@example.com"},
+			Metadata:     map[string]string{"team": "support"},
+			SendRecorder: scoped,
+		})
+		nested := team.WithDefaults(sendlix.Defaults{Category: "ticket"})
+		recorded := len(recorder.Records())
+
+		_, err := team.SendEmail(ctx, options, nil)
+		require.NoError(t, err)
+		_, err = nested.SendEmail(ctx, options, nil)
+		require.NoError(t, err)
+
+		records := scoped.Records()
+		require.Len(t, records, 2)
+		assert.Equal(t, map[string]string{"team": "support"}, records[0].Metadata)
+		assert.Equal(t, "ticket", records[1].Category, "derived clients inherit the recorder")
+		assert.Len(t, recorder.Records(), recorded)
+	})
+
+	t.Run("Closing a derived client keeps the connection open", func(t *testing.T) {
+		require.NoError(t, payments.Close())
+
+		_, err := marketing.SendEmail(ctx, options, nil)
+		require.NoError(t, err)
+
+		require.NoError(t, root.Close())
+		_, err = marketing.SendEmail(ctx, options, nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,192 @@
+package sendlix_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEMLSender records the messages sent through it and rejects messages
+// whose subject contains "reject".
+type fakeEMLSender struct {
+	delay time.Duration
+
+	mu          sync.Mutex
+	sent        []string
+	categories  []string
+	inFlight    int
+	maxInFlight int
+}
+
+func (f *fakeEMLSender) SendEMLEmail(ctx context.Context, emlData []byte, additional *sendlix.AdditionalOptions) ([]string, error) {
+	f.mu.Lock()
+	f.inFlight++
+	f.maxInFlight = max(f.maxInFlight, f.inFlight)
+	f.mu.Unlock()
+
+	time.Sleep(f.delay)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.inFlight--
+	if strings.Contains(string(emlData), "reject") {
+		return nil, errors.New("rejected by server")
+	}
+	f.sent = append(f.sent, string(emlData))
+	if additional != nil {
+		f.categories = append(f.categories, additional.Category)
+	}
+	return []string{"msg"}, nil
+}
+
+func (f *fakeEMLSender) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.sent)
+}
+
+// emlFixture writes a migration directory with valid, invalid, rejected,
+// and duplicate files and returns its path.
+func emlFixture(t *testing.T) string {
+	t.Helper()
+
+	eml := func(subject string) string {
+		return "From: old@example.com\r\nTo: user@example.com\r\nSubject: " + subject + "\r\n\r\nHello"
+	}
+	files := map[string]string{
+		"inbox/1.eml":        eml("first"),
+		"inbox/2.EML":        eml("second"),
+		"archive/2019/3.eml": eml("third"),
+		"copy/1.eml":         eml("first"),
+		"invalid.eml":        "From: old@example.com\r\nSubject: no recipients\r\n\r\nHello",
+		"rejected.eml":       eml("reject me"),
+		"notes.txt":          "not an email",
+	}
+
+	root := t.TempDir()
+	for path, content := range files {
+		full := filepath.Join(root, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+	return root
+}
+
+func TestMigrateEMLDirectory(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Report", func(t *testing.T) {
+		root := emlFixture(t)
+		sender := &fakeEMLSender{}
+		var mu sync.Mutex
+		results := make(map[string]error)
+
+		report, err := sendlix.MigrateEMLDirectory(ctx, sender, root, sendlix.MigrationOptions{
+			Category: "migration",
+			OnResult: func(path string, messageIDs []string, err error) {
+				mu.Lock()
+				defer mu.Unlock()
+				results[path] = err
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"archive/2019/3.eml", "copy/1.eml", "inbox/2.EML"}, report.Sent)
+		assert.Equal(t, []sendlix.MigrationSkip{{Path: "inbox/1.eml", Reason: "duplicate of copy/1.eml"}}, report.Skipped)
+		require.Len(t, report.Failed, 2)
+		assert.Equal(t, "invalid.eml", report.Failed[0].Path)
+		assertValidationError(t, report.Failed[0].Err, sendlix.CodeInvalidEML, "invalid.eml")
+		assert.Equal(t, "rejected.eml", report.Failed[1].Path)
+		assert.EqualError(t, report.Failed[1].Err, "rejected by server")
+
+		assert.Equal(t, 3, sender.count())
+		assert.Equal(t, []string{"migration", "migration", "migration"}, sender.categories)
+		assert.Len(t, results, 4, "OnResult is called for sent and rejected files")
+		assert.Error(t, results["rejected.eml"])
+	})
+
+	t.Run("Manifest resumes a migration", func(t *testing.T) {
+		root := emlFixture(t)
+		manifest := filepath.Join(t.TempDir(), "manifest")
+
+		first := &fakeEMLSender{}
+		_, err := sendlix.MigrateEMLDirectory(ctx, first, root, sendlix.MigrationOptions{
+			Manifest: manifest,
+			Filter:   func(path string) bool { return strings.HasPrefix(path, "inbox/") },
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, first.count())
+
+		second := &fakeEMLSender{}
+		report, err := sendlix.MigrateEMLDirectory(ctx, second, root, sendlix.MigrationOptions{Manifest: manifest})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"archive/2019/3.eml"}, report.Sent)
+		assert.Equal(t, []sendlix.MigrationSkip{
+			{Path: "copy/1.eml", Reason: "same content as inbox/1.eml in manifest"},
+			{Path: "inbox/1.eml", Reason: "listed in manifest"},
+			{Path: "inbox/2.EML", Reason: "listed in manifest"},
+		}, report.Skipped)
+		assert.Len(t, report.Failed, 2, "failed files are retried")
+		assert.Equal(t, 1, second.count())
+	})
+
+	t.Run("Dry run", func(t *testing.T) {
+		root := emlFixture(t)
+		manifest := filepath.Join(t.TempDir(), "manifest")
+		sender := &fakeEMLSender{}
+
+		report, err := sendlix.MigrateEMLDirectory(ctx, sender, root, sendlix.MigrationOptions{DryRun: true, Manifest: manifest})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"archive/2019/3.eml", "copy/1.eml", "inbox/2.EML", "rejected.eml"}, report.Sent)
+		assert.Len(t, report.Skipped, 1)
+		assert.Len(t, report.Failed, 1)
+		assert.Zero(t, sender.count())
+		assert.NoFileExists(t, manifest)
+	})
+
+	t.Run("Concurrency is bounded", func(t *testing.T) {
+		root := t.TempDir()
+		for i := range 12 {
+			content := "From: a@example.com\r\nTo: b@example.com\r\nSubject: " + string(rune('a'+i)) + "\r\n\r\nHi"
+			require.NoError(t, os.WriteFile(filepath.Join(root, string(rune('a'+i))+".eml"), []byte(content), 0o644))
+		}
+		sender := &fakeEMLSender{delay: 10 * time.Millisecond}
+
+		report, err := sendlix.MigrateEMLDirectory(ctx, sender, root, sendlix.MigrationOptions{Concurrency: 3})
+
+		require.NoError(t, err)
+		assert.Len(t, report.Sent, 12)
+		assert.LessOrEqual(t, sender.maxInFlight, 3)
+		assert.Greater(t, sender.maxInFlight, 1)
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		root := emlFixture(t)
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		sender := &fakeEMLSender{}
+
+		report, err := sendlix.MigrateEMLDirectory(canceled, sender, root, sendlix.MigrationOptions{})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		require.NotNil(t, report)
+		assert.Zero(t, sender.count())
+	})
+
+	t.Run("Missing directory", func(t *testing.T) {
+		_, err := sendlix.MigrateEMLDirectory(ctx, &fakeEMLSender{}, filepath.Join(t.TempDir(), "missing"), sendlix.MigrationOptions{})
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
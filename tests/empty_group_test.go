@@ -0,0 +1,66 @@
+package sendlix_test
+
+import (
+	"context"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailOnEmptyGroup(t *testing.T) {
+	ctx := context.Background()
+	data := sendlix.GroupMailData{
+		GroupID: "group-1",
+		From:    sendlix.EmailAddress{Email: "sender@example.com"},
+		Subject: "Hello",
+		Content: sendlix.MailContent{Text: "Hello"},
+	}
+
+	newClient := func(t *testing.T, messages []string, failOnEmpty bool) (*sendlix.EmailClient, *sendlix.MemorySendRecorder) {
+		server := newFakeServer(t)
+		server.update(func(h *fakeHandlers) {
+			h.sendGroupEmail = func(ctx context.Context, req *pb.GroupMailData) (*pb.SendEmailResponse, error) {
+				return &pb.SendEmailResponse{Message: messages, EmailsLeft: 10}, nil
+			}
+		})
+
+		recorder := sendlix.NewMemorySendRecorder()
+		config := server.config()
+		config.FailOnEmptyGroup = failOnEmpty
+		config.SendRecorder = recorder
+
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test-token"}, config)
+		require.NoError(t, err)
+		t.Cleanup(func() { client.Close() })
+		return client, recorder
+	}
+
+	t.Run("Empty group fails when enabled", func(t *testing.T) {
+		client, recorder := newClient(t, nil, true)
+
+		err := client.SendGroupEmail(ctx, data)
+		assert.ErrorIs(t, err, sendlix.ErrEmptyGroup)
+		assert.Contains(t, err.Error(), `"group-1"`)
+		assert.Empty(t, recorder.Records())
+
+		emailsLeft, _ := client.EmailsLeft()
+		assert.Equal(t, int64(10), emailsLeft, "quota is still tracked")
+	})
+
+	t.Run("Empty group succeeds by default", func(t *testing.T) {
+		client, recorder := newClient(t, nil, false)
+
+		require.NoError(t, client.SendGroupEmail(ctx, data))
+		assert.Len(t, recorder.Records(), 1)
+	})
+
+	t.Run("Non-empty group succeeds when enabled", func(t *testing.T) {
+		client, recorder := newClient(t, []string{"msg-1", "msg-2"}, true)
+
+		require.NoError(t, client.SendGroupEmail(ctx, data))
+		assert.Len(t, recorder.Records(), 1)
+	})
+}
@@ -0,0 +1,185 @@
+package sendlix_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	"github.com/sendlix/go-sdk/sendlixtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidationError(t *testing.T) {
+	t.Run("Default English messages", func(t *testing.T) {
+		_, err := sendlix.NewAuth("secret123456")
+
+		require.Error(t, err)
+		assert.Equal(t, "invalid API key format. Expected format: 'secret.keyID'", err.Error())
+		assert.True(t, errors.Is(err, sendlix.ErrInvalidAPIKeyFormat))
+	})
+
+	t.Run("Params are exposed and formatted", func(t *testing.T) {
+		client, err := sendlix.NewGroupClient(&MockAuth{Token: "test"}, nil)
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.InsertEmailsToGroup(context.Background(), "group-1", []sendlix.GroupEntry{
+			{Email: "user@example.com"},
+			{Name: "No Email"},
+		}, nil)
+
+		var validationErr *sendlix.ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, sendlix.CodeMissingEntryEmail, validationErr.Code)
+		assert.Equal(t, "1", validationErr.Params["index"])
+		assert.Equal(t, "email address is required for entry at index 1", err.Error())
+		assert.True(t, errors.Is(err, sendlix.ErrMissingEntryEmail))
+		assert.False(t, errors.Is(err, sendlix.ErrMissingEmail))
+	})
+}
+
+func TestMessageTranslator(t *testing.T) {
+	german := map[sendlix.ErrorCode]string{
+		sendlix.CodeMissingSubject:    "Betreff ist erforderlich",
+		sendlix.CodeMissingEntryEmail: "E-Mail-Adresse für Eintrag {index} fehlt",
+	}
+
+	sendlix.SetMessageTranslator(func(code sendlix.ErrorCode, params map[string]string) string {
+		msg := german[code]
+		if index, ok := params["index"]; ok {
+			msg = "E-Mail-Adresse für Eintrag " + index + " fehlt"
+		}
+		return msg
+	})
+	defer sendlix.SetMessageTranslator(nil)
+
+	client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, nil)
+	require.NoError(t, err)
+	defer client.Close()
+
+	t.Run("Translated message", func(t *testing.T) {
+		_, err := client.SendEmail(context.Background(), sendlixtest.MailOptionsWith(func(o *sendlix.MailOptions) {
+			o.Subject = ""
+		}), nil)
+
+		require.Error(t, err)
+		assert.Equal(t, "Betreff ist erforderlich", err.Error())
+		assert.True(t, errors.Is(err, sendlix.ErrMissingSubject))
+	})
+
+	t.Run("Translated message with params", func(t *testing.T) {
+		groupClient, err := sendlix.NewGroupClient(&MockAuth{Token: "test"}, nil)
+		require.NoError(t, err)
+		defer groupClient.Close()
+
+		_, err = groupClient.InsertEmailsToGroup(context.Background(), "group-1", []sendlix.GroupEntry{{}}, nil)
+
+		require.Error(t, err)
+		assert.Equal(t, "E-Mail-Adresse für Eintrag 0 fehlt", err.Error())
+		assert.True(t, errors.Is(err, sendlix.ErrMissingEntryEmail))
+	})
+
+	t.Run("Fallback to English", func(t *testing.T) {
+		_, err := client.SendEmail(context.Background(), sendlixtest.InvalidVariants()["missing from"].Options, nil)
+
+		require.Error(t, err)
+		assert.Equal(t, "from email is required", err.Error())
+		assert.True(t, errors.Is(err, sendlix.ErrMissingFrom))
+	})
+}
+
+func TestErrorCodes(t *testing.T) {
+	t.Run("Registered errors have unique codes", func(t *testing.T) {
+		seen := make(map[sendlix.ErrorCode]error)
+		for _, err := range sendlix.RegisteredErrors() {
+			code := sendlix.Code(err)
+			assert.NotEmpty(t, code, "%v", err)
+			assert.NotEqual(t, sendlix.CodeUnknown, code, "%v", err)
+			assert.True(t, strings.HasPrefix(string(code), "sendlix."), "%s", code)
+			if other, ok := seen[code]; ok {
+				t.Errorf("code %s is used by %q and %q", code, other, err)
+			}
+			seen[code] = err
+		}
+	})
+
+	t.Run("Every exported sentinel error is registered", func(t *testing.T) {
+		// Collect the exported Err* variables declared in the package source
+		files, err := filepath.Glob(filepath.Join("..", "*.go"))
+		require.NoError(t, err)
+
+		var names []string
+		fset := token.NewFileSet()
+		for _, file := range files {
+			f, err := parser.ParseFile(fset, file, nil, 0)
+			require.NoError(t, err)
+			for _, decl := range f.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.VAR {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					for _, name := range spec.(*ast.ValueSpec).Names {
+						if strings.HasPrefix(name.Name, "Err") {
+							names = append(names, name.Name)
+						}
+					}
+				}
+			}
+		}
+
+		assert.Len(t, sendlix.RegisteredErrors(), len(names), "sentinels declared: %v", names)
+	})
+
+	t.Run("Codes of SDK errors", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			err      error
+			expected sendlix.ErrorCode
+		}{
+			{"Nil", nil, ""},
+			{"Validation", fmt.Errorf("wrapped: %w", sendlix.ErrMissingFrom), sendlix.CodeMissingFrom},
+			{"Read-only", fmt.Errorf("%w: SendEmail is not permitted", sendlix.ErrReadOnlyMode), sendlix.CodeReadOnlyMode},
+			{"Unavailable", fmt.Errorf("failed to send email: %w", status.Error(codes.Unavailable, "down")), sendlix.CodeUnavailable},
+			{"Quota exceeded", retryInfoError(t, time.Second), sendlix.CodeQuotaExceeded},
+			{"Resource exhausted without quota details", status.Error(codes.ResourceExhausted, "busy"), sendlix.CodeResourceExhausted},
+			{"Message too large", status.Error(codes.ResourceExhausted, "grpc: received message larger than max (6291456 vs. 4194304)"), sendlix.CodeMessageTooLarge},
+			{"Unauthenticated", status.Error(codes.Unauthenticated, "bad token"), sendlix.CodeUnauthorized},
+			{"Unmapped status", status.Error(codes.DataLoss, "lost"), sendlix.CodeUnknown},
+			{"Context deadline", fmt.Errorf("failed to connect: %w", context.DeadlineExceeded), sendlix.CodeDeadlineExceeded},
+			{"Plain error", errors.New("boom"), sendlix.CodeUnknown},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.expected, sendlix.Code(tt.err))
+			})
+		}
+	})
+
+	t.Run("Auth failures take precedence over the transport error", func(t *testing.T) {
+		server := newFakeServer(t)
+
+		authErr := fmt.Errorf("failed to get JWT token: %w", status.Error(codes.Unavailable, "down"))
+		client, err := sendlix.NewGroupClient(&MockAuth{Error: authErr}, server.config())
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.CheckEmailInGroup(context.Background(), "group-1", "user@example.com")
+		require.Error(t, err)
+		assert.Equal(t, sendlix.CodeAuthFailed, sendlix.Code(err))
+		assert.ErrorIs(t, err, authErr)
+		assert.Contains(t, err.Error(), "failed to get auth header: failed to get JWT token")
+	})
+}
@@ -0,0 +1,341 @@
+package sendlix_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeServer is a TLS gRPC server implementing the Sendlix services for tests.
+// Handlers can be replaced per test; calls are counted per method.
+type fakeServer struct {
+	addr string
+
+	mu       sync.Mutex
+	calls    map[string]int
+	resumed  []bool
+	peers    map[string]bool
+	open     int
+	handlers fakeHandlers
+}
+
+// trackingListener counts the connections of a fakeServer that are
+// currently open.
+type trackingListener struct {
+	net.Listener
+	s *fakeServer
+}
+
+// trackedConn decrements the open connections of a fakeServer once when
+// closed.
+type trackedConn struct {
+	net.Conn
+	s    *fakeServer
+	once sync.Once
+}
+
+func (l *trackingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	l.s.mu.Lock()
+	l.s.open++
+	l.s.mu.Unlock()
+	return &trackedConn{Conn: conn, s: l.s}, nil
+}
+
+func (c *trackedConn) Close() error {
+	c.once.Do(func() {
+		c.s.mu.Lock()
+		c.s.open--
+		c.s.mu.Unlock()
+	})
+	return c.Conn.Close()
+}
+
+// fakeHandlers holds the per-method behavior of a fakeServer.
+type fakeHandlers struct {
+	getJwtToken          func(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error)
+	sendEmail            func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error)
+	sendEmlEmail         func(ctx context.Context, req *pb.EmlMailRequest) (*pb.SendEmailResponse, error)
+	sendGroupEmail       func(ctx context.Context, req *pb.GroupMailData) (*pb.SendEmailResponse, error)
+	insertEmailToGroup   func(ctx context.Context, req *pb.InsertEmailToGroupRequest) (*pb.UpdateResponse, error)
+	removeEmailFromGroup func(ctx context.Context, req *pb.RemoveEmailFromGroupRequest) (*pb.UpdateResponse, error)
+	checkEmailInGroup    func(ctx context.Context, req *pb.CheckEmailInGroupRequest) (*pb.CheckEmailInGroupResponse, error)
+}
+
+type fakeAuthService struct {
+	pb.UnimplementedAuthServer
+	s *fakeServer
+}
+
+type fakeEmailService struct {
+	pb.UnimplementedEmailServer
+	s *fakeServer
+}
+
+type fakeGroupService struct {
+	pb.UnimplementedGroupServer
+	s *fakeServer
+}
+
+// newFakeServer starts a fake server on a random local port and stops it
+// when the test finishes. Additional server options can be passed to
+// influence connection handling; grpc.Creds replaces the self-signed
+// certificate.
+func newFakeServer(t *testing.T, opts ...grpc.ServerOption) *fakeServer {
+	t.Helper()
+
+	s := &fakeServer{calls: make(map[string]int), peers: make(map[string]bool)}
+	h := &s.handlers
+	h.getJwtToken = func(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error) {
+		return &pb.AuthResponse{Token: "fake-token", Expires: timestamppb.New(time.Now().Add(time.Hour))}, nil
+	}
+	h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+		return &pb.SendEmailResponse{Message: []string{"msg-1"}, EmailsLeft: 100}, nil
+	}
+	h.sendEmlEmail = func(ctx context.Context, req *pb.EmlMailRequest) (*pb.SendEmailResponse, error) {
+		return &pb.SendEmailResponse{Message: []string{"eml-1"}, EmailsLeft: 100}, nil
+	}
+	h.sendGroupEmail = func(ctx context.Context, req *pb.GroupMailData) (*pb.SendEmailResponse, error) {
+		return &pb.SendEmailResponse{Message: []string{"group-1"}, EmailsLeft: 100}, nil
+	}
+	h.insertEmailToGroup = func(ctx context.Context, req *pb.InsertEmailToGroupRequest) (*pb.UpdateResponse, error) {
+		return &pb.UpdateResponse{Success: true, AffectedRows: int64(len(req.Entries))}, nil
+	}
+	h.removeEmailFromGroup = func(ctx context.Context, req *pb.RemoveEmailFromGroupRequest) (*pb.UpdateResponse, error) {
+		return &pb.UpdateResponse{Success: true, AffectedRows: 1}, nil
+	}
+	h.checkEmailInGroup = func(ctx context.Context, req *pb.CheckEmailInGroupRequest) (*pb.CheckEmailInGroupResponse, error) {
+		return &pb.CheckEmailInGroupResponse{Exists: true}, nil
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s.addr = lis.Addr().String()
+
+	opts = append([]grpc.ServerOption{grpc.Creds(credentials.NewTLS(selfSignedTLSConfig(t)))}, opts...)
+	opts = append(opts, grpc.UnaryInterceptor(s.recordTLS))
+	srv := grpc.NewServer(opts...)
+	pb.RegisterAuthServer(srv, &fakeAuthService{s: s})
+	pb.RegisterEmailServer(srv, &fakeEmailService{s: s})
+	pb.RegisterGroupServer(srv, &fakeGroupService{s: s})
+
+	go srv.Serve(&trackingListener{Listener: lis, s: s})
+	t.Cleanup(srv.Stop)
+
+	return s
+}
+
+// config returns a client configuration pointing at the fake server.
+func (s *fakeServer) config() *sendlix.ClientConfig {
+	return &sendlix.ClientConfig{
+		ServerAddress: s.addr,
+		UserAgent:     "sendlix-go-sdk-test/1.0.0",
+		Insecure:      true,
+	}
+}
+
+// dial returns a client connection to the fake server that skips
+// certificate verification. It is closed when the test finishes.
+func (s *fakeServer) dial(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+
+	conn, err := grpc.NewClient(s.addr, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+// count returns how often the given method was called.
+func (s *fakeServer) count(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[method]
+}
+
+// resumptions returns, per call, whether the TLS session was resumed.
+func (s *fakeServer) resumptions() []bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]bool(nil), s.resumed...)
+}
+
+// openConnections returns how many client connections are currently open.
+// The server closes its side shortly after the client, so tests should poll,
+// for example with assert.Eventually.
+func (s *fakeServer) openConnections() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.open
+}
+
+// connections returns how many client connections made calls.
+func (s *fakeServer) connections() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.peers)
+}
+
+// recordTLS is a server interceptor recording TLS session resumption and
+// the connections calls arrive on.
+func (s *fakeServer) recordTLS(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if p, ok := peer.FromContext(ctx); ok {
+		s.mu.Lock()
+		s.peers[p.Addr.String()] = true
+		s.mu.Unlock()
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			s.mu.Lock()
+			s.resumed = append(s.resumed, tlsInfo.State.DidResume)
+			s.mu.Unlock()
+		}
+	}
+	return handler(ctx, req)
+}
+
+// update replaces handlers while holding the server lock, so tests can
+// swap behavior without racing with in-flight RPCs.
+func (s *fakeServer) update(fn func(h *fakeHandlers)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(&s.handlers)
+}
+
+// record counts a call and returns the handlers current at that time.
+func (s *fakeServer) record(method string) fakeHandlers {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls[method]++
+	return s.handlers
+}
+
+func (a *fakeAuthService) GetJwtToken(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error) {
+	return a.s.record("GetJwtToken").getJwtToken(ctx, req)
+}
+
+func (e *fakeEmailService) SendEmail(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+	return e.s.record("SendEmail").sendEmail(ctx, req)
+}
+
+func (e *fakeEmailService) SendEmlEmail(ctx context.Context, req *pb.EmlMailRequest) (*pb.SendEmailResponse, error) {
+	return e.s.record("SendEmlEmail").sendEmlEmail(ctx, req)
+}
+
+func (e *fakeEmailService) SendGroupEmail(ctx context.Context, req *pb.GroupMailData) (*pb.SendEmailResponse, error) {
+	return e.s.record("SendGroupEmail").sendGroupEmail(ctx, req)
+}
+
+func (g *fakeGroupService) InsertEmailToGroup(ctx context.Context, req *pb.InsertEmailToGroupRequest) (*pb.UpdateResponse, error) {
+	return g.s.record("InsertEmailToGroup").insertEmailToGroup(ctx, req)
+}
+
+func (g *fakeGroupService) RemoveEmailFromGroup(ctx context.Context, req *pb.RemoveEmailFromGroupRequest) (*pb.UpdateResponse, error) {
+	return g.s.record("RemoveEmailFromGroup").removeEmailFromGroup(ctx, req)
+}
+
+func (g *fakeGroupService) CheckEmailInGroup(ctx context.Context, req *pb.CheckEmailInGroupRequest) (*pb.CheckEmailInGroupResponse, error) {
+	return g.s.record("CheckEmailInGroup").checkEmailInGroup(ctx, req)
+}
+
+// selfSignedTLSConfig creates a server TLS configuration with a freshly
+// generated self-signed certificate for 127.0.0.1.
+func selfSignedTLSConfig(t *testing.T) *tls.Config {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sendlix-fake-server"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+	}
+}
+
+// testCA is a private certificate authority issuing certificates for tests.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pool *x509.CertPool
+}
+
+// newTestCA generates a private certificate authority.
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "sendlix-test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return &testCA{cert: cert, key: key, pool: pool}
+}
+
+// issue returns a certificate signed by the CA for 127.0.0.1, usable for
+// the given purpose.
+func (ca *testCA) issue(t *testing.T, usage x509.ExtKeyUsage) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "sendlix-test"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
@@ -0,0 +1,270 @@
+package sendlix_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	"github.com/sendlix/go-sdk/sendlixtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixtures(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+	client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, server.config())
+	require.NoError(t, err)
+	defer client.Close()
+
+	t.Run("Valid fixtures are sent", func(t *testing.T) {
+		_, err := client.SendEmail(ctx, sendlixtest.ValidMailOptions(), nil)
+		assert.NoError(t, err)
+		assert.NoError(t, client.SendGroupEmail(ctx, sendlixtest.ValidGroupMailData()))
+	})
+
+	t.Run("Mutators do not leak", func(t *testing.T) {
+		sendlixtest.MailOptionsWith(func(o *sendlix.MailOptions) {
+			o.To[0].Email = "changed@example.com"
+		})
+		assert.Equal(t, "recipient@example.com", sendlixtest.ValidMailOptions().To[0].Email)
+	})
+
+	t.Run("Random addresses are deterministic", func(t *testing.T) {
+		assert.Equal(t, sendlixtest.RandomEmailAddress(42), sendlixtest.RandomEmailAddress(42))
+
+		seen := make(map[string]bool)
+		for seed := range int64(50) {
+			addr := sendlixtest.RandomEmailAddress(seed)
+			assert.Regexp(t, `^[a-z]+\.[a-z]+\.[0-9a-f]{4}@example\.(com|org|net)$`, addr.Email)
+			assert.NotEmpty(t, addr.Name)
+			seen[addr.Email] = true
+		}
+		assert.Len(t, seen, 50)
+	})
+
+	t.Run("Invalid variants", func(t *testing.T) {
+		before := server.count("SendEmail")
+		for name, variant := range sendlixtest.InvalidVariants() {
+			t.Run(name, func(t *testing.T) {
+				_, err := client.SendEmail(ctx, variant.Options, variant.Additional)
+				assertValidationError(t, err, variant.Code, variant.Field)
+			})
+		}
+		assert.Equal(t, before, server.count("SendEmail"))
+	})
+
+	t.Run("Invalid group variants", func(t *testing.T) {
+		before := server.count("SendGroupEmail")
+		for name, variant := range sendlixtest.InvalidGroupVariants() {
+			t.Run(name, func(t *testing.T) {
+				err := client.SendGroupEmail(ctx, variant.Data)
+				assertValidationError(t, err, variant.Code, variant.Field)
+			})
+		}
+		assert.Equal(t, before, server.count("SendGroupEmail"))
+	})
+
+	t.Run("Invalid entry variants", func(t *testing.T) {
+		config := server.config()
+		config.SubstitutionLimits = substitutionLimits()
+		groupClient, err := sendlix.NewGroupClient(&MockAuth{Token: "test"}, config)
+		require.NoError(t, err)
+		defer groupClient.Close()
+
+		_, err = groupClient.InsertEmailToGroup(ctx, "fixture-group", validGroupEntry())
+		require.NoError(t, err)
+
+		before := server.count("InsertEmailToGroup")
+		for name, variant := range invalidEntryVariants() {
+			t.Run(name, func(t *testing.T) {
+				_, err := groupClient.InsertEmailToGroup(ctx, "fixture-group", variant.entry)
+				assertValidationError(t, err, variant.code, variant.field)
+			})
+		}
+		assert.Equal(t, before, server.count("InsertEmailToGroup"))
+	})
+
+	t.Run("Invalid data URI variants", func(t *testing.T) {
+		for name, v := range invalidDataURIVariants() {
+			t.Run(name, func(t *testing.T) {
+				_, err := sendlix.EmbedImageDataURI(ctx, v.html, v.placeholder, v.data, v.imageType)
+				assertValidationError(t, err, v.code, v.field)
+			})
+		}
+	})
+
+	t.Run("Invalid variants cover every validation", func(t *testing.T) {
+		covered := make(map[sendlix.ErrorCode]bool)
+		for _, variant := range sendlixtest.InvalidVariants() {
+			covered[variant.Code] = true
+		}
+		for _, variant := range sendlixtest.InvalidGroupVariants() {
+			covered[variant.Code] = true
+		}
+		for _, variant := range invalidEntryVariants() {
+			covered[variant.code] = true
+		}
+		for _, variant := range invalidDataURIVariants() {
+			covered[variant.code] = true
+		}
+
+		for _, sentinel := range sendlix.RegisteredErrors() {
+			var validationErr *sendlix.ValidationError
+			if !errors.As(sentinel, &validationErr) {
+				continue
+			}
+			code := validationErr.Code
+			if reason, ok := uncoveredValidations[code]; ok {
+				assert.False(t, covered[code], "%s is covered by a variant but listed as uncovered (%s)", code, reason)
+				continue
+			}
+			assert.True(t, covered[code], "no invalid variant for %s", code)
+		}
+	})
+}
+
+// uncoveredValidations lists the validation codes that no fixture variant
+// covers, because they do not validate message or group entry content.
+var uncoveredValidations = map[sendlix.ErrorCode]string{
+	sendlix.CodeInvalidAPIKeyFormat:       "credentials",
+	sendlix.CodeEmptyAPISecret:            "credentials",
+	sendlix.CodeInvalidKeyID:              "credentials",
+	sendlix.CodeMissingAuth:               "credentials",
+	sendlix.CodeInvalidAuthType:           "credentials",
+	sendlix.CodeMissingAPIKeyEnv:          "credentials",
+	sendlix.CodeMissingAPIKeyFile:         "credentials",
+	sendlix.CodeMissingToken:              "credentials",
+	sendlix.CodeInvalidEmailAddressType:   "argument of NewEmailAddress",
+	sendlix.CodeMissingEntries:            "empty entry list rather than an invalid entry",
+	sendlix.CodeMissingEmail:              "argument of CheckEmailInGroup and RemoveEmailFromGroup",
+	sendlix.CodeMissingDomain:             "argument of the DNS record helpers",
+	sendlix.CodeInvalidMessageAttachment:  "argument of NewMessageAttachment",
+	sendlix.CodeInvalidEML:                "EML migration input",
+	sendlix.CodeEmptyDigest:               "digest input",
+	sendlix.CodeDigestTooLarge:            "digest input",
+	sendlix.CodeMissingAttachmentUploader: "depends on ClientConfig.AttachmentUploader",
+	sendlix.CodeUnknownCategory:           "depends on ClientConfig.CategoryRegistry",
+	sendlix.CodeUnknownPlaceholder:        "depends on ClientConfig.PlaceholderCheck",
+	sendlix.CodeSenderCheckFailed:         "depends on ClientConfig.SenderCheck",
+	sendlix.CodeStrictWarning:             "depends on ClientConfig.StrictWarnings",
+	sendlix.CodeMissingRecipientResolver:  "depends on ClientConfig.RecipientResolver",
+	sendlix.CodeMessageTooLarge:           "depends on ClientConfig.MaxSendMsgSize",
+	sendlix.CodeInvalidQuietWindow:        "client configuration",
+	sendlix.CodeInvalidRetryPolicy:        "client configuration",
+	sendlix.CodeMissingDeadLetter:         "client configuration",
+	sendlix.CodeUnknownCompressor:         "client configuration",
+	sendlix.CodeInvalidArgument:           "reported by the API",
+}
+
+// assertValidationError asserts that err is a ValidationError with the
+// given code and field.
+func assertValidationError(t *testing.T, err error, code sendlix.ErrorCode, field string) {
+	t.Helper()
+
+	var validationErr *sendlix.ValidationError
+	require.True(t, errors.As(err, &validationErr), "%v", err)
+	assert.Equal(t, code, validationErr.Code)
+	assert.Equal(t, field, validationErr.Field)
+}
+
+// validGroupEntry returns a GroupEntry that passes validation, including
+// the limits of substitutionLimits.
+func validGroupEntry() sendlix.GroupEntry {
+	return sendlix.GroupEntry{
+		Email:         "member@example.com",
+		Name:          "Example Member",
+		Substitutions: map[string]string{"first_name": "Example"},
+	}
+}
+
+// substitutionLimits returns the limits invalidEntryVariants are built to
+// exceed: at most 3 keys of 16 characters with values of 64 characters.
+func substitutionLimits() *sendlix.SubstitutionLimits {
+	return &sendlix.SubstitutionLimits{MaxKeys: 3, MaxKeyLength: 16, MaxValueLength: 64}
+}
+
+// invalidEntryVariant is a GroupEntry failing validation in one specific
+// way.
+type invalidEntryVariant struct {
+	entry sendlix.GroupEntry
+	code  sendlix.ErrorCode
+	field string
+}
+
+// invalidEntryVariants returns every way a GroupEntry can fail the
+// validation of InsertEmailToGroup with substitutionLimits configured.
+func invalidEntryVariants() map[string]invalidEntryVariant {
+	variant := func(code sendlix.ErrorCode, field string, mutate func(*sendlix.GroupEntry)) invalidEntryVariant {
+		entry := validGroupEntry()
+		mutate(&entry)
+		return invalidEntryVariant{entry: entry, code: code, field: field}
+	}
+	const substitutions = "entries[0].Substitutions"
+
+	return map[string]invalidEntryVariant{
+		"missing email": variant(sendlix.CodeMissingEntryEmail, "entries", func(e *sendlix.GroupEntry) {
+			e.Email = ""
+		}),
+		"name too long": variant(sendlix.CodeDisplayNameTooLong, "entries[0]", func(e *sendlix.GroupEntry) {
+			e.Name = strings.Repeat("n", sendlix.MaxDisplayNameLength+1)
+		}),
+		"too many substitutions": variant(sendlix.CodeTooManySubstitutions, substitutions, func(e *sendlix.GroupEntry) {
+			e.Substitutions = map[string]string{"a": "1", "b": "2", "c": "3", "d": "4"}
+		}),
+		"invalid substitution key": variant(sendlix.CodeInvalidSubstitutionKey, substitutions, func(e *sendlix.GroupEntry) {
+			e.Substitutions = map[string]string{"first name": "Example"}
+		}),
+		"substitution key too long": variant(sendlix.CodeSubstitutionKeyTooLong, substitutions, func(e *sendlix.GroupEntry) {
+			e.Substitutions = map[string]string{strings.Repeat("k", 17): "Example"}
+		}),
+		"substitution value too long": variant(sendlix.CodeSubstitutionValueTooLong, substitutions, func(e *sendlix.GroupEntry) {
+			e.Substitutions = map[string]string{"first_name": strings.Repeat("v", 65)}
+		}),
+	}
+}
+
+// invalidDataURIVariant is input to EmbedImageDataURI failing validation in
+// one specific way.
+type invalidDataURIVariant struct {
+	html        string
+	placeholder string
+	data        []byte
+	imageType   sendlix.MimeType
+	code        sendlix.ErrorCode
+	field       string
+}
+
+// invalidDataURIVariants returns every way the input of EmbedImageDataURI
+// can fail validation.
+func invalidDataURIVariants() map[string]invalidDataURIVariant {
+	variant := func(code sendlix.ErrorCode, field string, mutate func(*invalidDataURIVariant)) invalidDataURIVariant {
+		v := invalidDataURIVariant{
+			html:        `<p><img src="{{logo}}" alt="Logo"></p>`,
+			placeholder: "{{logo}}",
+			data:        []byte{0x89, 'P', 'N', 'G'},
+			imageType:   sendlix.MimeTypePNG,
+			code:        code,
+			field:       field,
+		}
+		mutate(&v)
+		return v
+	}
+
+	return map[string]invalidDataURIVariant{
+		"invalid image type": variant(sendlix.CodeInvalidImageType, "imageType", func(v *invalidDataURIVariant) {
+			v.imageType = sendlix.MimeType(99)
+		}),
+		"placeholder not found": variant(sendlix.CodeImagePlaceholderNotFound, "placeholder", func(v *invalidDataURIVariant) {
+			v.placeholder = "{{banner}}"
+		}),
+		"data URI too large": variant(sendlix.CodeDataURITooLarge, "data", func(v *invalidDataURIVariant) {
+			v.data = make([]byte, sendlix.MaxDataURISize)
+		}),
+		"data URIs too large": variant(sendlix.CodeDataURIsTooLarge, "htmlContent", func(v *invalidDataURIVariant) {
+			v.html += `<img src="data:image/png;base64,` + strings.Repeat("A", sendlix.MaxDataURIMessageSize) + `">`
+		}),
+	}
+}
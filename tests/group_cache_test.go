@@ -0,0 +1,224 @@
+package sendlix_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCachedGroupClient(t *testing.T, server *fakeServer, cacheConfig *sendlix.MembershipCacheConfig) *sendlix.GroupClient {
+	t.Helper()
+
+	config := server.config()
+	config.MembershipCache = cacheConfig
+
+	client, err := sendlix.NewGroupClient(&MockAuth{Token: "test-token"}, config)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+
+	return client
+}
+
+func TestMembershipCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Repeated checks are served from cache", func(t *testing.T) {
+		server := newFakeServer(t)
+		client := newCachedGroupClient(t, server, &sendlix.MembershipCacheConfig{TTL: time.Minute})
+
+		for _, email := range []string{"user@example.com", "user@Example.COM", " user@example.com "} {
+			exists, err := client.CheckEmailInGroup(ctx, "group-1", email)
+			require.NoError(t, err)
+			assert.True(t, exists)
+		}
+
+		assert.Equal(t, 1, server.count("CheckEmailInGroup"))
+		assert.Equal(t, sendlix.CacheStats{Hits: 2, Misses: 1}, client.CacheStats())
+	})
+
+	t.Run("Keys match the normalized request address", func(t *testing.T) {
+		server := newFakeServer(t)
+		server.update(func(h *fakeHandlers) {
+			h.checkEmailInGroup = func(ctx context.Context, req *pb.CheckEmailInGroupRequest) (*pb.CheckEmailInGroupResponse, error) {
+				return &pb.CheckEmailInGroupResponse{Exists: req.Email == "User@example.com"}, nil
+			}
+		})
+		client := newCachedGroupClient(t, server, &sendlix.MembershipCacheConfig{TTL: time.Minute})
+
+		// The local part is case-sensitive by default
+		exists, err := client.CheckEmailInGroup(ctx, "group-1", "User@example.com")
+		require.NoError(t, err)
+		assert.True(t, exists)
+		exists, err = client.CheckEmailInGroup(ctx, "group-1", "user@example.com")
+		require.NoError(t, err)
+		assert.False(t, exists, "another member's result is not served")
+		assert.Equal(t, 2, server.count("CheckEmailInGroup"))
+
+		config := server.config()
+		config.MembershipCache = &sendlix.MembershipCacheConfig{TTL: time.Minute}
+		config.LowercaseEmailLocalPart = true
+		lowercasing, err := sendlix.NewGroupClient(&MockAuth{Token: "test-token"}, config)
+		require.NoError(t, err)
+		defer lowercasing.Close()
+		for _, email := range []string{"User@example.com", "user@example.com"} {
+			_, err := lowercasing.CheckEmailInGroup(ctx, "group-1", email)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, sendlix.CacheStats{Hits: 1, Misses: 1}, lowercasing.CacheStats())
+	})
+
+	t.Run("Invalidation during a lookup", func(t *testing.T) {
+		server := newFakeServer(t)
+		received := make(chan struct{})
+		release := make(chan struct{})
+		var mu sync.Mutex
+		member := true
+		server.update(func(h *fakeHandlers) {
+			h.checkEmailInGroup = func(ctx context.Context, req *pb.CheckEmailInGroupRequest) (*pb.CheckEmailInGroupResponse, error) {
+				mu.Lock()
+				exists := member
+				mu.Unlock()
+				if server.count("CheckEmailInGroup") == 1 {
+					close(received)
+					<-release
+				}
+				return &pb.CheckEmailInGroupResponse{Exists: exists}, nil
+			}
+			h.removeEmailFromGroup = func(ctx context.Context, req *pb.RemoveEmailFromGroupRequest) (*pb.UpdateResponse, error) {
+				mu.Lock()
+				member = false
+				mu.Unlock()
+				return &pb.UpdateResponse{Success: true, AffectedRows: 1}, nil
+			}
+		})
+		client := newCachedGroupClient(t, server, &sendlix.MembershipCacheConfig{TTL: time.Minute})
+
+		// The slow lookup reads the membership before the removal
+		done := make(chan bool)
+		go func() {
+			exists, err := client.CheckEmailInGroup(ctx, "group-1", "user@example.com")
+			assert.NoError(t, err)
+			done <- exists
+		}()
+		<-received
+		_, err := client.RemoveEmailFromGroup(ctx, "group-1", "user@example.com")
+		require.NoError(t, err)
+		close(release)
+		assert.True(t, <-done)
+
+		exists, err := client.CheckEmailInGroup(ctx, "group-1", "user@example.com")
+		require.NoError(t, err)
+		assert.False(t, exists, "the stale result was not cached")
+		assert.Equal(t, 2, server.count("CheckEmailInGroup"))
+	})
+
+	t.Run("Entries expire after TTL", func(t *testing.T) {
+		server := newFakeServer(t)
+		client := newCachedGroupClient(t, server, &sendlix.MembershipCacheConfig{TTL: 50 * time.Millisecond})
+
+		_, err := client.CheckEmailInGroup(ctx, "group-1", "user@example.com")
+		require.NoError(t, err)
+
+		time.Sleep(100 * time.Millisecond)
+
+		_, err = client.CheckEmailInGroup(ctx, "group-1", "user@example.com")
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, server.count("CheckEmailInGroup"))
+		assert.Equal(t, sendlix.CacheStats{Hits: 0, Misses: 2}, client.CacheStats())
+	})
+
+	t.Run("Mutations invalidate cached entries", func(t *testing.T) {
+		server := newFakeServer(t)
+		client := newCachedGroupClient(t, server, &sendlix.MembershipCacheConfig{TTL: time.Minute})
+
+		_, err := client.CheckEmailInGroup(ctx, "group-1", "user@example.com")
+		require.NoError(t, err)
+
+		_, err = client.RemoveEmailFromGroup(ctx, "group-1", "user@EXAMPLE.com")
+		require.NoError(t, err)
+
+		server.update(func(h *fakeHandlers) {
+			h.checkEmailInGroup = func(ctx context.Context, req *pb.CheckEmailInGroupRequest) (*pb.CheckEmailInGroupResponse, error) {
+				return &pb.CheckEmailInGroupResponse{Exists: false}, nil
+			}
+		})
+
+		exists, err := client.CheckEmailInGroup(ctx, "group-1", "user@example.com")
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		_, err = client.InsertEmailToGroup(ctx, "group-1", sendlix.GroupEntry{Email: "user@example.com"})
+		require.NoError(t, err)
+
+		_, err = client.CheckEmailInGroup(ctx, "group-1", "user@example.com")
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, server.count("CheckEmailInGroup"))
+	})
+
+	t.Run("Bypass skips the cache", func(t *testing.T) {
+		server := newFakeServer(t)
+		client := newCachedGroupClient(t, server, &sendlix.MembershipCacheConfig{TTL: time.Minute})
+
+		_, err := client.CheckEmailInGroup(ctx, "group-1", "user@example.com")
+		require.NoError(t, err)
+
+		_, err = client.CheckEmailInGroup(sendlix.WithCacheBypass(ctx), "group-1", "user@example.com")
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, server.count("CheckEmailInGroup"))
+	})
+
+	t.Run("Least recently used entries are evicted", func(t *testing.T) {
+		server := newFakeServer(t)
+		client := newCachedGroupClient(t, server, &sendlix.MembershipCacheConfig{TTL: time.Minute, MaxEntries: 2})
+
+		for _, email := range []string{"a@example.com", "b@example.com", "a@example.com", "c@example.com", "a@example.com", "b@example.com"} {
+			_, err := client.CheckEmailInGroup(ctx, "group-1", email)
+			require.NoError(t, err)
+		}
+
+		// a, b, c and the evicted b require RPCs; both repeated a lookups are hits
+		assert.Equal(t, 4, server.count("CheckEmailInGroup"))
+		assert.Equal(t, sendlix.CacheStats{Hits: 2, Misses: 4}, client.CacheStats())
+	})
+
+	t.Run("Concurrent checks", func(t *testing.T) {
+		server := newFakeServer(t)
+		client := newCachedGroupClient(t, server, &sendlix.MembershipCacheConfig{TTL: time.Minute})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := client.CheckEmailInGroup(ctx, "group-1", "user@example.com")
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		stats := client.CacheStats()
+		assert.Equal(t, uint64(50), stats.Hits+stats.Misses)
+	})
+
+	t.Run("Disabled by default", func(t *testing.T) {
+		server := newFakeServer(t)
+		client := newCachedGroupClient(t, server, nil)
+
+		for i := 0; i < 2; i++ {
+			_, err := client.CheckEmailInGroup(ctx, "group-1", "user@example.com")
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, 2, server.count("CheckEmailInGroup"))
+		assert.Equal(t, sendlix.CacheStats{}, client.CacheStats())
+	})
+}
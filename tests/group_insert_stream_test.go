@@ -0,0 +1,156 @@
+package sendlix_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestInsertStream(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+
+	var mu sync.Mutex
+	var chunks []int
+	failAt := -1
+	var gate chan struct{}
+	server.update(func(h *fakeHandlers) {
+		h.insertEmailToGroup = func(ctx context.Context, req *pb.InsertEmailToGroupRequest) (*pb.UpdateResponse, error) {
+			mu.Lock()
+			wait := gate
+			mu.Unlock()
+			if wait != nil {
+				<-wait
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if len(chunks) == failAt {
+				return nil, status.Error(codes.Unavailable, "connection reset")
+			}
+			chunks = append(chunks, len(req.Entries))
+			return &pb.UpdateResponse{Success: true, Message: "chunk " + strconv.Itoa(len(chunks)), AffectedRows: int64(len(req.Entries))}, nil
+		}
+	})
+	reset := func(fail int, block chan struct{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		chunks = nil
+		failAt = fail
+		gate = block
+	}
+
+	client, err := sendlix.NewGroupClient(&MockAuth{Token: "test-token"}, server.config())
+	require.NoError(t, err)
+	defer client.Close()
+
+	entry := func(i int) sendlix.GroupEntry {
+		return sendlix.GroupEntry{Email: "user" + strconv.Itoa(i) + "@example.com"}
+	}
+
+	t.Run("Entries are sent in chunks", func(t *testing.T) {
+		reset(-1, nil)
+		stream, err := client.OpenInsertStream(ctx, "newsletter", nil)
+		require.NoError(t, err)
+
+		for i := 0; i < 2*sendlix.InsertStreamChunkSize+1; i++ {
+			require.NoError(t, stream.Add(entry(i)))
+		}
+		assert.Equal(t, 2*sendlix.InsertStreamChunkSize, stream.Acknowledged())
+
+		resp, err := stream.CloseAndRecv()
+		require.NoError(t, err)
+		assert.Equal(t, &sendlix.UpdateResponse{Success: true, Message: "chunk 3", AffectedRows: 2*sendlix.InsertStreamChunkSize + 1}, resp)
+		assert.Equal(t, []int{sendlix.InsertStreamChunkSize, sendlix.InsertStreamChunkSize, 1}, chunks)
+
+		assert.ErrorIs(t, stream.Add(entry(0)), sendlix.ErrInsertStreamClosed)
+		_, err = stream.CloseAndRecv()
+		assert.ErrorIs(t, err, sendlix.ErrInsertStreamClosed)
+		assert.Equal(t, sendlix.CodeInsertStreamClosed, sendlix.Code(err))
+	})
+
+	t.Run("Add blocks while a chunk is in flight", func(t *testing.T) {
+		release := make(chan struct{})
+		reset(-1, release)
+		stream, err := client.OpenInsertStream(ctx, "newsletter", nil)
+		require.NoError(t, err)
+
+		for i := 0; i < sendlix.InsertStreamChunkSize-1; i++ {
+			require.NoError(t, stream.Add(entry(i)))
+		}
+		done := make(chan error)
+		go func() {
+			done <- stream.Add(entry(sendlix.InsertStreamChunkSize))
+		}()
+
+		select {
+		case <-done:
+			t.Fatal("Add returned before the chunk was acknowledged")
+		case <-time.After(50 * time.Millisecond):
+		}
+		close(release)
+		assert.NoError(t, <-done)
+		assert.Equal(t, sendlix.InsertStreamChunkSize, stream.Acknowledged())
+
+		_, err = stream.CloseAndRecv()
+		assert.NoError(t, err)
+	})
+
+	t.Run("Mid-stream failure", func(t *testing.T) {
+		reset(1, nil)
+		stream, err := client.OpenInsertStream(ctx, "newsletter", nil)
+		require.NoError(t, err)
+
+		var addErr error
+		added := 0
+		for ; added < 3*sendlix.InsertStreamChunkSize && addErr == nil; added++ {
+			addErr = stream.Add(entry(added))
+		}
+		assert.Equal(t, 2*sendlix.InsertStreamChunkSize, added, "Add fails with the failing chunk")
+
+		var streamErr *sendlix.InsertStreamError
+		require.True(t, errors.As(addErr, &streamErr))
+		assert.Equal(t, sendlix.InsertStreamChunkSize, streamErr.Acknowledged)
+		assert.Equal(t, codes.Unavailable, status.Code(addErr))
+		assert.Equal(t, addErr, stream.Add(entry(0)))
+
+		resp, err := stream.CloseAndRecv()
+		assert.Equal(t, addErr, err)
+		assert.Equal(t, int64(sendlix.InsertStreamChunkSize), resp.AffectedRows)
+	})
+
+	t.Run("Failure of the final chunk", func(t *testing.T) {
+		reset(0, nil)
+		stream, err := client.OpenInsertStream(ctx, "newsletter", nil)
+		require.NoError(t, err)
+		require.NoError(t, stream.Add(entry(0)))
+
+		_, err = stream.CloseAndRecv()
+		var streamErr *sendlix.InsertStreamError
+		require.True(t, errors.As(err, &streamErr))
+		assert.Zero(t, streamErr.Acknowledged)
+		assert.Equal(t, "insert stream failed after 0 acknowledged entries: failed to insert emails to group: rpc error: code = Unavailable desc = connection reset", err.Error())
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		_, err := client.OpenInsertStream(ctx, "", nil)
+		assert.ErrorIs(t, err, sendlix.ErrMissingGroupID)
+
+		stream, err := client.OpenInsertStream(ctx, "newsletter", nil)
+		require.NoError(t, err)
+		require.NoError(t, stream.Add(entry(0)))
+		err = stream.Add(sendlix.GroupEntry{Name: "No Email"})
+		assert.ErrorIs(t, err, sendlix.ErrMissingEntryEmail)
+		assert.Equal(t, "email address is required for entry at index 1", err.Error())
+	})
+}
@@ -0,0 +1,121 @@
+package sendlix_test
+
+import (
+	"context"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestInsertEmailToGroups(t *testing.T) {
+	ctx := context.Background()
+	entry := sendlix.GroupEntry{Email: "user@example.com", Substitutions: map[string]string{"plan": "trial"}}
+
+	// newClient returns a client whose inserts fail for failInsert, whose
+	// removals fail for failRemove, and which reports existing as already
+	// containing the email. Removals are recorded per group.
+	newClient := func(t *testing.T, failInsert, failRemove, existing string) (*sendlix.GroupClient, *[]string) {
+		server := newFakeServer(t)
+		var removed []string
+		server.update(func(h *fakeHandlers) {
+			h.insertEmailToGroup = func(ctx context.Context, req *pb.InsertEmailToGroupRequest) (*pb.UpdateResponse, error) {
+				switch req.GroupId {
+				case failInsert:
+					return nil, status.Error(codes.Internal, "insert failed")
+				case existing:
+					return &pb.UpdateResponse{Success: true}, nil
+				}
+				assert.Equal(t, map[string]string{"plan": "trial"}, req.Entries[0].Substitutions)
+				return &pb.UpdateResponse{Success: true, AffectedRows: 1}, nil
+			}
+			h.removeEmailFromGroup = func(ctx context.Context, req *pb.RemoveEmailFromGroupRequest) (*pb.UpdateResponse, error) {
+				if req.GroupId == failRemove {
+					return nil, status.Error(codes.Unavailable, "remove failed")
+				}
+				removed = append(removed, req.GroupId)
+				return &pb.UpdateResponse{Success: true, AffectedRows: 1}, nil
+			}
+		})
+
+		client, err := sendlix.NewGroupClient(&MockAuth{Token: "test-token"}, server.config())
+		require.NoError(t, err)
+		t.Cleanup(func() { client.Close() })
+		return client, &removed
+	}
+
+	states := func(results []sendlix.GroupInsertResult) []string {
+		var result []string
+		for _, r := range results {
+			result = append(result, r.GroupID+"="+r.State.String())
+		}
+		return result
+	}
+
+	groups := []string{"onboarding", "updates", "tips"}
+
+	t.Run("All inserts succeed", func(t *testing.T) {
+		client, removed := newClient(t, "", "", "")
+
+		results, err := client.InsertEmailToGroups(ctx, groups, entry, true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"onboarding=inserted", "updates=inserted", "tips=inserted"}, states(results))
+		assert.Empty(t, *removed)
+	})
+
+	t.Run("Mid-sequence failure rolls back", func(t *testing.T) {
+		client, removed := newClient(t, "updates", "", "")
+
+		results, err := client.InsertEmailToGroups(ctx, groups, entry, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `group "updates"`)
+		assert.Equal(t, sendlix.CodeInternal, sendlix.Code(err))
+		assert.Equal(t, []string{"onboarding=rolled-back", "updates=insert-failed", "tips=skipped"}, states(results))
+		assert.Error(t, results[1].Err)
+		assert.Equal(t, []string{"onboarding"}, *removed)
+	})
+
+	t.Run("Existing memberships are kept", func(t *testing.T) {
+		client, removed := newClient(t, "tips", "", "onboarding")
+
+		results, err := client.InsertEmailToGroups(ctx, groups, entry, true)
+		require.Error(t, err)
+		assert.Equal(t, []string{"onboarding=inserted", "updates=rolled-back", "tips=insert-failed"}, states(results))
+		assert.Equal(t, []string{"updates"}, *removed)
+	})
+
+	t.Run("Rollback failure is reported", func(t *testing.T) {
+		client, removed := newClient(t, "tips", "onboarding", "")
+
+		results, err := client.InsertEmailToGroups(ctx, groups, entry, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `rollback of group "onboarding"`)
+		assert.Equal(t, []string{"onboarding=rollback-failed", "updates=rolled-back", "tips=insert-failed"}, states(results))
+		assert.Equal(t, sendlix.CodeUnavailable, sendlix.Code(results[0].Err))
+		assert.Equal(t, []string{"updates"}, *removed)
+	})
+
+	t.Run("Non-atomic mode attempts every group", func(t *testing.T) {
+		client, removed := newClient(t, "updates", "", "")
+
+		results, err := client.InsertEmailToGroups(ctx, groups, entry, false)
+		require.Error(t, err)
+		assert.Equal(t, []string{"onboarding=inserted", "updates=insert-failed", "tips=inserted"}, states(results))
+		assert.Empty(t, *removed)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		client, _ := newClient(t, "", "", "")
+
+		_, err := client.InsertEmailToGroups(ctx, nil, entry, true)
+		assert.ErrorIs(t, err, sendlix.ErrMissingGroupID)
+		_, err = client.InsertEmailToGroups(ctx, []string{"onboarding", ""}, entry, true)
+		assert.ErrorIs(t, err, sendlix.ErrMissingGroupID)
+		_, err = client.InsertEmailToGroups(ctx, groups, sendlix.GroupEntry{}, true)
+		assert.ErrorIs(t, err, sendlix.ErrMissingEmail)
+	})
+}
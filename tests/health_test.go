@@ -0,0 +1,190 @@
+package sendlix_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestHealth(t *testing.T) {
+	ctx := context.Background()
+	mail := sendlix.MailOptions{
+		From:    sendlix.EmailAddress{Email: "sender@example.com"},
+		To:      []sendlix.EmailAddress{{Email: "recipient@example.com"}},
+		Subject: "Hello",
+		Text:    "Hello",
+	}
+
+	newClient := func(t *testing.T, server *fakeServer, configure func(*sendlix.ClientConfig)) *sendlix.EmailClient {
+		config := server.config()
+		if configure != nil {
+			configure(config)
+		}
+		auth, err := sendlix.NewAuth("secret.123", sendlix.WithAuthConnection(server.dial(t)))
+		require.NoError(t, err)
+		client, err := sendlix.NewEmailClient(auth, config)
+		require.NoError(t, err)
+		t.Cleanup(func() { client.Close() })
+		return client
+	}
+
+	t.Run("Passive snapshot of a new client", func(t *testing.T) {
+		server := newFakeServer(t)
+		health := newClient(t, server, nil).Health(ctx, nil)
+
+		assert.Equal(t, sendlix.HealthOK, health.Status)
+		assert.Equal(t, connectivity.Idle, health.ConnectionState)
+		assert.Equal(t, "no calls yet", health.Calls.Detail)
+		assert.Equal(t, "token is requested on the next call", health.Auth.Detail)
+		assert.Equal(t, "no quota reading yet", health.Quota.Detail)
+		assert.True(t, health.TokenExpiry.IsZero())
+		assert.Zero(t, server.count("GetJwtToken"), "passive snapshots make no calls")
+	})
+
+	t.Run("After a successful send", func(t *testing.T) {
+		server := newFakeServer(t)
+		client := newClient(t, server, nil)
+		_, err := client.SendEmail(ctx, mail, nil)
+		require.NoError(t, err)
+
+		health := client.Health(ctx, nil)
+		assert.Equal(t, sendlix.HealthOK, health.Status)
+		assert.Equal(t, connectivity.Ready, health.ConnectionState)
+		assert.False(t, health.LastSuccess.IsZero())
+		assert.WithinDuration(t, time.Now().Add(time.Hour), health.TokenExpiry, time.Minute)
+		assert.Equal(t, int64(100), health.EmailsLeft)
+		assert.Equal(t, sendlix.ComponentHealth{Status: sendlix.HealthOK, Detail: "100 emails left"}, health.Quota)
+	})
+
+	sendReturns := func(server *fakeServer, resp *pb.SendEmailResponse, err error) {
+		server.update(func(h *fakeHandlers) {
+			h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+				return resp, err
+			}
+		})
+	}
+
+	t.Run("Quota", func(t *testing.T) {
+		server := newFakeServer(t)
+		client := newClient(t, server, func(c *sendlix.ClientConfig) { c.LowQuotaThreshold = 100 })
+
+		sendReturns(server, &pb.SendEmailResponse{Message: []string{"msg-1"}, EmailsLeft: 50}, nil)
+		_, err := client.SendEmail(ctx, mail, nil)
+		require.NoError(t, err)
+		health := client.Health(ctx, nil)
+		assert.Equal(t, sendlix.HealthDegraded, health.Quota.Status)
+		assert.Equal(t, sendlix.HealthDegraded, health.Status)
+
+		sendReturns(server, &pb.SendEmailResponse{Message: []string{"msg-2"}, EmailsLeft: 0}, nil)
+		_, err = client.SendEmail(ctx, mail, nil)
+		require.NoError(t, err)
+		health = client.Health(ctx, nil)
+		assert.Equal(t, sendlix.ComponentHealth{Status: sendlix.HealthDown, Detail: "quota exhausted"}, health.Quota)
+		assert.Equal(t, sendlix.HealthDown, health.Status)
+	})
+
+	t.Run("Failed calls", func(t *testing.T) {
+		server := newFakeServer(t)
+		client := newClient(t, server, nil)
+
+		sendReturns(server, nil, status.Error(codes.InvalidArgument, "bad recipient"))
+		_, err := client.SendEmail(ctx, mail, nil)
+		require.Error(t, err)
+		assert.Equal(t, sendlix.HealthOK, client.Health(ctx, nil).Status, "caller errors do not count")
+
+		sendReturns(server, nil, status.Error(codes.Unavailable, "overloaded"))
+		_, err = client.SendEmail(ctx, mail, nil)
+		require.Error(t, err)
+		health := client.Health(ctx, nil)
+		assert.Equal(t, sendlix.HealthDegraded, health.Calls.Status)
+		assert.Equal(t, sendlix.HealthDegraded, health.Status)
+		assert.Equal(t, sendlix.CodeUnavailable, sendlix.Code(health.LastError))
+
+		sendReturns(server, &pb.SendEmailResponse{Message: []string{"msg-1"}, EmailsLeft: 100}, nil)
+		_, err = client.SendEmail(ctx, mail, nil)
+		require.NoError(t, err)
+		health = client.Health(ctx, nil)
+		assert.Equal(t, sendlix.HealthOK, health.Status)
+		assert.True(t, health.LastSuccess.After(health.LastFailure))
+	})
+
+	t.Run("Failed token exchange", func(t *testing.T) {
+		server := newFakeServer(t)
+		server.update(func(h *fakeHandlers) {
+			h.getJwtToken = func(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error) {
+				return nil, status.Error(codes.Unavailable, "auth service down")
+			}
+		})
+		client := newClient(t, server, nil)
+
+		_, err := client.SendEmail(ctx, mail, nil)
+		require.ErrorIs(t, err, sendlix.ErrAuthFailed)
+
+		health := client.Health(ctx, nil)
+		assert.Equal(t, sendlix.HealthDown, health.Auth.Status)
+		assert.Contains(t, health.Auth.Detail, "auth service down")
+		assert.Equal(t, sendlix.HealthDegraded, health.Calls.Status)
+		assert.Equal(t, sendlix.HealthDown, health.Status)
+
+		server.update(func(h *fakeHandlers) {
+			h.getJwtToken = func(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error) {
+				return &pb.AuthResponse{Token: "token", Expires: timestamppb.New(time.Now().Add(time.Hour))}, nil
+			}
+		})
+		health = client.Health(ctx, &sendlix.HealthOptions{Active: true})
+		assert.Equal(t, sendlix.HealthOK, health.Auth.Status, "active checks obtain a token")
+	})
+
+	t.Run("Active check of an unreachable server", func(t *testing.T) {
+		server := newFakeServer(t)
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		lis.Close()
+
+		client := newClient(t, server, func(c *sendlix.ClientConfig) { c.ServerAddress = lis.Addr().String() })
+		checkCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		defer cancel()
+
+		health := client.Health(checkCtx, &sendlix.HealthOptions{Active: true})
+		assert.Equal(t, sendlix.HealthDown, health.Connection.Status)
+		assert.Contains(t, health.Connection.Detail, "failed to connect")
+		assert.Equal(t, sendlix.HealthDown, health.Status)
+	})
+
+	t.Run("Other clients and auth", func(t *testing.T) {
+		server := newFakeServer(t)
+		client, err := sendlix.NewGroupClient(&MockAuth{Token: "test"}, server.config())
+		require.NoError(t, err)
+		defer client.Close()
+
+		health := client.Health(ctx, &sendlix.HealthOptions{Active: true})
+		assert.Equal(t, sendlix.HealthOK, health.Status)
+		assert.Equal(t, "token state unknown", health.Auth.Detail)
+		assert.Equal(t, "not tracked", health.Quota.Detail)
+
+		client.Close()
+		health = client.Health(ctx, nil)
+		assert.Equal(t, sendlix.HealthDown, health.Connection.Status)
+	})
+
+	t.Run("Status names", func(t *testing.T) {
+		for status, name := range map[sendlix.HealthStatus]string{
+			sendlix.HealthOK:        "ok",
+			sendlix.HealthDegraded:  "degraded",
+			sendlix.HealthDown:      "down",
+			sendlix.HealthStatus(7): "HealthStatus(7)",
+		} {
+			assert.Equal(t, name, status.String())
+		}
+	})
+}
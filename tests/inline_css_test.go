@@ -0,0 +1,137 @@
+package sendlix_test
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+// TestInlineCSSGolden inlines every testdata/inline_css/<name>.html, using
+// <name>.css as an additional stylesheet if present, and compares the result
+// and warnings with <name>.golden.
+func TestInlineCSSGolden(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join("testdata", "inline_css", "*.html"))
+	require.NoError(t, err)
+	require.NotEmpty(t, inputs)
+
+	for _, input := range inputs {
+		name := strings.TrimSuffix(input, ".html")
+		t.Run(filepath.Base(name), func(t *testing.T) {
+			document, err := os.ReadFile(input)
+			require.NoError(t, err)
+
+			var options sendlix.InlineCSSOptions
+			if css, err := os.ReadFile(name + ".css"); err == nil {
+				options.Stylesheets = []string{string(css)}
+			}
+
+			result, err := sendlix.InlineCSSWithOptions(string(document), options)
+			require.NoError(t, err)
+
+			actual := result.HTML
+			for _, warning := range result.Warnings {
+				actual += "\n<!-- warning: " + warning + " -->"
+			}
+			actual += "\n"
+
+			golden := name + ".golden"
+			if *updateGolden {
+				require.NoError(t, os.WriteFile(golden, []byte(actual), 0o644))
+			}
+
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), actual)
+		})
+	}
+}
+
+func TestInlineCSS(t *testing.T) {
+	t.Run("Style blocks can be kept", func(t *testing.T) {
+		result, err := sendlix.InlineCSSWithOptions(
+			`<html><head><style>p { color: red }</style></head><body><p>Hi</p></body></html>`,
+			sendlix.InlineCSSOptions{KeepStyleBlocks: true},
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, `<html><head><style>p { color: red }</style></head><body><p style="color: red">Hi</p></body></html>`, result.HTML)
+	})
+
+	t.Run("Warnings are reported to OnWarning", func(t *testing.T) {
+		var warnings []string
+		_, err := sendlix.InlineCSSWithOptions(`<p>Hi</p>`, sendlix.InlineCSSOptions{
+			Stylesheets: []string{`p > span { color: red } @import url("x.css");`},
+			OnWarning:   func(warning string) { warnings = append(warnings, warning) },
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			`unsupported selector "p > span" was not inlined`,
+			`unsupported at-rule "@import url(\"x.css\");" was ignored`,
+		}, warnings)
+	})
+
+	t.Run("Malformed CSS", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			css      string
+			expected string
+		}{
+			{"Unterminated comment", "p { color: red } /* note", "invalid CSS: unterminated comment"},
+			{"Missing block", "p { color: red } h1", `invalid CSS: expected '{' after selector "h1"`},
+			{"Stray closing brace", "p { color: red } }", "invalid CSS: unexpected '}' at offset 17"},
+			{"Unterminated block", "p { color: red", `invalid CSS: unterminated block for selector "p"`},
+			{"Nested block", "p { color: red; h1 { margin: 0 } }", `invalid CSS: unterminated block for selector "p"`},
+			{"Unterminated at-rule", "@media print { p { color: red }", `invalid CSS: unterminated block for "@media print"`},
+			{"Missing selector", "{ color: red }", "invalid CSS: missing selector at offset 0"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := sendlix.InlineCSS(`<p>Hi</p>`, tt.css)
+				assert.EqualError(t, err, tt.expected)
+			})
+		}
+	})
+
+	t.Run("Send transform leaves options unchanged", func(t *testing.T) {
+		server := newFakeServer(t)
+		var captured *pb.SendMailRequest
+		server.update(func(h *fakeHandlers) {
+			h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+				captured = req
+				return &pb.SendEmailResponse{Message: []string{"msg-1"}}, nil
+			}
+		})
+
+		config := server.config()
+		config.InlineCSS = &sendlix.InlineCSSOptions{}
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test-token"}, config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		original := `<html><head><style>p { color: red }</style></head><body><p>Hi</p></body></html>`
+		options := sendlix.MailOptions{
+			From:    sendlix.EmailAddress{Email: "sender@example.com"},
+			To:      []sendlix.EmailAddress{{Email: "recipient@example.com"}},
+			Subject: "Styled",
+			Html:    original,
+		}
+
+		_, err = client.SendEmail(context.Background(), options, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, original, options.Html)
+		assert.Equal(t, `<html><head></head><body><p style="color: red">Hi</p></body></html>`, captured.GetTextContent().GetHtml())
+	})
+}
@@ -0,0 +1,111 @@
+package sendlix_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/sendlix/go-sdk/sendlixtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestLogger(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+	server.update(func(h *fakeHandlers) {
+		h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+			if req.Subject == "fail" {
+				return nil, status.Error(codes.Internal, "boom")
+			}
+			grpc.SetHeader(ctx, metadata.Pairs(sendlix.RequestIDHeader, "req-1"))
+			return &pb.SendEmailResponse{Message: []string{"msg-1"}, EmailsLeft: 100}, nil
+		}
+	})
+
+	// newClient returns a client logging to the returned buffer as JSON
+	newClient := func(t *testing.T, level slog.Level) (*sendlix.EmailClient, *bytes.Buffer) {
+		var buf bytes.Buffer
+		config := server.config()
+		config.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: level}))
+		client, err := sendlix.NewEmailClient("very-secret.123", config)
+		require.NoError(t, err)
+		t.Cleanup(func() { client.Close() })
+		return client, &buf
+	}
+	records := func(t *testing.T, buf *bytes.Buffer) []map[string]any {
+		var result []map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			if line == "" {
+				continue
+			}
+			var record map[string]any
+			require.NoError(t, json.Unmarshal([]byte(line), &record))
+			result = append(result, record)
+		}
+		return result
+	}
+
+	t.Run("Successful send", func(t *testing.T) {
+		client, buf := newClient(t, slog.LevelDebug)
+
+		_, err := client.SendEmail(ctx, sendlixtest.ValidMailOptions(), nil)
+		require.NoError(t, err)
+
+		logged := records(t, buf)
+		require.Len(t, logged, 1)
+		record := logged[0]
+		assert.Equal(t, "DEBUG", record["level"])
+		assert.Equal(t, "sendlix call", record["msg"])
+		assert.Equal(t, "SendEmail", record["method"])
+		assert.Equal(t, "OK", record["code"])
+		assert.Equal(t, "req-1", record["request_id"])
+		assert.Equal(t, []any{"msg-1"}, record["message_ids"])
+		assert.Equal(t, "Fixture subject", record["subject"])
+		assert.Equal(t, float64(1), record["recipients"])
+		assert.Contains(t, record, "duration")
+	})
+
+	t.Run("Failed send", func(t *testing.T) {
+		client, buf := newClient(t, slog.LevelDebug)
+
+		_, err := client.SendEmail(ctx, sendlixtest.MailOptionsWith(func(o *sendlix.MailOptions) { o.Subject = "fail" }), nil)
+		require.Error(t, err)
+
+		logged := records(t, buf)
+		require.Len(t, logged, 1)
+		assert.Equal(t, "Internal", logged[0]["code"])
+		assert.Contains(t, logged[0]["error"], "boom")
+		assert.NotContains(t, logged[0], "message_ids")
+	})
+
+	t.Run("Secrets, addresses, and bodies are not logged", func(t *testing.T) {
+		client, buf := newClient(t, slog.LevelDebug)
+
+		_, err := client.SendEmail(ctx, sendlixtest.ValidMailOptions(), nil)
+		require.NoError(t, err)
+
+		output := buf.String()
+		for _, secret := range []string{"very-secret", "fake-token", "recipient@example.com", "sender@example.com", "Fixture content"} {
+			assert.NotContains(t, output, secret)
+		}
+	})
+
+	t.Run("Debug disabled", func(t *testing.T) {
+		client, buf := newClient(t, slog.LevelInfo)
+
+		_, err := client.SendEmail(ctx, sendlixtest.ValidMailOptions(), nil)
+		require.NoError(t, err)
+
+		assert.Empty(t, buf.String())
+	})
+}
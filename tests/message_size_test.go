@@ -0,0 +1,116 @@
+package sendlix_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/sendlix/go-sdk/sendlixtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMessageSizeLimits(t *testing.T) {
+	ctx := context.Background()
+	const mb = 1024 * 1024
+	eml := []byte("Subject: Archive\r\n\r\n" + strings.Repeat("x", 6*mb))
+
+	server := newFakeServer(t, grpc.MaxRecvMsgSize(16*mb), grpc.MaxSendMsgSize(16*mb))
+	var received []byte
+	server.update(func(h *fakeHandlers) {
+		h.sendEmlEmail = func(ctx context.Context, req *pb.EmlMailRequest) (*pb.SendEmailResponse, error) {
+			received = req.Mail
+			return &pb.SendEmailResponse{Message: []string{"eml-1"}, EmailsLeft: 100}, nil
+		}
+	})
+
+	send := func(t *testing.T, configure func(config *sendlix.ClientConfig)) error {
+		config := server.config()
+		configure(config)
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+		require.NoError(t, err)
+		defer client.Close()
+		_, err = client.SendEMLEmail(ctx, eml, nil)
+		return err
+	}
+
+	t.Run("Large message within the limit", func(t *testing.T) {
+		err := send(t, func(config *sendlix.ClientConfig) { config.MaxSendMsgSize = 8 * mb })
+		require.NoError(t, err)
+		assert.True(t, bytes.Equal(eml, received))
+	})
+
+	t.Run("Message exceeding the limit", func(t *testing.T) {
+		before := server.count("SendEmlEmail")
+		err := send(t, func(config *sendlix.ClientConfig) { config.MaxSendMsgSize = 4 * mb })
+		assertValidationError(t, err, sendlix.CodeMessageTooLarge, "emlData")
+		assert.Contains(t, err.Error(), "limit of 4194304")
+		assert.Equal(t, before, server.count("SendEmlEmail"), "no request is made")
+	})
+
+	t.Run("Regular email exceeding the limit", func(t *testing.T) {
+		config := server.config()
+		config.MaxSendMsgSize = 4 * mb
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		options := sendlixtest.ValidMailOptions()
+		options.Text = strings.Repeat("x", 6*mb)
+		estimate, err := client.EstimateRequestSize(ctx, options, nil)
+		require.NoError(t, err)
+
+		before := server.count("SendEmail")
+		_, err = client.SendEmail(ctx, options, nil)
+
+		assertValidationError(t, err, sendlix.CodeMessageTooLarge, "options")
+		assert.Contains(t, err.Error(), fmt.Sprintf("message is %d bytes", estimate))
+		assert.Equal(t, before, server.count("SendEmail"), "no request is made")
+	})
+
+	t.Run("Default configuration", func(t *testing.T) {
+		// Neither side configures a limit, so the client sends the message
+		// and the server rejects it with its default limit of 4 MB
+		defaultServer := newFakeServer(t)
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, defaultServer.config())
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.SendEMLEmail(ctx, eml, nil)
+
+		assert.ErrorIs(t, err, sendlix.ErrMessageTooLarge)
+		assert.NotErrorIs(t, err, sendlix.ErrQuotaExceeded)
+		assert.Equal(t, sendlix.CodeMessageTooLarge, sendlix.Code(err))
+		assert.Contains(t, err.Error(), "exceeding the size limit of 4194304")
+	})
+
+	t.Run("Response size", func(t *testing.T) {
+		server.update(func(h *fakeHandlers) {
+			h.sendEmlEmail = func(ctx context.Context, req *pb.EmlMailRequest) (*pb.SendEmailResponse, error) {
+				return &pb.SendEmailResponse{Message: []string{strings.Repeat("m", 5*mb)}, EmailsLeft: 100}, nil
+			}
+		})
+		small := []byte("Subject: Hi\r\n\r\nHi")
+		sendSmall := func(maxRecv int) error {
+			config := server.config()
+			config.MaxRecvMsgSize = maxRecv
+			client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+			require.NoError(t, err)
+			defer client.Close()
+			_, err = client.SendEMLEmail(ctx, small, nil)
+			return err
+		}
+
+		err := sendSmall(0)
+		assert.ErrorIs(t, err, sendlix.ErrMessageTooLarge)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		require.NoError(t, sendSmall(8*mb))
+	})
+}
@@ -0,0 +1,136 @@
+package sendlix_test
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatsCollector(t *testing.T) {
+	t.Run("Percentiles of known latencies", func(t *testing.T) {
+		collector := sendlix.NewStatsCollector(0)
+
+		// 1ms..100ms in random order
+		for _, i := range rand.Perm(100) {
+			var err error
+			if i%10 == 0 {
+				err = errors.New("failed")
+			}
+			collector.Observe("SendEmail", time.Duration(i+1)*time.Millisecond, err)
+		}
+
+		stats := collector.Stats()["SendEmail"]
+		assert.Equal(t, uint64(100), stats.Count)
+		assert.Equal(t, uint64(10), stats.Errors)
+		assert.Equal(t, map[sendlix.ErrorCode]uint64{sendlix.CodeUnknown: 10}, stats.ErrorCodes)
+		assert.Equal(t, 50*time.Millisecond, stats.P50)
+		assert.Equal(t, 95*time.Millisecond, stats.P95)
+		assert.Equal(t, 99*time.Millisecond, stats.P99)
+		assert.Equal(t, 100*time.Millisecond, stats.Max)
+	})
+
+	t.Run("Percentiles cover the most recent window", func(t *testing.T) {
+		collector := sendlix.NewStatsCollector(10)
+
+		collector.Observe("CheckEmailInGroup", time.Second, nil)
+		for i := 0; i < 10; i++ {
+			collector.Observe("CheckEmailInGroup", time.Millisecond, nil)
+		}
+
+		stats := collector.Stats()["CheckEmailInGroup"]
+		assert.Equal(t, uint64(11), stats.Count)
+		assert.Equal(t, time.Millisecond, stats.P99, "outlier should have aged out of the window")
+		assert.Equal(t, time.Second, stats.Max, "max covers all calls")
+	})
+
+	t.Run("Reset discards everything", func(t *testing.T) {
+		collector := sendlix.NewStatsCollector(10)
+		collector.Observe("SendEmail", time.Millisecond, nil)
+
+		collector.Reset()
+
+		assert.Empty(t, collector.Stats())
+	})
+
+	t.Run("Concurrent use", func(t *testing.T) {
+		collector := sendlix.NewStatsCollector(64)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 500; j++ {
+					collector.Observe("SendEmail", time.Duration(j)*time.Microsecond, nil)
+					if j%100 == 0 {
+						collector.Stats()
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, uint64(4000), collector.Stats()["SendEmail"].Count)
+	})
+}
+
+func TestClientStats(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+	server.update(func(h *fakeHandlers) {
+		h.checkEmailInGroup = func(ctx context.Context, req *pb.CheckEmailInGroupRequest) (*pb.CheckEmailInGroupResponse, error) {
+			if req.Email == "missing@example.com" {
+				return nil, status.Error(codes.NotFound, "group not found")
+			}
+			time.Sleep(20 * time.Millisecond)
+			return &pb.CheckEmailInGroupResponse{Exists: true}, nil
+		}
+	})
+
+	t.Run("Disabled by default", func(t *testing.T) {
+		client, err := sendlix.NewGroupClient(&MockAuth{Token: "test-token"}, server.config())
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.CheckEmailInGroup(ctx, "group-1", "user@example.com")
+		require.NoError(t, err)
+		assert.Nil(t, client.Stats())
+	})
+
+	t.Run("Calls are observed per method", func(t *testing.T) {
+		config := server.config()
+		config.CollectStats = true
+		client, err := sendlix.NewGroupClient(&MockAuth{Token: "test-token"}, config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		for i := 0; i < 3; i++ {
+			_, err = client.CheckEmailInGroup(ctx, "group-1", "user@example.com")
+			require.NoError(t, err)
+		}
+		_, err = client.CheckEmailInGroup(ctx, "group-1", "missing@example.com")
+		require.Error(t, err)
+
+		stats := client.Stats()
+		require.Contains(t, stats, "CheckEmailInGroup")
+		check := stats["CheckEmailInGroup"]
+		assert.Equal(t, uint64(4), check.Count)
+		assert.Equal(t, uint64(1), check.Errors)
+		assert.Equal(t, map[sendlix.ErrorCode]uint64{sendlix.CodeNotFound: 1}, check.ErrorCodes)
+		assert.GreaterOrEqual(t, check.P50, 20*time.Millisecond)
+		assert.GreaterOrEqual(t, check.Max, check.P99)
+
+		client.ResetStats()
+		assert.Empty(t, client.Stats())
+	})
+}
@@ -0,0 +1,87 @@
+package sendlix_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/sendlix/go-sdk/sendlixprom"
+	"github.com/sendlix/go-sdk/sendlixtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMetrics(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+	server.update(func(h *fakeHandlers) {
+		h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+			if req.Subject == "fail" {
+				return nil, status.Error(codes.Internal, "boom")
+			}
+			return &pb.SendEmailResponse{Message: []string{"msg-1", "msg-2"}, EmailsLeft: 98}, nil
+		}
+		h.sendGroupEmail = func(ctx context.Context, req *pb.GroupMailData) (*pb.SendEmailResponse, error) {
+			return &pb.SendEmailResponse{Message: []string{"msg-3", "msg-4", "msg-5"}, EmailsLeft: 95}, nil
+		}
+	})
+
+	collector := sendlixprom.NewCollector()
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(collector))
+
+	config := server.config()
+	config.Metrics = collector
+	client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+	require.NoError(t, err)
+	defer client.Close()
+	groups, err := sendlix.NewGroupClient(&MockAuth{Token: "test"}, config)
+	require.NoError(t, err)
+	defer groups.Close()
+
+	_, err = client.SendEmail(ctx, sendlixtest.ValidMailOptions(), nil)
+	require.NoError(t, err)
+	err = client.SendGroupEmail(ctx, sendlix.GroupMailData{
+		GroupID: "newsletter",
+		From:    sendlix.EmailAddress{Email: "news@example.com"},
+		Subject: "News",
+		Content: sendlix.MailContent{Text: "News"},
+	})
+	require.NoError(t, err)
+	_, err = client.SendEmail(ctx, sendlixtest.MailOptionsWith(func(o *sendlix.MailOptions) { o.Subject = "fail" }), nil)
+	require.Error(t, err)
+	_, err = groups.CheckEmailInGroup(ctx, "newsletter", "user@example.com")
+	require.NoError(t, err)
+
+	expected := `
+# HELP sendlix_emails_left Remaining email quota reported by the most recent send.
+# TYPE sendlix_emails_left gauge
+sendlix_emails_left 95
+# HELP sendlix_emails_sent_total Messages accepted by the Sendlix API.
+# TYPE sendlix_emails_sent_total counter
+sendlix_emails_sent_total 5
+# HELP sendlix_rpc_requests_total Sendlix API calls by method and gRPC status code.
+# TYPE sendlix_rpc_requests_total counter
+sendlix_rpc_requests_total{code="Internal",method="SendEmail"} 1
+sendlix_rpc_requests_total{code="OK",method="CheckEmailInGroup"} 1
+sendlix_rpc_requests_total{code="OK",method="SendEmail"} 1
+sendlix_rpc_requests_total{code="OK",method="SendGroupEmail"} 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"sendlix_emails_left", "sendlix_emails_sent_total", "sendlix_rpc_requests_total"))
+
+	// One latency series per called method
+	count, err := testutil.GatherAndCount(registry, "sendlix_rpc_duration_seconds")
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	problems, err := testutil.CollectAndLint(collector)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+}
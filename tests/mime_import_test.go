@@ -0,0 +1,203 @@
+package sendlix_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/mail"
+	"strings"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readMIME parses a message written with \n line endings.
+func readMIME(t *testing.T, raw string) (mail.Header, io.Reader) {
+	t.Helper()
+	msg, err := mail.ReadMessage(strings.NewReader(strings.ReplaceAll(raw, "\n", "\r\n")))
+	require.NoError(t, err)
+	return msg.Header, msg.Body
+}
+
+func TestFromMIMEHeaderAndBody(t *testing.T) {
+	t.Run("Alternative message", func(t *testing.T) {
+		options, additional, err := sendlix.FromMIMEHeaderAndBody(readMIME(t, `From: "Shop" <shop@example.com>
+To: alice@example.com, "Bob B." <bob@example.com>
+Cc: carol@example.com
+Bcc: audit@example.com
+Reply-To: support@example.com
+Subject: =?UTF-8?Q?Gr=C3=BC=C3=9Fe?=
+X-Mailer: gomail
+MIME-Version: 1.0
+Content-Type: multipart/alternative; boundary=b1
+
+--b1
+Content-Type: text/plain; charset=UTF-8
+Content-Transfer-Encoding: quoted-printable
+
+Hallo =C3=BC
+--b1
+Content-Type: text/html; charset=UTF-8
+
+<p>Hallo</p>
+--b1--
+`))
+		require.NoError(t, err)
+		assert.Nil(t, additional)
+		assert.Equal(t, sendlix.MailOptions{
+			From:    sendlix.EmailAddress{Email: "shop@example.com", Name: "Shop"},
+			To:      []sendlix.EmailAddress{{Email: "alice@example.com"}, {Email: "bob@example.com", Name: "Bob B."}},
+			CC:      []sendlix.EmailAddress{{Email: "carol@example.com"}},
+			BCC:     []sendlix.EmailAddress{{Email: "audit@example.com"}},
+			ReplyTo: &sendlix.EmailAddress{Email: "support@example.com"},
+			Subject: "Grüße",
+			Text:    "Hallo ü",
+			Html:    "<p>Hallo</p>",
+		}, options)
+	})
+
+	t.Run("Single part", func(t *testing.T) {
+		options, additional, err := sendlix.FromMIMEHeaderAndBody(readMIME(t, `From: shop@example.com
+To: alice@example.com
+Subject: Plain
+
+Just text.
+`))
+		require.NoError(t, err)
+		assert.Nil(t, additional)
+		assert.Equal(t, "Just text.\r\n", options.Text)
+		assert.Empty(t, options.Html)
+	})
+
+	t.Run("Attachments", func(t *testing.T) {
+		options, additional, err := sendlix.FromMIMEHeaderAndBody(readMIME(t, `From: shop@example.com
+To: alice@example.com
+Subject: Invoice
+Content-Type: multipart/mixed; boundary=outer
+
+--outer
+Content-Type: multipart/alternative; boundary=inner
+
+--inner
+Content-Type: text/plain
+
+See attached.
+--inner
+Content-Type: text/html
+
+<p>See attached.</p>
+--inner--
+--outer
+Content-Type: application/pdf
+Content-Disposition: attachment; filename="invoice.pdf"
+Content-Transfer-Encoding: base64
+
+JVBERi0x
+LjQK
+--outer
+Content-Type: text/csv; name=items.csv
+
+a,b
+--outer--
+`))
+		require.NoError(t, err)
+		assert.Equal(t, "See attached.", options.Text)
+		assert.Equal(t, "<p>See attached.</p>", options.Html)
+		require.NotNil(t, additional)
+		require.Len(t, additional.Attachments, 2)
+		assert.Equal(t, "invoice.pdf", additional.Attachments[0].Filename)
+		assert.Equal(t, "application/pdf", additional.Attachments[0].ContentType)
+		assert.Equal(t, "items.csv", additional.Attachments[1].Filename)
+		assert.Equal(t, "text/csv", additional.Attachments[1].ContentType)
+	})
+
+	t.Run("EML fallback", func(t *testing.T) {
+		for name, raw := range map[string]string{
+			"Inline images": `From: shop@example.com
+To: alice@example.com
+Subject: Newsletter
+Content-Type: multipart/mixed; boundary=outer
+
+--outer
+Content-Type: multipart/related; boundary=related
+
+--related
+Content-Type: text/html
+
+<img src="cid:logo">
+--related
+Content-Type: image/png
+Content-ID: <logo>
+
+PNG
+--related--
+--outer--
+`,
+			"Other charset": `From: shop@example.com
+To: alice@example.com
+Subject: Latin
+Content-Type: text/plain; charset=iso-8859-1
+
+Hallo
+`,
+			"Several senders": `From: a@example.com, b@example.com
+To: alice@example.com
+Subject: Two
+
+Hi
+`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				headers, body := readMIME(t, raw)
+				_, _, err := sendlix.FromMIMEHeaderAndBody(headers, body)
+				assert.ErrorIs(t, err, sendlix.ErrUseEML)
+				assert.Equal(t, sendlix.CodeUseEML, sendlix.Code(err))
+
+				var useEML *sendlix.UseEMLError
+				require.ErrorAs(t, err, &useEML)
+				msg, err := mail.ReadMessage(bytes.NewReader(useEML.EML))
+				require.NoError(t, err, "the EML is a valid message")
+				assert.Equal(t, headers, msg.Header)
+				sent, err := io.ReadAll(msg.Body)
+				require.NoError(t, err)
+				assert.Equal(t, strings.ReplaceAll(raw[strings.Index(raw, "\n\n")+2:], "\n", "\r\n"), string(sent))
+			})
+		}
+	})
+
+	t.Run("EML is sendable", func(t *testing.T) {
+		server := newFakeServer(t)
+		var sent []byte
+		server.update(func(h *fakeHandlers) {
+			h.sendEmlEmail = func(ctx context.Context, req *pb.EmlMailRequest) (*pb.SendEmailResponse, error) {
+				sent = req.Mail
+				return &pb.SendEmailResponse{Message: []string{"eml-1"}}, nil
+			}
+		})
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, server.config())
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, _, err = sendlix.FromMIMEHeaderAndBody(readMIME(t, "From: shop@example.com\nTo: a@example.com\nSubject: Hi\nContent-Type: multipart/related; boundary=r\n\n--r\nContent-Type: text/html\n\nHi\n--r--\n"))
+		var useEML *sendlix.UseEMLError
+		require.ErrorAs(t, err, &useEML)
+		_, err = client.SendEMLEmail(context.Background(), useEML.EML, nil)
+		require.NoError(t, err)
+		assert.Equal(t, useEML.EML, sent)
+	})
+
+	t.Run("Malformed messages", func(t *testing.T) {
+		_, _, err := sendlix.FromMIMEHeaderAndBody(readMIME(t, "From: shop@example.com\nTo: not an address\nSubject: Hi\n\nHi\n"))
+		assert.ErrorContains(t, err, "invalid To header")
+		assert.NotErrorIs(t, err, sendlix.ErrUseEML)
+
+		_, _, err = sendlix.FromMIMEHeaderAndBody(readMIME(t, "From: shop@example.com\nSubject: Hi\nContent-Type: multipart/alternative\n\nHi\n"))
+		assert.ErrorContains(t, err, "no boundary")
+
+		_, _, err = sendlix.FromMIMEHeaderAndBody(readMIME(t, "From: shop@example.com\nSubject: Hi\nContent-Transfer-Encoding: base64\n\n!!!\n"))
+		assert.ErrorContains(t, err, "invalid base64 content")
+	})
+}
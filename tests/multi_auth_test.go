@@ -0,0 +1,172 @@
+package sendlix_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/sendlix/go-sdk/sendlixtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestMultiAuth(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+
+	var mu sync.Mutex
+	rejected := map[int64]codes.Code{}
+	reject := func(keyID int64, code codes.Code) {
+		mu.Lock()
+		defer mu.Unlock()
+		if code == codes.OK {
+			delete(rejected, keyID)
+			return
+		}
+		rejected[keyID] = code
+	}
+	server.update(func(h *fakeHandlers) {
+		h.getJwtToken = func(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			key := req.GetApiKey()
+			if code, ok := rejected[key.GetKeyID()]; ok {
+				return nil, status.Error(code, "key rejected")
+			}
+			return &pb.AuthResponse{Token: "token-" + key.GetSecret(), Expires: timestamppb.New(time.Now().Add(time.Hour))}, nil
+		}
+	})
+
+	var clockMu sync.Mutex
+	now := time.Now()
+	clock := func() time.Time {
+		clockMu.Lock()
+		defer clockMu.Unlock()
+		return now
+	}
+	advance := func(d time.Duration) {
+		clockMu.Lock()
+		defer clockMu.Unlock()
+		now = now.Add(d)
+	}
+
+	newMultiAuth := func(t *testing.T, keys ...string) *sendlix.MultiAuth {
+		auth, err := sendlix.NewMultiAuthWithOptions(keys, sendlix.WithAuthConnection(server.dial(t)),
+			sendlix.WithAuthClock(clock), sendlix.WithKeyProbeInterval(time.Minute), sendlix.WithRefreshMargin(0))
+		require.NoError(t, err)
+		t.Cleanup(func() { auth.Close() })
+		return auth
+	}
+	header := func(t *testing.T, auth sendlix.IAuth) string {
+		t.Helper()
+		_, value, err := auth.GetAuthHeader(ctx)
+		require.NoError(t, err)
+		return value
+	}
+
+	t.Run("Invalid keys", func(t *testing.T) {
+		_, err := sendlix.NewMultiAuth()
+		assert.ErrorIs(t, err, sendlix.ErrMissingAuth)
+
+		_, err = sendlix.NewMultiAuth("new.1", "topsecret")
+		assert.ErrorIs(t, err, sendlix.ErrInvalidAPIKeyFormat)
+		assert.Contains(t, err.Error(), "API key at index 1")
+		assert.NotContains(t, err.Error(), "topsecret")
+	})
+
+	t.Run("Uses the first accepted key", func(t *testing.T) {
+		auth := newMultiAuth(t, "new.101", "old.102")
+		assert.Equal(t, "Bearer token-new", header(t, auth))
+		assert.Equal(t, int64(101), auth.ActiveKeyID())
+
+		reject(201, codes.Unauthenticated)
+		defer reject(201, codes.OK)
+		auth = newMultiAuth(t, "new.201", "old.202")
+		assert.Equal(t, "Bearer token-old", header(t, auth))
+		assert.Equal(t, int64(202), auth.ActiveKeyID())
+	})
+
+	t.Run("Fails over and probes the preferred key", func(t *testing.T) {
+		reject(301, codes.PermissionDenied)
+		auth := newMultiAuth(t, "new.301", "old.302")
+		assert.Equal(t, "Bearer token-old", header(t, auth))
+
+		before := server.count("GetJwtToken")
+		advance(30 * time.Second)
+		assert.Equal(t, "Bearer token-old", header(t, auth))
+		assert.Equal(t, before, server.count("GetJwtToken"), "the fallback key is cached until the next probe")
+
+		advance(30 * time.Second)
+		assert.Equal(t, "Bearer token-old", header(t, auth), "a rejected probe keeps the fallback key")
+		assert.Equal(t, int64(302), auth.ActiveKeyID())
+
+		reject(301, codes.OK)
+		advance(time.Minute)
+		assert.Equal(t, "Bearer token-new", header(t, auth), "the preferred key is used once accepted")
+		assert.Equal(t, int64(301), auth.ActiveKeyID())
+	})
+
+	t.Run("Failed probe does not fail the request", func(t *testing.T) {
+		reject(401, codes.Unauthenticated)
+		auth := newMultiAuth(t, "new.401", "old.402")
+		assert.Equal(t, "Bearer token-old", header(t, auth))
+
+		reject(401, codes.Unavailable)
+		advance(time.Minute)
+		assert.Equal(t, "Bearer token-old", header(t, auth))
+	})
+
+	t.Run("Other errors do not fail over", func(t *testing.T) {
+		reject(501, codes.Unavailable)
+		auth := newMultiAuth(t, "new.501", "old.502")
+
+		_, _, err := auth.GetAuthHeader(ctx)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Equal(t, int64(501), auth.ActiveKeyID())
+	})
+
+	t.Run("All keys rejected", func(t *testing.T) {
+		reject(601, codes.Unauthenticated)
+		reject(602, codes.PermissionDenied)
+		auth := newMultiAuth(t, "first-secret.601", "second-secret.602")
+
+		_, _, err := auth.GetAuthHeader(ctx)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, sendlix.ErrPermissionDenied)
+		assert.Contains(t, err.Error(), "all 2 API keys failed")
+		assert.Contains(t, err.Error(), "API key 601")
+		assert.Contains(t, err.Error(), "API key 602")
+		assert.NotContains(t, err.Error(), "first-secret")
+		assert.NotContains(t, err.Error(), "second-secret")
+	})
+
+	t.Run("Clients retry rejected calls with the next key", func(t *testing.T) {
+		server.update(func(h *fakeHandlers) {
+			h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+				md, _ := metadata.FromIncomingContext(ctx)
+				if values := md.Get("authorization"); len(values) > 0 && values[0] == "Bearer token-revoked" {
+					return nil, status.Error(codes.Unauthenticated, "token revoked")
+				}
+				return &pb.SendEmailResponse{Message: []string{"msg-1"}}, nil
+			}
+		})
+		auth := newMultiAuth(t, "revoked.701", "new.702")
+		client, err := sendlix.NewEmailClient(auth, server.config())
+		require.NoError(t, err)
+		defer client.Close()
+
+		assert.Equal(t, "Bearer token-revoked", header(t, auth))
+		reject(701, codes.Unauthenticated)
+
+		_, err = client.SendEmail(ctx, sendlixtest.ValidMailOptions(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, int64(702), auth.ActiveKeyID())
+	})
+}
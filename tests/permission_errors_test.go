@@ -0,0 +1,150 @@
+package sendlix_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusWithInfo returns a gRPC error carrying an ErrorInfo detail.
+func statusWithInfo(t *testing.T, code codes.Code, reason string, metadata map[string]string) error {
+	t.Helper()
+	s, err := status.New(code, "denied").WithDetails(&errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   "sendlix.com",
+		Metadata: metadata,
+	})
+	require.NoError(t, err)
+	return s.Err()
+}
+
+func TestPermissionErrors(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		err      error
+		sentinel error
+		code     sendlix.ErrorCode
+		scope    *sendlix.InsufficientScopeError
+	}{
+		{
+			name:     "Insufficient scope",
+			err:      statusWithInfo(t, codes.PermissionDenied, "INSUFFICIENT_SCOPE", map[string]string{"operation": "groups.insert", "required_scope": "groups:write"}),
+			sentinel: sendlix.ErrInsufficientScope,
+			code:     sendlix.CodeInsufficientScope,
+			scope:    &sendlix.InsufficientScopeError{Operation: "groups.insert", RequiredScope: "groups:write"},
+		},
+		{
+			name:     "Insufficient scope without metadata",
+			err:      statusWithInfo(t, codes.PermissionDenied, "INSUFFICIENT_SCOPE", nil),
+			sentinel: sendlix.ErrInsufficientScope,
+			code:     sendlix.CodeInsufficientScope,
+			scope:    &sendlix.InsufficientScopeError{Operation: "InsertEmailToGroup"},
+		},
+		{
+			name:     "Account suspended",
+			err:      statusWithInfo(t, codes.PermissionDenied, "ACCOUNT_SUSPENDED", nil),
+			sentinel: sendlix.ErrAccountSuspended,
+			code:     sendlix.CodeAccountSuspended,
+		},
+		{
+			name:     "Key disabled",
+			err:      statusWithInfo(t, codes.Unauthenticated, "API_KEY_DISABLED", nil),
+			sentinel: sendlix.ErrAPIKeyDisabled,
+			code:     sendlix.CodeAPIKeyDisabled,
+		},
+		{
+			name:     "Unknown reason falls back",
+			err:      statusWithInfo(t, codes.PermissionDenied, "SOMETHING_ELSE", nil),
+			sentinel: sendlix.ErrPermissionDenied,
+			code:     sendlix.CodeForbidden,
+		},
+		{
+			name:     "No details fall back",
+			err:      status.Error(codes.PermissionDenied, "denied"),
+			sentinel: sendlix.ErrPermissionDenied,
+			code:     sendlix.CodeForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeServer(t)
+			server.update(func(h *fakeHandlers) {
+				h.insertEmailToGroup = func(ctx context.Context, req *pb.InsertEmailToGroupRequest) (*pb.UpdateResponse, error) {
+					return nil, tt.err
+				}
+			})
+
+			client, err := sendlix.NewGroupClient(&MockAuth{Token: "test-token"}, server.config())
+			require.NoError(t, err)
+			defer client.Close()
+
+			_, err = client.InsertEmailToGroup(ctx, "group-1", sendlix.GroupEntry{Email: "user@example.com"})
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tt.sentinel)
+			assert.Equal(t, tt.code, sendlix.Code(err))
+			assert.Equal(t, status.Code(tt.err), status.Code(err), "gRPC status is preserved")
+
+			var scopeErr *sendlix.InsufficientScopeError
+			if tt.scope == nil {
+				assert.False(t, errors.As(err, &scopeErr))
+				return
+			}
+			require.True(t, errors.As(err, &scopeErr))
+			assert.Equal(t, tt.scope.Operation, scopeErr.Operation)
+			assert.Equal(t, tt.scope.RequiredScope, scopeErr.RequiredScope)
+		})
+	}
+
+	t.Run("Other errors are unchanged", func(t *testing.T) {
+		server := newFakeServer(t)
+		server.update(func(h *fakeHandlers) {
+			h.insertEmailToGroup = func(ctx context.Context, req *pb.InsertEmailToGroupRequest) (*pb.UpdateResponse, error) {
+				return nil, statusWithInfo(t, codes.Unavailable, "ACCOUNT_SUSPENDED", nil)
+			}
+		})
+
+		client, err := sendlix.NewGroupClient(&MockAuth{Token: "test-token"}, server.config())
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.InsertEmailToGroup(ctx, "group-1", sendlix.GroupEntry{Email: "user@example.com"})
+		assert.NotErrorIs(t, err, sendlix.ErrAccountSuspended)
+		assert.Equal(t, sendlix.CodeUnavailable, sendlix.Code(err))
+	})
+
+	t.Run("Disabled key at token exchange", func(t *testing.T) {
+		server := newFakeServer(t)
+		server.update(func(h *fakeHandlers) {
+			h.getJwtToken = func(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error) {
+				return nil, statusWithInfo(t, codes.PermissionDenied, "API_KEY_DISABLED", nil)
+			}
+		})
+
+		client, err := sendlix.NewEmailClient("secret.123", server.config())
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.SendEmail(ctx, sendlix.MailOptions{
+			From:    sendlix.EmailAddress{Email: "sender@example.com"},
+			To:      []sendlix.EmailAddress{{Email: "recipient@example.com"}},
+			Subject: "Hello",
+			Text:    "Hello",
+		}, nil)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, sendlix.ErrAPIKeyDisabled)
+		assert.ErrorIs(t, err, sendlix.ErrAuthFailed)
+		assert.Equal(t, sendlix.CodeAPIKeyDisabled, sendlix.Code(err))
+		assert.Zero(t, server.count("SendEmail"))
+	})
+}
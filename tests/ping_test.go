@@ -0,0 +1,115 @@
+package sendlix_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// newHealthServer starts a TLS server providing only the gRPC health
+// service and returns its address.
+func newHealthServer(t *testing.T) (*health.Server, string) {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	healthServer := health.NewServer()
+	srv := grpc.NewServer(grpc.Creds(credentials.NewTLS(selfSignedTLSConfig(t))))
+	healthpb.RegisterHealthServer(srv, healthServer)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	return healthServer, lis.Addr().String()
+}
+
+func TestPing(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Without health service", func(t *testing.T) {
+		server := newFakeServer(t)
+		client, err := sendlix.NewGroupClient(&MockAuth{Token: "test"}, server.config())
+		require.NoError(t, err)
+		defer client.Close()
+
+		require.NoError(t, client.Ping(ctx))
+		assert.Equal(t, "READY", client.GetConnection().GetState().String())
+	})
+
+	t.Run("Health service", func(t *testing.T) {
+		healthServer, addr := newHealthServer(t)
+		config := &sendlix.ClientConfig{ServerAddress: addr, Insecure: true}
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		require.NoError(t, client.Ping(ctx))
+
+		healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+		err = client.Ping(ctx)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, sendlix.ErrNotServing)
+		assert.Equal(t, sendlix.CodeNotServing, sendlix.Code(err))
+	})
+
+	t.Run("Rejected credentials", func(t *testing.T) {
+		server := newFakeServer(t)
+		server.update(func(h *fakeHandlers) {
+			h.getJwtToken = func(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error) {
+				return nil, status.Error(codes.Unauthenticated, "invalid API key")
+			}
+		})
+		auth, err := sendlix.NewAuthWithConfig("secret.123", server.config())
+		require.NoError(t, err)
+		client, err := sendlix.NewEmailClient(sendlix.OwnedAuth(auth), server.config())
+		require.NoError(t, err)
+		defer client.Close()
+
+		err = client.Ping(ctx)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, sendlix.ErrAuthFailed)
+		assert.NotErrorIs(t, err, sendlix.ErrUnreachable)
+	})
+
+	t.Run("Failing IAuth", func(t *testing.T) {
+		server := newFakeServer(t)
+		client, err := sendlix.NewEmailClient(&MockAuth{Error: errors.New("token expired")}, server.config())
+		require.NoError(t, err)
+		defer client.Close()
+
+		err = client.Ping(ctx)
+		assert.ErrorIs(t, err, sendlix.ErrAuthFailed)
+	})
+
+	t.Run("Unreachable server", func(t *testing.T) {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := lis.Addr().String()
+		require.NoError(t, lis.Close())
+
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, &sendlix.ClientConfig{ServerAddress: addr, Insecure: true})
+		require.NoError(t, err)
+		defer client.Close()
+
+		pingCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+		defer cancel()
+		err = client.Ping(pingCtx)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, sendlix.ErrUnreachable)
+		assert.NotErrorIs(t, err, sendlix.ErrAuthFailed)
+		assert.Equal(t, sendlix.CodeUnreachable, sendlix.Code(err))
+	})
+}
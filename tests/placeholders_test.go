@@ -0,0 +1,145 @@
+package sendlix_test
+
+import (
+	"context"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPlaceholders(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      []string
+		keys         []string
+		placeholders []string
+		unknown      []string
+		unused       []string
+	}{
+		{
+			name:         "All placeholders known",
+			content:      []string{"Hi {{first_name}}", "Save {{discount}} today"},
+			keys:         []string{"first_name", "discount"},
+			placeholders: []string{"discount", "first_name"},
+		},
+		{
+			name:         "Typo is unknown and key is unused",
+			content:      []string{"Hi {{first_nme}}"},
+			keys:         []string{"first_name"},
+			placeholders: []string{"first_nme"},
+			unknown:      []string{"first_nme"},
+			unused:       []string{"first_name"},
+		},
+		{
+			name:         "Spaces and triple braces",
+			content:      []string{"{{ first_name }} and {{{discount}}}"},
+			keys:         []string{"first_name", "discount"},
+			placeholders: []string{"discount", "first_name"},
+		},
+		{
+			name:    "Escaped braces are literal",
+			content: []string{`Use \{{first_name}} in your template`},
+			unused:  []string{"first_name"},
+			keys:    []string{"first_name"},
+		},
+		{
+			name:         "Nested braces use the innermost placeholder",
+			content:      []string{"{{ {{first_name}} }}"},
+			keys:         []string{"first_name"},
+			placeholders: []string{"first_name"},
+		},
+		{
+			name:         "Placeholders inside HTML attributes",
+			content:      []string{`<a href="https://example.com/u?id={{user_id}}" title="{{ title }}">Unsubscribe</a>`},
+			keys:         []string{"user_id"},
+			placeholders: []string{"title", "user_id"},
+			unknown:      []string{"title"},
+		},
+		{
+			name:    "Invalid names and unterminated braces are ignored",
+			content: []string{"{{}} {{not a name}} {{$x}} {{open"},
+		},
+		{
+			name:         "Repeated placeholders are reported once",
+			content:      []string{"{{a}} {{a}}", "{{a}}"},
+			placeholders: []string{"a"},
+			unknown:      []string{"a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := sendlix.CheckPlaceholders(tt.keys, tt.content...)
+
+			assert.Equal(t, tt.placeholders, report.Placeholders)
+			assert.Equal(t, tt.unknown, report.Unknown)
+			assert.Equal(t, tt.unused, report.Unused)
+			assert.Equal(t, len(tt.unknown) == 0, report.OK())
+		})
+	}
+}
+
+func TestPlaceholderCheckOnSend(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+
+	options := sendlix.MailOptions{
+		From:    sendlix.EmailAddress{Email: "sender@example.com"},
+		To:      []sendlix.EmailAddress{{Email: "recipient@example.com"}},
+		Subject: "Hi {{first_nme}}",
+		Html:    `<img src="{{logo}}"><p>Save {{discount}}</p>`,
+		Images:  []sendlix.Image{{Placeholder: "{{logo}}", Data: []byte{0x89}, Type: sendlix.MimeTypePNG}},
+	}
+
+	t.Run("Strict mode rejects unknown placeholders", func(t *testing.T) {
+		config := server.config()
+		config.PlaceholderCheck = &sendlix.PlaceholderCheckConfig{
+			Keys:   []string{"first_name", "discount"},
+			Strict: true,
+		}
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test-token"}, config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		before := server.count("SendEmail")
+		_, err = client.SendEmail(ctx, options, nil)
+
+		assert.ErrorIs(t, err, sendlix.ErrUnknownPlaceholder)
+		assert.EqualError(t, err, "content uses placeholders without substitution keys: first_nme")
+		assert.Equal(t, before, server.count("SendEmail"))
+
+		err = client.SendGroupEmail(ctx, sendlix.GroupMailData{
+			GroupID: "group-1",
+			From:    sendlix.EmailAddress{Email: "sender@example.com"},
+			Subject: "News",
+			Content: sendlix.MailContent{Text: "Hi {{first_name}}, {{promo}}"},
+		})
+		assert.ErrorIs(t, err, sendlix.ErrUnknownPlaceholder)
+	})
+
+	t.Run("Warnings without strict mode", func(t *testing.T) {
+		var reports []sendlix.PlaceholderReport
+		config := server.config()
+		config.PlaceholderCheck = &sendlix.PlaceholderCheckConfig{
+			Keys:      []string{"first_name", "discount"},
+			OnWarning: func(report sendlix.PlaceholderReport) { reports = append(reports, report) },
+		}
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test-token"}, config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.SendEmail(ctx, options, nil)
+		require.NoError(t, err)
+
+		require.Len(t, reports, 1)
+		assert.Equal(t, []string{"first_nme"}, reports[0].Unknown)
+		assert.Equal(t, []string{"first_name"}, reports[0].Unused)
+
+		options.Subject = "Hi {{first_name}}"
+		_, err = client.SendEmail(ctx, options, nil)
+		require.NoError(t, err)
+		assert.Len(t, reports, 1, "clean sends must not produce warnings")
+	})
+}
@@ -0,0 +1,128 @@
+package sendlix_test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/sendlix/go-sdk/sendlixtest"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// assertConnectionsClosed asserts that every connection to server is closed
+// shortly after QuickSend returned.
+func assertConnectionsClosed(t *testing.T, server *fakeServer) {
+	t.Helper()
+	assert.Eventually(t, func() bool { return server.openConnections() == 0 }, 2*time.Second, 10*time.Millisecond,
+		"%d connections still open", server.openConnections())
+}
+
+func TestQuickSend(t *testing.T) {
+	t.Run("Invalid API key", func(t *testing.T) {
+		ids, err := sendlix.QuickSend(context.Background(), "invalid-key", sendlix.MailOptions{}, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, ids)
+		assert.Contains(t, err.Error(), "invalid API key format")
+	})
+
+	t.Run("Validation error", func(t *testing.T) {
+		ids, err := sendlix.QuickSend(context.Background(), "secret.123", sendlixtest.InvalidVariants()["missing from"].Options, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, ids)
+		assert.Contains(t, err.Error(), "from email is required")
+	})
+
+	t.Run("Validation error with config", func(t *testing.T) {
+		config := &sendlix.ClientConfig{
+			ServerAddress: "localhost:8080",
+			UserAgent:     "test-client/1.0.0",
+			Insecure:      true,
+		}
+
+		ids, err := sendlix.QuickSendWithConfig(context.Background(), "secret.123", config, sendlixtest.InvalidVariants()["missing subject"].Options, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, ids)
+		assert.Contains(t, err.Error(), "subject is required")
+	})
+	t.Run("Sends through the configured server", func(t *testing.T) {
+		server := newFakeServer(t)
+		var openDuringSend int
+		server.update(func(h *fakeHandlers) {
+			h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+				openDuringSend = server.openConnections()
+				return &pb.SendEmailResponse{Message: []string{"msg-1"}, EmailsLeft: 100}, nil
+			}
+		})
+
+		ids, err := sendlix.QuickSendWithConfig(context.Background(), "secret.123", server.config(), sendlix.MailOptions{
+			From:    sendlix.EmailAddress{Email: "sender@example.com"},
+			To:      []sendlix.EmailAddress{{Email: "recipient@example.com"}},
+			Subject: "Test",
+			Text:    "Hello",
+		}, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"msg-1"}, ids)
+		assert.Equal(t, 1, server.count("GetJwtToken"))
+		assert.Positive(t, openDuringSend)
+		assertConnectionsClosed(t, server)
+	})
+
+	t.Run("Closes connections when authentication fails", func(t *testing.T) {
+		server := newFakeServer(t)
+		server.update(func(h *fakeHandlers) {
+			h.getJwtToken = func(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error) {
+				return nil, status.Error(codes.Unauthenticated, "invalid API key")
+			}
+		})
+
+		_, err := sendlix.QuickSendWithConfig(context.Background(), "secret.123", server.config(), sendlixtest.ValidMailOptions(), nil)
+
+		assert.Error(t, err)
+		assert.Equal(t, 1, server.count("GetJwtToken"))
+		assert.Zero(t, server.count("SendEmail"))
+		assertConnectionsClosed(t, server)
+	})
+
+	t.Run("Closes connections when the client cannot be created", func(t *testing.T) {
+		server := newFakeServer(t)
+		config := server.config()
+		config.Compression = "nope"
+
+		runtime.GC()
+		before := runtime.NumGoroutine()
+		for i := 0; i < 20; i++ {
+			_, err := sendlix.QuickSendWithConfig(context.Background(), "secret.123", config, sendlixtest.ValidMailOptions(), nil)
+			assertValidationError(t, err, sendlix.CodeUnknownCompressor, "Compression")
+		}
+
+		// Connection goroutines exit asynchronously after Close
+		assert.Eventually(t, func() bool {
+			return runtime.NumGoroutine() <= before+5
+		}, 2*time.Second, 20*time.Millisecond, "%d goroutines leaked", runtime.NumGoroutine()-before)
+		assert.Zero(t, server.count("GetJwtToken"))
+	})
+
+	t.Run("Closes connections when the send fails", func(t *testing.T) {
+		server := newFakeServer(t)
+		server.update(func(h *fakeHandlers) {
+			h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+				return nil, status.Error(codes.Internal, "boom")
+			}
+		})
+
+		_, err := sendlix.QuickSendWithConfig(context.Background(), "secret.123", server.config(), sendlixtest.ValidMailOptions(), nil)
+
+		assert.Error(t, err)
+		assert.Equal(t, 1, server.count("SendEmail"))
+		assertConnectionsClosed(t, server)
+	})
+}
@@ -0,0 +1,240 @@
+package sendlix_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/sendlix/go-sdk/sendlixtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuietHours(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	night := sendlix.QuietWindow{Start: 22 * time.Hour, End: 7 * time.Hour}
+
+	var mu sync.Mutex
+	var requests []*pb.SendMailRequest
+	server.update(func(h *fakeHandlers) {
+		h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			requests = append(requests, req)
+			return &pb.SendEmailResponse{Message: []string{"msg-1"}}, nil
+		}
+	})
+	lastSendAt := func(t *testing.T) *time.Time {
+		t.Helper()
+		mu.Lock()
+		defer mu.Unlock()
+		require.NotEmpty(t, requests)
+		info := requests[len(requests)-1].AdditionalInfos
+		if info == nil || info.SendAt == nil {
+			return nil
+		}
+		sendAt := info.SendAt.AsTime()
+		return &sendAt
+	}
+
+	// Friday, 2024-06-14 23:00 in Berlin
+	friday := time.Date(2024, 6, 14, 23, 0, 0, 0, berlin)
+	newClient := func(t *testing.T, policy sendlix.QuietHours) *sendlix.EmailClient {
+		if policy.Location == nil {
+			policy.Location = berlin
+		}
+		if policy.Windows == nil {
+			policy.Windows = map[time.Weekday]sendlix.QuietWindow{time.Friday: night}
+		}
+		policy.Now = func() time.Time { return friday }
+		config := server.config()
+		config.QuietHours = &policy
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+		require.NoError(t, err)
+		t.Cleanup(func() { client.Close() })
+		return client
+	}
+	send := func(client *sendlix.EmailClient, additional *sendlix.AdditionalOptions) error {
+		_, err := client.SendEmail(ctx, sendlixtest.ValidMailOptions(), additional)
+		return err
+	}
+
+	t.Run("Reject", func(t *testing.T) {
+		client := newClient(t, sendlix.QuietHours{})
+		sends := server.count("SendEmail")
+
+		err := send(client, &sendlix.AdditionalOptions{Category: "promotions"})
+		assert.ErrorIs(t, err, sendlix.ErrQuietHours)
+		assert.Equal(t, sendlix.CodeQuietHours, sendlix.Code(err))
+		assert.EqualError(t, err, "delivery is not permitted during quiet hours: quiet hours end 2024-06-15T07:00:00+02:00")
+
+		err = client.SendGroupEmail(ctx, sendlixtest.ValidGroupMailData())
+		assert.ErrorIs(t, err, sendlix.ErrQuietHours)
+		_, err = client.SendEMLEmail(ctx, []byte("Subject: Hi\r\n\r\nHi"), nil)
+		assert.ErrorIs(t, err, sendlix.ErrQuietHours)
+		assert.Equal(t, sends, server.count("SendEmail"), "nothing is sent")
+
+		scheduled := time.Date(2024, 6, 15, 9, 0, 0, 0, berlin)
+		require.NoError(t, send(client, &sendlix.AdditionalOptions{SendAt: &scheduled}), "delivery after the window")
+		early := time.Date(2024, 6, 14, 21, 59, 0, 0, berlin)
+		require.NoError(t, send(client, &sendlix.AdditionalOptions{SendAt: &early}), "delivery before the window")
+		late := time.Date(2024, 6, 15, 6, 30, 0, 0, berlin)
+		assert.ErrorIs(t, send(client, &sendlix.AdditionalOptions{SendAt: &late}), sendlix.ErrQuietHours, "scheduled into the window")
+	})
+
+	t.Run("Categories", func(t *testing.T) {
+		client := newClient(t, sendlix.QuietHours{ExemptCategories: []string{"transactional"}})
+		assert.NoError(t, send(client, &sendlix.AdditionalOptions{Category: "transactional"}))
+		assert.ErrorIs(t, send(client, nil), sendlix.ErrQuietHours, "uncategorized sends are covered")
+
+		client = newClient(t, sendlix.QuietHours{Categories: []string{"promotions", "transactional"}, ExemptCategories: []string{"transactional"}})
+		assert.ErrorIs(t, send(client, &sendlix.AdditionalOptions{Category: "promotions"}), sendlix.ErrQuietHours)
+		assert.NoError(t, send(client, &sendlix.AdditionalOptions{Category: "transactional"}), "exemptions take precedence")
+		assert.NoError(t, send(client, &sendlix.AdditionalOptions{Category: "news"}))
+		assert.NoError(t, send(client, nil))
+
+		withDefault := client.WithDefaults(sendlix.Defaults{Category: "promotions"})
+		assert.ErrorIs(t, send(withDefault, nil), sendlix.ErrQuietHours, "default categories are covered")
+	})
+
+	t.Run("Reschedule", func(t *testing.T) {
+		client := newClient(t, sendlix.QuietHours{Action: sendlix.QuietHoursReschedule})
+		additional := &sendlix.AdditionalOptions{Category: "promotions"}
+
+		require.NoError(t, send(client, additional))
+		sendAt := lastSendAt(t)
+		require.NotNil(t, sendAt)
+		assert.True(t, time.Date(2024, 6, 15, 7, 0, 0, 0, berlin).Equal(*sendAt))
+		assert.Nil(t, additional.SendAt, "caller options must not be modified")
+
+		require.NoError(t, send(client, nil))
+		assert.NotNil(t, lastSendAt(t))
+
+		err := client.SendGroupEmail(ctx, sendlixtest.ValidGroupMailData())
+		assert.ErrorIs(t, err, sendlix.ErrQuietHours, "group sends cannot be scheduled")
+	})
+
+	t.Run("Warn", func(t *testing.T) {
+		client := newClient(t, sendlix.QuietHours{Action: sendlix.QuietHoursWarn})
+
+		var warnings []sendlix.Warning
+		_, err := client.SendEmail(sendlix.WithWarnings(ctx, &warnings), sendlixtest.ValidMailOptions(), nil)
+		require.NoError(t, err)
+		assert.Nil(t, lastSendAt(t))
+		require.Len(t, warnings, 1)
+		assert.Equal(t, sendlix.WarningQuietHours, warnings[0].Code)
+		assert.Equal(t, "SendAt", warnings[0].Field)
+	})
+
+	t.Run("Invalid windows", func(t *testing.T) {
+		for _, window := range []sendlix.QuietWindow{
+			{Start: 22 * time.Hour, End: 22 * time.Hour},
+			{Start: 24 * time.Hour, End: 7 * time.Hour},
+			{Start: -time.Hour, End: 7 * time.Hour},
+			{Start: 22 * time.Hour, End: 25 * time.Hour},
+			{Start: 22 * time.Hour},
+		} {
+			config := server.config()
+			config.QuietHours = &sendlix.QuietHours{Windows: map[time.Weekday]sendlix.QuietWindow{time.Monday: window}}
+			_, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+			assertValidationError(t, err, sendlix.CodeInvalidQuietWindow, "QuietHours.Windows")
+		}
+	})
+}
+
+func TestQuietUntil(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	night := sendlix.QuietWindow{Start: 22 * time.Hour, End: 7 * time.Hour}
+	daily := map[time.Weekday]sendlix.QuietWindow{}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		daily[day] = night
+	}
+
+	tests := []struct {
+		name    string
+		policy  sendlix.QuietHours
+		at      time.Time
+		until   time.Time
+		elapsed time.Duration
+	}{
+		{
+			name:    "Spring forward",
+			policy:  sendlix.QuietHours{Location: berlin, Windows: daily},
+			at:      time.Date(2024, 3, 30, 23, 0, 0, 0, berlin),
+			until:   time.Date(2024, 3, 31, 7, 0, 0, 0, berlin),
+			elapsed: 7 * time.Hour,
+		},
+		{
+			name:    "Fall back",
+			policy:  sendlix.QuietHours{Location: berlin, Windows: daily},
+			at:      time.Date(2024, 10, 26, 23, 0, 0, 0, berlin),
+			until:   time.Date(2024, 10, 27, 7, 0, 0, 0, berlin),
+			elapsed: 9 * time.Hour,
+		},
+		{
+			name: "End in skipped hour",
+			policy: sendlix.QuietHours{Location: berlin, Windows: map[time.Weekday]sendlix.QuietWindow{
+				time.Saturday: {Start: 22 * time.Hour, End: 2*time.Hour + 30*time.Minute},
+			}},
+			at:      time.Date(2024, 3, 30, 23, 0, 0, 0, berlin),
+			until:   time.Date(2024, 3, 31, 3, 30, 0, 0, berlin),
+			elapsed: 3*time.Hour + 30*time.Minute,
+		},
+		{
+			name:    "After midnight",
+			policy:  sendlix.QuietHours{Location: berlin, Windows: map[time.Weekday]sendlix.QuietWindow{time.Saturday: night}},
+			at:      time.Date(2024, 3, 31, 1, 30, 0, 0, berlin),
+			until:   time.Date(2024, 3, 31, 7, 0, 0, 0, berlin),
+			elapsed: 4*time.Hour + 30*time.Minute,
+		},
+		{
+			name: "Adjoining windows",
+			policy: sendlix.QuietHours{Location: berlin, Windows: map[time.Weekday]sendlix.QuietWindow{
+				time.Friday:   {Start: 20 * time.Hour, End: 24 * time.Hour},
+				time.Saturday: {Start: 0, End: 24 * time.Hour},
+				time.Sunday:   {Start: 0, End: 8 * time.Hour},
+			}},
+			at:      time.Date(2024, 6, 14, 21, 0, 0, 0, berlin),
+			until:   time.Date(2024, 6, 16, 8, 0, 0, 0, berlin),
+			elapsed: 35 * time.Hour,
+		},
+		{
+			name:    "UTC by default",
+			policy:  sendlix.QuietHours{Windows: daily},
+			at:      time.Date(2024, 6, 15, 1, 0, 0, 0, berlin),
+			until:   time.Date(2024, 6, 15, 7, 0, 0, 0, time.UTC),
+			elapsed: 8 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			until, quiet := tt.policy.QuietUntil(tt.at)
+			require.True(t, quiet)
+			assert.True(t, tt.until.Equal(until), "got %s", until)
+			assert.Equal(t, tt.elapsed, until.Sub(tt.at))
+		})
+	}
+
+	t.Run("Outside quiet hours", func(t *testing.T) {
+		policy := sendlix.QuietHours{Location: berlin, Windows: map[time.Weekday]sendlix.QuietWindow{time.Saturday: night}}
+		for _, at := range []time.Time{
+			time.Date(2024, 3, 30, 21, 59, 0, 0, berlin),
+			time.Date(2024, 3, 31, 7, 0, 0, 0, berlin),
+			time.Date(2024, 3, 29, 23, 0, 0, 0, berlin),
+		} {
+			until, quiet := policy.QuietUntil(at)
+			assert.False(t, quiet, at.String())
+			assert.True(t, until.IsZero())
+		}
+	})
+}
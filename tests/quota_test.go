@@ -0,0 +1,276 @@
+package sendlix_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/sendlix/go-sdk/sendlixtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestEmailsLeftTracking(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+
+	// Every send, regardless of type, consumes quota from the same counter.
+	var mu sync.Mutex
+	left := int64(12)
+	next := func() *pb.SendEmailResponse {
+		mu.Lock()
+		defer mu.Unlock()
+		left -= 3
+		return &pb.SendEmailResponse{Message: []string{"msg"}, EmailsLeft: left}
+	}
+	server.update(func(h *fakeHandlers) {
+		h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+			return next(), nil
+		}
+		h.sendEmlEmail = func(ctx context.Context, req *pb.EmlMailRequest) (*pb.SendEmailResponse, error) {
+			return next(), nil
+		}
+		h.sendGroupEmail = func(ctx context.Context, req *pb.GroupMailData) (*pb.SendEmailResponse, error) {
+			return next(), nil
+		}
+	})
+
+	var calls []int64
+	config := server.config()
+	config.LowQuotaThreshold = 5
+	config.OnLowQuota = func(emailsLeft int64) {
+		calls = append(calls, emailsLeft)
+	}
+
+	client, err := sendlix.NewEmailClient(&MockAuth{Token: "test-token"}, config)
+	require.NoError(t, err)
+	defer client.Close()
+
+	emailsLeft, updatedAt := client.EmailsLeft()
+	assert.Zero(t, emailsLeft)
+	assert.True(t, updatedAt.IsZero())
+
+	sends := []func() error{
+		func() error {
+			_, err := client.SendEmail(ctx, sendlix.MailOptions{
+				From:    sendlix.EmailAddress{Email: "sender@example.com"},
+				To:      []sendlix.EmailAddress{{Email: "recipient@example.com"}},
+				Subject: "Hello",
+				Text:    "Hello",
+			}, nil)
+			return err
+		},
+		func() error {
+			_, err := client.SendEMLEmail(ctx, []byte("Subject: Hello\r\n\r\nHello"), nil)
+			return err
+		},
+		func() error {
+			return client.SendGroupEmail(ctx, sendlix.GroupMailData{
+				GroupID: "group-1",
+				From:    sendlix.EmailAddress{Email: "sender@example.com"},
+				Subject: "Hello",
+				Content: sendlix.MailContent{Text: "Hello"},
+			})
+		},
+		func() error {
+			_, err := client.SendEmail(ctx, sendlix.MailOptions{
+				From:    sendlix.EmailAddress{Email: "sender@example.com"},
+				To:      []sendlix.EmailAddress{{Email: "recipient@example.com"}},
+				Subject: "Hello",
+				Text:    "Hello",
+			}, nil)
+			return err
+		},
+	}
+
+	expected := []int64{9, 6, 3, 0}
+	for i, send := range sends {
+		before := time.Now()
+		require.NoError(t, send())
+
+		emailsLeft, updatedAt := client.EmailsLeft()
+		assert.Equal(t, expected[i], emailsLeft)
+		assert.False(t, updatedAt.Before(before), "timestamp of send %d is stale", i)
+	}
+
+	// The threshold was crossed at 3; the later drop to 0 does not trigger again.
+	assert.Equal(t, []int64{3}, calls)
+
+	t.Run("Callback is armed again after the quota resets", func(t *testing.T) {
+		mu.Lock()
+		left = 103
+		mu.Unlock()
+
+		require.NoError(t, sends[1]())
+		assert.Len(t, calls, 1)
+
+		mu.Lock()
+		left = 7
+		mu.Unlock()
+
+		require.NoError(t, sends[2]())
+		assert.Equal(t, []int64{3, 4}, calls)
+	})
+}
+
+func TestQuotaCoordinator(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Concurrent sends do not overshoot the quota", func(t *testing.T) {
+		server := newFakeServer(t)
+
+		const quota = 20
+		var mu sync.Mutex
+		sent, overshoot := 0, 0
+		server.update(func(h *fakeHandlers) {
+			h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+				// Simulate varying latency so responses arrive out of order
+				time.Sleep(time.Duration(len(req.Subject)%5) * time.Millisecond)
+
+				mu.Lock()
+				defer mu.Unlock()
+				if sent >= quota {
+					overshoot++
+					return nil, status.Error(codes.ResourceExhausted, "quota exceeded")
+				}
+				sent++
+				return &pb.SendEmailResponse{Message: []string{"msg"}, EmailsLeft: int64(quota - sent)}, nil
+			}
+		})
+
+		coordinator := sendlix.NewMemoryQuotaCoordinator()
+		coordinator.Observe(ctx, quota)
+
+		config := server.config()
+		config.QuotaCoordinator = coordinator
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test-token"}, config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		var wg sync.WaitGroup
+		var succeeded, reserved atomic.Int64
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := client.SendEmail(ctx, sendlix.MailOptions{
+					From:    sendlix.EmailAddress{Email: "sender@example.com"},
+					To:      []sendlix.EmailAddress{{Email: "recipient@example.com"}},
+					Subject: strings.Repeat("x", i%7+1),
+					Text:    "Hello",
+				}, nil)
+				switch {
+				case err == nil:
+					succeeded.Add(1)
+				case errors.Is(err, sendlix.ErrQuotaReserved):
+					reserved.Add(1)
+				default:
+					t.Errorf("unexpected error: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Zero(t, overshoot)
+		assert.Equal(t, int64(quota), succeeded.Load())
+		assert.Equal(t, int64(100-quota), reserved.Load())
+
+		remaining, inFlight, known := coordinator.Remaining()
+		assert.True(t, known)
+		assert.Zero(t, remaining)
+		assert.Zero(t, inFlight)
+	})
+
+	t.Run("Reservations cover all recipients and are released on failure", func(t *testing.T) {
+		server := newFakeServer(t)
+		server.update(func(h *fakeHandlers) {
+			h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+				return nil, status.Error(codes.Unavailable, "try again later")
+			}
+		})
+
+		coordinator := sendlix.NewMemoryQuotaCoordinator()
+		coordinator.Observe(ctx, 3)
+
+		config := server.config()
+		config.QuotaCoordinator = coordinator
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test-token"}, config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		options := sendlix.MailOptions{
+			From:    sendlix.EmailAddress{Email: "sender@example.com"},
+			To:      []sendlix.EmailAddress{{Email: "a@example.com"}, {Email: "b@example.com"}},
+			CC:      []sendlix.EmailAddress{{Email: "c@example.com"}},
+			BCC:     []sendlix.EmailAddress{{Email: "d@example.com"}},
+			Subject: "Hello",
+			Text:    "Hello",
+		}
+
+		_, err = client.SendEmail(ctx, options, nil)
+		assert.ErrorIs(t, err, sendlix.ErrQuotaReserved)
+		assert.Zero(t, server.count("SendEmail"))
+
+		options.BCC = nil
+		_, err = client.SendEmail(ctx, options, nil)
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, sendlix.ErrQuotaReserved)
+
+		remaining, inFlight, _ := coordinator.Remaining()
+		assert.Equal(t, int64(3), remaining)
+		assert.Zero(t, inFlight)
+	})
+
+	t.Run("Message size errors are not quota errors", func(t *testing.T) {
+		server := newFakeServer(t)
+		server.update(func(h *fakeHandlers) {
+			h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+				return nil, status.Error(codes.ResourceExhausted, "grpc: received message larger than max (6291456 vs. 4194304)")
+			}
+		})
+
+		coordinator := sendlix.NewMemoryQuotaCoordinator()
+		coordinator.Observe(ctx, 3)
+
+		config := server.config()
+		config.QuotaCoordinator = coordinator
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test-token"}, config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.SendEmail(ctx, sendlixtest.ValidMailOptions(), nil)
+		assert.ErrorIs(t, err, sendlix.ErrMessageTooLarge)
+		assert.NotErrorIs(t, err, sendlix.ErrQuotaExceeded)
+
+		remaining, inFlight, known := coordinator.Remaining()
+		assert.True(t, known)
+		assert.Equal(t, int64(3), remaining)
+		assert.Zero(t, inFlight)
+	})
+
+	t.Run("Quota increases are accepted when idle", func(t *testing.T) {
+		coordinator := sendlix.NewMemoryQuotaCoordinator()
+
+		require.NoError(t, coordinator.Reserve(ctx, 1000), "unknown quota allows reservations")
+		coordinator.Release(ctx, 1000)
+
+		coordinator.Observe(ctx, 5)
+		require.NoError(t, coordinator.Reserve(ctx, 1))
+		coordinator.Observe(ctx, 500)
+		remaining, _, _ := coordinator.Remaining()
+		assert.Equal(t, int64(5), remaining, "stale higher value ignored while in flight")
+
+		coordinator.Release(ctx, 1)
+		coordinator.Observe(ctx, 500)
+		remaining, _, _ = coordinator.Remaining()
+		assert.Equal(t, int64(500), remaining)
+	})
+}
@@ -0,0 +1,145 @@
+package sendlix_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nonRPCMethods are client methods that never talk to the API.
+var nonRPCMethods = map[string]bool{
+	"Close":               true,
+	"GetConnection":       true,
+	"CacheStats":          true,
+	"EmailsLeft":          true,
+	"WarmUp":              true,
+	"WithDefaults":        true,
+	"Stats":               true,
+	"ResetStats":          true,
+	"Categories":          true,
+	"Health":              true,
+	"EffectiveConfig":     true,
+	"Reconnect":           true,
+	"EmbedImageDataURI":   true,
+	"EstimateRequestSize": true,
+}
+
+func TestReadOnlyMode(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+
+	config := server.config()
+	config.ReadOnly = true
+	uploader := newHTTPUploader(t)
+	config.AttachmentUploader = uploader
+
+	emailClient, err := sendlix.NewEmailClient(&MockAuth{Token: "test-token"}, config)
+	require.NoError(t, err)
+	defer emailClient.Close()
+
+	groupClient, err := sendlix.NewGroupClient(&MockAuth{Token: "test-token"}, config)
+	require.NoError(t, err)
+	defer groupClient.Close()
+
+	mail := sendlix.MailOptions{
+		From:    sendlix.EmailAddress{Email: "sender@example.com"},
+		To:      []sendlix.EmailAddress{{Email: "recipient@example.com"}},
+		Subject: "Drill",
+		Text:    "Drill",
+	}
+
+	// calls maps every RPC method to a valid invocation and whether it must
+	// be permitted in read-only mode.
+	calls := map[string]struct {
+		allowed bool
+		call    func() error
+	}{
+		"SendEmail": {false, func() error {
+			_, err := emailClient.SendEmail(ctx, mail, nil)
+			return err
+		}},
+		"SendEMLEmail": {false, func() error {
+			_, err := emailClient.SendEMLEmail(ctx, []byte("Subject: Drill\r\n\r\nDrill"), nil)
+			return err
+		}},
+		"SendGroupEmail": {false, func() error {
+			return emailClient.SendGroupEmail(ctx, sendlix.GroupMailData{
+				GroupID: "group-1",
+				From:    sendlix.EmailAddress{Email: "sender@example.com"},
+				Subject: "Drill",
+				Content: sendlix.MailContent{Text: "Drill"},
+			})
+		}},
+		"InsertEmailsToGroup": {false, func() error {
+			_, err := groupClient.InsertEmailsToGroup(ctx, "group-1", []sendlix.GroupEntry{{Email: "user@example.com"}}, nil)
+			return err
+		}},
+		"InsertEmailToGroup": {false, func() error {
+			_, err := groupClient.InsertEmailToGroup(ctx, "group-1", sendlix.GroupEntry{Email: "user@example.com"})
+			return err
+		}},
+		"InsertEmailToGroups": {false, func() error {
+			_, err := groupClient.InsertEmailToGroups(ctx, []string{"group-1", "group-2"}, sendlix.GroupEntry{Email: "user@example.com"}, true)
+			return err
+		}},
+		"OpenInsertStream": {false, func() error {
+			stream, err := groupClient.OpenInsertStream(ctx, "group-1", nil)
+			require.NoError(t, err)
+			require.NoError(t, stream.Add(sendlix.GroupEntry{Email: "user@example.com"}))
+			_, err = stream.CloseAndRecv()
+			return err
+		}},
+		"RemoveEmailFromGroup": {false, func() error {
+			_, err := groupClient.RemoveEmailFromGroup(ctx, "group-1", "user@example.com")
+			return err
+		}},
+		"CheckEmailInGroup": {true, func() error {
+			_, err := groupClient.CheckEmailInGroup(ctx, "group-1", "user@example.com")
+			return err
+		}},
+		"Ping": {true, func() error {
+			return emailClient.Ping(ctx)
+		}},
+	}
+
+	for _, client := range []interface{}{emailClient, groupClient} {
+		typ := reflect.TypeOf(client)
+		for i := 0; i < typ.NumMethod(); i++ {
+			name := typ.Method(i).Name
+			if nonRPCMethods[name] {
+				continue
+			}
+
+			t.Run(typ.Elem().Name()+"."+name, func(t *testing.T) {
+				tc, ok := calls[name]
+				require.True(t, ok, "method %s is not classified for read-only mode", name)
+
+				err := tc.call()
+				if tc.allowed {
+					assert.NoError(t, err)
+				} else {
+					assert.True(t, errors.Is(err, sendlix.ErrReadOnlyMode), "unexpected error: %v", err)
+				}
+			})
+		}
+	}
+
+	for _, method := range []string{"SendEmail", "SendEmlEmail", "SendGroupEmail", "InsertEmailToGroup", "RemoveEmailFromGroup"} {
+		assert.Zero(t, server.count(method), "mutating RPC %s reached the server", method)
+	}
+	assert.Equal(t, 1, server.count("CheckEmailInGroup"))
+
+	t.Run("Attachments are not uploaded", func(t *testing.T) {
+		_, err := emailClient.SendEmail(ctx, mail, &sendlix.AdditionalOptions{
+			Attachments: []sendlix.Attachment{sendlix.NewAttachmentFromBytes("report.csv", "text/csv", []byte("a,b"))},
+		})
+
+		assert.ErrorIs(t, err, sendlix.ErrReadOnlyMode)
+		assert.Empty(t, uploader.objects)
+	})
+}
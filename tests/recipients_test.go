@@ -0,0 +1,187 @@
+package sendlix_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/sendlix/go-sdk/sendlixtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeResolver resolves "team:<name>" references from a fixed directory.
+type fakeResolver struct {
+	mu        sync.Mutex
+	directory map[string][]sendlix.EmailAddress
+	err       error
+	calls     map[string]int
+}
+
+func (r *fakeResolver) Resolve(ctx context.Context, ref string) ([]sendlix.EmailAddress, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls[ref]++
+	if r.err != nil {
+		return nil, r.err
+	}
+	if !strings.HasPrefix(ref, "team:") {
+		return nil, errors.New("unknown reference type")
+	}
+	return r.directory[strings.TrimPrefix(ref, "team:")], nil
+}
+
+func (r *fakeResolver) count(ref string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.calls[ref]
+}
+
+func TestRecipientResolver(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+
+	var mu sync.Mutex
+	var requests []*pb.SendMailRequest
+	server.update(func(h *fakeHandlers) {
+		h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			requests = append(requests, req)
+			return &pb.SendEmailResponse{Message: []string{"msg-1"}}, nil
+		}
+	})
+
+	newClient := func(t *testing.T, configure func(*sendlix.ClientConfig)) (*sendlix.EmailClient, *fakeResolver) {
+		resolver := &fakeResolver{
+			directory: map[string][]sendlix.EmailAddress{
+				"billing": {{Email: "alice@example.com", Name: "Alice"}, {Email: "bob@example.com"}},
+				"support": {{Email: "support@example.com"}},
+				"empty":   nil,
+			},
+			calls: map[string]int{},
+		}
+		config := server.config()
+		config.RecipientResolver = resolver
+		if configure != nil {
+			configure(config)
+		}
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+		require.NoError(t, err)
+		t.Cleanup(func() { client.Close() })
+		return client, resolver
+	}
+	emails := func(addrs []*pb.EmailData) []string {
+		var result []string
+		for _, addr := range addrs {
+			result = append(result, addr.Email)
+		}
+		return result
+	}
+
+	t.Run("Mixed literal and referenced recipients", func(t *testing.T) {
+		client, resolver := newClient(t, nil)
+		requests = nil
+		options := sendlixtest.MailOptionsWith(func(o *sendlix.MailOptions) {
+			o.To = []sendlix.EmailAddress{{Email: "first@example.com"}, sendlix.RecipientRef("team:billing"), {Email: "last@example.com"}}
+			o.CC = []sendlix.EmailAddress{sendlix.RecipientRef("team:support")}
+			o.BCC = []sendlix.EmailAddress{sendlix.RecipientRef("team:billing")}
+		})
+
+		_, err := client.SendEmail(ctx, options, nil)
+		require.NoError(t, err)
+
+		require.Len(t, requests, 1)
+		assert.Equal(t, []string{"first@example.com", "alice@example.com", "bob@example.com", "last@example.com"}, emails(requests[0].To))
+		assert.Equal(t, "Alice", requests[0].To[1].Name)
+		assert.Equal(t, []string{"support@example.com"}, emails(requests[0].Cc))
+		assert.Equal(t, []string{"alice@example.com", "bob@example.com"}, emails(requests[0].Bcc))
+		assert.Equal(t, 1, resolver.count("team:billing"), "each reference is resolved once per send")
+		assert.Equal(t, sendlix.RecipientRef("team:billing"), options.To[1], "caller options must not be modified")
+	})
+
+	t.Run("Resolved addresses are validated", func(t *testing.T) {
+		client, resolver := newClient(t, nil)
+		resolver.directory["long"] = []sendlix.EmailAddress{{Email: "long@example.com", Name: strings.Repeat("x", 300)}}
+
+		_, err := client.SendEmail(ctx, sendlixtest.MailOptionsWith(func(o *sendlix.MailOptions) {
+			o.To = []sendlix.EmailAddress{{Email: "first@example.com"}, sendlix.RecipientRef("team:long")}
+		}), nil)
+		assertValidationError(t, err, sendlix.CodeDisplayNameTooLong, "To[1]")
+	})
+
+	t.Run("Unresolvable references", func(t *testing.T) {
+		client, _ := newClient(t, nil)
+		requests = nil
+
+		_, err := client.SendEmail(ctx, sendlixtest.MailOptionsWith(func(o *sendlix.MailOptions) {
+			o.To = []sendlix.EmailAddress{sendlix.RecipientRef("team:empty"), sendlix.RecipientRef("team:support"), sendlix.RecipientRef("user:7")}
+			o.CC = []sendlix.EmailAddress{sendlix.RecipientRef("user:7")}
+		}), nil)
+
+		var resolutionErr *sendlix.RecipientResolutionError
+		require.ErrorAs(t, err, &resolutionErr)
+		assert.Equal(t, []string{"team:empty", "user:7"}, resolutionErr.Refs)
+		assert.Nil(t, resolutionErr.Errs[0])
+		assert.EqualError(t, resolutionErr.Errs[1], "unknown reference type")
+		assert.ErrorIs(t, err, sendlix.ErrUnresolvedRecipients)
+		assert.Equal(t, sendlix.CodeUnresolvedRecipients, sendlix.Code(err))
+		assert.Equal(t, "failed to resolve recipients: team:empty (no addresses), user:7 (unknown reference type)", err.Error())
+		assert.Empty(t, requests, "nothing is sent")
+	})
+
+	t.Run("Resolver errors", func(t *testing.T) {
+		client, resolver := newClient(t, nil)
+		resolver.err = context.DeadlineExceeded
+
+		_, err := client.SendEmail(ctx, sendlixtest.MailOptionsWith(func(o *sendlix.MailOptions) {
+			o.To = []sendlix.EmailAddress{sendlix.RecipientRef("team:billing")}
+		}), nil)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorIs(t, err, sendlix.ErrUnresolvedRecipients)
+	})
+
+	t.Run("References require a resolver", func(t *testing.T) {
+		client, _ := newClient(t, func(c *sendlix.ClientConfig) { c.RecipientResolver = nil })
+
+		_, err := client.SendEmail(ctx, sendlixtest.MailOptionsWith(func(o *sendlix.MailOptions) {
+			o.To = []sendlix.EmailAddress{sendlix.RecipientRef("team:billing")}
+		}), nil)
+		assertValidationError(t, err, sendlix.CodeMissingRecipientResolver, "To")
+
+		_, err = client.SendEmail(ctx, sendlixtest.ValidMailOptions(), nil)
+		assert.NoError(t, err, "literal addresses need no resolver")
+	})
+
+	t.Run("Cache", func(t *testing.T) {
+		options := sendlixtest.MailOptionsWith(func(o *sendlix.MailOptions) {
+			o.To = []sendlix.EmailAddress{sendlix.RecipientRef("team:billing"), sendlix.RecipientRef("team:empty")}
+		})
+		send := func(client *sendlix.EmailClient) {
+			client.SendEmail(ctx, options, nil)
+		}
+
+		client, resolver := newClient(t, nil)
+		send(client)
+		send(client)
+		send(client.WithDefaults(sendlix.Defaults{Category: "billing"}))
+		assert.Equal(t, 1, resolver.count("team:billing"), "resolved references are cached and shared with derived clients")
+		assert.Equal(t, 3, resolver.count("team:empty"), "failures are not cached")
+
+		client, resolver = newClient(t, func(c *sendlix.ClientConfig) { c.RecipientCacheTTL = -1 })
+		send(client)
+		send(client)
+		assert.Equal(t, 2, resolver.count("team:billing"), "caching disabled")
+
+		client, resolver = newClient(t, func(c *sendlix.ClientConfig) { c.RecipientCacheTTL = time.Millisecond })
+		send(client)
+		time.Sleep(5 * time.Millisecond)
+		send(client)
+		assert.Equal(t, 2, resolver.count("team:billing"), "expired entries are resolved again")
+	})
+}
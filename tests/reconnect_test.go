@@ -0,0 +1,121 @@
+package sendlix_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	"github.com/sendlix/go-sdk/sendlixtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/connectivity"
+)
+
+func TestReconnect(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Concurrent calls", func(t *testing.T) {
+		server := newFakeServer(t)
+		emails, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, server.config())
+		require.NoError(t, err)
+		defer emails.Close()
+		derived := emails.WithDefaults(sendlix.Defaults{Category: "news"})
+
+		var calls atomic.Int64
+		var errs []error
+		var mu sync.Mutex
+		stop := make(chan struct{})
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			client := emails
+			if i%2 == 1 {
+				client = derived
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					select {
+					case <-stop:
+						return
+					default:
+					}
+					_, err := client.SendEmail(ctx, sendlixtest.ValidMailOptions(), nil)
+					calls.Add(1)
+					if err != nil {
+						mu.Lock()
+						errs = append(errs, err)
+						mu.Unlock()
+					}
+				}
+			}()
+		}
+
+		for i := 0; i < 5; i++ {
+			time.Sleep(20 * time.Millisecond)
+			before := emails.GetConnection()
+			reconnectCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			require.NoError(t, emails.Reconnect(reconnectCtx))
+			cancel()
+			assert.NotSame(t, before, emails.GetConnection())
+			assert.Same(t, emails.GetConnection(), derived.GetConnection())
+			assert.Equal(t, connectivity.Shutdown, before.GetState(), "the old connection is closed")
+		}
+		close(stop)
+		wg.Wait()
+
+		assert.Empty(t, errs, "no call observes a closed connection")
+		assert.Greater(t, calls.Load(), int64(5))
+		assert.Equal(t, connectivity.Ready, emails.GetConnection().GetState())
+	})
+
+	t.Run("Failed dial keeps the connection", func(t *testing.T) {
+		server := newFakeServer(t)
+		groups, err := sendlix.NewGroupClient(&MockAuth{Token: "test"}, server.config())
+		require.NoError(t, err)
+		defer groups.Close()
+		_, err = groups.CheckEmailInGroup(ctx, "group-1", "user@example.com")
+		require.NoError(t, err)
+		before := groups.GetConnection()
+
+		expired, cancel := context.WithCancel(ctx)
+		cancel()
+		err = groups.Reconnect(expired)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		assert.Same(t, before, groups.GetConnection())
+		_, err = groups.CheckEmailInGroup(ctx, "group-1", "user@example.com")
+		require.NoError(t, err)
+	})
+
+	t.Run("Shared connection", func(t *testing.T) {
+		server := newFakeServer(t)
+		auth, err := sendlix.NewAuthWithConfig("secret.1", server.config())
+		require.NoError(t, err)
+		emails, groups, err := sendlix.NewClientsWithSharedConn(auth, server.config())
+		require.NoError(t, err)
+		defer emails.Close()
+
+		require.NoError(t, groups.Reconnect(ctx))
+
+		assert.Same(t, emails.GetConnection(), groups.GetConnection())
+		assert.Same(t, emails.GetConnection(), auth.GetConnection(), "token exchanges use the new connection")
+		_, err = emails.SendEmail(ctx, sendlixtest.ValidMailOptions(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, 1, server.count("GetJwtToken"))
+	})
+
+	t.Run("After Close", func(t *testing.T) {
+		server := newFakeServer(t)
+		emails, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, server.config())
+		require.NoError(t, err)
+		require.NoError(t, emails.Close())
+
+		assert.Error(t, emails.Reconnect(ctx))
+		assert.Equal(t, connectivity.Shutdown, emails.GetConnection().GetState())
+	})
+}
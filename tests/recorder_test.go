@@ -0,0 +1,134 @@
+package sendlix_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	"github.com/sendlix/go-sdk/sendlixtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+	fixture := filepath.Join(t.TempDir(), "testdata", "welcome.json")
+	upstream := sendlixtest.NewServer(t)
+
+	welcome := sendlix.MailOptions{
+		From:    sendlix.EmailAddress{Email: "noreply@example.com"},
+		To:      []sendlix.EmailAddress{{Email: "Jane.Doe@Example.com", Name: "Jane Doe"}},
+		Subject: "Welcome",
+		Text:    "Welcome aboard",
+	}
+
+	newClients := func(t *testing.T, rec *sendlixtest.Recorder, apiKey string) (*sendlix.EmailClient, *sendlix.GroupClient) {
+		auth, err := sendlix.NewAuthWithConfig(apiKey, rec.Config())
+		require.NoError(t, err)
+		t.Cleanup(func() { auth.Close() })
+
+		emailClient, err := sendlix.NewEmailClient(auth, rec.Config())
+		require.NoError(t, err)
+		t.Cleanup(func() { emailClient.Close() })
+		groupClient, err := sendlix.NewGroupClient(auth, rec.Config())
+		require.NoError(t, err)
+		t.Cleanup(func() { groupClient.Close() })
+		return emailClient, groupClient
+	}
+
+	t.Run("Record", func(t *testing.T) {
+		rec := sendlixtest.NewRecorder(t, fixture, sendlixtest.ModeRecord, upstream.Config())
+		emailClient, groupClient := newClients(t, rec, "topsecret.42")
+
+		for i := 0; i < 2; i++ {
+			_, err := emailClient.SendEmail(ctx, welcome, nil)
+			require.NoError(t, err)
+		}
+		_, err := emailClient.SendEMLEmail(ctx, []byte("From: news@example.com\r\nTo: jane.doe@example.com\r\nSubject: Digest\r\n\r\nHello"), nil)
+		require.NoError(t, err)
+		_, err = groupClient.InsertEmailToGroup(ctx, "newsletter", sendlix.GroupEntry{Email: "jane.doe@example.com"})
+		require.NoError(t, err)
+
+		upstream.AssertSent(t, sendlixtest.Match{To: "jane.doe@example.com", Subject: "Welcome"})
+	})
+
+	t.Run("Fixture is sanitized", func(t *testing.T) {
+		data, err := os.ReadFile(fixture)
+		require.NoError(t, err)
+		content := string(data)
+
+		assert.NotContains(t, content, "topsecret")
+		assert.NotContains(t, content, `"token": "sendlixtest"`)
+		assert.NotContains(t, strings.ToLower(content), "jane")
+		assert.Contains(t, content, `"secret": "REDACTED"`)
+		assert.Contains(t, content, `"token": "REDACTED"`)
+		assert.Contains(t, content, "noreply@example.com", "sender addresses are kept")
+
+		var parsed struct {
+			Version      int
+			Interactions []struct {
+				Method   string
+				Request  map[string]any
+				Response map[string]any
+			}
+		}
+		require.NoError(t, json.Unmarshal(data, &parsed))
+		assert.Equal(t, 1, parsed.Version)
+		require.Len(t, parsed.Interactions, 5)
+		assert.Equal(t, "/sendlix.api.v1.Auth/GetJwtToken", parsed.Interactions[0].Method)
+
+		to := parsed.Interactions[1].Request["to"].([]any)[0].(map[string]any)
+		assert.Regexp(t, `^user-[0-9a-f]{8}@redacted\.invalid$`, to["email"])
+		assert.Equal(t, "REDACTED", to["name"])
+
+		entry := parsed.Interactions[4].Request["entries"].([]any)[0].(map[string]any)["email"].(map[string]any)
+		assert.Equal(t, to["email"], entry["email"], "addresses are mapped regardless of case")
+	})
+
+	t.Run("Replay", func(t *testing.T) {
+		rec := sendlixtest.NewRecorder(t, fixture, sendlixtest.ModeReplay, nil)
+		emailClient, groupClient := newClients(t, rec, "any.1")
+		before := len(upstream.Sent())
+
+		for _, want := range [][]string{{"msg-1"}, {"msg-2"}, {"msg-2"}} {
+			ids, err := emailClient.SendEmail(ctx, welcome, nil)
+			require.NoError(t, err)
+			assert.Equal(t, want, ids)
+		}
+
+		resp, err := groupClient.InsertEmailToGroup(ctx, "newsletter", sendlix.GroupEntry{Email: "jane.doe@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), resp.AffectedRows)
+		assert.Len(t, upstream.Sent(), before, "replays do not reach the upstream server")
+	})
+
+	t.Run("Unmatched request", func(t *testing.T) {
+		recT := &recordingT{TB: t}
+		rec := sendlixtest.NewRecorder(recT, fixture, sendlixtest.ModeReplay, nil)
+		emailClient, _ := newClients(t, rec, "any.1")
+
+		changed := welcome
+		changed.Subject = "Welcome!"
+		changed.CC = []sendlix.EmailAddress{{Email: "audit@example.com"}}
+		_, err := emailClient.SendEmail(ctx, changed, nil)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		require.Len(t, recT.errors, 1)
+		assert.Contains(t, recT.errors[0], "no recorded interaction in "+fixture+" matches /sendlix.api.v1.Email/SendEmail request")
+		assert.Contains(t, recT.errors[0], "  cc[0].email: recorded nothing, got \"user-")
+		assert.Contains(t, recT.errors[0], `  subject: recorded "Welcome", got "Welcome!"`)
+	})
+
+	t.Run("Mode from environment", func(t *testing.T) {
+		t.Setenv(sendlixtest.RecordEnvVar, "false")
+		assert.Equal(t, sendlixtest.ModeReplay, sendlixtest.ModeFromEnv())
+		t.Setenv(sendlixtest.RecordEnvVar, "1")
+		assert.Equal(t, sendlixtest.ModeRecord, sendlixtest.ModeFromEnv())
+	})
+}
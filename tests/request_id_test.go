@@ -0,0 +1,115 @@
+package sendlix_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/sendlix/go-sdk/sendlixtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestRequestID(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+
+	setRequestID := func(ctx context.Context, id string) {
+		grpc.SetHeader(ctx, metadata.Pairs(sendlix.RequestIDHeader, id))
+	}
+	server.update(func(h *fakeHandlers) {
+		h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+			switch req.Subject {
+			case "fail":
+				setRequestID(ctx, "req-failed")
+				return nil, status.Error(codes.Unavailable, "overloaded")
+			case "forbidden":
+				grpc.SetTrailer(ctx, metadata.Pairs(sendlix.RequestIDHeader, "req-forbidden"))
+				return nil, status.Error(codes.PermissionDenied, "denied")
+			case "anonymous":
+				return nil, status.Error(codes.Internal, "boom")
+			}
+			setRequestID(ctx, "req-email")
+			return &pb.SendEmailResponse{Message: []string{"msg-1"}}, nil
+		}
+		h.sendGroupEmail = func(ctx context.Context, req *pb.GroupMailData) (*pb.SendEmailResponse, error) {
+			setRequestID(ctx, "req-group")
+			return &pb.SendEmailResponse{Message: []string{"msg-2"}}, nil
+		}
+		h.insertEmailToGroup = func(ctx context.Context, req *pb.InsertEmailToGroupRequest) (*pb.UpdateResponse, error) {
+			setRequestID(ctx, "req-insert")
+			return &pb.UpdateResponse{Success: true, AffectedRows: int64(len(req.Entries))}, nil
+		}
+	})
+
+	recorder := sendlix.NewMemorySendRecorder()
+	config := server.config()
+	config.SendRecorder = recorder
+	client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+	require.NoError(t, err)
+	defer client.Close()
+
+	groups, err := sendlix.NewGroupClient(&MockAuth{Token: "test"}, server.config())
+	require.NoError(t, err)
+	defer groups.Close()
+
+	t.Run("Successful sends", func(t *testing.T) {
+		var requestID string
+		_, err := client.SendEmail(sendlix.WithRequestID(ctx, &requestID), sendlixtest.ValidMailOptions(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "req-email", requestID)
+
+		record, ok := recorder.Lookup("msg-1")
+		require.True(t, ok)
+		assert.Equal(t, "req-email", record.RequestID)
+
+		require.NoError(t, client.SendGroupEmail(sendlix.WithRequestID(ctx, &requestID), sendlixtest.ValidGroupMailData()))
+		assert.Equal(t, "req-group", requestID)
+		record, ok = recorder.Lookup("msg-2")
+		require.True(t, ok)
+		assert.Equal(t, "req-group", record.RequestID)
+	})
+
+	t.Run("Group updates", func(t *testing.T) {
+		resp, err := groups.InsertEmailToGroup(ctx, "group-1", sendlix.GroupEntry{Email: "a@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, "req-insert", resp.RequestID)
+
+		resp, err = groups.RemoveEmailFromGroup(ctx, "group-1", "a@example.com")
+		require.NoError(t, err)
+		assert.Empty(t, resp.RequestID, "no ID without response metadata")
+	})
+
+	t.Run("Failed sends", func(t *testing.T) {
+		var requestID string
+		_, err := client.SendEmail(sendlix.WithRequestID(ctx, &requestID), sendlixtest.MailOptionsWith(func(o *sendlix.MailOptions) { o.Subject = "fail" }), nil)
+		require.Error(t, err)
+		assert.Equal(t, "req-failed", sendlix.RequestID(err))
+		assert.Equal(t, "req-failed", requestID)
+		assert.Equal(t, sendlix.CodeUnavailable, sendlix.Code(err))
+		assert.Equal(t, "failed to send email: rpc error: code = Unavailable desc = overloaded", err.Error())
+
+		_, err = client.SendEmail(ctx, sendlixtest.MailOptionsWith(func(o *sendlix.MailOptions) { o.Subject = "forbidden" }), nil)
+		assert.Equal(t, "req-forbidden", sendlix.RequestID(err), "IDs are read from trailers too")
+		assert.ErrorIs(t, err, sendlix.ErrPermissionDenied)
+
+		_, err = client.SendEmail(sendlix.WithRequestID(ctx, &requestID), sendlixtest.MailOptionsWith(func(o *sendlix.MailOptions) { o.Subject = "anonymous" }), nil)
+		require.Error(t, err)
+		assert.Empty(t, sendlix.RequestID(err))
+		assert.Empty(t, requestID, "the ID of the most recent call")
+	})
+
+	t.Run("Errors without calls", func(t *testing.T) {
+		assert.Empty(t, sendlix.RequestID(nil))
+		assert.Empty(t, sendlix.RequestID(errors.New("other")))
+
+		_, err := client.SendEmail(ctx, sendlix.MailOptions{}, nil)
+		assert.Empty(t, sendlix.RequestID(err))
+	})
+}
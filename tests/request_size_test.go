@@ -0,0 +1,126 @@
+package sendlix_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/sendlix/go-sdk/sendlixtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+func TestEstimateRequestSize(t *testing.T) {
+	base := sendlix.MailOptions{
+		From:    sendlix.EmailAddress{Email: "sender@example.com", Name: "Sender"},
+		To:      []sendlix.EmailAddress{{Email: "recipient@example.com"}},
+		Subject: "Hello",
+		Html:    "<h1>Hello</h1>",
+		Text:    "Hello",
+	}
+
+	manyRecipients := base
+	for i := 0; i < 200; i++ {
+		manyRecipients.CC = append(manyRecipients.CC, sendlix.EmailAddress{Email: fmt.Sprintf("cc%d@example.com", i), Name: fmt.Sprintf("CC %d", i)})
+	}
+
+	withImages := base
+	withImages.Images = []sendlix.Image{
+		{Placeholder: "{{logo}}", Data: bytes.Repeat([]byte{0x89}, 256*1024), Type: sendlix.MimeTypePNG},
+		{Placeholder: "{{photo}}", Data: bytes.Repeat([]byte{0xFF}, 64*1024), Type: sendlix.MimeTypeJPEG},
+	}
+
+	sendAt := time.Now().Add(time.Hour)
+	tests := []struct {
+		name       string
+		options    sendlix.MailOptions
+		additional *sendlix.AdditionalOptions
+	}{
+		{name: "Minimal email", options: base},
+		{name: "Many recipients", options: manyRecipients},
+		{name: "Embedded images", options: withImages},
+		{
+			name:    "Attachments and scheduling",
+			options: base,
+			additional: &sendlix.AdditionalOptions{
+				Category: "newsletter",
+				SendAt:   &sendAt,
+				Attachments: []sendlix.Attachment{
+					{ContentURL: "https://example.com/report.pdf", Filename: "report.pdf", ContentType: "application/pdf"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeServer(t)
+			var actual int
+			server.update(func(h *fakeHandlers) {
+				h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+					data, err := proto.Marshal(protoadapt.MessageV2Of(req))
+					if err != nil {
+						return nil, err
+					}
+					actual = len(data)
+					return &pb.SendEmailResponse{Message: []string{"msg-1"}}, nil
+				}
+			})
+
+			client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, server.config())
+			require.NoError(t, err)
+			defer client.Close()
+
+			estimate, err := client.EstimateRequestSize(context.Background(), tt.options, tt.additional)
+			require.NoError(t, err)
+
+			_, err = client.SendEmail(context.Background(), tt.options, tt.additional)
+			require.NoError(t, err)
+
+			assert.InDelta(t, actual, estimate, 16)
+		})
+	}
+
+	t.Run("Invalid options", func(t *testing.T) {
+		server := newFakeServer(t)
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, server.config())
+		require.NoError(t, err)
+		defer client.Close()
+
+		size, err := client.EstimateRequestSize(context.Background(), sendlixtest.InvalidVariants()["missing from"].Options, nil)
+
+		assert.Zero(t, size)
+		assert.True(t, errors.Is(err, sendlix.ErrMissingFrom))
+		assert.Zero(t, server.count("SendEmail"))
+	})
+
+	t.Run("Client configuration", func(t *testing.T) {
+		server := newFakeServer(t)
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, server.config())
+		require.NoError(t, err)
+		defer client.Close()
+
+		noFrom := base
+		noFrom.From = sendlix.EmailAddress{}
+		withDefaults := client.WithDefaults(sendlix.Defaults{
+			From:     sendlix.EmailAddress{Email: "billing@example.com", Name: "Billing"},
+			Category: "invoices",
+		})
+
+		size, err := withDefaults.EstimateRequestSize(context.Background(), noFrom, nil)
+		require.NoError(t, err)
+		plain, err := client.EstimateRequestSize(context.Background(), base, nil)
+		require.NoError(t, err)
+		assert.NotEqual(t, plain, size)
+
+		_, err = client.EstimateRequestSize(context.Background(), noFrom, nil)
+		assert.True(t, errors.Is(err, sendlix.ErrMissingFrom))
+	})
+}
@@ -0,0 +1,184 @@
+package sendlix_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/sendlix/go-sdk/sendlixtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// failingSends makes SendEmail fail with err for the first failures calls.
+func failingSends(server *fakeServer, failures int32, err error) {
+	var calls atomic.Int32
+	server.update(func(h *fakeHandlers) {
+		h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+			if calls.Add(1) <= failures {
+				return nil, err
+			}
+			return &pb.SendEmailResponse{Message: []string{"msg-1"}, EmailsLeft: 100}, nil
+		}
+	})
+}
+
+// retryInfoError returns a RESOURCE_EXHAUSTED error asking to retry after
+// delay.
+func retryInfoError(t *testing.T, delay time.Duration) error {
+	s, err := status.New(codes.ResourceExhausted, "slow down").WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+	require.NoError(t, err)
+	return s.Err()
+}
+
+func TestRetryPolicy(t *testing.T) {
+	newClient := func(t *testing.T, server *fakeServer, policy *sendlix.RetryPolicy) *sendlix.EmailClient {
+		config := server.config()
+		config.RetryPolicy = policy
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+		require.NoError(t, err)
+		t.Cleanup(func() { client.Close() })
+		return client
+	}
+	fast := &sendlix.RetryPolicy{InitialBackoff: time.Millisecond, MaxBackoff: 50 * time.Millisecond}
+
+	tests := []struct {
+		name     string
+		policy   *sendlix.RetryPolicy
+		failures int32
+		err      func(t *testing.T) error
+		wantCode codes.Code
+		wantSent int
+	}{
+		{
+			name:     "Unavailable until success",
+			policy:   fast,
+			failures: 2,
+			err:      func(t *testing.T) error { return status.Error(codes.Unavailable, "restarting") },
+			wantCode: codes.OK,
+			wantSent: 3,
+		},
+		{
+			name:     "Attempts exhausted",
+			policy:   fast,
+			failures: 3,
+			err:      func(t *testing.T) error { return status.Error(codes.Unavailable, "restarting") },
+			wantCode: codes.Unavailable,
+			wantSent: 3,
+		},
+		{
+			name:     "More attempts",
+			policy:   &sendlix.RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Millisecond},
+			failures: 4,
+			err:      func(t *testing.T) error { return status.Error(codes.Unavailable, "restarting") },
+			wantCode: codes.OK,
+			wantSent: 5,
+		},
+		{
+			name:     "Invalid argument",
+			policy:   fast,
+			failures: 1,
+			err:      func(t *testing.T) error { return status.Error(codes.InvalidArgument, "bad sender") },
+			wantCode: codes.InvalidArgument,
+			wantSent: 1,
+		},
+		{
+			name:     "Exhausted quota",
+			policy:   fast,
+			failures: 1,
+			err:      func(t *testing.T) error { return status.Error(codes.ResourceExhausted, "quota exceeded") },
+			wantCode: codes.ResourceExhausted,
+			wantSent: 1,
+		},
+		{
+			name:     "Retry info",
+			policy:   fast,
+			failures: 1,
+			err:      func(t *testing.T) error { return retryInfoError(t, 10*time.Millisecond) },
+			wantCode: codes.OK,
+			wantSent: 2,
+		},
+		{
+			name:     "Retry info beyond MaxBackoff",
+			policy:   fast,
+			failures: 1,
+			err:      func(t *testing.T) error { return retryInfoError(t, time.Minute) },
+			wantCode: codes.ResourceExhausted,
+			wantSent: 1,
+		},
+		{
+			name:     "Code not listed",
+			policy:   &sendlix.RetryPolicy{InitialBackoff: time.Millisecond, RetryableCodes: []codes.Code{codes.Aborted}},
+			failures: 1,
+			err:      func(t *testing.T) error { return status.Error(codes.Unavailable, "restarting") },
+			wantCode: codes.Unavailable,
+			wantSent: 1,
+		},
+		{
+			name:     "Disabled",
+			failures: 1,
+			err:      func(t *testing.T) error { return status.Error(codes.Unavailable, "restarting") },
+			wantCode: codes.Unavailable,
+			wantSent: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeServer(t)
+			failingSends(server, tt.failures, tt.err(t))
+
+			_, err := newClient(t, server, tt.policy).SendEmail(context.Background(), sendlixtest.ValidMailOptions(), nil)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantSent, server.count("SendEmail"))
+		})
+	}
+
+	t.Run("Canceled during backoff", func(t *testing.T) {
+		server := newFakeServer(t)
+		failingSends(server, 1, status.Error(codes.Unavailable, "restarting"))
+		client := newClient(t, server, &sendlix.RetryPolicy{InitialBackoff: 10 * time.Second, MaxBackoff: 10 * time.Second})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+		start := time.Now()
+		_, err := client.SendEmail(ctx, sendlixtest.ValidMailOptions(), nil)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Less(t, time.Since(start), 2*time.Second)
+		assert.Equal(t, 1, server.count("SendEmail"))
+	})
+
+	t.Run("Deadline before backoff ends", func(t *testing.T) {
+		server := newFakeServer(t)
+		failingSends(server, 1, status.Error(codes.Unavailable, "restarting"))
+		client := newClient(t, server, &sendlix.RetryPolicy{InitialBackoff: 10 * time.Second, MaxBackoff: 10 * time.Second})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		start := time.Now()
+		_, err := client.SendEmail(ctx, sendlixtest.ValidMailOptions(), nil)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Less(t, time.Since(start), time.Second, "the call returns without waiting for the backoff")
+		assert.Equal(t, 1, server.count("SendEmail"))
+	})
+
+	t.Run("Invalid policy", func(t *testing.T) {
+		server := newFakeServer(t)
+		for _, policy := range []*sendlix.RetryPolicy{
+			{MaxAttempts: -1},
+			{RetryableCodes: []codes.Code{codes.Unavailable, codes.InvalidArgument}},
+		} {
+			config := server.config()
+			config.RetryPolicy = policy
+			_, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+			assert.ErrorIs(t, err, sendlix.ErrInvalidRetryPolicy)
+		}
+	})
+}
@@ -0,0 +1,179 @@
+package sendlix_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeExecer records executed statements instead of talking to a database.
+type fakeExecer struct {
+	mu   sync.Mutex
+	rows [][]any
+	err  error
+}
+
+func (f *fakeExecer) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.rows = append(f.rows, args)
+	return nil, nil
+}
+
+func newRecordingEmailClient(t *testing.T, server *fakeServer, configure func(*sendlix.ClientConfig)) *sendlix.EmailClient {
+	t.Helper()
+
+	config := server.config()
+	configure(config)
+
+	client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+
+	return client
+}
+
+func TestSendRecorder(t *testing.T) {
+	options := sendlix.MailOptions{
+		From:    sendlix.EmailAddress{Email: "sender@example.com"},
+		To:      []sendlix.EmailAddress{{Email: "to@example.com"}},
+		CC:      []sendlix.EmailAddress{{Email: "cc@example.com"}},
+		BCC:     []sendlix.EmailAddress{{Email: "bcc@example.com"}},
+		Subject: "Invoice",
+		Text:    "Your invoice",
+	}
+
+	t.Run("Records every send type", func(t *testing.T) {
+		server := newFakeServer(t)
+		recorder := sendlix.NewMemorySendRecorder()
+		client := newRecordingEmailClient(t, server, func(c *sendlix.ClientConfig) {
+			c.SendRecorder = recorder
+		})
+
+		ctx := sendlix.WithJobID(context.Background(), "job-42")
+		ctx = sendlix.WithRecordMetadata(ctx, map[string]string{"tenant": "acme"})
+
+		_, err := client.SendEmail(ctx, options, &sendlix.AdditionalOptions{Category: "billing"})
+		require.NoError(t, err)
+
+		_, err = client.SendEMLEmail(ctx, []byte("Subject: Hi\r\n\r\nHello"), &sendlix.AdditionalOptions{Category: "migration"})
+		require.NoError(t, err)
+
+		err = client.SendGroupEmail(context.Background(), sendlix.GroupMailData{
+			GroupID:  "newsletter",
+			From:     sendlix.EmailAddress{Email: "news@example.com"},
+			Subject:  "News",
+			Category: "marketing",
+			Content:  sendlix.MailContent{Text: "News"},
+		})
+		require.NoError(t, err)
+
+		records := recorder.Records()
+		require.Len(t, records, 3)
+
+		assert.Equal(t, sendlix.SendRecord{
+			Type:       sendlix.SendTypeEmail,
+			JobID:      "job-42",
+			MessageIDs: []string{"msg-1"},
+			Recipients: []string{"to@example.com", "cc@example.com", "bcc@example.com"},
+			Category:   "billing",
+			Metadata:   map[string]string{"tenant": "acme"},
+		}, records[0])
+
+		assert.Equal(t, sendlix.SendRecord{
+			Type:       sendlix.SendTypeEML,
+			JobID:      "job-42",
+			MessageIDs: []string{"eml-1"},
+			Category:   "migration",
+			Metadata:   map[string]string{"tenant": "acme"},
+		}, records[1])
+
+		assert.Equal(t, sendlix.SendRecord{
+			Type:       sendlix.SendTypeGroup,
+			MessageIDs: []string{"group-1"},
+			GroupID:    "newsletter",
+			Category:   "marketing",
+		}, records[2])
+
+		record, ok := recorder.Lookup("eml-1")
+		require.True(t, ok)
+		assert.Equal(t, sendlix.SendTypeEML, record.Type)
+
+		_, ok = recorder.Lookup("unknown")
+		assert.False(t, ok)
+	})
+
+	t.Run("SQL recorder", func(t *testing.T) {
+		server := newFakeServer(t)
+		db := &fakeExecer{}
+		client := newRecordingEmailClient(t, server, func(c *sendlix.ClientConfig) {
+			c.SendRecorder = sendlix.NewSQLSendRecorder(db, "INSERT INTO sends (message_id, job_id, category, recipients, group_id) VALUES (?, ?, ?, ?, ?)")
+		})
+
+		_, err := client.SendEmail(sendlix.WithJobID(context.Background(), "job-7"), options, nil)
+		require.NoError(t, err)
+		err = client.SendGroupEmail(context.Background(), sendlix.GroupMailData{
+			GroupID:  "newsletter",
+			From:     sendlix.EmailAddress{Email: "news@example.com"},
+			Subject:  "News",
+			Content:  sendlix.MailContent{Text: "News"},
+			Category: "news",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, [][]any{
+			{"msg-1", "job-7", "", "to@example.com,cc@example.com,bcc@example.com", ""},
+			{"group-1", "", "news", "", "newsletter"},
+		}, db.rows)
+	})
+
+	t.Run("Warn policy reports errors without failing", func(t *testing.T) {
+		server := newFakeServer(t)
+		var reported error
+		client := newRecordingEmailClient(t, server, func(c *sendlix.ClientConfig) {
+			c.SendRecorder = sendlix.NewSQLSendRecorder(&fakeExecer{err: errors.New("database is locked")}, "INSERT INTO sends VALUES (?, ?, ?, ?, ?)")
+			c.OnRecordError = func(record sendlix.SendRecord, err error) {
+				reported = err
+			}
+		})
+
+		ids, err := client.SendEmail(context.Background(), options, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"msg-1"}, ids)
+		require.Error(t, reported)
+		assert.Contains(t, reported.Error(), "database is locked")
+	})
+
+	t.Run("Fail policy returns errors with message IDs", func(t *testing.T) {
+		server := newFakeServer(t)
+		dbErr := errors.New("database is locked")
+		client := newRecordingEmailClient(t, server, func(c *sendlix.ClientConfig) {
+			c.SendRecorder = sendlix.NewSQLSendRecorder(&fakeExecer{err: dbErr}, "INSERT INTO sends VALUES (?, ?, ?, ?, ?)")
+			c.RecordFailurePolicy = sendlix.RecordFailureFail
+		})
+
+		ids, err := client.SendEmail(context.Background(), options, nil)
+
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, dbErr))
+		assert.Equal(t, []string{"msg-1"}, ids)
+
+		err = client.SendGroupEmail(context.Background(), sendlix.GroupMailData{
+			GroupID: "newsletter",
+			From:    sendlix.EmailAddress{Email: "news@example.com"},
+			Subject: "News",
+			Content: sendlix.MailContent{Text: "News"},
+		})
+		assert.True(t, errors.Is(err, dbErr))
+	})
+}
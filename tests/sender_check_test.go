@@ -0,0 +1,145 @@
+package sendlix_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSender(t *testing.T) {
+	rules := func(issues []sendlix.LintIssue) []sendlix.LintRule {
+		var result []sendlix.LintRule
+		for _, issue := range issues {
+			result = append(result, issue.Rule)
+		}
+		return result
+	}
+
+	t.Run("Unverified domain", func(t *testing.T) {
+		config := sendlix.SenderCheckConfig{VerifiedDomains: []string{"example.com"}}
+
+		assert.Empty(t, sendlix.CheckSender(sendlix.EmailAddress{Email: "news@Example.com"}, nil, config))
+
+		issues := sendlix.CheckSender(sendlix.EmailAddress{Email: "news@mail.example.com"}, nil, config)
+		require.Len(t, issues, 1)
+		assert.Equal(t, sendlix.LintIssue{
+			Rule:    sendlix.LintUnverifiedDomain,
+			Field:   "From",
+			Message: `domain "mail.example.com" is not a verified sending domain`,
+		}, issues[0])
+
+		assert.Empty(t, sendlix.CheckSender(sendlix.EmailAddress{Email: "news@other.com"}, nil, sendlix.SenderCheckConfig{}),
+			"skipped without verified domains")
+	})
+
+	t.Run("Reply-To alignment", func(t *testing.T) {
+		from := sendlix.EmailAddress{Email: "news@example.co.uk"}
+		tests := []struct {
+			replyTo  string
+			mismatch bool
+		}{
+			{"support@example.co.uk", false},
+			{"support@help.example.co.uk", false},
+			{"support@other.co.uk", true},
+			{"support@example.com", true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.replyTo, func(t *testing.T) {
+				issues := sendlix.CheckSender(from, &sendlix.EmailAddress{Email: tt.replyTo}, sendlix.SenderCheckConfig{})
+				if tt.mismatch {
+					assert.Equal(t, []sendlix.LintRule{sendlix.LintReplyToMismatch}, rules(issues))
+					assert.Equal(t, "ReplyTo", issues[0].Field)
+				} else {
+					assert.Empty(t, issues)
+				}
+			})
+		}
+	})
+
+	t.Run("Freemail sender", func(t *testing.T) {
+		issues := sendlix.CheckSender(sendlix.EmailAddress{Email: "shop@GMAIL.com"}, nil, sendlix.SenderCheckConfig{})
+		require.Len(t, issues, 1)
+		assert.Equal(t, `freemail_from: sender domain "gmail.com" is a freemail provider`, issues[0].String())
+
+		assert.Contains(t, sendlix.DefaultFreemailDomains(), "outlook.com")
+
+		custom := sendlix.SenderCheckConfig{FreemailDomains: []string{"example.com"}}
+		assert.Empty(t, sendlix.CheckSender(sendlix.EmailAddress{Email: "shop@gmail.com"}, nil, custom))
+		assert.Len(t, sendlix.CheckSender(sendlix.EmailAddress{Email: "shop@example.com"}, nil, custom), 1)
+	})
+
+	t.Run("Rules can be disabled", func(t *testing.T) {
+		from := sendlix.EmailAddress{Email: "shop@gmail.com"}
+		replyTo := &sendlix.EmailAddress{Email: "support@example.com"}
+		config := sendlix.SenderCheckConfig{VerifiedDomains: []string{"example.com"}}
+
+		assert.Equal(t, []sendlix.LintRule{
+			sendlix.LintUnverifiedDomain, sendlix.LintReplyToMismatch, sendlix.LintFreemailFrom,
+		}, rules(sendlix.CheckSender(from, replyTo, config)))
+
+		config.Disabled = []sendlix.LintRule{sendlix.LintUnverifiedDomain, sendlix.LintFreemailFrom}
+		assert.Equal(t, []sendlix.LintRule{sendlix.LintReplyToMismatch}, rules(sendlix.CheckSender(from, replyTo, config)))
+	})
+}
+
+func TestSenderCheckOnSend(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+
+	options := sendlix.MailOptions{
+		From:    sendlix.EmailAddress{Email: "shop@example.com"},
+		To:      []sendlix.EmailAddress{{Email: "recipient@example.com"}},
+		ReplyTo: &sendlix.EmailAddress{Email: "support@helpdesk.io"},
+		Subject: "Hello",
+		Text:    "Hello",
+	}
+
+	t.Run("Issues are reported as warnings", func(t *testing.T) {
+		var warnings []sendlix.LintIssue
+		config := server.config()
+		config.SenderCheck = &sendlix.SenderCheckConfig{
+			OnWarning: func(issues []sendlix.LintIssue) { warnings = append(warnings, issues...) },
+		}
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test-token"}, config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.SendEmail(ctx, options, nil)
+		require.NoError(t, err)
+		require.Len(t, warnings, 1)
+		assert.Equal(t, sendlix.LintReplyToMismatch, warnings[0].Rule)
+	})
+
+	t.Run("Strict rules reject the send", func(t *testing.T) {
+		config := server.config()
+		config.SenderCheck = &sendlix.SenderCheckConfig{Strict: []sendlix.LintRule{sendlix.LintFreemailFrom}}
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test-token"}, config)
+		require.NoError(t, err)
+		defer client.Close()
+
+		before := server.count("SendEmail")
+		_, err = client.SendEmail(ctx, options, nil)
+		require.NoError(t, err, "reply-to mismatch is not strict")
+
+		err = client.SendGroupEmail(ctx, sendlix.GroupMailData{
+			GroupID: "group-1",
+			From:    sendlix.EmailAddress{Email: "shop@gmail.com"},
+			Subject: "Hello",
+			Content: sendlix.MailContent{Text: "Hello"},
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, sendlix.ErrSenderCheckFailed))
+		assert.Equal(t, `sender check freemail_from failed: sender domain "gmail.com" is a freemail provider`, err.Error())
+
+		var validationErr *sendlix.ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, "From", validationErr.Field)
+		assert.Equal(t, before+1, server.count("SendEmail"))
+		assert.Zero(t, server.count("SendGroupEmail"))
+	})
+}
@@ -0,0 +1,148 @@
+package sendlix_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	"github.com/sendlix/go-sdk/sendlixtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingT captures assertion failures instead of failing the test.
+type recordingT struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestSendlixtest(t *testing.T) {
+	ctx := context.Background()
+	server := sendlixtest.NewServer(t)
+
+	emailClient, err := sendlix.NewEmailClient(server.Auth(), server.Config())
+	require.NoError(t, err)
+	defer emailClient.Close()
+
+	groupClient, err := sendlix.NewGroupClient(server.Auth(), server.Config())
+	require.NoError(t, err)
+	defer groupClient.Close()
+
+	ids, err := emailClient.SendEmail(ctx, sendlix.MailOptions{
+		From:    sendlix.EmailAddress{Email: "noreply@example.com"},
+		To:      []sendlix.EmailAddress{{Email: "user@example.com"}},
+		CC:      []sendlix.EmailAddress{{Email: "audit@example.com"}},
+		Subject: "Password reset",
+		Html:    "<p>Click the link to reset your password</p>",
+	}, &sendlix.AdditionalOptions{Category: "auth"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"msg-1", "msg-2"}, ids)
+
+	_, err = emailClient.SendEMLEmail(ctx, []byte("From: news@example.com\r\nTo: reader@example.com\r\nSubject: Weekly digest\r\n\r\nHello"), nil)
+	require.NoError(t, err)
+
+	_, err = groupClient.InsertEmailToGroup(ctx, "newsletter", sendlix.GroupEntry{Email: "reader@example.com"})
+	require.NoError(t, err)
+
+	t.Run("Captured requests", func(t *testing.T) {
+		sent := server.Sent()
+		require.Len(t, sent, 2)
+		assert.Equal(t, sendlixtest.MethodSendEmail, sent[0].Method)
+		assert.Equal(t, []string{"audit@example.com"}, sent[0].CC)
+		assert.Equal(t, "auth", sent[0].Category)
+		assert.Equal(t, sendlixtest.MethodSendEmlEmail, sent[1].Method)
+		assert.Equal(t, "news@example.com", sent[1].From)
+		assert.Equal(t, []string{"reader@example.com"}, sent[1].To)
+		assert.Equal(t, "Weekly digest", sent[1].Subject)
+
+		exists, err := groupClient.CheckEmailInGroup(ctx, "newsletter", "reader@example.com")
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("Passing assertions", func(t *testing.T) {
+		rec := &recordingT{}
+		assert.True(t, server.AssertSent(rec, sendlixtest.Match{To: "USER@example.com", SubjectContains: "reset", Category: "auth"}))
+		assert.True(t, server.AssertSent(rec, sendlixtest.Match{BodyContains: "Click the link"}))
+		assert.True(t, server.AssertNotSent(rec, sendlixtest.Match{To: "admin@example.com"}))
+		assert.True(t, server.AssertGroupInserted(rec, "newsletter", "Reader@example.com"))
+		assert.Empty(t, rec.errors)
+	})
+
+	t.Run("AssertSent lists near misses", func(t *testing.T) {
+		rec := &recordingT{}
+		assert.False(t, server.AssertSent(rec, sendlixtest.Match{To: "x@example.com", SubjectContains: "reset", Category: "auth"}))
+
+		require.Len(t, rec.errors, 1)
+		assert.Equal(t, `sendlixtest: no sent email matches {To: "x@example.com", SubjectContains: "reset", Category: "auth"}
+2 sent email(s):
+  [0] SendEmail from="noreply@example.com" to=["user@example.com"] cc=["audit@example.com"] subject="Password reset" category="auth"
+      To: want "x@example.com", got ["user@example.com"]
+  [1] SendEmlEmail from="news@example.com" to=["reader@example.com"] subject="Weekly digest"
+      To: want "x@example.com", got ["reader@example.com"]
+      SubjectContains: want "reset", got "Weekly digest"
+      Category: want "auth", got ""`, rec.errors[0])
+	})
+
+	t.Run("AssertNotSent lists matches", func(t *testing.T) {
+		rec := &recordingT{}
+		assert.False(t, server.AssertNotSent(rec, sendlixtest.Match{From: "news@example.com"}))
+
+		require.Len(t, rec.errors, 1)
+		assert.Equal(t, `sendlixtest: expected no sent email to match {From: "news@example.com"}, but 1 did:
+  [1] SendEmlEmail from="news@example.com" to=["reader@example.com"] subject="Weekly digest"`, rec.errors[0])
+	})
+
+	t.Run("AssertGroupInserted lists inserts", func(t *testing.T) {
+		rec := &recordingT{}
+		assert.False(t, server.AssertGroupInserted(rec, "vip", "reader@example.com"))
+
+		require.Len(t, rec.errors, 1)
+		assert.Equal(t, `sendlixtest: "reader@example.com" was not inserted into group "vip"
+1 group insert(s):
+  [0] group="newsletter" emails=["reader@example.com"]`, rec.errors[0])
+	})
+
+	t.Run("Matchers compose", func(t *testing.T) {
+		reset := sendlixtest.Match{SubjectContains: "reset"}
+		digest := sendlixtest.Match{SubjectContains: "digest"}
+		toUser := sendlixtest.Match{To: "user@example.com"}
+
+		rec := &recordingT{}
+		assert.True(t, server.AssertSent(rec, sendlixtest.All(reset, toUser)))
+		assert.True(t, server.AssertSent(rec, sendlixtest.Any(digest, sendlixtest.Match{To: "nobody@example.com"})))
+		assert.True(t, server.AssertSent(rec, sendlixtest.All(toUser, sendlixtest.Any(digest, reset))))
+		assert.True(t, server.AssertNotSent(rec, sendlixtest.All(digest, toUser)))
+		assert.Empty(t, rec.errors)
+
+		either := sendlixtest.Any(digest, sendlixtest.Match{Category: "news"})
+		assert.Equal(t, `Any({SubjectContains: "digest"}, {Category: "news"})`, either.String())
+		assert.Equal(t, `All({SubjectContains: "reset"}, Any({SubjectContains: "digest"}, {Category: "news"}))`,
+			sendlixtest.All(reset, either).String())
+		assert.Equal(t, []string{
+			`{SubjectContains: "digest"}: SubjectContains: want "digest", got "Password reset"`,
+			`{Category: "news"}: Category: want "news", got "auth"`,
+		}, either.Mismatches(server.Sent()[0]))
+		assert.NotEmpty(t, sendlixtest.Any().Mismatches(server.Sent()[0]), "empty Any matches nothing")
+		assert.Empty(t, sendlixtest.All().Mismatches(server.Sent()[0]), "empty All matches everything")
+	})
+
+	t.Run("Reset discards captured requests", func(t *testing.T) {
+		server.Reset()
+
+		rec := &recordingT{}
+		assert.False(t, server.AssertSent(rec, sendlixtest.Match{}))
+		assert.False(t, server.AssertGroupInserted(rec, "newsletter", "reader@example.com"))
+		assert.Equal(t, []string{
+			"sendlixtest: no sent email matches {}\nno emails were sent",
+			"sendlixtest: \"reader@example.com\" was not inserted into group \"newsletter\"\nno group inserts were made",
+		}, rec.errors)
+	})
+}
@@ -0,0 +1,128 @@
+package sendlix_test
+
+import (
+	"context"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/sendlix/go-sdk/sendlixtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewClientsWithSharedConn(t *testing.T) {
+	ctx := context.Background()
+
+	newClients := func(t *testing.T, server *fakeServer, config *sendlix.ClientConfig) (*sendlix.Auth, *sendlix.EmailClient, *sendlix.GroupClient) {
+		auth, err := sendlix.NewAuthWithConfig("secret.1", server.config())
+		require.NoError(t, err)
+		emails, groups, err := sendlix.NewClientsWithSharedConn(auth, config)
+		require.NoError(t, err)
+		t.Cleanup(func() { emails.Close() })
+		return auth, emails, groups
+	}
+
+	t.Run("Single connection", func(t *testing.T) {
+		server := newFakeServer(t)
+		auth, emails, groups := newClients(t, server, server.config())
+
+		_, err := emails.SendEmail(ctx, sendlixtest.ValidMailOptions(), nil)
+		require.NoError(t, err)
+		_, err = groups.InsertEmailToGroup(ctx, "group-1", sendlix.GroupEntry{Email: "a@example.com"})
+		require.NoError(t, err)
+		_, err = groups.CheckEmailInGroup(ctx, "group-1", "a@example.com")
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, server.count("GetJwtToken"))
+		assert.Equal(t, 1, server.connections())
+		assert.Same(t, emails.GetConnection(), auth.GetConnection())
+		assert.Same(t, emails.GetConnection(), groups.GetConnection())
+
+		require.NoError(t, groups.Close())
+		assert.Equal(t, connectivity.Shutdown, auth.GetConnection().GetState(), "closing a client closes the shared connection")
+		assert.NoError(t, auth.Close(), "auth does not own the shared connection")
+	})
+
+	t.Run("Read-only mode", func(t *testing.T) {
+		server := newFakeServer(t)
+		config := server.config()
+		config.ReadOnly = true
+		_, _, groups := newClients(t, server, config)
+
+		_, err := groups.CheckEmailInGroup(ctx, "group-1", "a@example.com")
+		require.NoError(t, err, "token exchanges are permitted")
+		assert.Equal(t, 1, server.count("GetJwtToken"))
+	})
+
+	t.Run("Rejected key", func(t *testing.T) {
+		server := newFakeServer(t)
+		server.update(func(h *fakeHandlers) {
+			h.getJwtToken = func(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error) {
+				return nil, status.Error(codes.PermissionDenied, "denied")
+			}
+		})
+		_, emails, _ := newClients(t, server, server.config())
+
+		_, err := emails.SendEmail(ctx, sendlixtest.ValidMailOptions(), nil)
+		assert.ErrorIs(t, err, sendlix.ErrAuthFailed)
+		assert.ErrorIs(t, err, sendlix.ErrPermissionDenied)
+		assert.Equal(t, "failed to send email: failed to get auth header: failed to get JWT token: permission denied: rpc error: code = PermissionDenied desc = denied", err.Error())
+		assert.Equal(t, 1, server.count("GetJwtToken"))
+		assert.Zero(t, server.count("SendEmail"))
+	})
+
+	t.Run("Email settings are validated", func(t *testing.T) {
+		server := newFakeServer(t)
+		auth, err := sendlix.NewAuthWithConfig("secret.1", server.config())
+		require.NoError(t, err)
+		defer auth.Close()
+		config := server.config()
+		config.SpoolOnUnavailable = &sendlix.SpoolConfig{}
+
+		_, _, err = sendlix.NewClientsWithSharedConn(auth, config)
+		assertValidationError(t, err, sendlix.CodeMissingDeadLetter, "SpoolOnUnavailable.DeadLetter")
+	})
+
+	t.Run("Missing auth", func(t *testing.T) {
+		_, _, err := sendlix.NewClientsWithSharedConn(nil, nil)
+		assertValidationError(t, err, sendlix.CodeMissingAuth, "auth")
+	})
+}
+
+func TestNewClients(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Single connection", func(t *testing.T) {
+		server := newFakeServer(t)
+		clients, err := sendlix.NewClients(&MockAuth{Token: "test"}, server.config())
+		require.NoError(t, err)
+
+		_, err = clients.Email().SendEmail(ctx, sendlixtest.ValidMailOptions(), nil)
+		require.NoError(t, err)
+		_, err = clients.Groups().CheckEmailInGroup(ctx, "group-1", "a@example.com")
+		require.NoError(t, err)
+
+		assert.Same(t, clients.Email().GetConnection(), clients.Groups().GetConnection())
+		assert.Equal(t, 1, server.connections())
+
+		require.NoError(t, clients.Close())
+		assert.Equal(t, connectivity.Shutdown, clients.Groups().GetConnection().GetState())
+	})
+
+	t.Run("Email settings are validated", func(t *testing.T) {
+		config := sendlix.DefaultClientConfig()
+		config.SpoolOnUnavailable = &sendlix.SpoolConfig{}
+
+		_, err := sendlix.NewClients(&MockAuth{Token: "test"}, config)
+		assertValidationError(t, err, sendlix.CodeMissingDeadLetter, "SpoolOnUnavailable.DeadLetter")
+	})
+
+	t.Run("Missing auth", func(t *testing.T) {
+		_, err := sendlix.NewClients(nil, nil)
+		assertValidationError(t, err, sendlix.CodeMissingAuth, "auth")
+	})
+}
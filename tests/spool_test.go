@@ -0,0 +1,197 @@
+package sendlix_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/sendlix/go-sdk/sendlixtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// spoolLog records the spool callbacks and the subjects the server
+// delivered, in order.
+type spoolLog struct {
+	mu        sync.Mutex
+	spooled   []string
+	flushed   []string
+	dead      map[string]error
+	delivered []string
+}
+
+func (l *spoolLog) config(maxMessages int, maxAge time.Duration) *sendlix.SpoolConfig {
+	return &sendlix.SpoolConfig{
+		MaxMessages:   maxMessages,
+		MaxAge:        maxAge,
+		RetryInterval: 10 * time.Millisecond,
+		OnSpooled: func(email sendlix.SpooledEmail) {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			l.spooled = append(l.spooled, email.Options.Subject)
+		},
+		OnFlushed: func(email sendlix.SpooledEmail, messageIDs []string) {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			l.flushed = append(l.flushed, email.Options.Subject)
+		},
+		DeadLetter: func(email sendlix.SpooledEmail, err error) {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			l.dead[email.Options.Subject] = err
+		},
+	}
+}
+
+func (l *spoolLog) snapshot() (spooled, flushed, delivered []string, dead map[string]error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	dead = make(map[string]error, len(l.dead))
+	for subject, err := range l.dead {
+		dead[subject] = err
+	}
+	return append([]string(nil), l.spooled...), append([]string(nil), l.flushed...), append([]string(nil), l.delivered...), dead
+}
+
+func TestSpoolOnUnavailable(t *testing.T) {
+	ctx := context.Background()
+
+	// setup returns a client whose server fails sends with UNAVAILABLE while
+	// outage is set, and rejects subjects starting with "bad".
+	setup := func(t *testing.T, maxMessages int, maxAge time.Duration) (*sendlix.EmailClient, *spoolLog, *atomic.Bool) {
+		server := newFakeServer(t)
+		log := &spoolLog{dead: make(map[string]error)}
+		outage := &atomic.Bool{}
+		server.update(func(h *fakeHandlers) {
+			h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+				if outage.Load() {
+					return nil, status.Error(codes.Unavailable, "load balancer restarting")
+				}
+				if req.Subject == "bad" {
+					return nil, status.Error(codes.InvalidArgument, "rejected")
+				}
+				log.mu.Lock()
+				defer log.mu.Unlock()
+				log.delivered = append(log.delivered, req.Subject)
+				return &pb.SendEmailResponse{Message: []string{"msg-" + req.Subject}, EmailsLeft: 100}, nil
+			}
+		})
+
+		config := server.config()
+		config.SpoolOnUnavailable = log.config(maxMessages, maxAge)
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+		require.NoError(t, err)
+		t.Cleanup(func() { client.Close() })
+		return client, log, outage
+	}
+	send := func(client *sendlix.EmailClient, subject string) ([]string, error) {
+		return client.SendEmail(ctx, sendlixtest.MailOptionsWith(func(o *sendlix.MailOptions) { o.Subject = subject }), nil)
+	}
+
+	t.Run("Flush in order after outage", func(t *testing.T) {
+		client, log, outage := setup(t, 0, time.Minute)
+		outage.Store(true)
+		for _, subject := range []string{"first", "second", "third"} {
+			_, err := send(client, subject)
+			assert.ErrorIs(t, err, sendlix.ErrSpooled)
+			assert.Equal(t, sendlix.CodeSpooled, sendlix.Code(err))
+		}
+		spooled, _, delivered, _ := log.snapshot()
+		assert.Equal(t, []string{"first", "second", "third"}, spooled)
+		assert.Empty(t, delivered)
+
+		outage.Store(false)
+		assert.Eventually(t, func() bool {
+			_, flushed, _, _ := log.snapshot()
+			return len(flushed) == 3
+		}, 5*time.Second, 10*time.Millisecond)
+		_, flushed, delivered, dead := log.snapshot()
+		assert.Equal(t, []string{"first", "second", "third"}, flushed)
+		assert.Equal(t, []string{"first", "second", "third"}, delivered)
+		assert.Empty(t, dead)
+
+		messageIDs, err := send(client, "fourth")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"msg-fourth"}, messageIDs)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		client, log, outage := setup(t, 0, 50*time.Millisecond)
+		outage.Store(true)
+		_, err := send(client, "stale")
+		require.ErrorIs(t, err, sendlix.ErrSpooled)
+
+		assert.Eventually(t, func() bool {
+			_, _, _, dead := log.snapshot()
+			return dead["stale"] != nil
+		}, 5*time.Second, 10*time.Millisecond)
+		_, _, _, dead := log.snapshot()
+		assert.ErrorIs(t, dead["stale"], sendlix.ErrSpoolDropped)
+		assert.Equal(t, codes.Unavailable, status.Code(dead["stale"]), "the last delivery error is included")
+
+		outage.Store(false)
+		_, err = send(client, "fresh")
+		assert.NoError(t, err, "sends are attempted again once the spool is empty")
+	})
+
+	t.Run("Full", func(t *testing.T) {
+		client, log, outage := setup(t, 2, time.Minute)
+		outage.Store(true)
+		for _, subject := range []string{"first", "second"} {
+			_, err := send(client, subject)
+			require.ErrorIs(t, err, sendlix.ErrSpooled)
+		}
+
+		_, err := send(client, "third")
+		assert.ErrorIs(t, err, sendlix.ErrSpoolDropped)
+		assert.NotErrorIs(t, err, sendlix.ErrSpooled)
+		assert.Equal(t, sendlix.CodeSpoolDropped, sendlix.Code(err))
+		_, _, _, dead := log.snapshot()
+		assert.ErrorIs(t, dead["third"], sendlix.ErrSpoolDropped)
+		assert.Len(t, dead, 1)
+	})
+
+	t.Run("Rejected on flush", func(t *testing.T) {
+		client, log, outage := setup(t, 0, time.Minute)
+		outage.Store(true)
+		for _, subject := range []string{"bad", "good"} {
+			_, err := send(client, subject)
+			require.ErrorIs(t, err, sendlix.ErrSpooled)
+		}
+
+		outage.Store(false)
+		assert.Eventually(t, func() bool {
+			_, flushed, _, _ := log.snapshot()
+			return len(flushed) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+		_, flushed, _, dead := log.snapshot()
+		assert.Equal(t, []string{"good"}, flushed)
+		assert.Equal(t, codes.InvalidArgument, status.Code(dead["bad"]))
+		assert.NotErrorIs(t, dead["bad"], sendlix.ErrSpoolDropped)
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		client, log, outage := setup(t, 0, time.Minute)
+		outage.Store(true)
+		_, err := send(client, "pending")
+		require.ErrorIs(t, err, sendlix.ErrSpooled)
+
+		require.NoError(t, client.Close())
+		_, _, _, dead := log.snapshot()
+		assert.ErrorIs(t, dead["pending"], sendlix.ErrSpoolDropped)
+		assert.Contains(t, dead["pending"].Error(), "client closed")
+	})
+
+	t.Run("Missing dead letter", func(t *testing.T) {
+		config := newFakeServer(t).config()
+		config.SpoolOnUnavailable = &sendlix.SpoolConfig{}
+		_, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+		assertValidationError(t, err, sendlix.CodeMissingDeadLetter, "SpoolOnUnavailable.DeadLetter")
+	})
+}
@@ -0,0 +1,178 @@
+package sendlix_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/sendlix/go-sdk/sendlixtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatusErrors(t *testing.T) {
+	server := newFakeServer(t)
+	emailClient, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, server.config())
+	require.NoError(t, err)
+	defer emailClient.Close()
+	groupClient, err := sendlix.NewGroupClient(&MockAuth{Token: "test"}, server.config())
+	require.NoError(t, err)
+	defer groupClient.Close()
+
+	// A successful send records the quota reported with QuotaExceededError
+	server.update(func(h *fakeHandlers) {
+		h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+			return &pb.SendEmailResponse{Message: []string{"msg-1"}, EmailsLeft: 2}, nil
+		}
+	})
+	_, err = emailClient.SendEmail(context.Background(), sendlixtest.ValidMailOptions(), nil)
+	require.NoError(t, err)
+
+	failSends := func(err error) {
+		server.update(func(h *fakeHandlers) {
+			h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+				return nil, err
+			}
+			h.insertEmailToGroup = func(ctx context.Context, req *pb.InsertEmailToGroupRequest) (*pb.UpdateResponse, error) {
+				return nil, err
+			}
+		})
+	}
+	send := func() error {
+		_, err := emailClient.SendEmail(context.Background(), sendlixtest.ValidMailOptions(), nil)
+		return err
+	}
+
+	t.Run("Resource exhausted", func(t *testing.T) {
+		failSends(retryInfoError(t, 30*time.Second))
+
+		err := send()
+
+		var quotaErr *sendlix.QuotaExceededError
+		require.ErrorAs(t, err, &quotaErr)
+		assert.Equal(t, int64(2), quotaErr.EmailsLeft)
+		assert.Equal(t, 30*time.Second, quotaErr.RetryAfter)
+		assert.ErrorIs(t, err, sendlix.ErrQuotaExceeded)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Equal(t, sendlix.CodeQuotaExceeded, sendlix.Code(err))
+	})
+
+	t.Run("Quota failure details", func(t *testing.T) {
+		s, err := status.New(codes.ResourceExhausted, "daily limit reached").WithDetails(&errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{{Subject: "account", Description: "daily email limit"}},
+		})
+		require.NoError(t, err)
+		failSends(s.Err())
+
+		err = send()
+
+		var quotaErr *sendlix.QuotaExceededError
+		require.ErrorAs(t, err, &quotaErr)
+		assert.Zero(t, quotaErr.RetryAfter)
+		assert.Equal(t, sendlix.CodeQuotaExceeded, sendlix.Code(err))
+	})
+
+	t.Run("Resource exhausted without quota details", func(t *testing.T) {
+		failSends(status.Error(codes.ResourceExhausted, "too many concurrent streams"))
+
+		err := send()
+
+		var quotaErr *sendlix.QuotaExceededError
+		assert.False(t, errors.As(err, &quotaErr))
+		assert.NotErrorIs(t, err, sendlix.ErrQuotaExceeded)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Equal(t, sendlix.CodeResourceExhausted, sendlix.Code(err))
+	})
+
+	t.Run("Message size limits", func(t *testing.T) {
+		failSends(status.Error(codes.ResourceExhausted, "grpc: received message larger than max (6291456 vs. 4194304)"))
+
+		err := send()
+
+		var quotaErr *sendlix.QuotaExceededError
+		assert.False(t, errors.As(err, &quotaErr))
+		assert.ErrorIs(t, err, sendlix.ErrMessageTooLarge)
+		assert.Contains(t, err.Error(), "message is 6291456 bytes, exceeding the size limit of 4194304")
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Equal(t, sendlix.CodeMessageTooLarge, sendlix.Code(err))
+
+		failSends(status.Error(codes.ResourceExhausted, "grpc: received message after decompression larger than max 4194304"))
+		err = send()
+		assert.ErrorIs(t, err, sendlix.ErrMessageTooLarge)
+		assert.Contains(t, err.Error(), "message is more than 4194304 bytes")
+	})
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		failSends(status.Error(codes.Unauthenticated, "key revoked"))
+
+		err := send()
+
+		var authErr *sendlix.AuthenticationError
+		require.ErrorAs(t, err, &authErr)
+		assert.ErrorIs(t, err, sendlix.ErrUnauthenticated)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		assert.Equal(t, sendlix.CodeUnauthorized, sendlix.Code(err))
+	})
+
+	t.Run("Invalid argument with field violation", func(t *testing.T) {
+		s, err := status.New(codes.InvalidArgument, "bad request").WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "subject", Description: "contains control characters"}},
+		})
+		require.NoError(t, err)
+		failSends(s.Err())
+
+		err = send()
+
+		var validationErr *sendlix.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "subject", validationErr.Field)
+		assert.ErrorIs(t, err, sendlix.ErrInvalidArgument)
+		assert.Contains(t, err.Error(), "subject: contains control characters")
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, sendlix.CodeInvalidArgument, sendlix.Code(err))
+	})
+
+	t.Run("Invalid argument without details", func(t *testing.T) {
+		failSends(status.Error(codes.InvalidArgument, "sender domain not verified"))
+
+		err := send()
+
+		var validationErr *sendlix.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Empty(t, validationErr.Field)
+		assert.Contains(t, err.Error(), "sender domain not verified")
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Group not found", func(t *testing.T) {
+		failSends(status.Error(codes.NotFound, "no such group"))
+
+		_, err := groupClient.InsertEmailToGroup(context.Background(), "newsletter", sendlix.GroupEntry{Email: "user@example.com"})
+
+		var notFound *sendlix.NotFoundError
+		require.ErrorAs(t, err, &notFound)
+		assert.Equal(t, "group", notFound.Resource)
+		assert.Equal(t, "newsletter", notFound.ID)
+		assert.ErrorIs(t, err, sendlix.ErrNotFound)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, sendlix.CodeNotFound, sendlix.Code(err))
+	})
+
+	t.Run("Other codes are unchanged", func(t *testing.T) {
+		failSends(status.Error(codes.Internal, "boom"))
+
+		err := send()
+
+		var quotaErr *sendlix.QuotaExceededError
+		var validationErr *sendlix.ValidationError
+		assert.False(t, errors.As(err, &quotaErr))
+		assert.False(t, errors.As(err, &validationErr))
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
@@ -0,0 +1,136 @@
+package sendlix_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubstitutionLimits(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+	config := server.config()
+	config.SubstitutionLimits = &sendlix.SubstitutionLimits{MaxKeys: 2, MaxKeyLength: 10, MaxValueLength: 5}
+	client, err := sendlix.NewGroupClient(&MockAuth{Token: "test"}, config)
+	require.NoError(t, err)
+	defer client.Close()
+
+	tests := []struct {
+		name          string
+		substitutions map[string]string
+		code          sendlix.ErrorCode
+		message       string
+	}{
+		{
+			name:          "Too many keys",
+			substitutions: map[string]string{"a": "1", "b": "2", "c": "3"},
+			code:          sendlix.CodeTooManySubstitutions,
+			message:       "has 3 substitutions, exceeding the limit of 2",
+		},
+		{
+			name:          "Invalid key",
+			substitutions: map[string]string{"first name": "Ada"},
+			code:          sendlix.CodeInvalidSubstitutionKey,
+			message:       `substitution key "first name" in`,
+		},
+		{
+			name:          "Key too long",
+			substitutions: map[string]string{"subscription": "pro"},
+			code:          sendlix.CodeSubstitutionKeyTooLong,
+			message:       `substitution key "subscription" in`,
+		},
+		{
+			name:          "Value too long",
+			substitutions: map[string]string{"plan": "enterprise"},
+			code:          sendlix.CodeSubstitutionValueTooLong,
+			message:       `value of substitution "plan" in`,
+		},
+		{
+			name:          "Multibyte value at limit",
+			substitutions: map[string]string{"city": "Düren"},
+		},
+		{
+			name:          "Valid",
+			substitutions: map[string]string{"first_name": "Ada", "plan.tier": "pro"},
+		},
+	}
+
+	callSites := []struct {
+		name  string
+		field string
+		call  func(entry sendlix.GroupEntry) error
+	}{
+		{
+			name:  "InsertEmailsToGroup",
+			field: "entries[1].Substitutions",
+			call: func(entry sendlix.GroupEntry) error {
+				_, err := client.InsertEmailsToGroup(ctx, "group", []sendlix.GroupEntry{{Email: "first@example.com"}, entry}, nil)
+				return err
+			},
+		},
+		{
+			name:  "InsertEmailToGroup",
+			field: "entries[0].Substitutions",
+			call: func(entry sendlix.GroupEntry) error {
+				_, err := client.InsertEmailToGroup(ctx, "group", entry)
+				return err
+			},
+		},
+		{
+			name:  "InsertEmailToGroups",
+			field: "entry.Substitutions",
+			call: func(entry sendlix.GroupEntry) error {
+				_, err := client.InsertEmailToGroups(ctx, []string{"a", "b"}, entry, true)
+				return err
+			},
+		},
+		{
+			name:  "InsertStream",
+			field: "entries[1].Substitutions",
+			call: func(entry sendlix.GroupEntry) error {
+				stream, err := client.OpenInsertStream(ctx, "group", nil)
+				require.NoError(t, err)
+				require.NoError(t, stream.Add(sendlix.GroupEntry{Email: "first@example.com"}))
+				if err := stream.Add(entry); err != nil {
+					return err
+				}
+				_, err = stream.CloseAndRecv()
+				return err
+			},
+		},
+	}
+
+	for _, site := range callSites {
+		for _, tt := range tests {
+			t.Run(site.name+"/"+tt.name, func(t *testing.T) {
+				before := server.count("InsertEmailToGroup")
+				err := site.call(sendlix.GroupEntry{Email: "user@example.com", Substitutions: tt.substitutions})
+				if tt.code == "" {
+					assert.NoError(t, err)
+					return
+				}
+
+				assertValidationError(t, err, tt.code, site.field)
+				assert.Contains(t, err.Error(), tt.message)
+				assert.True(t, strings.Contains(err.Error(), site.field), err.Error())
+				assert.Equal(t, before, server.count("InsertEmailToGroup"), "no request is sent")
+			})
+		}
+	}
+
+	t.Run("Unset", func(t *testing.T) {
+		unchecked, err := sendlix.NewGroupClient(&MockAuth{Token: "test"}, server.config())
+		require.NoError(t, err)
+		defer unchecked.Close()
+
+		_, err = unchecked.InsertEmailToGroup(ctx, "group", sendlix.GroupEntry{
+			Email:         "user@example.com",
+			Substitutions: map[string]string{"first name": strings.Repeat("x", 10000)},
+		})
+		assert.NoError(t, err)
+	})
+}
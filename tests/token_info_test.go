@@ -0,0 +1,91 @@
+package sendlix_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeJWT returns an unsigned JWT carrying claims.
+func fakeJWT(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	encode := base64.RawURLEncoding.EncodeToString
+	return encode([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + encode(payload) + ".c2lnbmF0dXJl"
+}
+
+func TestAuthTokenInfo(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+	expires := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	newAuth := func(t *testing.T, token string) *sendlix.Auth {
+		server.update(func(h *fakeHandlers) {
+			h.getJwtToken = func(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error) {
+				return &pb.AuthResponse{Token: token, Expires: timestamppb.New(expires)}, nil
+			}
+		})
+		auth, err := sendlix.NewAuth("secret.1", sendlix.WithAuthConnection(server.dial(t)))
+		require.NoError(t, err)
+		return auth
+	}
+
+	t.Run("Claims", func(t *testing.T) {
+		exp := expires.Add(-time.Minute)
+		auth := newAuth(t, fakeJWT(t, map[string]any{
+			"account_id": 4711,
+			"scopes":     []string{"email.send", "group.write"},
+			"exp":        exp.Unix(),
+			"region":     "eu",
+		}))
+		calls := server.count("GetJwtToken")
+
+		info, err := auth.TokenInfo(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "4711", info.AccountID)
+		assert.Equal(t, []string{"email.send", "group.write"}, info.Scopes)
+		assert.True(t, info.HasScope("group.write"))
+		assert.False(t, info.HasScope("group.read"))
+		assert.True(t, exp.Equal(info.ExpiresAt), "got %s", info.ExpiresAt)
+		assert.Equal(t, "eu", info.Claims["region"])
+
+		_, err = auth.TokenInfo(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, calls+1, server.count("GetJwtToken"), "the cached token is used")
+	})
+
+	t.Run("Alternative claim names", func(t *testing.T) {
+		auth := newAuth(t, fakeJWT(t, map[string]any{"accountId": "acc-1", "scope": "email.send  group.read"}))
+
+		info, err := auth.TokenInfo(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "acc-1", info.AccountID)
+		assert.Equal(t, []string{"email.send", "group.read"}, info.Scopes)
+		assert.True(t, expires.Equal(info.ExpiresAt), "expiry falls back to the exchange response")
+	})
+
+	t.Run("Malformed tokens", func(t *testing.T) {
+		encode := base64.RawURLEncoding.EncodeToString
+		for _, token := range []string{
+			"opaque-token",
+			"a.!!!.c",
+			"a." + encode([]byte("not json")) + ".c",
+			"a." + encode([]byte("[1]")) + ".c",
+			"a." + encode([]byte("null")) + ".c",
+		} {
+			auth := newAuth(t, token)
+			_, err := auth.TokenInfo(ctx)
+			assert.ErrorIs(t, err, sendlix.ErrMalformedToken, token)
+			assert.Equal(t, sendlix.CodeMalformedToken, sendlix.Code(err))
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package sendlix_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingTokenStore is a TokenStore counting its calls.
+type recordingTokenStore struct {
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+	gets      int
+	sets      int
+}
+
+func (s *recordingTokenStore) Get(ctx context.Context) (string, time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gets++
+	return s.token, s.expiresAt, s.token != ""
+}
+
+func (s *recordingTokenStore) Set(ctx context.Context, token string, expiresAt time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sets++
+	s.token = token
+	s.expiresAt = expiresAt
+}
+
+func TestTokenStore(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+
+	newAuth := func(t *testing.T, store sendlix.TokenStore) *sendlix.Auth {
+		auth, err := sendlix.NewAuth("secret.123", sendlix.WithAuthConnection(server.dial(t)), sendlix.WithTokenStore(store))
+		require.NoError(t, err)
+		return auth
+	}
+
+	t.Run("Empty store is populated", func(t *testing.T) {
+		store := &recordingTokenStore{}
+		auth := newAuth(t, store)
+		before := server.count("GetJwtToken")
+
+		_, value, err := auth.GetAuthHeader(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer fake-token", value)
+		assert.Equal(t, before+1, server.count("GetJwtToken"))
+		assert.Equal(t, 1, store.gets)
+		assert.Equal(t, 1, store.sets)
+		assert.Equal(t, "fake-token", store.token)
+		assert.True(t, store.expiresAt.After(time.Now()))
+	})
+
+	t.Run("Stored token is used", func(t *testing.T) {
+		store := &recordingTokenStore{token: "stored-token", expiresAt: time.Now().Add(time.Hour)}
+		auth := newAuth(t, store)
+		before := server.count("GetJwtToken")
+
+		for i := 0; i < 3; i++ {
+			_, value, err := auth.GetAuthHeader(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, "Bearer stored-token", value)
+		}
+		assert.Equal(t, before, server.count("GetJwtToken"))
+		assert.Equal(t, 1, store.gets, "store is consulted only without a fresh in-process token")
+		assert.Equal(t, 0, store.sets)
+
+		stats := auth.Stats()
+		assert.Equal(t, uint64(1), stats.StoreHits)
+		assert.Equal(t, uint64(0), stats.Refreshes)
+		assert.Equal(t, uint64(2), stats.CacheHits)
+	})
+
+	t.Run("Expired stored token is replaced", func(t *testing.T) {
+		store := &recordingTokenStore{token: "expired-token", expiresAt: time.Now().Add(-time.Minute)}
+		auth := newAuth(t, store)
+		before := server.count("GetJwtToken")
+
+		_, value, err := auth.GetAuthHeader(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer fake-token", value)
+		assert.Equal(t, before+1, server.count("GetJwtToken"))
+		assert.Equal(t, "fake-token", store.token)
+		assert.Equal(t, uint64(0), auth.Stats().StoreHits)
+	})
+
+	t.Run("Stored token within refresh margin is replaced", func(t *testing.T) {
+		store := &recordingTokenStore{token: "expiring-token", expiresAt: time.Now().Add(10 * time.Second)}
+		auth := newAuth(t, store)
+
+		_, value, err := auth.GetAuthHeader(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer fake-token", value)
+		assert.Equal(t, 1, store.sets)
+	})
+
+	t.Run("Memory store shared between instances", func(t *testing.T) {
+		store := sendlix.NewMemoryTokenStore()
+		first := newAuth(t, store)
+		second := newAuth(t, store)
+		before := server.count("GetJwtToken")
+
+		_, _, err := first.GetAuthHeader(ctx)
+		require.NoError(t, err)
+		_, value, err := second.GetAuthHeader(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer fake-token", value)
+		assert.Equal(t, before+1, server.count("GetJwtToken"))
+		assert.Equal(t, uint64(1), second.Stats().StoreHits)
+	})
+}
@@ -0,0 +1,90 @@
+package sendlix_test
+
+import (
+	"context"
+	"testing"
+
+	sendlix "github.com/sendlix/go-sdk"
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"github.com/sendlix/go-sdk/sendlixtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// spanAttributes returns the attributes of a span keyed by name.
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestTracing(t *testing.T) {
+	server := newFakeServer(t)
+	server.update(func(h *fakeHandlers) {
+		h.sendEmail = func(ctx context.Context, req *pb.SendMailRequest) (*pb.SendEmailResponse, error) {
+			if req.Subject == "fail" {
+				return nil, status.Error(codes.Internal, "boom")
+			}
+			return &pb.SendEmailResponse{Message: []string{"msg-1"}, EmailsLeft: 42}, nil
+		}
+	})
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	config := server.config()
+	config.TracerProvider = provider
+	client, err := sendlix.NewEmailClient(&MockAuth{Token: "test"}, config)
+	require.NoError(t, err)
+	defer client.Close()
+
+	t.Run("Send span nests under the caller's span", func(t *testing.T) {
+		ctx, parent := provider.Tracer("test").Start(context.Background(), "handle request")
+		_, err := client.SendEmail(ctx, sendlixtest.MailOptionsWith(func(o *sendlix.MailOptions) {
+			o.CC = []sendlix.EmailAddress{{Email: "cc@example.com"}}
+		}), &sendlix.AdditionalOptions{Category: "welcome"})
+		parent.End()
+		require.NoError(t, err)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+		span := spans[0]
+		assert.Equal(t, "sendlix.api.v1.Email/SendEmail", span.Name())
+		assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+		assert.Equal(t, parent.SpanContext().TraceID(), span.SpanContext().TraceID())
+
+		attrs := spanAttributes(span)
+		assert.Equal(t, "grpc", attrs["rpc.system"].AsString())
+		assert.Equal(t, "SendEmail", attrs["rpc.method"].AsString())
+		assert.Equal(t, int64(2), attrs["sendlix.recipient_count"].AsInt64())
+		assert.Equal(t, "welcome", attrs["sendlix.category"].AsString())
+		assert.Equal(t, int64(42), attrs["sendlix.emails_left"].AsInt64())
+		assert.Equal(t, int64(codes.OK), attrs["rpc.grpc.status_code"].AsInt64())
+		assert.Equal(t, otelcodes.Unset, span.Status().Code)
+	})
+
+	t.Run("Failed send records the status", func(t *testing.T) {
+		before := len(recorder.Ended())
+		_, err := client.SendEmail(context.Background(), sendlixtest.MailOptionsWith(func(o *sendlix.MailOptions) { o.Subject = "fail" }), nil)
+		require.Error(t, err)
+
+		spans := recorder.Ended()[before:]
+		require.Len(t, spans, 1)
+		span := spans[0]
+		assert.Equal(t, otelcodes.Error, span.Status().Code)
+		assert.Equal(t, "boom", span.Status().Description)
+		assert.Equal(t, int64(codes.Internal), spanAttributes(span)["rpc.grpc.status_code"].AsInt64())
+		assert.NotContains(t, spanAttributes(span), attribute.Key("sendlix.emails_left"))
+		require.Len(t, span.Events(), 1)
+		assert.Equal(t, "exception", span.Events()[0].Name)
+	})
+}
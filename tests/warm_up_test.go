@@ -0,0 +1,107 @@
+package sendlix_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarmUp(t *testing.T) {
+	server := newFakeServer(t)
+
+	t.Run("Connection becomes ready", func(t *testing.T) {
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test-token"}, server.config())
+		require.NoError(t, err)
+		defer client.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		require.NoError(t, client.WarmUp(ctx))
+		assert.Equal(t, "READY", client.GetConnection().GetState().String())
+	})
+
+	t.Run("Auth errors are returned", func(t *testing.T) {
+		client, err := sendlix.NewEmailClient(&MockAuth{Error: errors.New("invalid key")}, server.config())
+		require.NoError(t, err)
+		defer client.Close()
+
+		err = client.WarmUp(context.Background())
+		assert.EqualError(t, err, "failed to get auth header: invalid key")
+	})
+}
+
+func TestWarmAll(t *testing.T) {
+	server := newFakeServer(t)
+
+	t.Run("One failing of three", func(t *testing.T) {
+		emailClient, err := sendlix.NewEmailClient(&MockAuth{Token: "test-token"}, server.config())
+		require.NoError(t, err)
+		defer emailClient.Close()
+
+		groupClient, err := sendlix.NewGroupClient(&MockAuth{Token: "test-token"}, server.config())
+		require.NoError(t, err)
+		defer groupClient.Close()
+
+		authErr := errors.New("invalid key")
+		failing, err := sendlix.NewGroupClient(&MockAuth{Error: authErr}, server.config())
+		require.NoError(t, err)
+		defer failing.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		err = sendlix.WarmAll(ctx, emailClient, groupClient, failing)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, authErr)
+		assert.Equal(t, "warm-up of client 2 (*sendlix.GroupClient) failed: failed to get auth header: invalid key", err.Error())
+	})
+
+	t.Run("Deadline expires mid-warm", func(t *testing.T) {
+		// A listener that accepts connections but never completes the TLS handshake
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer lis.Close()
+		go func() {
+			for {
+				conn, err := lis.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+			}
+		}()
+
+		healthy, err := sendlix.NewEmailClient(&MockAuth{Token: "test-token"}, server.config())
+		require.NoError(t, err)
+		defer healthy.Close()
+
+		config := server.config()
+		config.ServerAddress = lis.Addr().String()
+		hanging, err := sendlix.NewEmailClient(&MockAuth{Token: "test-token"}, config)
+		require.NoError(t, err)
+		defer hanging.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		err = sendlix.WarmAll(ctx, healthy, hanging)
+
+		assert.Less(t, time.Since(start), 2*time.Second)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "warm-up of client 1 (*sendlix.EmailClient) failed")
+		assert.NotContains(t, err.Error(), "client 0")
+	})
+
+	t.Run("No clients", func(t *testing.T) {
+		assert.NoError(t, sendlix.WarmAll(context.Background()))
+	})
+}
@@ -0,0 +1,289 @@
+package sendlix_test
+
+import (
+	"context"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	sendlix "github.com/sendlix/go-sdk"
+	"github.com/sendlix/go-sdk/sendlixtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarnings(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+
+	options := sendlix.MailOptions{
+		From:    sendlix.EmailAddress{Email: "shop@gmail.com"},
+		To:      []sendlix.EmailAddress{{Email: "recipient@example.com"}},
+		Subject: "Hello {{first_nme}}",
+		Html:    `<style>a:hover { color: red }</style><p>Hello</p>`,
+	}
+
+	newClient := func(t *testing.T, strict ...sendlix.ErrorCode) *sendlix.EmailClient {
+		config := server.config()
+		config.SenderCheck = &sendlix.SenderCheckConfig{}
+		config.PlaceholderCheck = &sendlix.PlaceholderCheckConfig{Keys: []string{"first_name"}}
+		config.InlineCSS = &sendlix.InlineCSSOptions{}
+		config.StrictWarnings = strict
+		client, err := sendlix.NewEmailClient(&MockAuth{Token: "test-token"}, config)
+		require.NoError(t, err)
+		t.Cleanup(func() { client.Close() })
+		return client
+	}
+
+	t.Run("Warnings are collected", func(t *testing.T) {
+		client := newClient(t)
+		before := server.count("SendEmail")
+
+		var warnings []sendlix.Warning
+		_, err := client.SendEmail(sendlix.WithWarnings(ctx, &warnings), options, nil)
+		require.NoError(t, err)
+		assert.Equal(t, before+1, server.count("SendEmail"))
+
+		require.Len(t, warnings, 4)
+		assert.Equal(t, sendlix.Warning{
+			Code:    sendlix.WarningFreemailFrom,
+			Field:   "From",
+			Message: `sender domain "gmail.com" is a freemail provider`,
+		}, warnings[0])
+		assert.Equal(t, sendlix.Warning{
+			Code:    sendlix.WarningUnknownPlaceholder,
+			Field:   "Content",
+			Message: "placeholder {{first_nme}} has no substitution key",
+		}, warnings[1])
+		assert.Equal(t, sendlix.WarningUnusedSubstitution, warnings[2].Code)
+		assert.Equal(t, sendlix.WarningCSSRuleNotInlined, warnings[3].Code)
+		assert.Equal(t, "Html", warnings[3].Field)
+	})
+
+	t.Run("Group sends", func(t *testing.T) {
+		client := newClient(t)
+
+		var warnings []sendlix.Warning
+		err := client.SendGroupEmail(sendlix.WithWarnings(ctx, &warnings), sendlix.GroupMailData{
+			GroupID: "group-1",
+			From:    options.From,
+			Subject: "Hello {{first_name}}",
+			Content: sendlix.MailContent{HTML: options.Html},
+		})
+		require.NoError(t, err)
+		require.Len(t, warnings, 2)
+		assert.Equal(t, sendlix.WarningFreemailFrom, warnings[0].Code)
+		assert.Equal(t, "Content.HTML", warnings[1].Field)
+	})
+
+	t.Run("Without collector", func(t *testing.T) {
+		_, err := newClient(t).SendEmail(ctx, options, nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Concurrent calls share a collector", func(t *testing.T) {
+		client := newClient(t)
+
+		var warnings []sendlix.Warning
+		warnCtx := sendlix.WithWarnings(ctx, &warnings)
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := client.SendEmail(warnCtx, options, nil)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+		assert.Len(t, warnings, 40)
+	})
+
+	t.Run("Strict warnings reject the call", func(t *testing.T) {
+		client := newClient(t, sendlix.WarningUnknownPlaceholder)
+		before := server.count("SendEmail")
+
+		var warnings []sendlix.Warning
+		_, err := client.SendEmail(sendlix.WithWarnings(ctx, &warnings), options, nil)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, sendlix.ErrStrictWarning))
+		assert.Equal(t, "sendlix.warning.unknown_placeholder: placeholder {{first_nme}} has no substitution key", err.Error())
+		assert.Equal(t, sendlix.CodeStrictWarning, sendlix.Code(err))
+
+		var validationErr *sendlix.ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, "Content", validationErr.Field)
+
+		assert.Equal(t, before, server.count("SendEmail"))
+		require.Len(t, warnings, 1, "warnings before the strict one are still collected")
+		assert.Equal(t, sendlix.WarningFreemailFrom, warnings[0].Code)
+	})
+
+	t.Run("Strict subset only", func(t *testing.T) {
+		client := newClient(t, sendlix.WarningReplyToMismatch)
+		_, err := client.SendEmail(ctx, options, nil)
+		assert.NoError(t, err)
+	})
+}
+
+func TestStrictWarningCodes(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t)
+
+	// scenario produces a single warning of its code, counting the calls of
+	// the OnWarning callback of the check producing it, if it has one
+	type scenario struct {
+		configure func(config *sendlix.ClientConfig, callbacks *int)
+		run       func(ctx context.Context, client *sendlix.EmailClient) error
+	}
+	send := func(mutators ...func(o *sendlix.MailOptions)) func(context.Context, *sendlix.EmailClient) error {
+		return func(ctx context.Context, client *sendlix.EmailClient) error {
+			_, err := client.SendEmail(ctx, sendlixtest.MailOptionsWith(mutators...), nil)
+			return err
+		}
+	}
+	senderCheck := func(verified ...string) func(*sendlix.ClientConfig, *int) {
+		return func(config *sendlix.ClientConfig, callbacks *int) {
+			config.SenderCheck = &sendlix.SenderCheckConfig{
+				VerifiedDomains: verified,
+				OnWarning:       func([]sendlix.LintIssue) { *callbacks++ },
+			}
+		}
+	}
+	placeholderCheck := func(keys ...string) func(*sendlix.ClientConfig, *int) {
+		return func(config *sendlix.ClientConfig, callbacks *int) {
+			config.PlaceholderCheck = &sendlix.PlaceholderCheckConfig{
+				Keys:      keys,
+				OnWarning: func(sendlix.PlaceholderReport) { *callbacks++ },
+			}
+		}
+	}
+
+	scenarios := map[sendlix.ErrorCode]scenario{
+		sendlix.WarningUnverifiedDomain: {
+			configure: senderCheck("verified.example.org"),
+			run:       send(),
+		},
+		sendlix.WarningReplyToMismatch: {
+			configure: senderCheck(),
+			run: send(func(o *sendlix.MailOptions) {
+				o.ReplyTo = &sendlix.EmailAddress{Email: "support@other.net"}
+			}),
+		},
+		sendlix.WarningFreemailFrom: {
+			configure: senderCheck(),
+			run: send(func(o *sendlix.MailOptions) {
+				o.From.Email = "shop@gmail.com"
+			}),
+		},
+		sendlix.WarningUnknownPlaceholder: {
+			configure: placeholderCheck(),
+			run: send(func(o *sendlix.MailOptions) {
+				o.Subject = "Hello {{first_nme}}"
+			}),
+		},
+		sendlix.WarningUnusedSubstitution: {
+			configure: placeholderCheck("first_name"),
+			run:       send(),
+		},
+		sendlix.WarningCSSRuleNotInlined: {
+			configure: func(config *sendlix.ClientConfig, callbacks *int) {
+				config.InlineCSS = &sendlix.InlineCSSOptions{OnWarning: func(string) { *callbacks++ }}
+			},
+			run: send(func(o *sendlix.MailOptions) {
+				o.Html = `<style>a:hover { color: red }</style><p>Hello</p>`
+			}),
+		},
+		sendlix.WarningLargeDataURI: {
+			run: func(ctx context.Context, client *sendlix.EmailClient) error {
+				_, err := client.EmbedImageDataURI(ctx, `<img src="{{logo}}">`, "{{logo}}", make([]byte, sendlix.DataURIWarningSize), sendlix.MimeTypePNG)
+				return err
+			},
+		},
+		sendlix.WarningQuietHours: {
+			configure: func(config *sendlix.ClientConfig, callbacks *int) {
+				windows := make(map[time.Weekday]sendlix.QuietWindow)
+				for day := time.Sunday; day <= time.Saturday; day++ {
+					windows[day] = sendlix.QuietWindow{Start: 0, End: 24 * time.Hour}
+				}
+				config.QuietHours = &sendlix.QuietHours{Windows: windows, Action: sendlix.QuietHoursWarn}
+			},
+			run: send(),
+		},
+		sendlix.WarningBidiControl: {
+			run: send(func(o *sendlix.MailOptions) {
+				o.From.Name = "Example ‮Sender"
+			}),
+		},
+	}
+
+	t.Run("Every warning code has a scenario", func(t *testing.T) {
+		f, err := parser.ParseFile(token.NewFileSet(), filepath.Join("..", "warnings.go"), nil, 0)
+		require.NoError(t, err)
+
+		var codes []sendlix.ErrorCode
+		for _, decl := range f.Decls {
+			decl, ok := decl.(*ast.GenDecl)
+			if !ok || decl.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				spec := spec.(*ast.ValueSpec)
+				for i, name := range spec.Names {
+					if lit, ok := spec.Values[i].(*ast.BasicLit); ok && strings.HasPrefix(name.Name, "Warning") {
+						codes = append(codes, sendlix.ErrorCode(strings.Trim(lit.Value, `"`)))
+					}
+				}
+			}
+		}
+
+		require.NotEmpty(t, codes)
+		for _, code := range codes {
+			assert.Contains(t, scenarios, code, "no scenario for %s", code)
+		}
+		assert.Len(t, scenarios, len(codes))
+	})
+
+	for code, sc := range scenarios {
+		newClient := func(t *testing.T, callbacks *int, strict ...sendlix.ErrorCode) *sendlix.EmailClient {
+			config := server.config()
+			if sc.configure != nil {
+				sc.configure(config, callbacks)
+			}
+			config.StrictWarnings = strict
+			client, err := sendlix.NewEmailClient(&MockAuth{Token: "test-token"}, config)
+			require.NoError(t, err)
+			t.Cleanup(func() { client.Close() })
+			return client
+		}
+
+		t.Run(string(code), func(t *testing.T) {
+			var callbacks int
+			var warnings []sendlix.Warning
+			err := sc.run(sendlix.WithWarnings(ctx, &warnings), newClient(t, &callbacks))
+			require.NoError(t, err)
+			require.Len(t, warnings, 1)
+			assert.Equal(t, code, warnings[0].Code)
+			lenientCallbacks := callbacks
+
+			callbacks = 0
+			warnings = nil
+			before := server.count("SendEmail")
+			err = sc.run(sendlix.WithWarnings(ctx, &warnings), newClient(t, &callbacks, code))
+
+			var validationErr *sendlix.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.ErrorIs(t, err, sendlix.ErrStrictWarning)
+			assert.Equal(t, string(code), validationErr.Params["warning"])
+			assert.Empty(t, warnings, "strict warnings are not collected")
+			assert.Zero(t, callbacks, "OnWarning is not called for rejected sends (called %d times when lenient)", lenientCallbacks)
+			assert.Equal(t, before, server.count("SendEmail"))
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package sendlix
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrMalformedToken is returned by TokenInfo when the token issued by the
+// authentication service is not a JWT with a JSON payload.
+var ErrMalformedToken = errors.New("token is not a valid JWT")
+
+// TokenInfo holds the claims of the JWT token an Auth authenticates with.
+// The claims are decoded without verifying the token's signature, so they
+// are only suitable for pre-checks and tagging; the server remains the
+// authority on what a token permits.
+type TokenInfo struct {
+	// AccountID is the Sendlix account the API key belongs to, from the
+	// "account_id" or "accountId" claim, or "" if the token has neither
+	AccountID string
+	// Scopes are the permissions granted to the API key, e.g.
+	// "group.write", from the "scopes" array or the space-separated
+	// "scope" claim
+	Scopes []string
+	// ExpiresAt is when the token expires, from the "exp" claim or, if the
+	// token has none, the expiry reported by the authentication service
+	ExpiresAt time.Time
+	// Claims holds all claims of the token payload. Numbers are
+	// json.Number values
+	Claims map[string]any
+}
+
+// HasScope reports whether the token grants a scope.
+//
+// Parameters:
+//   - scope: Scope to look for, e.g. "group.write"
+//
+// Returns:
+//   - bool: true if scope is listed in Scopes
+func (i *TokenInfo) HasScope(scope string) bool {
+	return slices.Contains(i.Scopes, scope)
+}
+
+// TokenInfo returns the claims of the token GetAuthHeader would use,
+// fetching a new token if none is cached.
+//
+// Parameters:
+//   - ctx: Context for the authentication request, if one is needed
+//
+// Returns:
+//   - *TokenInfo: Decoded claims of the token
+//   - error: Any error encountered during token retrieval, or an error
+//     wrapping ErrMalformedToken if the token cannot be decoded
+//
+// Example:
+//
+//	info, err := auth.TokenInfo(ctx)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	if !info.HasScope("group.write") {
+//		log.Fatalf("API key of account %s cannot modify groups", info.AccountID)
+//	}
+func (a *Auth) TokenInfo(ctx context.Context) (*TokenInfo, error) {
+	_, value, err := a.GetAuthHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := parseTokenInfo(strings.TrimPrefix(value, "Bearer "))
+	if err != nil {
+		return nil, err
+	}
+	if info.ExpiresAt.IsZero() {
+		info.ExpiresAt, _ = a.TokenExpiry()
+	}
+	return info, nil
+}
+
+// parseTokenInfo decodes the payload of a JWT without verifying it.
+func parseTokenInfo(token string) (*TokenInfo, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: expected 3 segments, got %d", ErrMalformedToken, len(parts))
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedToken, err)
+	}
+
+	var claims map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(&claims); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedToken, err)
+	}
+	if claims == nil {
+		return nil, fmt.Errorf("%w: payload is not a JSON object", ErrMalformedToken)
+	}
+
+	info := &TokenInfo{Claims: claims}
+	for _, name := range []string{"account_id", "accountId"} {
+		if id := claimString(claims[name]); id != "" {
+			info.AccountID = id
+			break
+		}
+	}
+
+	switch scopes := claims["scopes"].(type) {
+	case []any:
+		for _, scope := range scopes {
+			if s, ok := scope.(string); ok {
+				info.Scopes = append(info.Scopes, s)
+			}
+		}
+	case string:
+		info.Scopes = strings.Fields(scopes)
+	}
+	if info.Scopes == nil {
+		if scope, ok := claims["scope"].(string); ok {
+			info.Scopes = strings.Fields(scope)
+		}
+	}
+
+	if exp, ok := claims["exp"].(json.Number); ok {
+		if seconds, err := exp.Float64(); err == nil {
+			info.ExpiresAt = time.Unix(0, int64(seconds*float64(time.Second)))
+		}
+	}
+
+	return info, nil
+}
+
+// claimString returns a string or number claim as a string, or "".
+func claimString(claim any) string {
+	switch v := claim.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	}
+	return ""
+}
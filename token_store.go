@@ -0,0 +1,115 @@
+package sendlix
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// TokenStore holds a JWT token outside of an Auth instance, so that tokens
+// survive process restarts or are shared between processes, for example
+// through Redis. A store holds the token of a single API key.
+//
+// Auth keeps an in-process copy of its token and consults the store only
+// when that copy is missing or stale, before exchanging the API key for a
+// new token. Tokens obtained from the token exchange are written to the
+// store. Stored tokens that expire within the refresh margin are ignored.
+//
+// Implementations must be safe for concurrent use. Store errors should be
+// logged by the implementation and reported as a miss from Get; Auth then
+// falls back to the token exchange.
+type TokenStore interface {
+	// Get returns the stored token and its expiry. ok is false if no token
+	// is stored or it could not be read.
+	Get(ctx context.Context) (token string, expiresAt time.Time, ok bool)
+
+	// Set stores a token obtained from the token exchange.
+	Set(ctx context.Context, token string, expiresAt time.Time)
+}
+
+// MemoryTokenStore is a TokenStore keeping the token in memory. Auth caches
+// tokens in memory without a store; MemoryTokenStore is useful to share a
+// token between several Auth instances of the same API key in one process.
+type MemoryTokenStore struct {
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewMemoryTokenStore creates an empty in-memory token store.
+//
+// Returns:
+//   - *MemoryTokenStore: Empty store
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{}
+}
+
+// Get implements TokenStore.
+func (s *MemoryTokenStore) Get(ctx context.Context) (string, time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.token, s.expiresAt, s.token != ""
+}
+
+// Set implements TokenStore.
+func (s *MemoryTokenStore) Set(ctx context.Context, token string, expiresAt time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = token
+	s.expiresAt = expiresAt
+}
+
+// WithTokenStore makes Auth read tokens from and write tokens to store. See
+// TokenStore.
+//
+// Parameters:
+//   - store: Store to use. Default: nil (tokens are cached in memory only)
+//
+// Returns:
+//   - AuthOption: Option to pass to NewAuth
+//
+// Example:
+//
+//	auth, err := sendlix.NewAuth(apiKey, sendlix.WithTokenStore(redisTokenStore{client: rdb, key: "sendlix:jwt"}))
+func WithTokenStore(store TokenStore) AuthOption {
+	return func(a *Auth) {
+		a.store = store
+	}
+}
+
+// loadToken returns the stored token if it is usable, or nil.
+func (a *Auth) loadToken(ctx context.Context) *tokenCache {
+	if a.store == nil {
+		return nil
+	}
+
+	token, expiresAt, ok := a.store.Get(ctx)
+	now := a.now()
+	if !ok || token == "" || !now.Add(a.clockSkew+a.refreshMargin).Before(expiresAt) {
+		return nil
+	}
+
+	a.stats.storeHits.Add(1)
+	return &tokenCache{token: token, expiresAt: expiresAt, fetchedAt: now}
+}
+
+// obtainToken returns a usable token from the store, or exchanges the API
+// key for a new token and stores it. bypassStore skips reading the store,
+// as after its token was rejected. exchanged reports whether a token
+// exchange was attempted.
+func (a *Auth) obtainToken(ctx context.Context, bypassStore bool) (token *tokenCache, exchanged bool, err error) {
+	if !bypassStore {
+		if token := a.loadToken(ctx); token != nil {
+			return token, false, nil
+		}
+	}
+
+	token, err = a.fetchToken(ctx)
+	if err != nil {
+		return nil, true, err
+	}
+	if a.store != nil {
+		a.store.Set(ctx, token.token, token.expiresAt)
+	}
+	return token, true, nil
+}
@@ -0,0 +1,80 @@
+package sendlix
+
+import (
+	"context"
+	"strings"
+
+	pb "github.com/sendlix/go-sdk/internal/proto"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// tracerName is the instrumentation name of the spans created by the SDK.
+const tracerName = "github.com/sendlix/go-sdk"
+
+// tracingInterceptor creates a gRPC unary interceptor that records every
+// call as a client span, a child of the span in the call's context. Spans
+// carry the rpc.system, rpc.service, rpc.method, and rpc.grpc.status_code
+// attributes, and for sends sendlix.recipient_count, sendlix.category,
+// sendlix.group_id, and sendlix.emails_left from the response. Failed calls
+// record the error and set the span status to Error.
+//
+// Parameters:
+//   - provider: Provider of the tracer creating the spans
+//
+// Returns:
+//   - grpc.UnaryClientInterceptor: Configured tracing interceptor
+func tracingInterceptor(provider trace.TracerProvider) grpc.UnaryClientInterceptor {
+	tracer := provider.Tracer(tracerName)
+
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		service, name, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+		attrs := append([]attribute.KeyValue{
+			attribute.String("rpc.system", "grpc"),
+			attribute.String("rpc.service", service),
+			attribute.String("rpc.method", name),
+		}, spanAttrs(req)...)
+
+		ctx, span := tracer.Start(ctx, service+"/"+name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+		defer span.End()
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(status.Code(err))))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(otelcodes.Error, status.Convert(err).Message())
+		} else if resp, ok := reply.(*pb.SendEmailResponse); ok {
+			span.SetAttributes(attribute.Int64("sendlix.emails_left", resp.EmailsLeft))
+		}
+		return err
+	}
+}
+
+// spanAttrs returns the span attributes describing a request.
+func spanAttrs(req interface{}) []attribute.KeyValue {
+	switch r := req.(type) {
+	case *pb.SendMailRequest:
+		return []attribute.KeyValue{
+			attribute.Int("sendlix.recipient_count", len(r.To)+len(r.Cc)+len(r.Bcc)),
+			attribute.String("sendlix.category", r.GetAdditionalInfos().GetCategory()),
+		}
+	case *pb.EmlMailRequest:
+		return []attribute.KeyValue{attribute.String("sendlix.category", r.GetAdditionalInfos().GetCategory())}
+	case *pb.GroupMailData:
+		return []attribute.KeyValue{
+			attribute.String("sendlix.group_id", r.GroupId),
+			attribute.String("sendlix.category", r.Category),
+		}
+	case *pb.InsertEmailToGroupRequest:
+		return []attribute.KeyValue{attribute.String("sendlix.group_id", r.GroupId)}
+	case *pb.RemoveEmailFromGroupRequest:
+		return []attribute.KeyValue{attribute.String("sendlix.group_id", r.GroupId)}
+	case *pb.CheckEmailInGroupRequest:
+		return []attribute.KeyValue{attribute.String("sendlix.group_id", r.GroupId)}
+	default:
+		return nil
+	}
+}
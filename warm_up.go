@@ -0,0 +1,84 @@
+package sendlix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Warmer is implemented by clients that can establish their connection and
+// credentials ahead of the first request. All API clients implement it
+// through BaseClient.
+type Warmer interface {
+	WarmUp(ctx context.Context) error
+}
+
+// WarmUp connects to the server and obtains an authentication header, so
+// the first request does not pay for the TLS handshake and token exchange.
+// It returns once the connection is ready, or with an error if the
+// connection fails or ctx is done first.
+//
+// Parameters:
+//   - ctx: Context bounding the warm-up (a deadline is recommended)
+//
+// Returns:
+//   - error: Connection or authentication error
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//	defer cancel()
+//
+//	if err := client.WarmUp(ctx); err != nil {
+//		log.Fatal(err)
+//	}
+func (c *BaseClient) WarmUp(ctx context.Context) error {
+	if err := waitForReady(ctx, c.GetConnection(), c.config.ServerAddress); err != nil {
+		return err
+	}
+
+	if _, _, err := c.auth.GetAuthHeader(ctx); err != nil {
+		return fmt.Errorf("%w: %w", ErrAuthFailed, err)
+	}
+
+	return nil
+}
+
+// WarmAll warms up several clients concurrently, typically at application
+// startup. It waits for all warm-ups to finish and returns the errors of
+// all failed ones joined together, each prefixed with the position and type
+// of its client. Pass a context with a deadline to bound startup time.
+//
+// Parameters:
+//   - ctx: Context bounding all warm-ups
+//   - warmers: Clients to warm up
+//
+// Returns:
+//   - error: Joined warm-up errors, nil if all clients are ready
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//
+//	if err := sendlix.WarmAll(ctx, emailClient, groupClient); err != nil {
+//		log.Fatalf("sendlix not reachable: %v", err)
+//	}
+func WarmAll(ctx context.Context, warmers ...Warmer) error {
+	errs := make([]error, len(warmers))
+
+	var wg sync.WaitGroup
+	for i, w := range warmers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := w.WarmUp(ctx); err != nil {
+				errs[i] = fmt.Errorf("warm-up of client %d (%T) failed: %w", i, w, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,96 @@
+package sendlix
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// Warning codes of advisory checks. Warnings never fail a call unless their
+// code is listed in ClientConfig.StrictWarnings.
+const (
+	WarningUnverifiedDomain   ErrorCode = "sendlix.warning.unverified_domain"
+	WarningReplyToMismatch    ErrorCode = "sendlix.warning.reply_to_mismatch"
+	WarningFreemailFrom       ErrorCode = "sendlix.warning.freemail_from"
+	WarningUnknownPlaceholder ErrorCode = "sendlix.warning.unknown_placeholder"
+	WarningUnusedSubstitution ErrorCode = "sendlix.warning.unused_substitution"
+	WarningCSSRuleNotInlined  ErrorCode = "sendlix.warning.css_rule_not_inlined"
+	WarningLargeDataURI       ErrorCode = "sendlix.warning.large_data_uri"
+	WarningQuietHours         ErrorCode = "sendlix.warning.quiet_hours"
+	WarningBidiControl        ErrorCode = "sendlix.warning.bidi_control"
+)
+
+// Warning is an advisory finding of a check performed by a send method,
+// such as a sender check or placeholder check.
+type Warning struct {
+	// Code identifies the kind of warning, e.g. WarningFreemailFrom
+	Code ErrorCode
+	// Field is the path of the input field concerned, e.g. "From" or
+	// "Content.HTML"
+	Field string
+	// Message describes the finding
+	Message string
+}
+
+// String returns the warning in the form "field: message".
+func (w Warning) String() string {
+	return w.Field + ": " + w.Message
+}
+
+// warningsKey is the context key of the warning collector.
+type warningsKey struct{}
+
+// warningCollector appends warnings to a caller-provided slice.
+type warningCollector struct {
+	mu   sync.Mutex
+	dest *[]Warning
+}
+
+// WithWarnings returns a context that collects the warnings of calls made
+// with it into dest. Calls still succeed when warnings are found; use
+// ClientConfig.StrictWarnings to reject them instead. Collection is safe for
+// concurrent calls sharing the context.
+//
+// Parameters:
+//   - ctx: Parent context
+//   - dest: Slice the warnings are appended to
+//
+// Returns:
+//   - context.Context: Context to pass to send methods
+//
+// Example:
+//
+//	var warnings []sendlix.Warning
+//	_, err := client.SendEmail(sendlix.WithWarnings(ctx, &warnings), options, nil)
+//	for _, w := range warnings {
+//		log.Printf("sendlix warning %s: %s", w.Code, w)
+//	}
+func WithWarnings(ctx context.Context, dest *[]Warning) context.Context {
+	return context.WithValue(ctx, warningsKey{}, &warningCollector{dest: dest})
+}
+
+// warn reports a warning to the collector of ctx, if any. It returns an
+// ErrStrictWarning error if the warning's code is listed in
+// ClientConfig.StrictWarnings. Every warning of a client goes through warn,
+// and OnWarning callbacks of checks are only called for warnings it
+// accepted.
+func (c *BaseClient) warn(ctx context.Context, w Warning) error {
+	if slices.Contains(c.config.StrictWarnings, w.Code) {
+		return newValidationError(CodeStrictWarning, w.Field, map[string]string{
+			"warning": string(w.Code),
+			"message": w.Message,
+		})
+	}
+
+	collectWarning(ctx, w)
+	return nil
+}
+
+// collectWarning appends a warning to the collector of ctx, if any.
+func collectWarning(ctx context.Context, w Warning) {
+	if collector, ok := ctx.Value(warningsKey{}).(*warningCollector); ok && collector.dest != nil {
+		collector.mu.Lock()
+		*collector.dest = append(*collector.dest, w)
+		collector.mu.Unlock()
+	}
+}